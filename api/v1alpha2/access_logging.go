@@ -0,0 +1,100 @@
+package v1alpha2
+
+// Names of the access log providers configured by the Istio module.
+const (
+	EnvoyAccessLogProviderName      = "envoy"
+	StdoutJSONAccessLogProviderName = "stdout-json"
+	KymaLogsAccessLogProviderName   = "kyma-logs"
+)
+
+// Configures access logging in the service mesh.
+type AccessLogging struct {
+	// Defines additional access log providers. The providers can be referenced by name in **defaultProviders**
+	// or in an Istio Telemetry resource.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=10
+	// +listType=map
+	// +listMapKey=name
+	Providers []AccessLogProvider `json:"providers,omitempty"`
+
+	// Defines the names of the access log providers used by all workloads in the service mesh, unless they're overridden by an Istio Telemetry resource.
+	// Each name must be `envoy`, `stdout-json`, `kyma-logs`, or the name of one of the **providers**. If not set, access logging is not enabled by default.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=10
+	// +listType=set
+	DefaultProviders []string `json:"defaultProviders,omitempty"`
+
+	// Defines a [CEL expression](https://istio.io/latest/docs/reference/config/telemetry/#AccessLogging-Filter) that selects the requests
+	// logged by the default providers, for example, `response.code >= 500`. If not set, all requests are logged.
+	// The filter requires at least one default provider.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=1024
+	Filter *string `json:"filter,omitempty"`
+}
+
+// Defines an access log provider. Exactly one of **file** or **openTelemetry** must be set.
+// +kubebuilder:validation:XValidation:rule="has(self.file) != has(self.openTelemetry)",message="exactly one of file or openTelemetry must be set"
+type AccessLogProvider struct {
+	// Specifies a unique name identifying the access log provider.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Configures a provider that writes the access logs to a file of the Istio proxy.
+	// +kubebuilder:validation:Optional
+	File *FileAccessLogProvider `json:"file,omitempty"`
+
+	// Configures a provider that sends the access logs to an OpenTelemetry collector using the OpenTelemetry Access Log Service (ALS).
+	// +kubebuilder:validation:Optional
+	OpenTelemetry *OpenTelemetryAccessLogProvider `json:"openTelemetry,omitempty"`
+}
+
+// Configures an access log provider that writes the access logs to a file.
+// +kubebuilder:validation:XValidation:rule="!has(self.format) || !(has(self.format.text) && has(self.format.labels))",message="only one of format.text or format.labels can be set for a file provider"
+type FileAccessLogProvider struct {
+	// Specifies the path of the file. The default value is `/dev/stdout`.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="/dev/stdout"
+	Path string `json:"path,omitempty"`
+
+	// Defines the format of the access logs. Set **text** for plain text logs, or **labels** for JSON logs.
+	// If not set, the default Envoy format is used.
+	// +kubebuilder:validation:Optional
+	Format *AccessLogFormat `json:"format,omitempty"`
+}
+
+// Configures an access log provider that sends the access logs to an OpenTelemetry collector.
+type OpenTelemetryAccessLogProvider struct {
+	// Specifies the service of the OpenTelemetry collector.
+	// The recommended format is `[Namespace/]Hostname`, for example, `otel-collector.observability.svc.cluster.local`.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Service string `json:"service"`
+
+	// Specifies the port of the OTLP gRPC endpoint of the Service.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port uint32 `json:"port"`
+
+	// Specifies the name of the log, which is sent to the collector.
+	// +kubebuilder:validation:Optional
+	LogName string `json:"logName,omitempty"`
+
+	// Defines the format of the access logs. The **text** is used as the body of the log record, and the **labels** are added as attributes.
+	// +kubebuilder:validation:Optional
+	Format *AccessLogFormat `json:"format,omitempty"`
+}
+
+// Defines the format of the access logs. Envoy [command operators](https://www.envoyproxy.io/docs/envoy/latest/configuration/observability/access_log/usage#command-operators)
+// can be used in the values.
+type AccessLogFormat struct {
+	// Defines the format of the access log as text, for example, `[%START_TIME%] %REQ(:METHOD)% %RESPONSE_CODE%`.
+	// +kubebuilder:validation:Optional
+	Text *string `json:"text,omitempty"`
+
+	// Defines the format of the access log as labels. The key is the name of the label, for example, `tenant_id: "%REQ(X-TENANT-ID)%"`.
+	// +kubebuilder:validation:Optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
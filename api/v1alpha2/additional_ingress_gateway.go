@@ -0,0 +1,63 @@
+package v1alpha2
+
+// DefaultIngressGatewayName is the name of the Istio Ingress Gateway that is always installed by the Istio module.
+const DefaultIngressGatewayName = "istio-ingressgateway"
+
+// Defines the type of the Service of an additional Istio Ingress Gateway.
+type GatewayServiceType string
+
+const (
+	// Exposes the gateway using a load balancer of the cloud provider.
+	GatewayServiceTypeLoadBalancer GatewayServiceType = "LoadBalancer"
+	// Exposes the gateway on a static port of each node.
+	GatewayServiceTypeNodePort GatewayServiceType = "NodePort"
+	// Exposes the gateway only inside the cluster.
+	GatewayServiceTypeClusterIP GatewayServiceType = "ClusterIP"
+)
+
+// Configures an additional Istio Ingress Gateway deployed in the `istio-system` namespace next to the default `istio-ingressgateway`.
+// +kubebuilder:validation:XValidation:rule="!has(self.externalTrafficPolicy) || !has(self.serviceType) || self.serviceType != 'ClusterIP'",message="externalTrafficPolicy can't be set for a gateway with the ClusterIP Service type"
+type AdditionalIngressGateway struct {
+	// Specifies the name of the gateway. The name is used for the Deployment, the Service, and the HorizontalPodAutoscaler of the gateway.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=50
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:XValidation:rule="self != 'istio-ingressgateway' && self != 'istio-egressgateway'",message="the name is reserved for a gateway of the Istio module"
+	Name string `json:"name"`
+
+	// Defines the labels of the gateway Pods. The labels are used to select the gateway in Istio Gateway resources.
+	// The default labels are `app: <name>` and `istio: <name>`. The labels defined here are added to the default labels and can override them.
+	// The labels can't be changed after the gateway is created, because they're used as the selector of the gateway Deployment.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="labels are immutable"
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Defines the type of the gateway Service. The default value is `LoadBalancer`.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=LoadBalancer;NodePort;ClusterIP
+	ServiceType *GatewayServiceType `json:"serviceType,omitempty"`
+
+	// Defines annotations added to the gateway Service, for example, to configure the load balancer of the cloud provider.
+	// +kubebuilder:validation:Optional
+	ServiceAnnotations map[string]string `json:"serviceAnnotations,omitempty"`
+
+	// Defines the external traffic policy of the gateway Service. Valid configurations are `"Local"` or `"Cluster"`.
+	// The external traffic policy set to `"Local"` preserves the client IP in the request, but also introduces the risk of unbalanced traffic distribution.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Local;Cluster
+	ExternalTrafficPolicy *string `json:"externalTrafficPolicy,omitempty"`
+
+	// Defines the Kubernetes resources' configuration for the gateway. It's a subset of [KubernetesResourcesSpec](https://istio.io/latest/docs/reference/config/istio.operator.v1alpha1/#KubernetesResourcesSpec).
+	// If not set, the configuration of the default Istio Ingress Gateway is used.
+	// +kubebuilder:validation:Optional
+	K8s *KubernetesResourcesConfig `json:"k8s,omitempty"`
+}
+
+// GetAdditionalIngressGateways returns the additional Istio Ingress Gateways configured in the Istio CR.
+func (i *Istio) GetAdditionalIngressGateways() []AdditionalIngressGateway {
+	if i.Spec.Components == nil {
+		return nil
+	}
+	return i.Spec.Components.AdditionalIngressGateways
+}
@@ -28,14 +28,41 @@ var conditionReasons = map[ConditionReason]conditionMeta{
 	ConditionReasonValidationFailed:   {Type: ConditionTypeReady, Status: metav1.ConditionFalse, Message: ConditionReasonValidationFailedMessage},
 	ConditionReasonOlderCRExists:      {Type: ConditionTypeReady, Status: metav1.ConditionFalse, Message: ConditionReasonOlderCRExistsMessage},
 	ConditionReasonOldestCRNotFound:   {Type: ConditionTypeReady, Status: metav1.ConditionFalse, Message: ConditionReasonOldestCRNotFoundMessage},
+	ConditionReasonPlanGenerated:      {Type: ConditionTypeReady, Status: metav1.ConditionFalse, Message: ConditionReasonPlanGeneratedMessage},
 
-	ConditionReasonIstioInstallNotNeeded:        {Type: ConditionTypeReady, Status: metav1.ConditionFalse, Message: ConditionReasonIstioInstallNotNeededMessage},
-	ConditionReasonIstioInstallSucceeded:        {Type: ConditionTypeReady, Status: metav1.ConditionFalse, Message: ConditionReasonIstioInstallSucceededMessage},
-	ConditionReasonIstioUninstallSucceeded:      {Type: ConditionTypeReady, Status: metav1.ConditionFalse, Message: ConditionReasonIstioUninstallSucceededMessage},
-	ConditionReasonIstioInstallUninstallFailed:  {Type: ConditionTypeReady, Status: metav1.ConditionFalse, Message: ConditionReasonIstioInstallUninstallFailedMessage},
-	ConditionReasonCustomResourceMisconfigured:  {Type: ConditionTypeReady, Status: metav1.ConditionFalse, Message: ConditionReasonCustomResourceMisconfiguredMessage},
-	ConditionReasonIstioCRsDangling:             {Type: ConditionTypeReady, Status: metav1.ConditionFalse, Message: ConditionReasonIstioCRsDanglingMessage},
-	ConditionReasonIstioVersionUpdateNotAllowed: {Type: ConditionTypeReady, Status: metav1.ConditionFalse, Message: ConditionReasonIstioVersionUpdateNotAllowedMessage},
+	ConditionReasonIstioInstallNotNeeded:           {Type: ConditionTypeReady, Status: metav1.ConditionFalse, Message: ConditionReasonIstioInstallNotNeededMessage},
+	ConditionReasonIstioInstallSucceeded:           {Type: ConditionTypeReady, Status: metav1.ConditionFalse, Message: ConditionReasonIstioInstallSucceededMessage},
+	ConditionReasonIstioUninstallSucceeded:         {Type: ConditionTypeReady, Status: metav1.ConditionFalse, Message: ConditionReasonIstioUninstallSucceededMessage},
+	ConditionReasonIstioInstallUninstallFailed:     {Type: ConditionTypeReady, Status: metav1.ConditionFalse, Message: ConditionReasonIstioInstallUninstallFailedMessage},
+	ConditionReasonCustomResourceMisconfigured:     {Type: ConditionTypeReady, Status: metav1.ConditionFalse, Message: ConditionReasonCustomResourceMisconfiguredMessage},
+	ConditionReasonIstioCRsDangling:                {Type: ConditionTypeReady, Status: metav1.ConditionFalse, Message: ConditionReasonIstioCRsDanglingMessage},
+	ConditionReasonIstioVersionUpdateNotAllowed:    {Type: ConditionTypeReady, Status: metav1.ConditionFalse, Message: ConditionReasonIstioVersionUpdateNotAllowedMessage},
+	ConditionReasonCanaryUpgradeInProgress:         {Type: ConditionTypeReady, Status: metav1.ConditionFalse, Message: ConditionReasonCanaryUpgradeInProgressMessage},
+	ConditionReasonCanaryUpgradeSucceeded:          {Type: ConditionTypeReady, Status: metav1.ConditionFalse, Message: ConditionReasonCanaryUpgradeSucceededMessage},
+	ConditionReasonCanaryUpgradeFailed:             {Type: ConditionTypeReady, Status: metav1.ConditionFalse, Message: ConditionReasonCanaryUpgradeFailedMessage},
+	ConditionReasonUpgradePreflightNotAcknowledged: {Type: ConditionTypeReady, Status: metav1.ConditionFalse, Message: ConditionReasonUpgradePreflightNotAcknowledgedMessage},
+
+	ConditionReasonUpgradePreflightFindingsFound: {
+		Type:    ConditionTypeUpgradePreflightFindingsFound,
+		Status:  metav1.ConditionTrue,
+		Message: ConditionReasonUpgradePreflightFindingsFoundMessage,
+	},
+	ConditionReasonUpgradePreflightFindingsNotFound: {
+		Type:    ConditionTypeUpgradePreflightFindingsFound,
+		Status:  metav1.ConditionFalse,
+		Message: ConditionReasonUpgradePreflightFindingsNotFoundMessage,
+	},
+
+	ConditionReasonPermissiveMtlsConfigured: {
+		Type:    ConditionTypePermissiveMtlsConfigured,
+		Status:  metav1.ConditionTrue,
+		Message: ConditionReasonPermissiveMtlsConfiguredMessage,
+	},
+	ConditionReasonStrictMtlsEnforced: {
+		Type:    ConditionTypePermissiveMtlsConfigured,
+		Status:  metav1.ConditionFalse,
+		Message: ConditionReasonStrictMtlsEnforcedMessage,
+	},
 
 	ConditionReasonCRsReconcileSucceeded: {Type: ConditionTypeReady, Status: metav1.ConditionFalse, Message: ConditionReasonCRsReconcileSucceededMessage},
 	ConditionReasonCRsReconcileFailed:    {Type: ConditionTypeReady, Status: metav1.ConditionFalse, Message: ConditionReasonCRsReconcileFailedMessage},
@@ -60,6 +87,22 @@ var conditionReasons = map[ConditionReason]conditionMeta{
 		Status:  metav1.ConditionFalse,
 		Message: ConditionReasonProxySidecarManualRestartRequiredMessage,
 	},
+	ConditionReasonProxySidecarRestartInProgress: {
+		Type:    ConditionTypeProxySidecarRestartSucceeded,
+		Status:  metav1.ConditionFalse,
+		Message: ConditionReasonProxySidecarRestartInProgressMessage,
+	},
+
+	ConditionReasonProxySidecarRestartDeferred: {
+		Type:    ConditionTypeProxySidecarRestartDeferred,
+		Status:  metav1.ConditionTrue,
+		Message: ConditionReasonProxySidecarRestartDeferredMessage,
+	},
+	ConditionReasonProxySidecarRestartNotDeferred: {
+		Type:    ConditionTypeProxySidecarRestartDeferred,
+		Status:  metav1.ConditionFalse,
+		Message: ConditionReasonProxySidecarRestartNotDeferredMessage,
+	},
 
 	ConditionReasonIngressGatewayRestartSucceeded: {Type: ConditionTypeReady, Status: metav1.ConditionFalse, Message: ConditionReasonIngressGatewayRestartSucceededMessage},
 	ConditionReasonIngressGatewayRestartFailed:    {Type: ConditionTypeReady, Status: metav1.ConditionFalse, Message: ConditionReasonIngressGatewayRestartFailedMessage},
@@ -67,6 +110,30 @@ var conditionReasons = map[ConditionReason]conditionMeta{
 	ConditionReasonEgressGatewayRestartSucceeded: {Type: ConditionTypeReady, Status: metav1.ConditionFalse, Message: ConditionReasonEgressGatewayRestartSucceededMessage},
 	ConditionReasonEgressGatewayRestartFailed:    {Type: ConditionTypeReady, Status: metav1.ConditionFalse, Message: ConditionReasonEgressGatewayRestartFailedMessage},
 
+	ConditionReasonCertificateAuthorityUpdated: {Type: ConditionTypeReady, Status: metav1.ConditionFalse, Message: ConditionReasonCertificateAuthorityUpdatedMessage},
+	ConditionReasonCertificateAuthorityFailed:  {Type: ConditionTypeReady, Status: metav1.ConditionFalse, Message: ConditionReasonCertificateAuthorityFailedMessage},
+
+	ConditionReasonRootCARotationInProgress: {
+		Type:    ConditionTypeRootCARotationInProgress,
+		Status:  metav1.ConditionTrue,
+		Message: ConditionReasonRootCARotationInProgressMessage,
+	},
+	ConditionReasonRootCARotationSucceeded: {
+		Type:    ConditionTypeRootCARotationInProgress,
+		Status:  metav1.ConditionFalse,
+		Message: ConditionReasonRootCARotationSucceededMessage,
+	},
+	ConditionReasonRootCARotationNotRequired: {
+		Type:    ConditionTypeRootCARotationInProgress,
+		Status:  metav1.ConditionFalse,
+		Message: ConditionReasonRootCARotationNotRequiredMessage,
+	},
+	ConditionReasonRootCARotationFailed: {
+		Type:    ConditionTypeRootCARotationInProgress,
+		Status:  metav1.ConditionUnknown,
+		Message: ConditionReasonRootCARotationFailedMessage,
+	},
+
 	ConditionReasonIngressTargetingUserResourceFound: {
 		Type:    ConditionTypeIngressTargetingUserResourceFound,
 		Status:  metav1.ConditionTrue,
@@ -0,0 +1,25 @@
+package v1alpha2_test
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // Ginkgo tests are generally written without a direct package reference
+	. "github.com/onsi/gomega"    //nolint:revive // Gomega asserts are generally written without a direct package reference
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"sigs.k8s.io/yaml"
+)
+
+var _ = Describe("Istio CRD", func() {
+	It("should fit into the last-applied-configuration annotation of client-side kubectl apply", func() {
+		// given
+		crd, err := os.ReadFile("../../config/crd/bases/operator.kyma-project.io_istios.yaml")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		// when
+		crdJSON, err := yaml.YAMLToJSON(crd)
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(len(crdJSON)).To(BeNumerically("<", apivalidation.TotalAnnotationSizeLimitB))
+	})
+})
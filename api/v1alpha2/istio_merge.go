@@ -3,10 +3,13 @@ package v1alpha2
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
 	"strconv"
 
 	"github.com/golang/protobuf/ptypes/duration"
 	"github.com/kyma-project/istio/operator/internal/istiofeatures"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/structpb"
 	meshv1alpha1 "istio.io/api/mesh/v1alpha1"
 	iopv1alpha1 "istio.io/istio/operator/pkg/apis"
@@ -15,6 +18,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -23,6 +27,12 @@ import (
 type MergeOptions struct {
 	Features        istiofeatures.IstioFeatures
 	EnableDualStack bool
+	Revision        string
+	// LoadBalancerAnnotations are the Istio Ingress Gateway Service annotations managed for the cloud provider.
+	LoadBalancerAnnotations map[string]string
+	// InternalLoadBalancerAnnotations are the annotations that make the load balancer internal for the cloud provider.
+	// If nil, the cloud provider doesn't support internal load balancers.
+	InternalLoadBalancerAnnotations map[string]string
 }
 
 // +kubebuilder:object:generate=false
@@ -40,6 +50,22 @@ func WithDualStackEnabled() MergeOption {
 	}
 }
 
+// WithRevision installs the Istio control plane as the given revision.
+func WithRevision(revision string) MergeOption {
+	return func(options *MergeOptions) {
+		options.Revision = revision
+	}
+}
+
+// WithLoadBalancerAnnotations sets the Istio Ingress Gateway Service annotations managed for the cloud provider
+// and the annotations used to make the load balancer internal.
+func WithLoadBalancerAnnotations(annotations, internalAnnotations map[string]string) MergeOption {
+	return func(options *MergeOptions) {
+		options.LoadBalancerAnnotations = annotations
+		options.InternalLoadBalancerAnnotations = internalAnnotations
+	}
+}
+
 func (i *Istio) MergeInto(op iopv1alpha1.IstioOperator, options ...MergeOption) (iopv1alpha1.IstioOperator, error) {
 	mergedConfigOp, err := i.mergeConfig(op, options...)
 	if err != nil {
@@ -272,6 +298,265 @@ func (m *meshConfigBuilder) BuildProxyStatsMatcher(matcher *ProxyStatsMatcher) *
 	return m
 }
 
+func (m *meshConfigBuilder) BuildProxyLifecycle(lifecycle *ProxyLifecycle) *meshConfigBuilder {
+	if lifecycle == nil {
+		return m
+	}
+
+	if lifecycle.HoldApplicationUntilProxyStarts != nil {
+		err := m.c.SetPath("defaultConfig.holdApplicationUntilProxyStarts", *lifecycle.HoldApplicationUntilProxyStarts)
+		if err != nil {
+			return nil
+		}
+	}
+
+	if lifecycle.TerminationDrainDuration != nil {
+		// ProxyConfig expects the protobuf JSON format of a duration, which only allows seconds
+		drainDuration := strconv.FormatFloat(lifecycle.TerminationDrainDuration.Seconds(), 'f', -1, 64) + "s"
+		err := m.c.SetPath("defaultConfig.terminationDrainDuration", drainDuration)
+		if err != nil {
+			return nil
+		}
+	}
+
+	if lifecycle.Concurrency != nil {
+		err := m.c.SetPath("defaultConfig.concurrency", *lifecycle.Concurrency)
+		if err != nil {
+			return nil
+		}
+	}
+
+	if lifecycle.ExitOnZeroActiveConnections != nil {
+		err := m.c.SetPath("defaultConfig.proxyMetadata.EXIT_ON_ZERO_ACTIVE_CONNECTIONS", strconv.FormatBool(*lifecycle.ExitOnZeroActiveConnections))
+		if err != nil {
+			return nil
+		}
+	}
+
+	return m
+}
+
+func proxyLifecycle(components *Components) *ProxyLifecycle {
+	if components == nil || components.Proxy == nil {
+		return nil
+	}
+	return components.Proxy.Lifecycle
+}
+
+func (m *meshConfigBuilder) BuildTracingConfiguration(tracing *Tracing) *meshConfigBuilder {
+	if tracing == nil {
+		return m
+	}
+
+	if tracing.SamplingPercentage != nil {
+		sampling, err := strconv.ParseFloat(*tracing.SamplingPercentage, 64)
+		if err != nil {
+			return nil
+		}
+		err = m.c.SetPath("defaultConfig.tracing.sampling", sampling)
+		if err != nil {
+			return nil
+		}
+	}
+
+	if len(tracing.CustomTags) > 0 {
+		customTags := make(map[string]interface{}, len(tracing.CustomTags))
+		for name, tag := range tracing.CustomTags {
+			customTag, err := protomarshal.ToJSONMap(tracingCustomTag(tag))
+			if err != nil {
+				return nil
+			}
+			customTags[name] = customTag
+		}
+		err := m.c.SetPath("defaultConfig.tracing.customTags", customTags)
+		if err != nil {
+			return nil
+		}
+	}
+
+	if len(tracing.Providers) > 0 {
+		extensionProviders := values.TryGetPathAs[[]interface{}](m.c, "extensionProviders")
+		for _, provider := range tracing.Providers {
+			providerMap, err := protomarshal.ToJSONMap(tracingExtensionProvider(provider))
+			if err != nil {
+				return nil
+			}
+			extensionProviders = append(extensionProviders, providerMap)
+		}
+		err := m.c.SetPath("extensionProviders", &extensionProviders)
+		if err != nil {
+			return nil
+		}
+	}
+
+	if tracing.DefaultProvider != nil {
+		err := m.c.SetPath("defaultProviders.tracing", []string{*tracing.DefaultProvider})
+		if err != nil {
+			return nil
+		}
+	}
+
+	return m
+}
+
+func (m *meshConfigBuilder) BuildAccessLoggingConfiguration(accessLogging *AccessLogging) *meshConfigBuilder {
+	if accessLogging == nil {
+		return m
+	}
+
+	if len(accessLogging.Providers) > 0 {
+		extensionProviders := values.TryGetPathAs[[]interface{}](m.c, "extensionProviders")
+		for _, provider := range accessLogging.Providers {
+			extensionProvider, err := accessLogExtensionProvider(provider)
+			if err != nil {
+				return nil
+			}
+			providerMap, err := protomarshal.ToJSONMap(extensionProvider)
+			if err != nil {
+				return nil
+			}
+			extensionProviders = append(extensionProviders, providerMap)
+		}
+		err := m.c.SetPath("extensionProviders", &extensionProviders)
+		if err != nil {
+			return nil
+		}
+	}
+
+	if len(accessLogging.DefaultProviders) > 0 {
+		err := m.c.SetPath("defaultProviders.accessLogging", accessLogging.DefaultProviders)
+		if err != nil {
+			return nil
+		}
+	}
+
+	return m
+}
+
+func accessLogExtensionProvider(provider AccessLogProvider) (*meshv1alpha1.MeshConfig_ExtensionProvider, error) {
+	extensionProvider := &meshv1alpha1.MeshConfig_ExtensionProvider{Name: provider.Name}
+
+	if provider.File != nil {
+		fileProvider := &meshv1alpha1.MeshConfig_ExtensionProvider_EnvoyFileAccessLogProvider{Path: provider.File.Path}
+		if fileProvider.Path == "" {
+			fileProvider.Path = "/dev/stdout"
+		}
+		if format := provider.File.Format; format != nil {
+			if format.Text != nil {
+				fileProvider.LogFormat = &meshv1alpha1.MeshConfig_ExtensionProvider_EnvoyFileAccessLogProvider_LogFormat{
+					LogFormat: &meshv1alpha1.MeshConfig_ExtensionProvider_EnvoyFileAccessLogProvider_LogFormat_Text{Text: *format.Text},
+				}
+			} else if len(format.Labels) > 0 {
+				labels, err := accessLogLabels(format.Labels)
+				if err != nil {
+					return nil, err
+				}
+				fileProvider.LogFormat = &meshv1alpha1.MeshConfig_ExtensionProvider_EnvoyFileAccessLogProvider_LogFormat{
+					LogFormat: &meshv1alpha1.MeshConfig_ExtensionProvider_EnvoyFileAccessLogProvider_LogFormat_Labels{Labels: labels},
+				}
+			}
+		}
+		extensionProvider.Provider = &meshv1alpha1.MeshConfig_ExtensionProvider_EnvoyFileAccessLog{EnvoyFileAccessLog: fileProvider}
+		return extensionProvider, nil
+	}
+
+	if provider.OpenTelemetry != nil {
+		otelProvider := &meshv1alpha1.MeshConfig_ExtensionProvider_EnvoyOpenTelemetryLogProvider{
+			Service: provider.OpenTelemetry.Service,
+			Port:    provider.OpenTelemetry.Port,
+			LogName: provider.OpenTelemetry.LogName,
+		}
+		if format := provider.OpenTelemetry.Format; format != nil {
+			otelProvider.LogFormat = &meshv1alpha1.MeshConfig_ExtensionProvider_EnvoyOpenTelemetryLogProvider_LogFormat{}
+			if format.Text != nil {
+				otelProvider.LogFormat.Text = *format.Text
+			}
+			if len(format.Labels) > 0 {
+				labels, err := accessLogLabels(format.Labels)
+				if err != nil {
+					return nil, err
+				}
+				otelProvider.LogFormat.Labels = labels
+			}
+		}
+		extensionProvider.Provider = &meshv1alpha1.MeshConfig_ExtensionProvider_EnvoyOtelAls{EnvoyOtelAls: otelProvider}
+	}
+
+	return extensionProvider, nil
+}
+
+func accessLogLabels(labels map[string]string) (*structpb.Struct, error) {
+	fields := make(map[string]interface{}, len(labels))
+	for k, v := range labels {
+		fields[k] = v
+	}
+	return structpb.NewStruct(fields)
+}
+
+func tracingExtensionProvider(provider TracingProvider) *meshv1alpha1.MeshConfig_ExtensionProvider {
+	otel := &meshv1alpha1.MeshConfig_ExtensionProvider_OpenTelemetryTracingProvider{
+		Service: provider.Service,
+		Port:    provider.Port,
+	}
+
+	var timeout *duration.Duration
+	if provider.Timeout != nil {
+		timeout = durationpb.New(provider.Timeout.Duration)
+	}
+	headers := tracingProviderHeaders(provider.Headers)
+
+	if provider.Protocol == TracingProtocolHTTP {
+		otel.Http = &meshv1alpha1.MeshConfig_ExtensionProvider_HttpService{
+			Timeout: timeout,
+			Headers: headers,
+		}
+		if provider.Path != nil {
+			otel.Http.Path = *provider.Path
+		}
+	} else if timeout != nil || len(headers) > 0 {
+		otel.Grpc = &meshv1alpha1.MeshConfig_ExtensionProvider_GrpcService{
+			Timeout:         timeout,
+			InitialMetadata: headers,
+		}
+	}
+
+	return &meshv1alpha1.MeshConfig_ExtensionProvider{
+		Name:     provider.Name,
+		Provider: &meshv1alpha1.MeshConfig_ExtensionProvider_Opentelemetry{Opentelemetry: otel},
+	}
+}
+
+// tracingProviderHeaders returns the headers sorted by name to keep the rendered mesh config stable between reconciliations.
+func tracingProviderHeaders(headers map[string]string) []*meshv1alpha1.MeshConfig_ExtensionProvider_HttpHeader {
+	names := slices.Sorted(maps.Keys(headers))
+	httpHeaders := make([]*meshv1alpha1.MeshConfig_ExtensionProvider_HttpHeader, 0, len(names))
+	for _, name := range names {
+		httpHeaders = append(httpHeaders, &meshv1alpha1.MeshConfig_ExtensionProvider_HttpHeader{
+			Name:        name,
+			HeaderValue: &meshv1alpha1.MeshConfig_ExtensionProvider_HttpHeader_Value{Value: headers[name]},
+		})
+	}
+	return httpHeaders
+}
+
+func tracingCustomTag(tag TracingCustomTag) *meshv1alpha1.Tracing_CustomTag {
+	switch {
+	case tag.Literal != nil:
+		return &meshv1alpha1.Tracing_CustomTag{Type: &meshv1alpha1.Tracing_CustomTag_Literal{
+			Literal: &meshv1alpha1.Tracing_Literal{Value: *tag.Literal},
+		}}
+	case tag.Environment != nil:
+		return &meshv1alpha1.Tracing_CustomTag{Type: &meshv1alpha1.Tracing_CustomTag_Environment{
+			Environment: &meshv1alpha1.Tracing_Environment{Name: tag.Environment.Name, DefaultValue: tag.Environment.DefaultValue},
+		}}
+	case tag.Header != nil:
+		return &meshv1alpha1.Tracing_CustomTag{Type: &meshv1alpha1.Tracing_CustomTag_Header{
+			Header: &meshv1alpha1.Tracing_RequestHeader{Name: tag.Header.Name, DefaultValue: tag.Header.DefaultValue},
+		}}
+	}
+	return &meshv1alpha1.Tracing_CustomTag{}
+}
+
 func (i *Istio) mergeConfig(op iopv1alpha1.IstioOperator, options ...MergeOption) (iopv1alpha1.IstioOperator, error) {
 	opts := &MergeOptions{
 		EnableDualStack: false,
@@ -279,6 +564,9 @@ func (i *Istio) mergeConfig(op iopv1alpha1.IstioOperator, options ...MergeOption
 	for _, option := range options {
 		option(opts)
 	}
+	if opts.Revision != "" {
+		op.Spec.Revision = opts.Revision
+	}
 	mcb, err := newMeshConfigBuilder(op)
 	if err != nil {
 		return op, err
@@ -296,6 +584,9 @@ func (i *Istio) mergeConfig(op iopv1alpha1.IstioOperator, options ...MergeOption
 		BuildTrustDomainConfig(i.Spec.Config.TrustDomain).
 		BuildDNSProxyingConfiguration(i.Spec.Config.EnableDNSProxying).
 		BuildProxyStatsMatcher(i.Spec.Config.ProxyStatsMatcher).
+		BuildProxyLifecycle(proxyLifecycle(i.Spec.Components)).
+		BuildTracingConfiguration(i.Spec.Config.Tracing).
+		BuildAccessLoggingConfiguration(i.Spec.Config.AccessLogging).
 		Build()
 
 	op.Spec.MeshConfig = newMeshConfig
@@ -488,7 +779,13 @@ func (i *Istio) mergeResources(op iopv1alpha1.IstioOperator, options ...MergeOpt
 			op.Spec.Components.IngressGateways[0].Kubernetes = &iopv1alpha1.KubernetesResources{}
 		}
 		if i.Spec.Components.IngressGateway.K8s != nil {
-			err := mergeK8sConfig(op.Spec.Components.IngressGateways[0].Kubernetes, *i.Spec.Components.IngressGateway.K8s)
+			err := mergeK8sConfig(op.Spec.Components.IngressGateways[0].Kubernetes, *i.Spec.Components.IngressGateway.K8s, DefaultIngressGatewayName)
+			if err != nil {
+				return op, err
+			}
+		}
+		if i.Spec.Components.IngressGateway.Service != nil {
+			err := mergeIngressGatewayService(op.Spec.Components.IngressGateways[0].Kubernetes, *i.Spec.Components.IngressGateway.Service, opts)
 			if err != nil {
 				return op, err
 			}
@@ -504,7 +801,7 @@ func (i *Istio) mergeResources(op iopv1alpha1.IstioOperator, options ...MergeOpt
 			op.Spec.Components.EgressGateways[0].Kubernetes = &iopv1alpha1.KubernetesResources{}
 		}
 		if i.Spec.Components.EgressGateway.K8s != nil {
-			err := mergeK8sConfig(op.Spec.Components.EgressGateways[0].Kubernetes, *i.Spec.Components.EgressGateway.K8s)
+			err := mergeK8sConfig(op.Spec.Components.EgressGateways[0].Kubernetes, *i.Spec.Components.EgressGateway.K8s, egressGatewayName)
 			if err != nil {
 				return op, err
 			}
@@ -514,6 +811,14 @@ func (i *Istio) mergeResources(op iopv1alpha1.IstioOperator, options ...MergeOpt
 		}
 	}
 
+	for _, gateway := range i.Spec.Components.AdditionalIngressGateways {
+		gatewaySpec, err := additionalIngressGatewayComponent(op, gateway)
+		if err != nil {
+			return op, err
+		}
+		op.Spec.Components.IngressGateways = append(op.Spec.Components.IngressGateways, gatewaySpec)
+	}
+
 	//nolint:nestif // `if i.Spec.Components.Pilot != nil` has complex nested blocks (complexity: 6) TODO refactor
 	if i.Spec.Components.Pilot != nil {
 		if op.Spec.Components.Pilot == nil {
@@ -523,10 +828,14 @@ func (i *Istio) mergeResources(op iopv1alpha1.IstioOperator, options ...MergeOpt
 			op.Spec.Components.Pilot.Kubernetes = &iopv1alpha1.KubernetesResources{}
 		}
 		if i.Spec.Components.Pilot.K8s != nil {
-			err := mergeK8sConfig(op.Spec.Components.Pilot.Kubernetes, *i.Spec.Components.Pilot.K8s)
+			err := mergeK8sConfig(op.Spec.Components.Pilot.Kubernetes, *i.Spec.Components.Pilot.K8s, istiodDeploymentName(opts.Revision))
 			if err != nil {
 				return op, err
 			}
+			// The tolerations replace the tolerations of the chart, so the toleration of the CNI readiness taint must be kept
+			if len(i.Spec.Components.Pilot.K8s.Tolerations) > 0 {
+				op.Spec.Components.Pilot.Kubernetes.Tolerations = append([]*corev1.Toleration{cniNotReadyToleration()}, op.Spec.Components.Pilot.Kubernetes.Tolerations...)
+			}
 		}
 	}
 
@@ -670,6 +979,14 @@ func (i *Istio) mergeResources(op iopv1alpha1.IstioOperator, options ...MergeOpt
 				}
 			}
 		}
+
+		// The tolerations replace the tolerations of the chart, so the DaemonSet must keep tolerating all taints it tolerates by default
+		if i.Spec.Components.Cni.K8S != nil && len(i.Spec.Components.Cni.K8S.Tolerations) > 0 {
+			op.Spec.Components.Cni.Kubernetes.Tolerations = defaultCniTolerations()
+			for _, toleration := range i.Spec.Components.Cni.K8S.Tolerations {
+				op.Spec.Components.Cni.Kubernetes.Tolerations = append(op.Spec.Components.Cni.Kubernetes.Tolerations, toleration.DeepCopy())
+			}
+		}
 	}
 
 	if opts.Features.EnableControlPlaneVPA {
@@ -688,11 +1005,240 @@ func (i *Istio) mergeResources(op iopv1alpha1.IstioOperator, options ...MergeOpt
 		}
 	}
 
+	return i.enablePodDisruptionBudgets(op)
+}
+
+// enablePodDisruptionBudgets makes the Istio chart render the PodDisruptionBudgets of the components that have a PodDisruptionBudget configured in the Istio CR.
+// The chart renders a PodDisruptionBudget only if PodDisruptionBudgets are enabled globally and the autoscaleMin value of the component is greater than 1.
+// The values of the ingress gateways are shared by all ingress gateways, so the ingress gateways without a configured PodDisruptionBudget
+// get a PodDisruptionBudget that allows evicting one Pod at a time.
+func (i *Istio) enablePodDisruptionBudgets(op iopv1alpha1.IstioOperator) (iopv1alpha1.IstioOperator, error) {
+	var autoscaleMinPaths []string
+	if i.Spec.Components.Pilot != nil && i.Spec.Components.Pilot.K8s != nil && i.Spec.Components.Pilot.K8s.PodDisruptionBudget != nil {
+		autoscaleMinPaths = append(autoscaleMinPaths, "pilot.autoscaleMin")
+	}
+	if i.hasIngressGatewayPodDisruptionBudget() {
+		autoscaleMinPaths = append(autoscaleMinPaths, "gateways.istio-ingressgateway.autoscaleMin")
+		for idx := range op.Spec.Components.IngressGateways {
+			k8s := op.Spec.Components.IngressGateways[idx].Kubernetes
+			if k8s != nil && k8s.PodDisruptionBudget == nil {
+				maxUnavailable := intstr.FromInt32(1)
+				k8s.PodDisruptionBudget = &policyv1.PodDisruptionBudgetSpec{MaxUnavailable: &maxUnavailable}
+			}
+		}
+	}
+	if i.Spec.Components.EgressGateway != nil && i.Spec.Components.EgressGateway.K8s != nil && i.Spec.Components.EgressGateway.K8s.PodDisruptionBudget != nil {
+		autoscaleMinPaths = append(autoscaleMinPaths, "gateways.istio-egressgateway.autoscaleMin")
+	}
+	if len(autoscaleMinPaths) == 0 {
+		return op, nil
+	}
+
+	valuesMap, err := values.MapFromObject(op.Spec.Values)
+	if err != nil {
+		return op, err
+	}
+	if valuesMap == nil {
+		valuesMap = make(values.Map)
+	}
+
+	err = valuesMap.SetPath("global.defaultPodDisruptionBudget.enabled", true)
+	if err != nil {
+		return op, err
+	}
+	for _, path := range autoscaleMinPaths {
+		// The HorizontalPodAutoscaler is patched with the hpaSpec of the component, so autoscaleMin only controls whether the PodDisruptionBudget is rendered
+		autoscaleMin, ok := valuesMap.GetPath(path)
+		if number, isNumber := autoscaleMin.(float64); ok && isNumber && number > 1 {
+			continue
+		}
+		err = valuesMap.SetPath(path, 2)
+		if err != nil {
+			return op, err
+		}
+	}
+
+	op.Spec.Values, err = values.ConvertMap[json.RawMessage](valuesMap)
+	if err != nil {
+		return op, err
+	}
 	return op, nil
 }
 
+func (i *Istio) hasIngressGatewayPodDisruptionBudget() bool {
+	if i.Spec.Components.IngressGateway != nil && i.Spec.Components.IngressGateway.K8s != nil && i.Spec.Components.IngressGateway.K8s.PodDisruptionBudget != nil {
+		return true
+	}
+	for _, gateway := range i.Spec.Components.AdditionalIngressGateways {
+		if gateway.K8s != nil && gateway.K8s.PodDisruptionBudget != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultIngressGatewayServicePorts are the ports exposed by the Istio Ingress Gateway Service in the Istio chart.
+// The ports configured in the component replace the ports of the chart, so the default ports must always be part of them.
+var defaultIngressGatewayServicePorts = []corev1.ServicePort{
+	{Name: "status-port", Port: 15021, TargetPort: intstr.FromInt32(15021), Protocol: corev1.ProtocolTCP},
+	{Name: "http2", Port: 80, TargetPort: intstr.FromInt32(8080), Protocol: corev1.ProtocolTCP},
+	{Name: "https", Port: 443, TargetPort: intstr.FromInt32(8443), Protocol: corev1.ProtocolTCP},
+}
+
+// mergeIngressGatewayService applies the Service configuration of the default Istio Ingress Gateway. The user annotations are
+// added on top of the annotations managed for the cloud provider, so they must not use the keys of the provider annotations.
+func mergeIngressGatewayService(base *iopv1alpha1.KubernetesResources, service IngressGatewayService, opts *MergeOptions) error {
+	for key := range service.Annotations {
+		_, isProviderAnnotation := opts.LoadBalancerAnnotations[key]
+		_, isInternalAnnotation := opts.InternalLoadBalancerAnnotations[key]
+		if isProviderAnnotation || isInternalAnnotation {
+			return fmt.Errorf("annotation %s of Istio Ingress Gateway Service is managed for the cloud provider and can't be overridden", key)
+		}
+	}
+
+	if service.Internal && opts.InternalLoadBalancerAnnotations == nil {
+		return fmt.Errorf("internal load balancer for Istio Ingress Gateway is not supported by the cloud provider")
+	}
+
+	if base.Service == nil {
+		base.Service = &corev1.ServiceSpec{}
+	}
+
+	if len(service.Ports) > 0 {
+		ports := slices.Clone(defaultIngressGatewayServicePorts)
+		for _, port := range service.Ports {
+			targetPort := port.Port
+			if port.TargetPort != nil {
+				targetPort = *port.TargetPort
+			}
+			protocol := corev1.ProtocolTCP
+			if port.Protocol != "" {
+				protocol = corev1.Protocol(port.Protocol)
+			}
+			ports = append(ports, corev1.ServicePort{
+				Name:       port.Name,
+				Port:       port.Port,
+				TargetPort: intstr.FromInt32(targetPort),
+				Protocol:   protocol,
+			})
+		}
+		base.Service.Ports = ports
+	}
+
+	if len(service.LoadBalancerSourceRanges) > 0 {
+		base.Service.LoadBalancerSourceRanges = slices.Clone(service.LoadBalancerSourceRanges)
+	}
+
+	if service.LoadBalancerIP != nil {
+		base.Service.LoadBalancerIP = *service.LoadBalancerIP
+	}
+
+	if service.Internal || len(service.Annotations) > 0 {
+		if base.ServiceAnnotations == nil {
+			base.ServiceAnnotations = map[string]string{}
+		}
+		if service.Internal {
+			maps.Copy(base.ServiceAnnotations, opts.InternalLoadBalancerAnnotations)
+		}
+		maps.Copy(base.ServiceAnnotations, service.Annotations)
+	}
+
+	return nil
+}
+
+// additionalIngressGatewayComponent renders an additional Istio Ingress Gateway. The gateway inherits the Kubernetes configuration of the default
+// Istio Ingress Gateway, except for the settings that refer to the default gateway by name, like affinity and overlays.
+func additionalIngressGatewayComponent(op iopv1alpha1.IstioOperator, gateway AdditionalIngressGateway) (iopv1alpha1.GatewayComponentSpec, error) {
+	k8s := &iopv1alpha1.KubernetesResources{}
+	for _, ingressGateway := range op.Spec.Components.IngressGateways {
+		if ingressGateway.Name != DefaultIngressGatewayName || ingressGateway.Kubernetes == nil {
+			continue
+		}
+		k8s.HpaSpec = ingressGateway.Kubernetes.HpaSpec.DeepCopy()
+		k8s.PodAnnotations = maps.Clone(ingressGateway.Kubernetes.PodAnnotations)
+		k8s.PodDisruptionBudget = ingressGateway.Kubernetes.PodDisruptionBudget.DeepCopy()
+		k8s.Resources = ingressGateway.Kubernetes.Resources.DeepCopy()
+		k8s.SecurityContext = ingressGateway.Kubernetes.SecurityContext.DeepCopy()
+		k8s.Strategy = ingressGateway.Kubernetes.Strategy.DeepCopy()
+		k8s.NodeSelector = maps.Clone(ingressGateway.Kubernetes.NodeSelector)
+		k8s.PriorityClassName = ingressGateway.Kubernetes.PriorityClassName
+		for _, toleration := range ingressGateway.Kubernetes.Tolerations {
+			k8s.Tolerations = append(k8s.Tolerations, toleration.DeepCopy())
+		}
+	}
+
+	if gateway.K8s != nil {
+		err := mergeK8sConfig(k8s, *gateway.K8s, gateway.Name)
+		if err != nil {
+			return iopv1alpha1.GatewayComponentSpec{}, err
+		}
+	}
+
+	// The HorizontalPodAutoscaler is patched with the hpaSpec, so it must target the Deployment of the additional gateway.
+	if k8s.HpaSpec != nil {
+		k8s.HpaSpec.ScaleTargetRef = autoscalingv2.CrossVersionObjectReference{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Name:       gateway.Name,
+		}
+	}
+
+	k8s.ServiceAnnotations = maps.Clone(gateway.ServiceAnnotations)
+
+	k8s.Overlays = append(k8s.Overlays, iopv1alpha1.KubernetesOverlay{
+		ApiVersion: "apps/v1",
+		Kind:       "Deployment",
+		Name:       gateway.Name,
+		Patches: []iopv1alpha1.Patch{
+			{
+				Path:  "spec.template.metadata.labels.kyma-project\\.io/module",
+				Value: structpb.NewStringValue("istio"),
+			},
+		},
+	})
+
+	var servicePatches []iopv1alpha1.Patch
+	if gateway.ServiceType != nil {
+		servicePatches = append(servicePatches, iopv1alpha1.Patch{
+			Path:  "spec.type",
+			Value: structpb.NewStringValue(string(*gateway.ServiceType)),
+		})
+	}
+	if gateway.ExternalTrafficPolicy != nil {
+		servicePatches = append(servicePatches, iopv1alpha1.Patch{
+			Path:  "spec.externalTrafficPolicy",
+			Value: structpb.NewStringValue(*gateway.ExternalTrafficPolicy),
+		})
+	}
+	if len(servicePatches) > 0 {
+		k8s.Overlays = append(k8s.Overlays, iopv1alpha1.KubernetesOverlay{
+			ApiVersion: "v1",
+			Kind:       "Service",
+			Name:       gateway.Name,
+			Patches:    servicePatches,
+		})
+	}
+
+	// The labels replace the labels of the default gateway, so the default gateway selectors don't match the Pods of the additional gateway.
+	labels := map[string]string{
+		"app":   gateway.Name,
+		"istio": gateway.Name,
+	}
+	maps.Copy(labels, gateway.Labels)
+
+	return iopv1alpha1.GatewayComponentSpec{
+		ComponentSpec: iopv1alpha1.ComponentSpec{
+			Enabled:    boolValue(true),
+			Namespace:  "istio-system",
+			Kubernetes: k8s,
+		},
+		Name:  gateway.Name,
+		Label: labels,
+	}, nil
+}
+
 //nolint:gocognit,funlen // cognitive complexity 61 of func `mergeK8sConfig` is high (> 20), Function 'mergeK8sConfig' has too many statements (52 > 50) TODO: refactor this function
-func mergeK8sConfig(base *iopv1alpha1.KubernetesResources, newConfig KubernetesResourcesConfig) error {
+func mergeK8sConfig(base *iopv1alpha1.KubernetesResources, newConfig KubernetesResourcesConfig, deploymentName string) error {
 	//nolint:nestif // `if newConfig.Resources != nil` has complex nested blocks (complexity: 27) TODO refactor
 	if newConfig.Resources != nil {
 		if base.Resources == nil {
@@ -795,9 +1341,163 @@ func mergeK8sConfig(base *iopv1alpha1.KubernetesResources, newConfig KubernetesR
 			}
 		}
 	}
+
+	if newConfig.PodDisruptionBudget != nil {
+		err := mergePodDisruptionBudget(base, *newConfig.PodDisruptionBudget, deploymentName)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(newConfig.NodeSelector) > 0 {
+		if base.NodeSelector == nil {
+			base.NodeSelector = map[string]string{}
+		}
+		maps.Copy(base.NodeSelector, newConfig.NodeSelector)
+	}
+
+	if len(newConfig.Tolerations) > 0 {
+		base.Tolerations = nil
+		for _, toleration := range newConfig.Tolerations {
+			base.Tolerations = append(base.Tolerations, toleration.DeepCopy())
+		}
+	}
+
+	if newConfig.Affinity != nil {
+		base.Affinity = mergeAffinity(base.Affinity, *newConfig.Affinity)
+	}
+
+	if newConfig.PriorityClassName != nil {
+		base.PriorityClassName = *newConfig.PriorityClassName
+	}
+
+	// KubernetesResources doesn't support topology spread constraints, so they are applied with an overlay on the Deployment
+	if len(newConfig.TopologySpreadConstraints) > 0 {
+		constraints, err := topologySpreadConstraintsValue(newConfig.TopologySpreadConstraints)
+		if err != nil {
+			return err
+		}
+		base.Overlays = append(base.Overlays, iopv1alpha1.KubernetesOverlay{
+			ApiVersion: "apps/v1",
+			Kind:       "Deployment",
+			Name:       deploymentName,
+			Patches: []iopv1alpha1.Patch{
+				{
+					Path:  "spec.template.spec.topologySpreadConstraints",
+					Value: constraints,
+				},
+			},
+		})
+	}
 	return nil
 }
 
+// mergePodDisruptionBudget replaces the PodDisruptionBudget of the component. The PodDisruptionBudget must allow evicting at least one Pod
+// when the component runs with the minimum number of replicas, otherwise node drains are blocked.
+func mergePodDisruptionBudget(base *iopv1alpha1.KubernetesResources, pdb PodDisruptionBudget, deploymentName string) error {
+	minReplicas := int32(1)
+	if base.HpaSpec != nil && base.HpaSpec.MinReplicas != nil {
+		minReplicas = *base.HpaSpec.MinReplicas
+	}
+	if err := pdb.ValidateMinReplicas(deploymentName, minReplicas); err != nil {
+		return err
+	}
+
+	spec := &policyv1.PodDisruptionBudgetSpec{}
+	if pdb.MinAvailable != nil {
+		minAvailableValue := *pdb.MinAvailable
+		spec.MinAvailable = &minAvailableValue
+	}
+	if pdb.MaxUnavailable != nil {
+		maxUnavailableValue := *pdb.MaxUnavailable
+		spec.MaxUnavailable = &maxUnavailableValue
+	}
+
+	base.PodDisruptionBudget = spec
+	return nil
+}
+
+// ValidateMinReplicas returns an error if the PodDisruptionBudget doesn't allow evicting at least one Pod of the component when it runs
+// with the given minimum number of replicas, because such a PodDisruptionBudget blocks node drains.
+func (pdb PodDisruptionBudget) ValidateMinReplicas(deploymentName string, minReplicas int32) error {
+	if minReplicas < 2 {
+		return fmt.Errorf("podDisruptionBudget of %s requires at least 2 minimum replicas, but the minimum replicas are %d", deploymentName, minReplicas)
+	}
+	if pdb.MinAvailable != nil {
+		minAvailable, err := intstr.GetScaledValueFromIntOrPercent(pdb.MinAvailable, int(minReplicas), true)
+		if err != nil {
+			return err
+		}
+		if minAvailable >= int(minReplicas) {
+			return fmt.Errorf("podDisruptionBudget minAvailable of %s must be lower than the minimum replicas %d", deploymentName, minReplicas)
+		}
+	}
+	if pdb.MaxUnavailable != nil {
+		maxUnavailable, err := intstr.GetScaledValueFromIntOrPercent(pdb.MaxUnavailable, int(minReplicas), true)
+		if err != nil {
+			return err
+		}
+		if maxUnavailable < 1 {
+			return fmt.Errorf("podDisruptionBudget maxUnavailable of %s must allow at least one unavailable Pod", deploymentName)
+		}
+	}
+	return nil
+}
+
+// mergeAffinity replaces each type of affinity of the base that is configured in the new affinity.
+func mergeAffinity(base *corev1.Affinity, newAffinity corev1.Affinity) *corev1.Affinity {
+	if base == nil {
+		base = &corev1.Affinity{}
+	}
+	if newAffinity.NodeAffinity != nil {
+		base.NodeAffinity = newAffinity.NodeAffinity.DeepCopy()
+	}
+	if newAffinity.PodAffinity != nil {
+		base.PodAffinity = newAffinity.PodAffinity.DeepCopy()
+	}
+	if newAffinity.PodAntiAffinity != nil {
+		base.PodAntiAffinity = newAffinity.PodAntiAffinity.DeepCopy()
+	}
+	return base
+}
+
+func topologySpreadConstraintsValue(constraints []corev1.TopologySpreadConstraint) (*structpb.Value, error) {
+	raw, err := json.Marshal(constraints)
+	if err != nil {
+		return nil, err
+	}
+	var list []any
+	err = json.Unmarshal(raw, &list)
+	if err != nil {
+		return nil, err
+	}
+	return structpb.NewValue(list)
+}
+
+const egressGatewayName = "istio-egressgateway"
+
+// istiodDeploymentName returns the name of the istiod Deployment, which contains the revision if the control plane is installed as a revision.
+func istiodDeploymentName(revision string) string {
+	if revision == "" {
+		return "istiod"
+	}
+	return "istiod-" + revision
+}
+
+// defaultCniTolerations are the tolerations that the Istio chart sets for the Istio CNI DaemonSet, so it runs on all nodes.
+func defaultCniTolerations() []*corev1.Toleration {
+	return []*corev1.Toleration{
+		{Effect: corev1.TaintEffectNoSchedule, Operator: corev1.TolerationOpExists},
+		{Key: "CriticalAddonsOnly", Operator: corev1.TolerationOpExists},
+		{Effect: corev1.TaintEffectNoExecute, Operator: corev1.TolerationOpExists},
+	}
+}
+
+// cniNotReadyToleration is the toleration that the Istio chart sets for istiod, so istiod can run on nodes on which Istio CNI isn't ready yet.
+func cniNotReadyToleration() *corev1.Toleration {
+	return &corev1.Toleration{Key: "cni.istio.io/not-ready", Operator: corev1.TolerationOpExists}
+}
+
 func removeMemoryMetricsFromHPA(hpaSpec *autoscalingv2.HorizontalPodAutoscalerSpec) {
 	if hpaSpec == nil || len(hpaSpec.Metrics) == 0 {
 		return
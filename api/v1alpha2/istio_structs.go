@@ -1,7 +1,10 @@
 package v1alpha2
 
 import (
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
@@ -52,6 +55,152 @@ type Config struct {
 	// For more information, see [Envoy Statistics](https://istio.io/latest/docs/ops/configuration/telemetry/envoy-stats/).
 	// +kubebuilder:validation:Optional
 	ProxyStatsMatcher *ProxyStatsMatcher `json:"proxyStatsMatcher,omitempty"`
+
+	// Configures distributed tracing in the service mesh, including the sampling rate and additional OpenTelemetry tracing providers.
+	// +kubebuilder:validation:Optional
+	Tracing *Tracing `json:"tracing,omitempty"`
+
+	// Configures access logging in the service mesh, including additional access log providers, the default providers, and a filter.
+	// +kubebuilder:validation:Optional
+	AccessLogging *AccessLogging `json:"accessLogging,omitempty"`
+
+	// Configures the mutual TLS (mTLS) mode enforced in the service mesh.
+	// If not set, mTLS is enforced in STRICT mode across the whole mesh.
+	// +kubebuilder:validation:Optional
+	Mtls *Mtls `json:"mtls,omitempty"`
+
+	// Configures a custom certificate authority (CA) that istiod uses to sign the workload certificates.
+	// If not set, istiod uses a self-signed root certificate.
+	// +kubebuilder:validation:Optional
+	CertificateAuthority *CertificateAuthority `json:"certificateAuthority,omitempty"`
+
+	// Configures the automated rotation of the self-signed root certificate authority (CA) of istiod.
+	// The rotation doesn't apply if a custom CA is configured.
+	// +kubebuilder:validation:Optional
+	RootCARotation *RootCARotation `json:"rootCARotation,omitempty"`
+}
+
+// Configures the automated rotation of the self-signed root certificate authority (CA) of istiod.
+type RootCARotation struct {
+	// Defines how long before the expiry of the self-signed root certificate the rotation starts. The default value is `2160h` (90 days).
+	// The rotation restarts istiod and all proxies three times, so the time must be long enough to finish all restarts.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="duration(self) > duration('0s')",message="renewBefore must be positive"
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+}
+
+// Configures a custom certificate authority (CA), which the Istio module puts in place as the `istio-system/cacerts` Secret.
+type CertificateAuthority struct {
+	// References the Secret with the CA. The Secret must contain the CA certificate in `ca-cert.pem`, its private key in `ca-key.pem`,
+	// the root certificate in `root-cert.pem`, and the certificate chain from the CA certificate to the root certificate in `cert-chain.pem`.
+	// +kubebuilder:validation:Required
+	SecretRef SecretReference `json:"secretRef"`
+}
+
+// References a Secret in the cluster.
+// +kubebuilder:validation:XValidation:rule="!(self.namespace == 'istio-system' && self.name == 'cacerts')",message="secretRef must not reference the istio-system/cacerts Secret, which is managed by the Istio module"
+type SecretReference struct {
+	// Defines the name of the Secret.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name"`
+	// Defines the namespace of the Secret.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Namespace string `json:"namespace"`
+}
+
+// Defines the mutual TLS (mTLS) mode applied to workloads in the service mesh.
+type MtlsMode string
+
+const (
+	// Workloads accept only mTLS traffic.
+	MtlsModeStrict MtlsMode = "STRICT"
+	// Workloads accept both mTLS and plain text traffic.
+	MtlsModePermissive MtlsMode = "PERMISSIVE"
+)
+
+// Configures the mesh-wide mTLS mode and the namespaces that use a different mode.
+type Mtls struct {
+	// Defines the mTLS mode applied to the whole service mesh. The default value is "STRICT".
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=STRICT;PERMISSIVE
+	// +kubebuilder:default=STRICT
+	Mode MtlsMode `json:"mode,omitempty"`
+
+	// Defines a list of namespaces that use an mTLS mode different from the mesh-wide one.
+	// For each namespace in the list, the Istio module creates and manages a namespace-wide PeerAuthentication.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=100
+	// +listType=map
+	// +listMapKey=name
+	Namespaces []MtlsNamespace `json:"namespaces,omitempty"`
+}
+
+// Configures the mTLS mode of a single namespace.
+type MtlsNamespace struct {
+	// Defines the name of the namespace.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:XValidation:rule="self != 'istio-system'",message="the mesh-wide mTLS mode of istio-system namespace is configured with the mode field"
+	Name string `json:"name"`
+
+	// Defines the mTLS mode applied to the workloads in the namespace.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=STRICT;PERMISSIVE
+	Mode MtlsMode `json:"mode"`
+}
+
+// GetMtlsMode returns the mesh-wide mTLS mode. If the mode is not configured, STRICT mode is returned.
+func (i *Istio) GetMtlsMode() MtlsMode {
+	if i.Spec.Config.Mtls == nil || i.Spec.Config.Mtls.Mode == "" {
+		return MtlsModeStrict
+	}
+	return i.Spec.Config.Mtls.Mode
+}
+
+// GetMtlsNamespaces returns the namespaces that use an mTLS mode different from the mesh-wide one.
+func (i *Istio) GetMtlsNamespaces() []MtlsNamespace {
+	if i.Spec.Config.Mtls == nil {
+		return nil
+	}
+	return i.Spec.Config.Mtls.Namespaces
+}
+
+// GetCertificateAuthorityFingerprint returns the fingerprint of the custom certificate authority that istiod uses,
+// or an empty string if istiod uses a self-signed root certificate.
+func (i *Istio) GetCertificateAuthorityFingerprint() string {
+	if i.Status.CertificateAuthority == nil {
+		return ""
+	}
+	return i.Status.CertificateAuthority.Fingerprint
+}
+
+// GetProxyConfigHashes returns the proxy config hashes of the last installation of Istio, or empty hashes if Istio was not installed yet.
+func (i *Istio) GetProxyConfigHashes() ProxyConfigHashes {
+	if i.Status.ProxyConfigHashes == nil {
+		return ProxyConfigHashes{}
+	}
+	return *i.Status.ProxyConfigHashes
+}
+
+const defaultRootCARenewBefore = 90 * 24 * time.Hour
+
+// GetRootCARenewBefore returns how long before the expiry of the self-signed root certificate the rotation starts.
+func (i *Istio) GetRootCARenewBefore() time.Duration {
+	if i.Spec.Config.RootCARotation == nil || i.Spec.Config.RootCARotation.RenewBefore == nil || i.Spec.Config.RootCARotation.RenewBefore.Duration <= 0 {
+		return defaultRootCARenewBefore
+	}
+	return i.Spec.Config.RootCARotation.RenewBefore.Duration
+}
+
+// IsRootCARotationInProgress returns true if the rotation of the self-signed root certificate authority is in progress.
+func (i *Istio) IsRootCARotationInProgress() bool {
+	return i.Status.RootCARotation != nil && i.Status.RootCARotation.Phase != RootCARotationPhaseNone
 }
 
 // Configures the stats matcher for Istio proxy sidecars and gateways.
@@ -83,7 +232,13 @@ type Components struct {
 	// Configures the Istiod component.
 	Pilot *IstioComponent `json:"pilot,omitempty"`
 	// Configures the Istio Ingress Gateway component.
-	IngressGateway *IstioComponent `json:"ingressGateway,omitempty"`
+	IngressGateway *IngressGateway `json:"ingressGateway,omitempty"`
+	// Defines additional Istio Ingress Gateways deployed in the `istio-system` namespace, for example, a public and an internal gateway.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=10
+	// +listType=map
+	// +listMapKey=name
+	AdditionalIngressGateways []AdditionalIngressGateway `json:"additionalIngressGateways,omitempty"`
 	// Configures the Istio CNI DaemonSet component.
 	Cni *CniComponent `json:"cni,omitempty"`
 	// Configures the Istio sidecar proxy component.
@@ -104,13 +259,91 @@ type KubernetesResourcesConfig struct {
 	// Defines Kubernetes resources' configuration. See [Resource Management for Pods and Containers](https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/).
 	// +kubebuilder:validation:Optional
 	Resources *Resources `json:"resources,omitempty"`
+	// Defines the labels of the nodes on which the Pods can be scheduled. The labels are added to the default node selector of the component.
+	// See [Assign Pods to Nodes](https://kubernetes.io/docs/concepts/scheduling-eviction/assign-pod-node/#nodeselector).
+	// +kubebuilder:validation:Optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Defines the tolerations of the Pods, which allow scheduling the Pods on nodes with matching taints. The tolerations replace the default tolerations of the component.
+	// For istiod, the toleration of the `cni.istio.io/not-ready` taint is always kept.
+	// See [Taints and Tolerations](https://kubernetes.io/docs/concepts/scheduling-eviction/taint-and-toleration/).
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=50
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// Defines the Pod scheduling affinity constraints. Each configured type of affinity replaces the default affinity of the same type.
+	// See [Affinity and anti-affinity](https://kubernetes.io/docs/concepts/scheduling-eviction/assign-pod-node/#affinity-and-anti-affinity).
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+	// Defines how the Pods are spread across topology domains, such as zones or nodes.
+	// See [Pod Topology Spread Constraints](https://kubernetes.io/docs/concepts/scheduling-eviction/topology-spread-constraints/).
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=10
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	// Defines the name of the PriorityClass of the Pods. The PriorityClass must exist in the cluster.
+	// See [Pod Priority and Preemption](https://kubernetes.io/docs/concepts/scheduling-eviction/pod-priority-preemption/).
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	PriorityClassName *string `json:"priorityClassName,omitempty"`
+	// Configures the PodDisruptionBudget of the component, which limits the number of Pods that are evicted at the same time, for example, during a node pool upgrade.
+	// The PodDisruptionBudget must allow evicting at least one Pod when the component runs with the minimum number of replicas of its HorizontalPodAutoscaler.
+	// If **hpaSpec.minReplicas** is not set, the default minimum number of replicas of the cluster size applies, which is `1` on evaluation clusters.
+	// Set **hpaSpec.minReplicas** to at least `2` together with the PodDisruptionBudget.
+	// See [Specifying a Disruption Budget for your Application](https://kubernetes.io/docs/tasks/run-application/configure-pdb/).
+	// +kubebuilder:validation:Optional
+	PodDisruptionBudget *PodDisruptionBudget `json:"podDisruptionBudget,omitempty"`
+}
+
+// Configures the PodDisruptionBudget of a component. Exactly one of **minAvailable** or **maxUnavailable** must be set.
+// +kubebuilder:validation:XValidation:rule="has(self.minAvailable) != has(self.maxUnavailable)",message="exactly one of minAvailable or maxUnavailable must be set"
+type PodDisruptionBudget struct {
+	// Specifies the number or percentage of Pods that must remain available during an eviction.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XIntOrString
+	// +kubebuilder:validation:Pattern="^((100|[0-9]{1,2})%|[0-9]+)$"
+	// +kubebuilder:validation:XValidation:rule="(type(self) == int ? self >= 0 && self <= 2147483647: self.size() >= 0)",message="must not be negative, more than 2147483647 or an empty string"
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+	// Specifies the maximum number or percentage of Pods that can be unavailable during an eviction.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XIntOrString
+	// +kubebuilder:validation:Pattern="^((100|[0-9]{1,2})%|[0-9]+)$"
+	// +kubebuilder:validation:XValidation:rule="(type(self) == int ? self >= 0 && self <= 2147483647: self.size() >= 0)",message="must not be negative, more than 2147483647 or an empty string"
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
 }
 
 // Configures the Istio sidecar proxy component.
 type ProxyComponent struct {
 	// Contains a subset of [KubernetesResourcesSpec](https://istio.io/latest/docs/reference/config/istio.operator.v1alpha1/#KubernetesResourcesSpec).
-	// +kubebuilder:validation:Required
-	K8S *ProxyK8sConfig `json:"k8s"`
+	// +kubebuilder:validation:Optional
+	K8S *ProxyK8sConfig `json:"k8s,omitempty"`
+	// Configures the startup and shutdown behavior of the Istio sidecar proxies.
+	// When the configuration changes, the proxy sidecars are restarted to apply it.
+	// +kubebuilder:validation:Optional
+	Lifecycle *ProxyLifecycle `json:"lifecycle,omitempty"`
+}
+
+// Configures the startup and shutdown behavior of the Istio sidecar proxies. See [ProxyConfig](https://istio.io/latest/docs/reference/config/istio.mesh.v1alpha1/#ProxyConfig).
+type ProxyLifecycle struct {
+	// Defines whether the application container starts only after the proxy sidecar is ready to handle traffic. The default value is `true`.
+	// +kubebuilder:validation:Optional
+	HoldApplicationUntilProxyStarts *bool `json:"holdApplicationUntilProxyStarts,omitempty"`
+	// Defines how long the proxy sidecar keeps draining the existing connections after the Pod receives the termination signal, for example, `30s`.
+	// The default value is `5s`.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('0s')",message="terminationDrainDuration must not be negative"
+	TerminationDrainDuration *metav1.Duration `json:"terminationDrainDuration,omitempty"`
+	// Defines the number of worker threads of the proxy sidecar. If set to `0`, the proxy sidecar uses all CPU cores of the node.
+	// By default, the number of worker threads is based on the CPU limit of the proxy sidecar.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=256
+	Concurrency *int32 `json:"concurrency,omitempty"`
+	// Defines whether the proxy sidecar keeps draining after the Pod receives the termination signal until there are no active connections left.
+	// The **terminationDrainDuration** is then the minimum time the proxy sidecar waits before it checks the active connections.
+	// +kubebuilder:validation:Optional
+	ExitOnZeroActiveConnections *bool `json:"exitOnZeroActiveConnections,omitempty"`
 }
 
 // Contains a subset of [KubernetesResourcesSpec](https://istio.io/latest/docs/reference/config/istio.operator.v1alpha1/#KubernetesResourcesSpec).
@@ -130,10 +363,18 @@ type CniComponent struct {
 type CniK8sConfig struct {
 	// Defines the Pod scheduling affinity constraints. See [Affinity and anti-affinity](https://kubernetes.io/docs/concepts/scheduling-eviction/assign-pod-node/#affinity-and-anti-affinity).
 	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
 	Affinity *corev1.Affinity `json:"affinity,omitempty"`
 	// Defines Kubernetes resources' configuration. See [Resource Management for Pods and Containers](https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/).
 	// +kubebuilder:validation:Optional
 	Resources *Resources `json:"resources,omitempty"`
+	// Defines tolerations added to the default tolerations of the Istio CNI DaemonSet. By default, the DaemonSet tolerates all taints with the `NoSchedule` and `NoExecute` effects.
+	// See [Taints and Tolerations](https://kubernetes.io/docs/concepts/scheduling-eviction/taint-and-toleration/).
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=50
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
 }
 
 // Configures the [HorizontalPodAutoscaler](https://kubernetes.io/docs/tasks/run-application/horizontal-pod-autoscale/).
@@ -149,7 +390,7 @@ type HPASpec struct {
 	MinReplicas *int32 `json:"minReplicas,omitempty"`
 }
 
-// Defines the configuration for the generic Istio components, that is, Istio Ingress gateway and istiod.
+// Defines the configuration for the generic Istio components, that is, istiod.
 type IstioComponent struct {
 	// Defines the Kubernetes resources' configuration for Istio components. It's a subset of [KubernetesResourcesSpec](https://istio.io/latest/docs/reference/config/istio.operator.v1alpha1/#KubernetesResourcesSpec).
 	// +kubebuilder:validation:Required
@@ -197,6 +438,81 @@ type ResourceClaims struct {
 	Memory *string `json:"memory,omitempty"`
 }
 
+// Configures the Istio Ingress Gateway component.
+type IngressGateway struct {
+	// Defines the Kubernetes resources' configuration for Istio Ingress Gateway. It's a subset of [KubernetesResourcesSpec](https://istio.io/latest/docs/reference/config/istio.operator.v1alpha1/#KubernetesResourcesSpec).
+	// +kubebuilder:validation:Optional
+	K8s *KubernetesResourcesConfig `json:"k8s,omitempty"`
+	// Configures the Service of Istio Ingress Gateway.
+	// +kubebuilder:validation:Optional
+	Service *IngressGatewayService `json:"service,omitempty"`
+}
+
+// Configures the Service of Istio Ingress Gateway.
+type IngressGatewayService struct {
+	// Defines additional ports exposed by the Service, for example, to expose non-HTTP services. The ports `15021`, `80`, and `443` are always exposed.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=20
+	// +listType=map
+	// +listMapKey=name
+	Ports []GatewayServicePort `json:"ports,omitempty"`
+	// Restricts the client IP ranges that can access the load balancer, for example, `203.0.113.0/24`. Each range must be a valid CIDR.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=50
+	// +listType=set
+	LoadBalancerSourceRanges []string `json:"loadBalancerSourceRanges,omitempty"`
+	// Requests a static IP address for the load balancer. Whether the IP address can be requested depends on the cloud provider.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MinLength=1
+	LoadBalancerIP *string `json:"loadBalancerIP,omitempty"`
+	// Defines annotations added to the Service on top of the annotations set for the cloud provider.
+	// The annotations that are managed for the cloud provider can't be overridden.
+	// +kubebuilder:validation:Optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Exposes Istio Ingress Gateway using an internal load balancer, which is only reachable from the network of the cluster.
+	// The Istio module sets the annotations required by the cloud provider. The default value is `false`.
+	// +kubebuilder:validation:Optional
+	Internal bool `json:"internal,omitempty"`
+}
+
+// Defines the protocol of a Service port.
+type GatewayServicePortProtocol string
+
+const (
+	// The port uses TCP.
+	GatewayServicePortProtocolTCP GatewayServicePortProtocol = "TCP"
+	// The port uses UDP.
+	GatewayServicePortProtocolUDP GatewayServicePortProtocol = "UDP"
+)
+
+// Defines an additional port exposed by the Service of Istio Ingress Gateway.
+// +kubebuilder:validation:XValidation:rule="has(self.targetPort) || self.port > 1024",message="targetPort must be set for a port lower than 1025"
+type GatewayServicePort struct {
+	// Specifies a unique name of the port. The names `status-port`, `http2`, and `https` are used by the default ports.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=15
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:XValidation:rule="self != 'status-port' && self != 'http2' && self != 'https'",message="the name is used by a default port of Istio Ingress Gateway"
+	Name string `json:"name"`
+	// Specifies the port exposed by the Service.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+	// Specifies the port on which the gateway Pods accept the traffic. The gateway Pods don't run as root, so the target port must be greater than `1024`.
+	// If not set, the value of **port** is used.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1025
+	// +kubebuilder:validation:Maximum=65535
+	TargetPort *int32 `json:"targetPort,omitempty"`
+	// Defines the protocol of the port. The default value is `TCP`.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=TCP;UDP
+	// +kubebuilder:default=TCP
+	Protocol GatewayServicePortProtocol `json:"protocol,omitempty"`
+}
+
 // Configures the Istio Egress Gateway component.
 type EgressGateway struct {
 	// Defines the Kubernetes resources' configuration for Istio Egress Gateway. It's a subset of [KubernetesResourcesSpec](https://istio.io/latest/docs/reference/config/istio.operator.v1alpha1/#KubernetesResourcesSpec).
@@ -206,3 +522,264 @@ type EgressGateway struct {
 	// +kubebuilder:validation:Optional
 	Enabled *bool `json:"enabled,omitempty"`
 }
+
+// Defines how upgrades of the Istio control plane are rolled out.
+type UpgradeStrategy struct {
+	// Defines the upgrade mode. With `InPlace`, the Istio control plane is upgraded in place and all proxy sidecars are restarted at once.
+	// With `Canary`, the new Istio version is installed as a separate revision alongside the old one. The namespaces are moved to the new revision in stages, and the old revision is removed
+	// after the proxy sidecars of all moved namespaces run the new Istio version. The default value is `InPlace`.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=InPlace;Canary
+	// +kubebuilder:default=InPlace
+	Mode UpgradeMode `json:"mode,omitempty"`
+	// Defines the number of namespaces that are moved to the new revision in one stage of a canary upgrade. The default value is `1`.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	NamespacesPerStage *int `json:"namespacesPerStage,omitempty"`
+	// Configures the pre-flight check that reports the Istio resources created by you that are likely to break on the new Istio version before the upgrade is installed.
+	// +kubebuilder:validation:Optional
+	Preflight *UpgradePreflight `json:"preflight,omitempty"`
+}
+
+// Configures the pre-flight check of Istio resources created by the user that runs before Istio is upgraded to a new version.
+// The findings are written to the `kyma-system/istio-upgrade-preflight` ConfigMap.
+type UpgradePreflight struct {
+	// Blocks the upgrade to a new Istio version until the findings of the pre-flight check are acknowledged in **acknowledgedVersion**.
+	// The default value is `false`, which means that the findings are only reported.
+	// +kubebuilder:validation:Optional
+	BlockUntilAcknowledged bool `json:"blockUntilAcknowledged,omitempty"`
+	// Acknowledges the findings of the pre-flight check for the upgrade to the given Istio version, for example, `1.28.0`.
+	// +kubebuilder:validation:Optional
+	AcknowledgedVersion string `json:"acknowledgedVersion,omitempty"`
+}
+
+// Defines the upgrade mode of the Istio control plane.
+type UpgradeMode string
+
+const (
+	// Upgrade the Istio control plane in place.
+	UpgradeModeInPlace UpgradeMode = "InPlace"
+	// Install the new Istio version as a separate revision and move the namespaces to it in stages.
+	UpgradeModeCanary UpgradeMode = "Canary"
+)
+
+// IsCanaryUpgradeEnabled returns true if the Istio control plane is upgraded using a canary revision.
+func (i *Istio) IsCanaryUpgradeEnabled() bool {
+	return i.Spec.UpgradeStrategy != nil && i.Spec.UpgradeStrategy.Mode == UpgradeModeCanary
+}
+
+// IsUpgradeBlockedByPreflight returns true if the upgrade to the given Istio version must wait until the pre-flight findings are acknowledged.
+func (i *Istio) IsUpgradeBlockedByPreflight(targetVersion string) bool {
+	if i.Spec.UpgradeStrategy == nil || i.Spec.UpgradeStrategy.Preflight == nil {
+		return false
+	}
+	preflight := i.Spec.UpgradeStrategy.Preflight
+	return preflight.BlockUntilAcknowledged && preflight.AcknowledgedVersion != targetVersion
+}
+
+// GetNamespacesPerStage returns the number of namespaces that are moved to the new revision in one stage of a canary upgrade.
+func (i *Istio) GetNamespacesPerStage() int {
+	if i.Spec.UpgradeStrategy == nil || i.Spec.UpgradeStrategy.NamespacesPerStage == nil || *i.Spec.UpgradeStrategy.NamespacesPerStage < 1 {
+		return 1
+	}
+	return *i.Spec.UpgradeStrategy.NamespacesPerStage
+}
+
+// Defines the rollout of proxy sidecar restarts. Restart waves and maintenance windows only apply to customer workloads. Proxy sidecars of Kyma workloads
+// are always restarted immediately.
+type ProxyRestart struct {
+	// Defines the ordered waves in which the proxy sidecars of customer workloads are restarted. The waves are restarted one after another.
+	// A namespace belongs to the first wave whose selector matches the namespace labels. The namespaces that don't match any wave are restarted after all waves.
+	// +kubebuilder:validation:Optional
+	Waves []RestartWave `json:"waves,omitempty"`
+	// Defines the time windows in which the proxy sidecars of customer workloads are allowed to be restarted.
+	// If no windows are defined, the restarts are allowed at any time. Outside of the windows, the restarts are deferred until the next window opens.
+	// +kubebuilder:validation:Optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+	// Enables waiting for the restarted workloads to become ready after each batch of restarted Pods. If too many workloads don't become ready,
+	// the restart of further workloads is stopped until they become ready.
+	// +kubebuilder:validation:Optional
+	HealthGate *RestartHealthGate `json:"healthGate,omitempty"`
+	// Defines which customer workloads are restarted automatically. With `OptOut`, all workloads are restarted except the ones in namespaces,
+	// Deployments, StatefulSets, and DaemonSets labeled with `operator.kyma-project.io/proxy-restart: disabled`. With `OptIn`, only the workloads in namespaces
+	// labeled with `operator.kyma-project.io/proxy-restart: enabled` are restarted. Workloads that are not restarted automatically are reported as requiring a manual restart. The default value is `OptOut`.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=OptOut;OptIn
+	// +kubebuilder:default=OptOut
+	Mode ProxyRestartMode `json:"mode,omitempty"`
+	// Defines how the proxy sidecars of Pods owned by workload kinds that are not supported for the restart are restarted. Deployments, StatefulSets,
+	// DaemonSets, ReplicaSets, ReplicationControllers, Argo Rollouts, and OpenKruise CloneSets, StatefulSets, and DaemonSets are supported.
+	// With `Warning`, the Pods are not restarted and are reported as requiring a manual restart. With `DeletePod`, the Pods are deleted one at a time,
+	// so that their owner recreates them. With `PatchTemplate`, the restart annotation is added to the Pod template in `spec.template` of the owner,
+	// which triggers a rollout of owners that follow the conventions of Kubernetes workloads. The default value is `Warning`.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Warning;DeletePod;PatchTemplate
+	// +kubebuilder:default=Warning
+	UnknownOwnerStrategy UnknownOwnerStrategy `json:"unknownOwnerStrategy,omitempty"`
+	// Defines how many workloads are restarted at the same time and how many requests the restart sends to the Kubernetes API server.
+	// +kubebuilder:validation:Optional
+	Concurrency *RestartConcurrency `json:"concurrency,omitempty"`
+}
+
+// Defines which customer workloads are restarted automatically.
+type ProxyRestartMode string
+
+const (
+	// Restart all customer workloads except the ones that opted out.
+	ProxyRestartModeOptOut ProxyRestartMode = "OptOut"
+	// Restart only the customer workloads in namespaces that opted in.
+	ProxyRestartModeOptIn ProxyRestartMode = "OptIn"
+)
+
+// Defines how the proxy sidecars of Pods owned by workload kinds that are not supported for the restart are restarted.
+type UnknownOwnerStrategy string
+
+const (
+	// Don't restart the Pods and report them as requiring a manual restart.
+	UnknownOwnerStrategyWarning UnknownOwnerStrategy = "Warning"
+	// Delete the Pods one at a time, so that their owner recreates them.
+	UnknownOwnerStrategyDeletePod UnknownOwnerStrategy = "DeletePod"
+	// Add the restart annotation to the Pod template of the owner.
+	UnknownOwnerStrategyPatchTemplate UnknownOwnerStrategy = "PatchTemplate"
+)
+
+// Defines how the restart of proxy sidecars waits for the restarted workloads to become ready.
+type RestartHealthGate struct {
+	// Defines how long the Deployments, StatefulSets, and DaemonSets restarted in a batch may take to finish their rollout before they are
+	// considered unhealthy. The default value is `5m`.
+	// +kubebuilder:validation:Optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// Defines the number of workloads that may fail to become ready before the restart of further workloads is stopped. The default value is `0`.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	FailureThreshold *int `json:"failureThreshold,omitempty"`
+}
+
+const defaultRestartHealthGateTimeout = 5 * time.Minute
+
+// GetTimeout returns how long to wait for the restarted workloads to finish their rollout.
+func (g *RestartHealthGate) GetTimeout() time.Duration {
+	if g.Timeout == nil || g.Timeout.Duration <= 0 {
+		return defaultRestartHealthGateTimeout
+	}
+	return g.Timeout.Duration
+}
+
+// GetFailureThreshold returns the number of workloads that may fail to become ready before the restart is stopped.
+func (g *RestartHealthGate) GetFailureThreshold() int {
+	if g.FailureThreshold == nil || *g.FailureThreshold < 0 {
+		return 0
+	}
+	return *g.FailureThreshold
+}
+
+// Defines the limits of the concurrent restart of proxy sidecars.
+type RestartConcurrency struct {
+	// Defines the maximum number of workloads that are restarted at the same time. The default value is `5`.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrentRestarts *int `json:"maxConcurrentRestarts,omitempty"`
+	// Defines the maximum number of workloads in a single namespace that are restarted at the same time. The default value is `2`.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	MaxConcurrentRestartsPerNamespace *int `json:"maxConcurrentRestartsPerNamespace,omitempty"`
+	// Defines the maximum number of requests per second that the restart sends to the Kubernetes API server. The default value is `20`.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	RequestsPerSecond *int `json:"requestsPerSecond,omitempty"`
+}
+
+// GetMaxConcurrentRestarts returns the maximum number of workloads that are restarted at the same time, or 0 if it is not set.
+func (c *RestartConcurrency) GetMaxConcurrentRestarts() int {
+	if c == nil || c.MaxConcurrentRestarts == nil {
+		return 0
+	}
+	return *c.MaxConcurrentRestarts
+}
+
+// GetMaxConcurrentRestartsPerNamespace returns the maximum number of workloads in a single namespace that are restarted at the same time, or 0 if it is not set.
+func (c *RestartConcurrency) GetMaxConcurrentRestartsPerNamespace() int {
+	if c == nil || c.MaxConcurrentRestartsPerNamespace == nil {
+		return 0
+	}
+	return *c.MaxConcurrentRestartsPerNamespace
+}
+
+// GetRequestsPerSecond returns the maximum number of requests per second that the restart sends to the Kubernetes API server, or 0 if it is not set.
+func (c *RestartConcurrency) GetRequestsPerSecond() int {
+	if c == nil || c.RequestsPerSecond == nil {
+		return 0
+	}
+	return *c.RequestsPerSecond
+}
+
+// Defines a wave of namespaces in which the proxy sidecars of customer workloads are restarted together.
+type RestartWave struct {
+	// Defines the name of the wave.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Selects the namespaces that belong to the wave.
+	// +kubebuilder:validation:Required
+	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector"`
+}
+
+// Defines a recurring time window in which the proxy sidecars of customer workloads are allowed to be restarted.
+type MaintenanceWindow struct {
+	// Defines the start of the window in the standard cron format with five fields: minute, hour, day of month, month, and day of week,
+	// or with a descriptor such as `@daily`. For example, `0 22 * * MON-FRI` opens the window at 22:00 from Monday to Friday.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=6
+	Schedule string `json:"schedule"`
+	// Defines how long the window stays open after it starts, for example, `2h`.
+	// +kubebuilder:validation:Required
+	Duration metav1.Duration `json:"duration"`
+	// Defines the IANA time zone in which the schedule is evaluated, for example, `Europe/Berlin`. The default value is `UTC`.
+	// +kubebuilder:validation:Optional
+	TimeZone *string `json:"timeZone,omitempty"`
+}
+
+// GetRestartWaves returns the waves in which the proxy sidecars of customer workloads are restarted.
+func (i *Istio) GetRestartWaves() []RestartWave {
+	if i.Spec.ProxyRestart == nil {
+		return nil
+	}
+	return i.Spec.ProxyRestart.Waves
+}
+
+// GetMaintenanceWindows returns the time windows in which the proxy sidecars of customer workloads are allowed to be restarted.
+func (i *Istio) GetMaintenanceWindows() []MaintenanceWindow {
+	if i.Spec.ProxyRestart == nil {
+		return nil
+	}
+	return i.Spec.ProxyRestart.MaintenanceWindows
+}
+
+// IsProxyRestartOptIn returns true if only the customer workloads in namespaces that opted in are restarted automatically.
+func (i *Istio) IsProxyRestartOptIn() bool {
+	return i.Spec.ProxyRestart != nil && i.Spec.ProxyRestart.Mode == ProxyRestartModeOptIn
+}
+
+// GetRestartHealthGate returns the health gate of the proxy sidecar restart or nil if the restart is not health-gated.
+func (i *Istio) GetRestartHealthGate() *RestartHealthGate {
+	if i.Spec.ProxyRestart == nil {
+		return nil
+	}
+	return i.Spec.ProxyRestart.HealthGate
+}
+
+// GetUnknownOwnerStrategy returns how the proxy sidecars of Pods owned by workload kinds that are not supported for the restart are restarted.
+func (i *Istio) GetUnknownOwnerStrategy() UnknownOwnerStrategy {
+	if i.Spec.ProxyRestart == nil || i.Spec.ProxyRestart.UnknownOwnerStrategy == "" {
+		return UnknownOwnerStrategyWarning
+	}
+	return i.Spec.ProxyRestart.UnknownOwnerStrategy
+}
+
+// GetRestartConcurrency returns the limits of the concurrent restart of proxy sidecars or nil if the defaults are used.
+func (i *Istio) GetRestartConcurrency() *RestartConcurrency {
+	if i.Spec.ProxyRestart == nil {
+		return nil
+	}
+	return i.Spec.ProxyRestart.Concurrency
+}
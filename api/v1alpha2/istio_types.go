@@ -43,6 +43,10 @@ const (
 	ConditionTypeReady                             ConditionType = "Ready"
 	ConditionTypeProxySidecarRestartSucceeded      ConditionType = "ProxySidecarRestartSucceeded"
 	ConditionTypeIngressTargetingUserResourceFound ConditionType = "IngressTargetingUserResourceFound"
+	ConditionTypeProxySidecarRestartDeferred       ConditionType = "ProxySidecarRestartDeferred"
+	ConditionTypeUpgradePreflightFindingsFound     ConditionType = "UpgradePreflightFindingsFound"
+	ConditionTypePermissiveMtlsConfigured          ConditionType = "PermissiveMtlsConfigured"
+	ConditionTypeRootCARotationInProgress          ConditionType = "RootCARotationInProgress"
 
 	// General
 
@@ -67,6 +71,9 @@ const (
 	// Reconciliation did not happen as the oldest Istio Custom Resource could not be found.
 	ConditionReasonOldestCRNotFound        ConditionReason = "OldestCRNotFound"
 	ConditionReasonOldestCRNotFoundMessage                 = "Oldest Istio custom resource could not be found"
+	// Reconciliation did not apply the changes, because plan mode is enabled for the Istio Custom Resource.
+	ConditionReasonPlanGenerated        ConditionReason = "PlanGenerated"
+	ConditionReasonPlanGeneratedMessage                 = "Plan mode is enabled. The changes of the Istio custom resource were not applied"
 
 	// Istio installation / uninstallation
 
@@ -91,6 +98,36 @@ const (
 	// Istio version update is not allowed.
 	ConditionReasonIstioVersionUpdateNotAllowed        ConditionReason = "IstioVersionUpdateNotAllowed"
 	ConditionReasonIstioVersionUpdateNotAllowedMessage                 = "Update to the new Istio version is not allowed"
+	// Canary upgrade of the Istio control plane is in progress.
+	ConditionReasonCanaryUpgradeInProgress        ConditionReason = "CanaryUpgradeInProgress"
+	ConditionReasonCanaryUpgradeInProgressMessage                 = "Canary upgrade of the Istio control plane is in progress"
+	// Canary upgrade of the Istio control plane succeeded.
+	ConditionReasonCanaryUpgradeSucceeded        ConditionReason = "CanaryUpgradeSucceeded"
+	ConditionReasonCanaryUpgradeSucceededMessage                 = "Canary upgrade of the Istio control plane succeeded"
+	// Canary upgrade of the Istio control plane failed.
+	ConditionReasonCanaryUpgradeFailed        ConditionReason = "CanaryUpgradeFailed"
+	ConditionReasonCanaryUpgradeFailedMessage                 = "Canary upgrade of the Istio control plane failed"
+	// Istio upgrade is blocked until the findings of the upgrade pre-flight check are acknowledged.
+	ConditionReasonUpgradePreflightNotAcknowledged        ConditionReason = "UpgradePreflightNotAcknowledged"
+	ConditionReasonUpgradePreflightNotAcknowledgedMessage                 = "Istio upgrade is blocked until the findings of the upgrade pre-flight check are acknowledged"
+
+	// Upgrade pre-flight check
+
+	// Upgrade pre-flight check found Istio resources that are likely to break on the new Istio version.
+	ConditionReasonUpgradePreflightFindingsFound        ConditionReason = "UpgradePreflightFindingsFound"
+	ConditionReasonUpgradePreflightFindingsFoundMessage                 = "Upgrade pre-flight check found Istio resources that are likely to break on the new Istio version"
+	// Upgrade pre-flight check found no Istio resources that are likely to break on the new Istio version.
+	ConditionReasonUpgradePreflightFindingsNotFound        ConditionReason = "UpgradePreflightFindingsNotFound"
+	ConditionReasonUpgradePreflightFindingsNotFoundMessage                 = "Upgrade pre-flight check found no Istio resources that are likely to break on the new Istio version"
+
+	// mTLS
+
+	// PERMISSIVE mTLS mode is configured mesh-wide or for at least one namespace.
+	ConditionReasonPermissiveMtlsConfigured        ConditionReason = "PermissiveMtlsConfigured"
+	ConditionReasonPermissiveMtlsConfiguredMessage                 = "PERMISSIVE mTLS mode is configured, workloads accept plain text traffic"
+	// STRICT mTLS mode is enforced in the whole service mesh.
+	ConditionReasonStrictMtlsEnforced        ConditionReason = "StrictMtlsEnforced"
+	ConditionReasonStrictMtlsEnforcedMessage                 = "STRICT mTLS mode is enforced in the whole service mesh"
 
 	// Istio CRs
 
@@ -115,6 +152,15 @@ const (
 	// A manual restart of the proxy sidecar is required for some workloads.
 	ConditionReasonProxySidecarManualRestartRequired        ConditionReason = "ProxySidecarManualRestartRequired"
 	ConditionReasonProxySidecarManualRestartRequiredMessage                 = "Proxy sidecar manual restart is required for some workloads"
+	// Proxy sidecar restart waits for the rollout of the restarted workloads.
+	ConditionReasonProxySidecarRestartInProgress        ConditionReason = "ProxySidecarRestartInProgress"
+	ConditionReasonProxySidecarRestartInProgressMessage                 = "Proxy sidecar restart waits for the rollout of the restarted workloads"
+	// Proxy sidecar restart of customer workloads is deferred until the next maintenance window.
+	ConditionReasonProxySidecarRestartDeferred        ConditionReason = "ProxySidecarRestartDeferred"
+	ConditionReasonProxySidecarRestartDeferredMessage                 = "Proxy sidecar restart of customer workloads is deferred until the next maintenance window"
+	// Proxy sidecar restart of customer workloads is not deferred.
+	ConditionReasonProxySidecarRestartNotDeferred        ConditionReason = "ProxySidecarRestartNotDeferred"
+	ConditionReasonProxySidecarRestartNotDeferredMessage                 = "Proxy sidecar restart of customer workloads is not deferred"
 
 	// Ingress gateway
 
@@ -134,6 +180,30 @@ const (
 	ConditionReasonEgressGatewayRestartFailed        ConditionReason = "EgressGatewayRestartFailed"
 	ConditionReasonEgressGatewayRestartFailedMessage                 = "Istio Egress Gateway restart failed"
 
+	// Certificate authority
+
+	// The custom certificate authority was put in place and istiod was restarted.
+	ConditionReasonCertificateAuthorityUpdated        ConditionReason = "CertificateAuthorityUpdated"
+	ConditionReasonCertificateAuthorityUpdatedMessage                 = "Custom certificate authority was updated and istiod was restarted"
+	// Reconciliation of the custom certificate authority failed.
+	ConditionReasonCertificateAuthorityFailed        ConditionReason = "CertificateAuthorityFailed"
+	ConditionReasonCertificateAuthorityFailedMessage                 = "Custom certificate authority reconciliation failed"
+
+	// Root certificate authority rotation
+
+	// The rotation of the self-signed root certificate authority is in progress.
+	ConditionReasonRootCARotationInProgress        ConditionReason = "RootCARotationInProgress"
+	ConditionReasonRootCARotationInProgressMessage                 = "Rotation of the self-signed root certificate authority is in progress"
+	// The rotation of the self-signed root certificate authority succeeded.
+	ConditionReasonRootCARotationSucceeded        ConditionReason = "RootCARotationSucceeded"
+	ConditionReasonRootCARotationSucceededMessage                 = "Rotation of the self-signed root certificate authority succeeded"
+	// The self-signed root certificate authority doesn't need to be rotated.
+	ConditionReasonRootCARotationNotRequired        ConditionReason = "RootCARotationNotRequired"
+	ConditionReasonRootCARotationNotRequiredMessage                 = "Self-signed root certificate authority doesn't need to be rotated"
+	// The rotation of the self-signed root certificate authority failed.
+	ConditionReasonRootCARotationFailed        ConditionReason = "RootCARotationFailed"
+	ConditionReasonRootCARotationFailedMessage                 = "Rotation of the self-signed root certificate authority failed"
+
 	// User resource
 
 	// Resource targeting Istio Ingress Gateway found.
@@ -175,12 +245,20 @@ type IstioSpec struct {
 	// Enabling this option is likely to cause connectivity issues in the cluster if you don't properly set up your workloads first.
 	// +kubebuilder:validation:Optional
 	NetworkPoliciesEnabled bool `json:"networkPoliciesEnabled,omitempty"`
+	// Defines how upgrades of the Istio control plane to a new Istio version are rolled out.
+	// +kubebuilder:validation:Optional
+	UpgradeStrategy *UpgradeStrategy `json:"upgradeStrategy,omitempty"`
+	// Defines the rollout of proxy sidecar restarts.
+	// +kubebuilder:validation:Optional
+	ProxyRestart *ProxyRestart `json:"proxyRestart,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:categories={kyma-modules,kyma-istio}
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:JSONPath=".status.state",name="State",type="string"
+// +kubebuilder:printcolumn:JSONPath=".status.proxyReset.restartedPods",name="Restarted Pods",type="integer"
+// +kubebuilder:printcolumn:JSONPath=".status.proxyReset.pendingPods",name="Pending Pods",type="integer"
 // +kubebuilder:storageversion
 
 // Contains the Istio custom resource's specification and its current status.
@@ -213,6 +291,129 @@ type IstioStatus struct {
 	Conditions *[]metav1.Condition `json:"conditions,omitempty"`
 	// Describes the Istio status.
 	Description string `json:"description,omitempty"`
+	// Describes the progress of the canary upgrade of the Istio control plane. It is only set while a canary upgrade is in progress.
+	CanaryUpgrade *CanaryUpgradeStatus `json:"canaryUpgrade,omitempty"`
+	// Describes the progress of the last restart of proxy sidecars that had Pods to restart.
+	ProxyReset *ProxyResetStatus `json:"proxyReset,omitempty"`
+	// Describes the restarted workloads that the health gate of the proxy sidecar restart waits for. It is only set if
+	// **spec.proxyRestart.healthGate** is configured and restarted workloads are rolling out or did not become ready.
+	RestartHealthGate *RestartHealthGateStatus `json:"restartHealthGate,omitempty"`
+	// Describes the custom certificate authority (CA) that istiod uses. It is only set if **spec.config.certificateAuthority** is configured.
+	CertificateAuthority *CertificateAuthorityStatus `json:"certificateAuthority,omitempty"`
+	// Describes the self-signed root certificate authority (CA) of istiod and the progress of its rotation.
+	// It is only set if istiod uses a self-signed root certificate.
+	RootCARotation *RootCARotationStatus `json:"rootCARotation,omitempty"`
+	// Contains the hashes of the proxy configuration that was applied with the last installation of Istio.
+	// Proxies that were created with a different hash are restarted.
+	ProxyConfigHashes *ProxyConfigHashes `json:"proxyConfigHashes,omitempty"`
+}
+
+// Describes the phase of the rotation of the self-signed root certificate authority (CA).
+// +kubebuilder:validation:Enum="";TrustBundlePublished;RootSwitched;OldRootRetired
+type RootCARotationPhase string
+
+const (
+	// No rotation is in progress.
+	RootCARotationPhaseNone RootCARotationPhase = ""
+	// istiod distributes a trust bundle with the old and the new root certificate, but still signs the workload certificates with the old root.
+	RootCARotationPhaseTrustBundlePublished RootCARotationPhase = "TrustBundlePublished"
+	// istiod signs the workload certificates with the new root and still distributes the trust bundle with both root certificates.
+	RootCARotationPhaseRootSwitched RootCARotationPhase = "RootSwitched"
+	// istiod uses the new self-signed root certificate and the old root certificate is no longer trusted.
+	RootCARotationPhaseOldRootRetired RootCARotationPhase = "OldRootRetired"
+)
+
+// Describes the self-signed root certificate authority (CA) of istiod and the progress of its rotation.
+type RootCARotationStatus struct {
+	// The phase of the rotation. It is empty if no rotation is in progress.
+	Phase RootCARotationPhase `json:"phase,omitempty"`
+	// The time when the proxies were restarted in the current phase. The next phase starts when all proxies created before this time are restarted.
+	ProxiesRestartedAt *metav1.Time `json:"proxiesRestartedAt,omitempty"`
+	// The time when the self-signed root certificate in use expires.
+	NotAfter metav1.Time `json:"notAfter"`
+}
+
+// Describes the custom certificate authority (CA) that istiod uses.
+type CertificateAuthorityStatus struct {
+	// The subject of the CA certificate.
+	Subject string `json:"subject"`
+	// The time when the CA certificate expires.
+	NotAfter metav1.Time `json:"notAfter"`
+	// The SHA-256 fingerprint of the certificates of the CA. A change of the fingerprint restarts the proxy sidecars.
+	Fingerprint string `json:"fingerprint"`
+}
+
+// Contains the SHA-256 hashes of the rendered mesh configuration that is relevant for the proxies of each target.
+type ProxyConfigHashes struct {
+	// The hash of the proxy configuration of the Istio sidecar proxies.
+	Sidecars string `json:"sidecars,omitempty"`
+	// The hash of the proxy configuration of the Istio Ingress Gateways.
+	IngressGateway string `json:"ingressGateway,omitempty"`
+	// The hash of the proxy configuration of the Istio Egress Gateway.
+	EgressGateway string `json:"egressGateway,omitempty"`
+	// The fingerprint of the custom certificate authority (CA) that is included in the hashes. It is empty if istiod uses a self-signed
+	// root certificate.
+	CertificateAuthorityFingerprint string `json:"certificateAuthorityFingerprint,omitempty"`
+}
+
+// Describes the progress of a canary upgrade of the Istio control plane.
+type CanaryUpgradeStatus struct {
+	// The Istio revision that was installed before the upgrade. It is removed when the upgrade is finished.
+	SourceRevision string `json:"sourceRevision"`
+	// The Istio revision that is installed alongside the source revision.
+	TargetRevision string `json:"targetRevision"`
+	// The Istio version of the target revision.
+	TargetVersion string `json:"targetVersion"`
+	// The namespaces that were already moved to the target revision.
+	MigratedNamespaces []string `json:"migratedNamespaces,omitempty"`
+}
+
+// Describes the progress of a restart of proxy sidecars.
+type ProxyResetStatus struct {
+	// The proxy image to which the sidecars are restarted.
+	TargetImage string `json:"targetImage"`
+	// The number of Pods matched by the restart predicates.
+	MatchedPods int `json:"matchedPods"`
+	// The number of matched Pods whose restart was triggered.
+	RestartedPods int `json:"restartedPods"`
+	// The number of Pods that still need a restart, because the restart was deferred or stopped.
+	PendingPods int `json:"pendingPods"`
+	// The number of workloads that could not be restarted, grouped by the kind of the workload.
+	FailuresByOwnerKind map[string]int `json:"failuresByOwnerKind,omitempty"`
+	// The number of matched Pods, grouped by the name of the predicate that triggered their restart.
+	RestartsByPredicate map[string]int `json:"restartsByPredicate,omitempty"`
+	// The time when the restart started.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// The time when the restart finished. It is not set while Pods are pending.
+	FinishTime *metav1.Time `json:"finishTime,omitempty"`
+}
+
+// Describes the restarted workloads that the health gate of the proxy sidecar restart waits for.
+type RestartHealthGateStatus struct {
+	// The restarted workloads whose rollout is not finished yet. No further workloads are restarted until the rollouts finish
+	// or the timeout of the health gate is exceeded.
+	PendingRollouts []WorkloadReference `json:"pendingRollouts,omitempty"`
+	// The time when the pending workloads were restarted.
+	RolloutStartTime *metav1.Time `json:"rolloutStartTime,omitempty"`
+	// The restarted workloads that did not finish their rollout within the timeout of the health gate. A workload is removed
+	// from the list when its rollout finishes or the workload is deleted.
+	UnhealthyWorkloads []WorkloadReference `json:"unhealthyWorkloads,omitempty"`
+	// Signifies that the restart of proxy sidecars is stopped, because more workloads than the failure threshold of the health gate
+	// are unhealthy. The restart resumes only after enough unhealthy workloads become ready or are deleted, or the failure threshold is increased.
+	Tripped bool `json:"tripped,omitempty"`
+}
+
+// Identifies a workload whose proxy sidecars are restarted.
+type WorkloadReference struct {
+	// The kind of the workload, for example, `Deployment`.
+	Kind string `json:"kind"`
+	// The namespace of the workload.
+	Namespace string `json:"namespace"`
+	// The name of the workload.
+	Name string `json:"name"`
+	// The predicate that triggered the restart of the workload.
+	// +kubebuilder:validation:Optional
+	Predicate string `json:"predicate,omitempty"`
 }
 
 //nolint:gochecknoinits // this is a scaffolded file. TODO: remove init function
@@ -18,6 +18,8 @@ import (
 	"istio.io/istio/pkg/util/protomarshal"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
@@ -972,7 +974,7 @@ var _ = Describe("Merge", func() {
 				memoryLimit := "500Mi"
 
 				istioCR := istiov1alpha2.Istio{Spec: istiov1alpha2.IstioSpec{Components: &istiov1alpha2.Components{
-					IngressGateway: &istiov1alpha2.IstioComponent{
+					IngressGateway: &istiov1alpha2.IngressGateway{
 						K8s: &istiov1alpha2.KubernetesResourcesConfig{
 							Resources: &istiov1alpha2.Resources{
 								Limits: &istiov1alpha2.ResourceClaims{
@@ -1007,7 +1009,7 @@ var _ = Describe("Merge", func() {
 				memoryRequests := "500Mi"
 
 				istioCR := istiov1alpha2.Istio{Spec: istiov1alpha2.IstioSpec{Components: &istiov1alpha2.Components{
-					IngressGateway: &istiov1alpha2.IstioComponent{K8s: &istiov1alpha2.KubernetesResourcesConfig{
+					IngressGateway: &istiov1alpha2.IngressGateway{K8s: &istiov1alpha2.KubernetesResourcesConfig{
 						Resources: &istiov1alpha2.Resources{
 							Requests: &istiov1alpha2.ResourceClaims{
 								CPU:    &cpuRequests,
@@ -1030,6 +1032,260 @@ var _ = Describe("Merge", func() {
 				Expect(iopMemoryRequests.String()).To(Equal(memoryRequests))
 			})
 		})
+
+		Context("Service", func() {
+			providerAnnotations := map[string]string{"service.beta.kubernetes.io/aws-load-balancer-scheme": "internet-facing"}
+			internalAnnotations := map[string]string{
+				"service.beta.kubernetes.io/aws-load-balancer-scheme":   "internal",
+				"service.beta.kubernetes.io/aws-load-balancer-internal": "true",
+			}
+
+			It("should add the configured ports to the default ports of the Service", func() {
+				// given
+				iop := iopv1alpha1.IstioOperator{
+					Spec: iopv1alpha1.IstioOperatorSpec{},
+				}
+				targetPort := int32(9000)
+
+				istioCR := istiov1alpha2.Istio{Spec: istiov1alpha2.IstioSpec{Components: &istiov1alpha2.Components{
+					IngressGateway: &istiov1alpha2.IngressGateway{Service: &istiov1alpha2.IngressGatewayService{
+						Ports: []istiov1alpha2.GatewayServicePort{
+							{Name: "tcp-db", Port: 5432},
+							{Name: "udp-dns", Port: 53, TargetPort: &targetPort, Protocol: istiov1alpha2.GatewayServicePortProtocolUDP},
+						},
+					}},
+				}}}
+
+				// when
+				out, err := istioCR.MergeInto(iop)
+
+				// then
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(out.Spec.Components.IngressGateways[0].Kubernetes.Service.Ports).To(Equal([]corev1.ServicePort{
+					{Name: "status-port", Port: 15021, TargetPort: intstr.FromInt32(15021), Protocol: corev1.ProtocolTCP},
+					{Name: "http2", Port: 80, TargetPort: intstr.FromInt32(8080), Protocol: corev1.ProtocolTCP},
+					{Name: "https", Port: 443, TargetPort: intstr.FromInt32(8443), Protocol: corev1.ProtocolTCP},
+					{Name: "tcp-db", Port: 5432, TargetPort: intstr.FromInt32(5432), Protocol: corev1.ProtocolTCP},
+					{Name: "udp-dns", Port: 53, TargetPort: intstr.FromInt32(9000), Protocol: corev1.ProtocolUDP},
+				}))
+			})
+
+			It("should set loadBalancerSourceRanges and loadBalancerIP of the Service", func() {
+				// given
+				iop := iopv1alpha1.IstioOperator{
+					Spec: iopv1alpha1.IstioOperatorSpec{},
+				}
+				loadBalancerIP := "203.0.113.10"
+
+				istioCR := istiov1alpha2.Istio{Spec: istiov1alpha2.IstioSpec{Components: &istiov1alpha2.Components{
+					IngressGateway: &istiov1alpha2.IngressGateway{Service: &istiov1alpha2.IngressGatewayService{
+						LoadBalancerSourceRanges: []string{"203.0.113.0/24", "198.51.100.0/24"},
+						LoadBalancerIP:           &loadBalancerIP,
+					}},
+				}}}
+
+				// when
+				out, err := istioCR.MergeInto(iop)
+
+				// then
+				Expect(err).ShouldNot(HaveOccurred())
+				service := out.Spec.Components.IngressGateways[0].Kubernetes.Service
+				Expect(service.LoadBalancerSourceRanges).To(Equal([]string{"203.0.113.0/24", "198.51.100.0/24"}))
+				Expect(service.LoadBalancerIP).To(Equal(loadBalancerIP))
+				Expect(service.Ports).To(BeEmpty())
+			})
+
+			It("should add the internal load balancer annotations of the cloud provider and the user annotations", func() {
+				// given
+				iop := iopv1alpha1.IstioOperator{
+					Spec: iopv1alpha1.IstioOperatorSpec{},
+				}
+
+				istioCR := istiov1alpha2.Istio{Spec: istiov1alpha2.IstioSpec{Components: &istiov1alpha2.Components{
+					IngressGateway: &istiov1alpha2.IngressGateway{Service: &istiov1alpha2.IngressGatewayService{
+						Internal:    true,
+						Annotations: map[string]string{"external-dns.alpha.kubernetes.io/hostname": "internal.example.com"},
+					}},
+				}}}
+
+				// when
+				out, err := istioCR.MergeInto(iop, istiov1alpha2.WithLoadBalancerAnnotations(providerAnnotations, internalAnnotations))
+
+				// then
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(out.Spec.Components.IngressGateways[0].Kubernetes.ServiceAnnotations).To(Equal(map[string]string{
+					"service.beta.kubernetes.io/aws-load-balancer-scheme":   "internal",
+					"service.beta.kubernetes.io/aws-load-balancer-internal": "true",
+					"external-dns.alpha.kubernetes.io/hostname":             "internal.example.com",
+				}))
+			})
+
+			It("should return an error when a user annotation overrides an annotation of the cloud provider", func() {
+				// given
+				iop := iopv1alpha1.IstioOperator{
+					Spec: iopv1alpha1.IstioOperatorSpec{},
+				}
+
+				istioCR := istiov1alpha2.Istio{Spec: istiov1alpha2.IstioSpec{Components: &istiov1alpha2.Components{
+					IngressGateway: &istiov1alpha2.IngressGateway{Service: &istiov1alpha2.IngressGatewayService{
+						Annotations: map[string]string{"service.beta.kubernetes.io/aws-load-balancer-scheme": "internal"},
+					}},
+				}}}
+
+				// when
+				_, err := istioCR.MergeInto(iop, istiov1alpha2.WithLoadBalancerAnnotations(providerAnnotations, internalAnnotations))
+
+				// then
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("service.beta.kubernetes.io/aws-load-balancer-scheme"))
+			})
+
+			It("should return an error when an internal load balancer is not supported by the cloud provider", func() {
+				// given
+				iop := iopv1alpha1.IstioOperator{
+					Spec: iopv1alpha1.IstioOperatorSpec{},
+				}
+
+				istioCR := istiov1alpha2.Istio{Spec: istiov1alpha2.IstioSpec{Components: &istiov1alpha2.Components{
+					IngressGateway: &istiov1alpha2.IngressGateway{Service: &istiov1alpha2.IngressGatewayService{
+						Internal: true,
+					}},
+				}}}
+
+				// when
+				_, err := istioCR.MergeInto(iop)
+
+				// then
+				Expect(err).Should(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("not supported by the cloud provider"))
+			})
+		})
+	})
+
+	Context("AdditionalIngressGateways", func() {
+		defaultIngressGatewayIop := func() iopv1alpha1.IstioOperator {
+			minReplicas := int32(3)
+			return iopv1alpha1.IstioOperator{
+				Spec: iopv1alpha1.IstioOperatorSpec{
+					Components: &iopv1alpha1.IstioComponentSpec{
+						IngressGateways: []iopv1alpha1.GatewayComponentSpec{
+							{
+								Name: "istio-ingressgateway",
+								ComponentSpec: iopv1alpha1.ComponentSpec{
+									Kubernetes: &iopv1alpha1.KubernetesResources{
+										HpaSpec: &autoscalingv2.HorizontalPodAutoscalerSpec{
+											ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "istio-ingressgateway"},
+											MinReplicas:    &minReplicas,
+											MaxReplicas:    15,
+										},
+										Resources: &corev1.ResourceRequirements{
+											Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+		}
+
+		It("should add additional ingress gateways after the default ingress gateway", func() {
+			// given
+			istioCR := istiov1alpha2.Istio{Spec: istiov1alpha2.IstioSpec{Components: &istiov1alpha2.Components{
+				AdditionalIngressGateways: []istiov1alpha2.AdditionalIngressGateway{
+					{Name: "public-ingressgateway"},
+					{Name: "internal-ingressgateway"},
+				},
+			}}}
+
+			// when
+			out, err := istioCR.MergeInto(defaultIngressGatewayIop())
+
+			// then
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(out.Spec.Components.IngressGateways).To(HaveLen(3))
+			Expect(out.Spec.Components.IngressGateways[0].Name).To(Equal("istio-ingressgateway"))
+			Expect(out.Spec.Components.IngressGateways[1].Name).To(Equal("public-ingressgateway"))
+			Expect(out.Spec.Components.IngressGateways[1].Namespace).To(Equal("istio-system"))
+			Expect(out.Spec.Components.IngressGateways[1].Enabled.GetValueOrFalse()).To(BeTrue())
+			Expect(out.Spec.Components.IngressGateways[1].Label).To(Equal(map[string]string{"app": "public-ingressgateway", "istio": "public-ingressgateway"}))
+			Expect(out.Spec.Components.IngressGateways[2].Name).To(Equal("internal-ingressgateway"))
+		})
+
+		It("should inherit the Kubernetes configuration of the default ingress gateway and target its own Deployment with the HPA", func() {
+			// given
+			maxReplicas := int32(5)
+			istioCR := istiov1alpha2.Istio{Spec: istiov1alpha2.IstioSpec{Components: &istiov1alpha2.Components{
+				AdditionalIngressGateways: []istiov1alpha2.AdditionalIngressGateway{
+					{
+						Name: "internal-ingressgateway",
+						K8s: &istiov1alpha2.KubernetesResourcesConfig{
+							HPASpec: &istiov1alpha2.HPASpec{MaxReplicas: &maxReplicas},
+						},
+					},
+				},
+			}}}
+
+			// when
+			out, err := istioCR.MergeInto(defaultIngressGatewayIop())
+
+			// then
+			Expect(err).ShouldNot(HaveOccurred())
+			k8s := out.Spec.Components.IngressGateways[1].Kubernetes
+			Expect(k8s.HpaSpec.ScaleTargetRef.Name).To(Equal("internal-ingressgateway"))
+			Expect(k8s.HpaSpec.MaxReplicas).To(Equal(int32(5)))
+			Expect(*k8s.HpaSpec.MinReplicas).To(Equal(int32(3)))
+			Expect(k8s.Resources.Requests.Cpu().String()).To(Equal("100m"))
+
+			Expect(out.Spec.Components.IngressGateways[0].Kubernetes.HpaSpec.ScaleTargetRef.Name).To(Equal("istio-ingressgateway"))
+			Expect(out.Spec.Components.IngressGateways[0].Kubernetes.HpaSpec.MaxReplicas).To(Equal(int32(15)))
+		})
+
+		It("should merge custom labels, resources and Service configuration of an additional ingress gateway", func() {
+			// given
+			cpuLimit := "500m"
+			serviceType := istiov1alpha2.GatewayServiceTypeNodePort
+			externalTrafficPolicy := "Local"
+			istioCR := istiov1alpha2.Istio{Spec: istiov1alpha2.IstioSpec{Components: &istiov1alpha2.Components{
+				AdditionalIngressGateways: []istiov1alpha2.AdditionalIngressGateway{
+					{
+						Name:                  "internal-ingressgateway",
+						Labels:                map[string]string{"istio": "internal", "tier": "internal"},
+						ServiceType:           &serviceType,
+						ServiceAnnotations:    map[string]string{"service.beta.kubernetes.io/aws-load-balancer-internal": "true"},
+						ExternalTrafficPolicy: &externalTrafficPolicy,
+						K8s: &istiov1alpha2.KubernetesResourcesConfig{
+							Resources: &istiov1alpha2.Resources{Limits: &istiov1alpha2.ResourceClaims{CPU: &cpuLimit}},
+						},
+					},
+				},
+			}}}
+
+			// when
+			out, err := istioCR.MergeInto(defaultIngressGatewayIop())
+
+			// then
+			Expect(err).ShouldNot(HaveOccurred())
+			gateway := out.Spec.Components.IngressGateways[1]
+			Expect(gateway.Label).To(Equal(map[string]string{"app": "internal-ingressgateway", "istio": "internal", "tier": "internal"}))
+			Expect(gateway.Kubernetes.Resources.Limits.Cpu().String()).To(Equal("500m"))
+			Expect(gateway.Kubernetes.ServiceAnnotations).To(HaveKeyWithValue("service.beta.kubernetes.io/aws-load-balancer-internal", "true"))
+
+			Expect(gateway.Kubernetes.Overlays).To(HaveLen(2))
+			Expect(gateway.Kubernetes.Overlays[0].Kind).To(Equal("Deployment"))
+			Expect(gateway.Kubernetes.Overlays[0].Name).To(Equal("internal-ingressgateway"))
+			Expect(gateway.Kubernetes.Overlays[0].Patches[0].Value.(*structpb.Value).GetStringValue()).To(Equal("istio"))
+			Expect(gateway.Kubernetes.Overlays[1].Kind).To(Equal("Service"))
+			Expect(gateway.Kubernetes.Overlays[1].Name).To(Equal("internal-ingressgateway"))
+			Expect(gateway.Kubernetes.Overlays[1].Patches).To(HaveLen(2))
+			Expect(gateway.Kubernetes.Overlays[1].Patches[0].Path).To(Equal("spec.type"))
+			Expect(gateway.Kubernetes.Overlays[1].Patches[0].Value.(*structpb.Value).GetStringValue()).To(Equal("NodePort"))
+			Expect(gateway.Kubernetes.Overlays[1].Patches[1].Path).To(Equal("spec.externalTrafficPolicy"))
+			Expect(gateway.Kubernetes.Overlays[1].Patches[1].Value.(*structpb.Value).GetStringValue()).To(Equal("Local"))
+
+			Expect(out.Spec.Components.IngressGateways[0].Kubernetes.Resources.Limits).To(BeEmpty())
+		})
 	})
 
 	Context("Ztunnel", func() {
@@ -1209,7 +1465,7 @@ var _ = Describe("Merge", func() {
 			}
 
 			istioCR := istiov1alpha2.Istio{Spec: istiov1alpha2.IstioSpec{Components: &istiov1alpha2.Components{
-				IngressGateway: &istiov1alpha2.IstioComponent{K8s: &istiov1alpha2.KubernetesResourcesConfig{
+				IngressGateway: &istiov1alpha2.IngressGateway{K8s: &istiov1alpha2.KubernetesResourcesConfig{
 					Strategy: &istiov1alpha2.Strategy{
 						RollingUpdate: &istiov1alpha2.RollingUpdate{
 							MaxUnavailable: &maxUnavailable,
@@ -1243,7 +1499,7 @@ var _ = Describe("Merge", func() {
 			minReplicas := int32(4)
 
 			istioCR := istiov1alpha2.Istio{Spec: istiov1alpha2.IstioSpec{Components: &istiov1alpha2.Components{
-				IngressGateway: &istiov1alpha2.IstioComponent{K8s: &istiov1alpha2.KubernetesResourcesConfig{
+				IngressGateway: &istiov1alpha2.IngressGateway{K8s: &istiov1alpha2.KubernetesResourcesConfig{
 					HPASpec: &istiov1alpha2.HPASpec{
 						MaxReplicas: &maxReplicas,
 						MinReplicas: &minReplicas,
@@ -1568,6 +1824,275 @@ var _ = Describe("Merge", func() {
 		})
 	})
 
+	Context("Scheduling", func() {
+		It("should set nodeSelector, tolerations and priorityClassName of istiod and keep the CNI readiness toleration", func() {
+			// given
+			iop := iopv1alpha1.IstioOperator{
+				Spec: iopv1alpha1.IstioOperatorSpec{},
+			}
+			priorityClassName := "mesh-critical"
+
+			istioCR := istiov1alpha2.Istio{Spec: istiov1alpha2.IstioSpec{Components: &istiov1alpha2.Components{
+				Pilot: &istiov1alpha2.IstioComponent{K8s: &istiov1alpha2.KubernetesResourcesConfig{
+					NodeSelector: map[string]string{"node-pool": "system"},
+					Tolerations: []corev1.Toleration{
+						{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "system", Effect: corev1.TaintEffectNoSchedule},
+					},
+					PriorityClassName: &priorityClassName,
+				}},
+			}}}
+
+			// when
+			out, err := istioCR.MergeInto(iop)
+
+			// then
+			Expect(err).ShouldNot(HaveOccurred())
+			k8s := out.Spec.Components.Pilot.Kubernetes
+			Expect(k8s.NodeSelector).To(Equal(map[string]string{"node-pool": "system"}))
+			Expect(k8s.PriorityClassName).To(Equal(priorityClassName))
+			Expect(k8s.Tolerations).To(Equal([]*corev1.Toleration{
+				{Key: "cni.istio.io/not-ready", Operator: corev1.TolerationOpExists},
+				{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "system", Effect: corev1.TaintEffectNoSchedule},
+			}))
+		})
+
+		It("should add an overlay with topologySpreadConstraints for the istiod Deployment of the revision", func() {
+			// given
+			iop := iopv1alpha1.IstioOperator{
+				Spec: iopv1alpha1.IstioOperatorSpec{},
+			}
+
+			istioCR := istiov1alpha2.Istio{Spec: istiov1alpha2.IstioSpec{Components: &istiov1alpha2.Components{
+				Pilot: &istiov1alpha2.IstioComponent{K8s: &istiov1alpha2.KubernetesResourcesConfig{
+					TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+						{
+							MaxSkew:           1,
+							TopologyKey:       "topology.kubernetes.io/zone",
+							WhenUnsatisfiable: corev1.ScheduleAnyway,
+							LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "istiod"}},
+						},
+					},
+				}},
+			}}}
+
+			// when
+			out, err := istioCR.MergeInto(iop, istiov1alpha2.WithRevision("1-27"))
+
+			// then
+			Expect(err).ShouldNot(HaveOccurred())
+			overlays := out.Spec.Components.Pilot.Kubernetes.Overlays
+			Expect(overlays).To(HaveLen(1))
+			Expect(overlays[0].Kind).To(Equal("Deployment"))
+			Expect(overlays[0].Name).To(Equal("istiod-1-27"))
+			Expect(overlays[0].Patches[0].Path).To(Equal("spec.template.spec.topologySpreadConstraints"))
+			constraints := overlays[0].Patches[0].Value.(*structpb.Value).GetListValue().AsSlice()
+			Expect(constraints).To(ConsistOf(map[string]any{
+				"maxSkew":           float64(1),
+				"topologyKey":       "topology.kubernetes.io/zone",
+				"whenUnsatisfiable": "ScheduleAnyway",
+				"labelSelector":     map[string]any{"matchLabels": map[string]any{"app": "istiod"}},
+			}))
+		})
+
+		It("should replace only the configured type of affinity of Istio Ingress Gateway", func() {
+			// given
+			iop := iopv1alpha1.IstioOperator{
+				Spec: iopv1alpha1.IstioOperatorSpec{
+					Components: &iopv1alpha1.IstioComponentSpec{
+						IngressGateways: []iopv1alpha1.GatewayComponentSpec{
+							{
+								Name: "istio-ingressgateway",
+								ComponentSpec: iopv1alpha1.ComponentSpec{
+									Kubernetes: &iopv1alpha1.KubernetesResources{
+										Affinity: &corev1.Affinity{
+											PodAntiAffinity: &corev1.PodAntiAffinity{
+												PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+													{Weight: 100, PodAffinityTerm: corev1.PodAffinityTerm{TopologyKey: "kubernetes.io/hostname"}},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			nodeAffinity := &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{
+						{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "node-pool", Operator: corev1.NodeSelectorOpIn, Values: []string{"edge"}}}},
+					},
+				},
+			}
+
+			istioCR := istiov1alpha2.Istio{Spec: istiov1alpha2.IstioSpec{Components: &istiov1alpha2.Components{
+				IngressGateway: &istiov1alpha2.IngressGateway{K8s: &istiov1alpha2.KubernetesResourcesConfig{
+					Affinity: &corev1.Affinity{NodeAffinity: nodeAffinity},
+				}},
+			}}}
+
+			// when
+			out, err := istioCR.MergeInto(iop)
+
+			// then
+			Expect(err).ShouldNot(HaveOccurred())
+			affinity := out.Spec.Components.IngressGateways[0].Kubernetes.Affinity
+			Expect(affinity.NodeAffinity).To(Equal(nodeAffinity))
+			Expect(affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution).To(HaveLen(1))
+		})
+
+		It("should add the configured tolerations to the default tolerations of Istio CNI", func() {
+			// given
+			iop := iopv1alpha1.IstioOperator{
+				Spec: iopv1alpha1.IstioOperatorSpec{},
+			}
+
+			istioCR := istiov1alpha2.Istio{Spec: istiov1alpha2.IstioSpec{Components: &istiov1alpha2.Components{
+				Cni: &istiov1alpha2.CniComponent{K8S: &istiov1alpha2.CniK8sConfig{
+					Tolerations: []corev1.Toleration{
+						{Key: "edge", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectPreferNoSchedule},
+					},
+				}},
+			}}}
+
+			// when
+			out, err := istioCR.MergeInto(iop)
+
+			// then
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(out.Spec.Components.Cni.Kubernetes.Tolerations).To(Equal([]*corev1.Toleration{
+				{Effect: corev1.TaintEffectNoSchedule, Operator: corev1.TolerationOpExists},
+				{Key: "CriticalAddonsOnly", Operator: corev1.TolerationOpExists},
+				{Effect: corev1.TaintEffectNoExecute, Operator: corev1.TolerationOpExists},
+				{Key: "edge", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectPreferNoSchedule},
+			}))
+		})
+	})
+
+	Context("PodDisruptionBudget", func() {
+		It("should set the PodDisruptionBudget of istiod and enable PodDisruptionBudgets in the values", func() {
+			// given
+			iop := iopv1alpha1.IstioOperator{
+				Spec: iopv1alpha1.IstioOperatorSpec{},
+			}
+			minReplicas := int32(3)
+			minAvailable := intstr.FromInt32(2)
+
+			istioCR := istiov1alpha2.Istio{Spec: istiov1alpha2.IstioSpec{Components: &istiov1alpha2.Components{
+				Pilot: &istiov1alpha2.IstioComponent{K8s: &istiov1alpha2.KubernetesResourcesConfig{
+					HPASpec:             &istiov1alpha2.HPASpec{MinReplicas: &minReplicas},
+					PodDisruptionBudget: &istiov1alpha2.PodDisruptionBudget{MinAvailable: &minAvailable},
+				}},
+			}}}
+
+			// when
+			out, err := istioCR.MergeInto(iop)
+
+			// then
+			Expect(err).ShouldNot(HaveOccurred())
+			pdb := out.Spec.Components.Pilot.Kubernetes.PodDisruptionBudget
+			Expect(pdb.MinAvailable).To(Equal(&minAvailable))
+			Expect(pdb.MaxUnavailable).To(BeNil())
+
+			valuesMap, err := values.MapFromObject(out.Spec.Values)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(valuesMap.GetPathBool("global.defaultPodDisruptionBudget.enabled")).To(BeTrue())
+			autoscaleMin, exists := valuesMap.GetPath("pilot.autoscaleMin")
+			Expect(exists).To(BeTrue())
+			Expect(autoscaleMin).To(Equal(float64(2)))
+		})
+
+		It("should replace the default PodDisruptionBudget of Istio Ingress Gateway and keep a higher autoscaleMin", func() {
+			// given
+			defaultMaxUnavailable := intstr.FromInt32(1)
+			iop := iopv1alpha1.IstioOperator{
+				Spec: iopv1alpha1.IstioOperatorSpec{
+					Components: &iopv1alpha1.IstioComponentSpec{
+						IngressGateways: []iopv1alpha1.GatewayComponentSpec{
+							{
+								Name: "istio-ingressgateway",
+								ComponentSpec: iopv1alpha1.ComponentSpec{
+									Kubernetes: &iopv1alpha1.KubernetesResources{
+										HpaSpec:             &autoscalingv2.HorizontalPodAutoscalerSpec{MinReplicas: ptr.To(int32(3))},
+										PodDisruptionBudget: &policyv1.PodDisruptionBudgetSpec{MaxUnavailable: &defaultMaxUnavailable},
+									},
+								},
+							},
+						},
+					},
+					Values: json.RawMessage(`{"gateways":{"istio-ingressgateway":{"autoscaleMin":3}}}`),
+				},
+			}
+			minAvailable := intstr.FromString("50%")
+
+			istioCR := istiov1alpha2.Istio{Spec: istiov1alpha2.IstioSpec{Components: &istiov1alpha2.Components{
+				IngressGateway: &istiov1alpha2.IngressGateway{K8s: &istiov1alpha2.KubernetesResourcesConfig{
+					PodDisruptionBudget: &istiov1alpha2.PodDisruptionBudget{MinAvailable: &minAvailable},
+				}},
+			}}}
+
+			// when
+			out, err := istioCR.MergeInto(iop)
+
+			// then
+			Expect(err).ShouldNot(HaveOccurred())
+			pdb := out.Spec.Components.IngressGateways[0].Kubernetes.PodDisruptionBudget
+			Expect(pdb.MinAvailable).To(Equal(&minAvailable))
+			Expect(pdb.MaxUnavailable).To(BeNil())
+
+			valuesMap, err := values.MapFromObject(out.Spec.Values)
+			Expect(err).ShouldNot(HaveOccurred())
+			autoscaleMin, exists := valuesMap.GetPath("gateways.istio-ingressgateway.autoscaleMin")
+			Expect(exists).To(BeTrue())
+			Expect(autoscaleMin).To(Equal(float64(3)))
+		})
+
+		It("should return an error when the minimum replicas of the component are lower than 2", func() {
+			// given
+			iop := iopv1alpha1.IstioOperator{
+				Spec: iopv1alpha1.IstioOperatorSpec{},
+			}
+			maxUnavailable := intstr.FromInt32(1)
+
+			istioCR := istiov1alpha2.Istio{Spec: istiov1alpha2.IstioSpec{Components: &istiov1alpha2.Components{
+				EgressGateway: &istiov1alpha2.EgressGateway{K8s: &istiov1alpha2.KubernetesResourcesConfig{
+					PodDisruptionBudget: &istiov1alpha2.PodDisruptionBudget{MaxUnavailable: &maxUnavailable},
+				}},
+			}}}
+
+			// when
+			_, err := istioCR.MergeInto(iop)
+
+			// then
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("requires at least 2 minimum replicas"))
+		})
+
+		It("should return an error when the PodDisruptionBudget doesn't allow evicting a Pod with the minimum replicas", func() {
+			// given
+			iop := iopv1alpha1.IstioOperator{
+				Spec: iopv1alpha1.IstioOperatorSpec{},
+			}
+			minReplicas := int32(2)
+			minAvailable := intstr.FromString("100%")
+
+			istioCR := istiov1alpha2.Istio{Spec: istiov1alpha2.IstioSpec{Components: &istiov1alpha2.Components{
+				Pilot: &istiov1alpha2.IstioComponent{K8s: &istiov1alpha2.KubernetesResourcesConfig{
+					HPASpec:             &istiov1alpha2.HPASpec{MinReplicas: &minReplicas},
+					PodDisruptionBudget: &istiov1alpha2.PodDisruptionBudget{MinAvailable: &minAvailable},
+				}},
+			}}}
+
+			// when
+			_, err := istioCR.MergeInto(iop)
+
+			// then
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("must be lower than the minimum replicas 2"))
+		})
+	})
+
 	Context("Proxy", func() {
 		It("should update Proxy resources configuration if they are present in Istio CR", func() {
 			// given
@@ -1703,6 +2228,313 @@ var _ = Describe("Merge", func() {
 		})
 	})
 
+	Context("ProxyLifecycle", func() {
+		It("Should set the proxy lifecycle in the default proxy config of the mesh config", func() {
+			// given
+			m := mesh.DefaultMeshConfig()
+			meshConfigRaw := convert(m)
+			iop := iopv1alpha1.IstioOperator{
+				Spec: iopv1alpha1.IstioOperatorSpec{
+					MeshConfig: meshConfigRaw,
+				},
+			}
+
+			istioCR := istiov1alpha2.Istio{Spec: istiov1alpha2.IstioSpec{Components: &istiov1alpha2.Components{
+				Proxy: &istiov1alpha2.ProxyComponent{
+					Lifecycle: &istiov1alpha2.ProxyLifecycle{
+						HoldApplicationUntilProxyStarts: ptr.To(false),
+						TerminationDrainDuration:        &metav1.Duration{Duration: 90 * time.Second},
+						Concurrency:                     ptr.To(int32(2)),
+						ExitOnZeroActiveConnections:     ptr.To(true),
+					},
+				},
+			}}}
+
+			// when
+			out, err := istioCR.MergeInto(iop)
+
+			// then
+			Expect(err).ShouldNot(HaveOccurred())
+			meshConfig, err := values.MapFromObject(out.Spec.MeshConfig)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(meshConfig.GetPathBool("defaultConfig.holdApplicationUntilProxyStarts")).To(BeFalse())
+			Expect(meshConfig.GetPathString("defaultConfig.terminationDrainDuration")).To(Equal("90s"))
+			concurrency, exists := meshConfig.GetPath("defaultConfig.concurrency")
+			Expect(exists).To(BeTrue())
+			Expect(concurrency).To(Equal(float64(2)))
+			Expect(meshConfig.GetPathString("defaultConfig.proxyMetadata.EXIT_ON_ZERO_ACTIVE_CONNECTIONS")).To(Equal("true"))
+
+			parsedMeshConfig := &meshv1alpha1.MeshConfig{}
+			err = protomarshal.Unmarshal(out.Spec.MeshConfig, parsedMeshConfig)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(parsedMeshConfig.DefaultConfig.TerminationDrainDuration.AsDuration()).To(Equal(90 * time.Second))
+		})
+
+		It("Should keep the default proxy config when the proxy lifecycle is not configured", func() {
+			// given
+			iop := iopv1alpha1.IstioOperator{
+				Spec: iopv1alpha1.IstioOperatorSpec{
+					MeshConfig: json.RawMessage(`{"defaultConfig":{"holdApplicationUntilProxyStarts":true}}`),
+				},
+			}
+
+			istioCR := istiov1alpha2.Istio{Spec: istiov1alpha2.IstioSpec{Components: &istiov1alpha2.Components{
+				Proxy: &istiov1alpha2.ProxyComponent{},
+			}}}
+
+			// when
+			out, err := istioCR.MergeInto(iop)
+
+			// then
+			Expect(err).ShouldNot(HaveOccurred())
+			meshConfig, err := values.MapFromObject(out.Spec.MeshConfig)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(meshConfig.GetPathBool("defaultConfig.holdApplicationUntilProxyStarts")).To(BeTrue())
+			_, exists := meshConfig.GetPath("defaultConfig.terminationDrainDuration")
+			Expect(exists).To(BeFalse())
+		})
+	})
+
+	Context("Tracing", func() {
+		mergeTracing := func(tracing *istiov1alpha2.Tracing) *meshv1alpha1.MeshConfig {
+			iop := iopv1alpha1.IstioOperator{
+				Spec: iopv1alpha1.IstioOperatorSpec{
+					MeshConfig: convert(mesh.DefaultMeshConfig()),
+				},
+			}
+			istioCR := istiov1alpha2.Istio{Spec: istiov1alpha2.IstioSpec{Config: istiov1alpha2.Config{Tracing: tracing}}}
+
+			out, err := istioCR.MergeInto(iop)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			var meshConfig meshv1alpha1.MeshConfig
+			Expect(protomarshal.Unmarshal(out.Spec.MeshConfig, &meshConfig)).To(Succeed())
+			return &meshConfig
+		}
+
+		findProvider := func(meshConfig *meshv1alpha1.MeshConfig, name string) *meshv1alpha1.MeshConfig_ExtensionProvider_OpenTelemetryTracingProvider {
+			for _, provider := range meshConfig.ExtensionProviders {
+				if provider.Name == name {
+					return provider.GetOpentelemetry()
+				}
+			}
+			return nil
+		}
+
+		It("should set the sampling percentage in the mesh config", func() {
+			meshConfig := mergeTracing(&istiov1alpha2.Tracing{SamplingPercentage: ptr.To("0.5")})
+
+			Expect(meshConfig.DefaultConfig.Tracing.Sampling).To(Equal(0.5))
+		})
+
+		It("should not change the mesh config when tracing is not configured", func() {
+			meshConfig := mergeTracing(nil)
+
+			Expect(meshConfig.DefaultProviders.GetTracing()).To(BeEmpty())
+			Expect(findProvider(meshConfig, "otel-grpc")).To(BeNil())
+		})
+
+		It("should add an OTLP gRPC provider with headers as initial metadata and timeout", func() {
+			meshConfig := mergeTracing(&istiov1alpha2.Tracing{
+				Providers: []istiov1alpha2.TracingProvider{{
+					Name:    "otel-grpc",
+					Service: "otel-collector.observability.svc.cluster.local",
+					Port:    4317,
+					Headers: map[string]string{"x-tenant": "prod", "authorization": "Bearer token"},
+					Timeout: &metav1.Duration{Duration: 2 * time.Second},
+				}},
+			})
+
+			provider := findProvider(meshConfig, "otel-grpc")
+			Expect(provider).NotTo(BeNil())
+			Expect(provider.Service).To(Equal("otel-collector.observability.svc.cluster.local"))
+			Expect(provider.Port).To(BeEquivalentTo(4317))
+			Expect(provider.Http).To(BeNil())
+			Expect(provider.Grpc.Timeout.AsDuration()).To(Equal(2 * time.Second))
+			Expect(provider.Grpc.InitialMetadata).To(HaveLen(2))
+			Expect(provider.Grpc.InitialMetadata[0].Name).To(Equal("authorization"))
+			Expect(provider.Grpc.InitialMetadata[0].GetValue()).To(Equal("Bearer token"))
+			Expect(provider.Grpc.InitialMetadata[1].Name).To(Equal("x-tenant"))
+		})
+
+		It("should add an OTLP HTTP provider with path and keep the kyma-traces provider", func() {
+			meshConfig := mergeTracing(&istiov1alpha2.Tracing{
+				Providers: []istiov1alpha2.TracingProvider{{
+					Name:     "otel-http",
+					Service:  "otel-collector.observability.svc.cluster.local",
+					Port:     4318,
+					Protocol: istiov1alpha2.TracingProtocolHTTP,
+					Path:     ptr.To("/v1/traces"),
+					Headers:  map[string]string{"x-tenant": "prod"},
+				}},
+			})
+
+			provider := findProvider(meshConfig, "otel-http")
+			Expect(provider).NotTo(BeNil())
+			Expect(provider.Grpc).To(BeNil())
+			Expect(provider.Http.Path).To(Equal("/v1/traces"))
+			Expect(provider.Http.Headers).To(HaveLen(1))
+			Expect(provider.Http.Headers[0].GetValue()).To(Equal("prod"))
+		})
+
+		It("should set the default tracing provider", func() {
+			meshConfig := mergeTracing(&istiov1alpha2.Tracing{DefaultProvider: ptr.To(istiov1alpha2.KymaTracesProviderName)})
+
+			Expect(meshConfig.DefaultProviders.GetTracing()).To(ConsistOf(istiov1alpha2.KymaTracesProviderName))
+		})
+
+		It("should set custom tags from literals, environment variables and request headers", func() {
+			meshConfig := mergeTracing(&istiov1alpha2.Tracing{
+				CustomTags: map[string]istiov1alpha2.TracingCustomTag{
+					"cluster":  {Literal: ptr.To("prod-eu")},
+					"pod":      {Environment: &istiov1alpha2.TracingTagSource{Name: "POD_NAME", DefaultValue: "unknown"}},
+					"tenantId": {Header: &istiov1alpha2.TracingTagSource{Name: "x-tenant-id"}},
+				},
+			})
+
+			customTags := meshConfig.DefaultConfig.Tracing.CustomTags
+			Expect(customTags).To(HaveLen(3))
+			Expect(customTags["cluster"].GetLiteral().Value).To(Equal("prod-eu"))
+			Expect(customTags["pod"].GetEnvironment().Name).To(Equal("POD_NAME"))
+			Expect(customTags["pod"].GetEnvironment().DefaultValue).To(Equal("unknown"))
+			Expect(customTags["tenantId"].GetHeader().Name).To(Equal("x-tenant-id"))
+		})
+	})
+
+	Context("AccessLogging", func() {
+		mergeAccessLogging := func(accessLogging *istiov1alpha2.AccessLogging) *meshv1alpha1.MeshConfig {
+			iop := iopv1alpha1.IstioOperator{
+				Spec: iopv1alpha1.IstioOperatorSpec{
+					MeshConfig: convert(mesh.DefaultMeshConfig()),
+				},
+			}
+			istioCR := istiov1alpha2.Istio{Spec: istiov1alpha2.IstioSpec{Config: istiov1alpha2.Config{AccessLogging: accessLogging}}}
+
+			out, err := istioCR.MergeInto(iop)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			var meshConfig meshv1alpha1.MeshConfig
+			Expect(protomarshal.Unmarshal(out.Spec.MeshConfig, &meshConfig)).To(Succeed())
+			return &meshConfig
+		}
+
+		findProvider := func(meshConfig *meshv1alpha1.MeshConfig, name string) *meshv1alpha1.MeshConfig_ExtensionProvider {
+			for _, provider := range meshConfig.ExtensionProviders {
+				if provider.Name == name {
+					return provider
+				}
+			}
+			return nil
+		}
+
+		It("should add a file provider with JSON labels", func() {
+			meshConfig := mergeAccessLogging(&istiov1alpha2.AccessLogging{
+				Providers: []istiov1alpha2.AccessLogProvider{{
+					Name: "tenant-json",
+					File: &istiov1alpha2.FileAccessLogProvider{
+						Format: &istiov1alpha2.AccessLogFormat{Labels: map[string]string{
+							"tenant_id":     "%REQ(X-TENANT-ID)%",
+							"response_code": "%RESPONSE_CODE%",
+						}},
+					},
+				}},
+			})
+
+			provider := findProvider(meshConfig, "tenant-json").GetEnvoyFileAccessLog()
+			Expect(provider).NotTo(BeNil())
+			Expect(provider.Path).To(Equal("/dev/stdout"))
+			labels := provider.LogFormat.GetLabels().AsMap()
+			Expect(labels).To(HaveKeyWithValue("tenant_id", "%REQ(X-TENANT-ID)%"))
+			Expect(labels).To(HaveKeyWithValue("response_code", "%RESPONSE_CODE%"))
+		})
+
+		It("should add a file provider with a text format", func() {
+			meshConfig := mergeAccessLogging(&istiov1alpha2.AccessLogging{
+				Providers: []istiov1alpha2.AccessLogProvider{{
+					Name: "tenant-text",
+					File: &istiov1alpha2.FileAccessLogProvider{
+						Path:   "/dev/stderr",
+						Format: &istiov1alpha2.AccessLogFormat{Text: ptr.To("[%START_TIME%] %RESPONSE_CODE%\n")},
+					},
+				}},
+			})
+
+			provider := findProvider(meshConfig, "tenant-text").GetEnvoyFileAccessLog()
+			Expect(provider).NotTo(BeNil())
+			Expect(provider.Path).To(Equal("/dev/stderr"))
+			Expect(provider.LogFormat.GetText()).To(Equal("[%START_TIME%] %RESPONSE_CODE%\n"))
+		})
+
+		It("should add an OpenTelemetry ALS provider with text and labels", func() {
+			meshConfig := mergeAccessLogging(&istiov1alpha2.AccessLogging{
+				Providers: []istiov1alpha2.AccessLogProvider{{
+					Name: "otel-logs",
+					OpenTelemetry: &istiov1alpha2.OpenTelemetryAccessLogProvider{
+						Service: "otel-collector.observability.svc.cluster.local",
+						Port:    4317,
+						LogName: "mesh-access",
+						Format: &istiov1alpha2.AccessLogFormat{
+							Text:   ptr.To("%RESPONSE_CODE%"),
+							Labels: map[string]string{"tenant_id": "%REQ(X-TENANT-ID)%"},
+						},
+					},
+				}},
+			})
+
+			provider := findProvider(meshConfig, "otel-logs").GetEnvoyOtelAls()
+			Expect(provider).NotTo(BeNil())
+			Expect(provider.Service).To(Equal("otel-collector.observability.svc.cluster.local"))
+			Expect(provider.Port).To(BeEquivalentTo(4317))
+			Expect(provider.LogName).To(Equal("mesh-access"))
+			Expect(provider.LogFormat.Text).To(Equal("%RESPONSE_CODE%"))
+			Expect(provider.LogFormat.Labels.AsMap()).To(HaveKeyWithValue("tenant_id", "%REQ(X-TENANT-ID)%"))
+		})
+
+		It("should set the default access log providers", func() {
+			meshConfig := mergeAccessLogging(&istiov1alpha2.AccessLogging{DefaultProviders: []string{"stdout-json"}})
+
+			Expect(meshConfig.DefaultProviders.GetAccessLogging()).To(ConsistOf("stdout-json"))
+		})
+	})
+
+	Context("Revision", func() {
+		It("should set the revision of the Istio operator when the revision option is set", func() {
+			// given
+			m := mesh.DefaultMeshConfig()
+			iop := iopv1alpha1.IstioOperator{
+				Spec: iopv1alpha1.IstioOperatorSpec{
+					MeshConfig: convert(m),
+				},
+			}
+			istioCR := istiov1alpha2.Istio{Spec: istiov1alpha2.IstioSpec{}}
+
+			// when
+			out, err := istioCR.MergeInto(iop, istiov1alpha2.WithRevision("1-27-1"))
+
+			// then
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(out.Spec.Revision).To(Equal("1-27-1"))
+		})
+
+		It("should not change the revision of the Istio operator when the revision option is not set", func() {
+			// given
+			m := mesh.DefaultMeshConfig()
+			iop := iopv1alpha1.IstioOperator{
+				Spec: iopv1alpha1.IstioOperatorSpec{
+					MeshConfig: convert(m),
+				},
+			}
+			istioCR := istiov1alpha2.Istio{Spec: istiov1alpha2.IstioSpec{}}
+
+			// when
+			out, err := istioCR.MergeInto(iop)
+
+			// then
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(out.Spec.Revision).To(BeEmpty())
+		})
+	})
+
 })
 
 func convert(a *meshv1alpha1.MeshConfig) json.RawMessage {
@@ -0,0 +1,113 @@
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// KymaTracesProviderName is the name of the OpenTelemetry tracing provider that sends traces to the Kyma Telemetry module.
+const KymaTracesProviderName = "kyma-traces"
+
+// Configures distributed tracing in the service mesh.
+// Istio proxies propagate the trace context using the W3C Trace Context `traceparent` and `tracestate` HTTP headers.
+// +kubebuilder:validation:XValidation:rule="!has(self.defaultProvider) || self.defaultProvider == 'kyma-traces' || (has(self.providers) && self.providers.exists(p, p.name == self.defaultProvider))",message="defaultProvider must be kyma-traces or the name of one of the providers"
+type Tracing struct {
+	// Defines the percentage of requests that are sampled for tracing, for example, `1` or `0.5`.
+	// The value must be between `0` and `100` with at most two decimal places. If not set, Istio samples 1% of the requests.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^(100(\.0{1,2})?|[0-9]{1,2}(\.[0-9]{1,2})?)$`
+	SamplingPercentage *string `json:"samplingPercentage,omitempty"`
+
+	// Defines additional OpenTelemetry (OTLP) tracing providers. The providers can be referenced by name in **defaultProvider**
+	// or in an Istio Telemetry resource.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=10
+	// +listType=map
+	// +listMapKey=name
+	Providers []TracingProvider `json:"providers,omitempty"`
+
+	// Defines the name of the tracing provider used by all workloads in the service mesh, unless it's overridden by an Istio Telemetry resource.
+	// It must be `kyma-traces` or the name of one of the **providers**. If not set, no default tracing provider is configured.
+	// +kubebuilder:validation:Optional
+	DefaultProvider *string `json:"defaultProvider,omitempty"`
+
+	// Defines custom tags added to each span. The key is the name of the tag.
+	// +kubebuilder:validation:Optional
+	CustomTags map[string]TracingCustomTag `json:"customTags,omitempty"`
+}
+
+// Defines the protocol used to export traces to an OpenTelemetry collector.
+type TracingProtocol string
+
+const (
+	// Export traces using OTLP over gRPC.
+	TracingProtocolGRPC TracingProtocol = "GRPC"
+	// Export traces using OTLP over HTTP.
+	TracingProtocolHTTP TracingProtocol = "HTTP"
+)
+
+// Defines an OpenTelemetry (OTLP) tracing provider.
+type TracingProvider struct {
+	// Specifies a unique name identifying the tracing provider.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self != 'kyma-traces' && self != 'envoy' && self != 'stdout-json' && self != 'kyma-logs'",message="the name is reserved for an extension provider of the Istio module"
+	Name string `json:"name"`
+
+	// Specifies the service of the OpenTelemetry collector.
+	// The recommended format is `[Namespace/]Hostname`, for example, `otel-collector.observability.svc.cluster.local`.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Service string `json:"service"`
+
+	// Specifies the port of the Service.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port uint32 `json:"port"`
+
+	// Defines the protocol used to export the traces. The default value is `GRPC`.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=GRPC;HTTP
+	// +kubebuilder:default=GRPC
+	Protocol TracingProtocol `json:"protocol,omitempty"`
+
+	// Specifies the path of the OTLP HTTP endpoint, for example, `/v1/traces`. Only used with the `HTTP` protocol.
+	// +kubebuilder:validation:Optional
+	Path *string `json:"path,omitempty"`
+
+	// Defines headers added to the export requests, for example, for authentication. With the `GRPC` protocol, the headers are sent as initial metadata.
+	// +kubebuilder:validation:Optional
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// Specifies the timeout for the export requests to the OpenTelemetry collector.
+	// +kubebuilder:validation:Optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// Defines the source of a custom tag value. Exactly one of the sources must be set.
+// +kubebuilder:validation:XValidation:rule="[has(self.literal), has(self.environment), has(self.header)].filter(x, x).size() == 1",message="exactly one of literal, environment or header must be set"
+type TracingCustomTag struct {
+	// Sets the tag to a literal value.
+	// +kubebuilder:validation:Optional
+	Literal *string `json:"literal,omitempty"`
+
+	// Sets the tag to the value of an environment variable of the Istio proxy.
+	// +kubebuilder:validation:Optional
+	Environment *TracingTagSource `json:"environment,omitempty"`
+
+	// Sets the tag to the value of a header of the incoming request.
+	// +kubebuilder:validation:Optional
+	Header *TracingTagSource `json:"header,omitempty"`
+}
+
+// Defines an environment variable or a request header used as the value of a custom tag.
+type TracingTagSource struct {
+	// Specifies the name of the environment variable or the request header.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Defines the value used if the environment variable or the request header is not present.
+	// +kubebuilder:validation:Optional
+	DefaultValue string `json:"defaultValue,omitempty"`
+}
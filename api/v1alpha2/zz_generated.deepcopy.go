@@ -27,6 +27,134 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessLogFormat) DeepCopyInto(out *AccessLogFormat) {
+	*out = *in
+	if in.Text != nil {
+		in, out := &in.Text, &out.Text
+		*out = new(string)
+		**out = **in
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessLogFormat.
+func (in *AccessLogFormat) DeepCopy() *AccessLogFormat {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessLogFormat)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessLogProvider) DeepCopyInto(out *AccessLogProvider) {
+	*out = *in
+	if in.File != nil {
+		in, out := &in.File, &out.File
+		*out = new(FileAccessLogProvider)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OpenTelemetry != nil {
+		in, out := &in.OpenTelemetry, &out.OpenTelemetry
+		*out = new(OpenTelemetryAccessLogProvider)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessLogProvider.
+func (in *AccessLogProvider) DeepCopy() *AccessLogProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessLogProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessLogging) DeepCopyInto(out *AccessLogging) {
+	*out = *in
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]AccessLogProvider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DefaultProviders != nil {
+		in, out := &in.DefaultProviders, &out.DefaultProviders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Filter != nil {
+		in, out := &in.Filter, &out.Filter
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessLogging.
+func (in *AccessLogging) DeepCopy() *AccessLogging {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessLogging)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdditionalIngressGateway) DeepCopyInto(out *AdditionalIngressGateway) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ServiceType != nil {
+		in, out := &in.ServiceType, &out.ServiceType
+		*out = new(GatewayServiceType)
+		**out = **in
+	}
+	if in.ServiceAnnotations != nil {
+		in, out := &in.ServiceAnnotations, &out.ServiceAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ExternalTrafficPolicy != nil {
+		in, out := &in.ExternalTrafficPolicy, &out.ExternalTrafficPolicy
+		*out = new(string)
+		**out = **in
+	}
+	if in.K8s != nil {
+		in, out := &in.K8s, &out.K8s
+		*out = new(KubernetesResourcesConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdditionalIngressGateway.
+func (in *AdditionalIngressGateway) DeepCopy() *AdditionalIngressGateway {
+	if in == nil {
+		return nil
+	}
+	out := new(AdditionalIngressGateway)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Authorizer) DeepCopyInto(out *Authorizer) {
 	*out = *in
@@ -57,6 +185,58 @@ func (in *Authorizer) DeepCopy() *Authorizer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryUpgradeStatus) DeepCopyInto(out *CanaryUpgradeStatus) {
+	*out = *in
+	if in.MigratedNamespaces != nil {
+		in, out := &in.MigratedNamespaces, &out.MigratedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryUpgradeStatus.
+func (in *CanaryUpgradeStatus) DeepCopy() *CanaryUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateAuthority) DeepCopyInto(out *CertificateAuthority) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateAuthority.
+func (in *CertificateAuthority) DeepCopy() *CertificateAuthority {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateAuthority)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateAuthorityStatus) DeepCopyInto(out *CertificateAuthorityStatus) {
+	*out = *in
+	in.NotAfter.DeepCopyInto(&out.NotAfter)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateAuthorityStatus.
+func (in *CertificateAuthorityStatus) DeepCopy() *CertificateAuthorityStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateAuthorityStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CniComponent) DeepCopyInto(out *CniComponent) {
 	*out = *in
@@ -90,6 +270,13 @@ func (in *CniK8sConfig) DeepCopyInto(out *CniK8sConfig) {
 		*out = new(Resources)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CniK8sConfig.
@@ -112,9 +299,16 @@ func (in *Components) DeepCopyInto(out *Components) {
 	}
 	if in.IngressGateway != nil {
 		in, out := &in.IngressGateway, &out.IngressGateway
-		*out = new(IstioComponent)
+		*out = new(IngressGateway)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AdditionalIngressGateways != nil {
+		in, out := &in.AdditionalIngressGateways, &out.AdditionalIngressGateways
+		*out = make([]AdditionalIngressGateway, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Cni != nil {
 		in, out := &in.Cni, &out.Cni
 		*out = new(CniComponent)
@@ -187,6 +381,31 @@ func (in *Config) DeepCopyInto(out *Config) {
 		*out = new(ProxyStatsMatcher)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Tracing != nil {
+		in, out := &in.Tracing, &out.Tracing
+		*out = new(Tracing)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AccessLogging != nil {
+		in, out := &in.AccessLogging, &out.AccessLogging
+		*out = new(AccessLogging)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Mtls != nil {
+		in, out := &in.Mtls, &out.Mtls
+		*out = new(Mtls)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CertificateAuthority != nil {
+		in, out := &in.CertificateAuthority, &out.CertificateAuthority
+		*out = new(CertificateAuthority)
+		**out = **in
+	}
+	if in.RootCARotation != nil {
+		in, out := &in.RootCARotation, &out.RootCARotation
+		*out = new(RootCARotation)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Config.
@@ -245,6 +464,46 @@ func (in *Experimental) DeepCopy() *Experimental {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileAccessLogProvider) DeepCopyInto(out *FileAccessLogProvider) {
+	*out = *in
+	if in.Format != nil {
+		in, out := &in.Format, &out.Format
+		*out = new(AccessLogFormat)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileAccessLogProvider.
+func (in *FileAccessLogProvider) DeepCopy() *FileAccessLogProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(FileAccessLogProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayServicePort) DeepCopyInto(out *GatewayServicePort) {
+	*out = *in
+	if in.TargetPort != nil {
+		in, out := &in.TargetPort, &out.TargetPort
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayServicePort.
+func (in *GatewayServicePort) DeepCopy() *GatewayServicePort {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayServicePort)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HPASpec) DeepCopyInto(out *HPASpec) {
 	*out = *in
@@ -327,6 +586,70 @@ func (in *InCheck) DeepCopy() *InCheck {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressGateway) DeepCopyInto(out *IngressGateway) {
+	*out = *in
+	if in.K8s != nil {
+		in, out := &in.K8s, &out.K8s
+		*out = new(KubernetesResourcesConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(IngressGatewayService)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressGateway.
+func (in *IngressGateway) DeepCopy() *IngressGateway {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressGateway)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressGatewayService) DeepCopyInto(out *IngressGatewayService) {
+	*out = *in
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]GatewayServicePort, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LoadBalancerSourceRanges != nil {
+		in, out := &in.LoadBalancerSourceRanges, &out.LoadBalancerSourceRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LoadBalancerIP != nil {
+		in, out := &in.LoadBalancerIP, &out.LoadBalancerIP
+		*out = new(string)
+		**out = **in
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressGatewayService.
+func (in *IngressGatewayService) DeepCopy() *IngressGatewayService {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressGatewayService)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Istio) DeepCopyInto(out *Istio) {
 	*out = *in
@@ -420,6 +743,16 @@ func (in *IstioSpec) DeepCopyInto(out *IstioSpec) {
 		*out = new(Experimental)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.UpgradeStrategy != nil {
+		in, out := &in.UpgradeStrategy, &out.UpgradeStrategy
+		*out = new(UpgradeStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProxyRestart != nil {
+		in, out := &in.ProxyRestart, &out.ProxyRestart
+		*out = new(ProxyRestart)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IstioSpec.
@@ -446,6 +779,36 @@ func (in *IstioStatus) DeepCopyInto(out *IstioStatus) {
 			}
 		}
 	}
+	if in.CanaryUpgrade != nil {
+		in, out := &in.CanaryUpgrade, &out.CanaryUpgrade
+		*out = new(CanaryUpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProxyReset != nil {
+		in, out := &in.ProxyReset, &out.ProxyReset
+		*out = new(ProxyResetStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RestartHealthGate != nil {
+		in, out := &in.RestartHealthGate, &out.RestartHealthGate
+		*out = new(RestartHealthGateStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CertificateAuthority != nil {
+		in, out := &in.CertificateAuthority, &out.CertificateAuthority
+		*out = new(CertificateAuthorityStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RootCARotation != nil {
+		in, out := &in.RootCARotation, &out.RootCARotation
+		*out = new(RootCARotationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProxyConfigHashes != nil {
+		in, out := &in.ProxyConfigHashes, &out.ProxyConfigHashes
+		*out = new(ProxyConfigHashes)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IstioStatus.
@@ -476,6 +839,42 @@ func (in *KubernetesResourcesConfig) DeepCopyInto(out *KubernetesResourcesConfig
 		*out = new(Resources)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PriorityClassName != nil {
+		in, out := &in.PriorityClassName, &out.PriorityClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudget)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesResourcesConfig.
@@ -489,11 +888,32 @@ func (in *KubernetesResourcesConfig) DeepCopy() *KubernetesResourcesConfig {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Metrics) DeepCopyInto(out *Metrics) {
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
 	*out = *in
+	out.Duration = in.Duration
+	if in.TimeZone != nil {
+		in, out := &in.TimeZone, &out.TimeZone
+		*out = new(string)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Metrics.
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Metrics) DeepCopyInto(out *Metrics) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Metrics.
 func (in *Metrics) DeepCopy() *Metrics {
 	if in == nil {
 		return nil
@@ -503,6 +923,61 @@ func (in *Metrics) DeepCopy() *Metrics {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Mtls) DeepCopyInto(out *Mtls) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]MtlsNamespace, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Mtls.
+func (in *Mtls) DeepCopy() *Mtls {
+	if in == nil {
+		return nil
+	}
+	out := new(Mtls)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MtlsNamespace) DeepCopyInto(out *MtlsNamespace) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MtlsNamespace.
+func (in *MtlsNamespace) DeepCopy() *MtlsNamespace {
+	if in == nil {
+		return nil
+	}
+	out := new(MtlsNamespace)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenTelemetryAccessLogProvider) DeepCopyInto(out *OpenTelemetryAccessLogProvider) {
+	*out = *in
+	if in.Format != nil {
+		in, out := &in.Format, &out.Format
+		*out = new(AccessLogFormat)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenTelemetryAccessLogProvider.
+func (in *OpenTelemetryAccessLogProvider) DeepCopy() *OpenTelemetryAccessLogProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenTelemetryAccessLogProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PilotFeatures) DeepCopyInto(out *PilotFeatures) {
 	*out = *in
@@ -518,6 +993,31 @@ func (in *PilotFeatures) DeepCopy() *PilotFeatures {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodDisruptionBudget) DeepCopyInto(out *PodDisruptionBudget) {
+	*out = *in
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDisruptionBudget.
+func (in *PodDisruptionBudget) DeepCopy() *PodDisruptionBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(PodDisruptionBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProxyComponent) DeepCopyInto(out *ProxyComponent) {
 	*out = *in
@@ -526,6 +1026,11 @@ func (in *ProxyComponent) DeepCopyInto(out *ProxyComponent) {
 		*out = new(ProxyK8sConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Lifecycle != nil {
+		in, out := &in.Lifecycle, &out.Lifecycle
+		*out = new(ProxyLifecycle)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyComponent.
@@ -538,6 +1043,21 @@ func (in *ProxyComponent) DeepCopy() *ProxyComponent {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyConfigHashes) DeepCopyInto(out *ProxyConfigHashes) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyConfigHashes.
+func (in *ProxyConfigHashes) DeepCopy() *ProxyConfigHashes {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyConfigHashes)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProxyK8sConfig) DeepCopyInto(out *ProxyK8sConfig) {
 	*out = *in
@@ -558,6 +1078,117 @@ func (in *ProxyK8sConfig) DeepCopy() *ProxyK8sConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyLifecycle) DeepCopyInto(out *ProxyLifecycle) {
+	*out = *in
+	if in.HoldApplicationUntilProxyStarts != nil {
+		in, out := &in.HoldApplicationUntilProxyStarts, &out.HoldApplicationUntilProxyStarts
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TerminationDrainDuration != nil {
+		in, out := &in.TerminationDrainDuration, &out.TerminationDrainDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Concurrency != nil {
+		in, out := &in.Concurrency, &out.Concurrency
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ExitOnZeroActiveConnections != nil {
+		in, out := &in.ExitOnZeroActiveConnections, &out.ExitOnZeroActiveConnections
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyLifecycle.
+func (in *ProxyLifecycle) DeepCopy() *ProxyLifecycle {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyLifecycle)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyResetStatus) DeepCopyInto(out *ProxyResetStatus) {
+	*out = *in
+	if in.FailuresByOwnerKind != nil {
+		in, out := &in.FailuresByOwnerKind, &out.FailuresByOwnerKind
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.RestartsByPredicate != nil {
+		in, out := &in.RestartsByPredicate, &out.RestartsByPredicate
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.FinishTime != nil {
+		in, out := &in.FinishTime, &out.FinishTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyResetStatus.
+func (in *ProxyResetStatus) DeepCopy() *ProxyResetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyResetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyRestart) DeepCopyInto(out *ProxyRestart) {
+	*out = *in
+	if in.Waves != nil {
+		in, out := &in.Waves, &out.Waves
+		*out = make([]RestartWave, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HealthGate != nil {
+		in, out := &in.HealthGate, &out.HealthGate
+		*out = new(RestartHealthGate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Concurrency != nil {
+		in, out := &in.Concurrency, &out.Concurrency
+		*out = new(RestartConcurrency)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyRestart.
+func (in *ProxyRestart) DeepCopy() *ProxyRestart {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyRestart)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProxyStatsMatcher) DeepCopyInto(out *ProxyStatsMatcher) {
 	*out = *in
@@ -643,6 +1274,106 @@ func (in *Resources) DeepCopy() *Resources {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestartConcurrency) DeepCopyInto(out *RestartConcurrency) {
+	*out = *in
+	if in.MaxConcurrentRestarts != nil {
+		in, out := &in.MaxConcurrentRestarts, &out.MaxConcurrentRestarts
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxConcurrentRestartsPerNamespace != nil {
+		in, out := &in.MaxConcurrentRestartsPerNamespace, &out.MaxConcurrentRestartsPerNamespace
+		*out = new(int)
+		**out = **in
+	}
+	if in.RequestsPerSecond != nil {
+		in, out := &in.RequestsPerSecond, &out.RequestsPerSecond
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestartConcurrency.
+func (in *RestartConcurrency) DeepCopy() *RestartConcurrency {
+	if in == nil {
+		return nil
+	}
+	out := new(RestartConcurrency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestartHealthGate) DeepCopyInto(out *RestartHealthGate) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestartHealthGate.
+func (in *RestartHealthGate) DeepCopy() *RestartHealthGate {
+	if in == nil {
+		return nil
+	}
+	out := new(RestartHealthGate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestartHealthGateStatus) DeepCopyInto(out *RestartHealthGateStatus) {
+	*out = *in
+	if in.PendingRollouts != nil {
+		in, out := &in.PendingRollouts, &out.PendingRollouts
+		*out = make([]WorkloadReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.RolloutStartTime != nil {
+		in, out := &in.RolloutStartTime, &out.RolloutStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.UnhealthyWorkloads != nil {
+		in, out := &in.UnhealthyWorkloads, &out.UnhealthyWorkloads
+		*out = make([]WorkloadReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestartHealthGateStatus.
+func (in *RestartHealthGateStatus) DeepCopy() *RestartHealthGateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RestartHealthGateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestartWave) DeepCopyInto(out *RestartWave) {
+	*out = *in
+	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestartWave.
+func (in *RestartWave) DeepCopy() *RestartWave {
+	if in == nil {
+		return nil
+	}
+	out := new(RestartWave)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RollingUpdate) DeepCopyInto(out *RollingUpdate) {
 	*out = *in
@@ -668,6 +1399,61 @@ func (in *RollingUpdate) DeepCopy() *RollingUpdate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RootCARotation) DeepCopyInto(out *RootCARotation) {
+	*out = *in
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RootCARotation.
+func (in *RootCARotation) DeepCopy() *RootCARotation {
+	if in == nil {
+		return nil
+	}
+	out := new(RootCARotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RootCARotationStatus) DeepCopyInto(out *RootCARotationStatus) {
+	*out = *in
+	if in.ProxiesRestartedAt != nil {
+		in, out := &in.ProxiesRestartedAt, &out.ProxiesRestartedAt
+		*out = (*in).DeepCopy()
+	}
+	in.NotAfter.DeepCopyInto(&out.NotAfter)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RootCARotationStatus.
+func (in *RootCARotationStatus) DeepCopy() *RootCARotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RootCARotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretReference) DeepCopyInto(out *SecretReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretReference.
+func (in *SecretReference) DeepCopy() *SecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Strategy) DeepCopyInto(out *Strategy) {
 	*out = *in
@@ -748,3 +1534,174 @@ func (in *ToUpstream) DeepCopy() *ToUpstream {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Tracing) DeepCopyInto(out *Tracing) {
+	*out = *in
+	if in.SamplingPercentage != nil {
+		in, out := &in.SamplingPercentage, &out.SamplingPercentage
+		*out = new(string)
+		**out = **in
+	}
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]TracingProvider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DefaultProvider != nil {
+		in, out := &in.DefaultProvider, &out.DefaultProvider
+		*out = new(string)
+		**out = **in
+	}
+	if in.CustomTags != nil {
+		in, out := &in.CustomTags, &out.CustomTags
+		*out = make(map[string]TracingCustomTag, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Tracing.
+func (in *Tracing) DeepCopy() *Tracing {
+	if in == nil {
+		return nil
+	}
+	out := new(Tracing)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracingCustomTag) DeepCopyInto(out *TracingCustomTag) {
+	*out = *in
+	if in.Literal != nil {
+		in, out := &in.Literal, &out.Literal
+		*out = new(string)
+		**out = **in
+	}
+	if in.Environment != nil {
+		in, out := &in.Environment, &out.Environment
+		*out = new(TracingTagSource)
+		**out = **in
+	}
+	if in.Header != nil {
+		in, out := &in.Header, &out.Header
+		*out = new(TracingTagSource)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingCustomTag.
+func (in *TracingCustomTag) DeepCopy() *TracingCustomTag {
+	if in == nil {
+		return nil
+	}
+	out := new(TracingCustomTag)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracingProvider) DeepCopyInto(out *TracingProvider) {
+	*out = *in
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = new(string)
+		**out = **in
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingProvider.
+func (in *TracingProvider) DeepCopy() *TracingProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(TracingProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracingTagSource) DeepCopyInto(out *TracingTagSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingTagSource.
+func (in *TracingTagSource) DeepCopy() *TracingTagSource {
+	if in == nil {
+		return nil
+	}
+	out := new(TracingTagSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradePreflight) DeepCopyInto(out *UpgradePreflight) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradePreflight.
+func (in *UpgradePreflight) DeepCopy() *UpgradePreflight {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradePreflight)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeStrategy) DeepCopyInto(out *UpgradeStrategy) {
+	*out = *in
+	if in.NamespacesPerStage != nil {
+		in, out := &in.NamespacesPerStage, &out.NamespacesPerStage
+		*out = new(int)
+		**out = **in
+	}
+	if in.Preflight != nil {
+		in, out := &in.Preflight, &out.Preflight
+		*out = new(UpgradePreflight)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeStrategy.
+func (in *UpgradeStrategy) DeepCopy() *UpgradeStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadReference) DeepCopyInto(out *WorkloadReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadReference.
+func (in *WorkloadReference) DeepCopy() *WorkloadReference {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadReference)
+	in.DeepCopyInto(out)
+	return out
+}
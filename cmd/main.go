@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
 	"os"
@@ -51,6 +52,7 @@ import (
 
 	operatorv1alpha2 "github.com/kyma-project/istio/operator/api/v1alpha2"
 	controllers "github.com/kyma-project/istio/operator/internal/controller"
+	istiowebhook "github.com/kyma-project/istio/operator/internal/webhook"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -62,6 +64,7 @@ const (
 	reconciliationIntervalDefault = 10 * time.Hour
 
 	WebhookServiceDefaultPort = 9443
+	webhookCertDir            = "/tmp/k8s-webhook-server/serving-certs"
 )
 
 //nolint:gochecknoglobals // it was scaffolded by controller-gen TODO: remove this global variable when possible
@@ -71,6 +74,7 @@ var (
 
 type FlagVar struct {
 	enableLeaderElection   bool
+	enableWebhooks         bool
 	failureBaseDelay       time.Duration
 	failureMaxDelay        time.Duration
 	metricsAddr            string
@@ -140,6 +144,12 @@ func main() {
 		setupLog.Error(err, "Unable to create controller", "controller", "Istio")
 		os.Exit(1)
 	}
+	if flagVar.enableWebhooks {
+		if err = setupWebhook(mgr); err != nil {
+			setupLog.Error(err, "Unable to create webhook", "webhook", "Istio")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	if err = mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -158,6 +168,24 @@ func main() {
 	}
 }
 
+// setupWebhook provides the serving certificate of the webhook server before the manager starts the server, keeps the certificate
+// valid while the manager runs, and registers the validating webhook for the Istio CR.
+func setupWebhook(mgr manager.Manager) error {
+	// The cache of the manager client is not started yet, so the certificate is provided with a client reading from the API server.
+	c, err := client.New(mgr.GetConfig(), client.Options{Scheme: mgr.GetScheme()})
+	if err != nil {
+		return err
+	}
+	certificateProvider := istiowebhook.NewCertificateProvider(c, webhookCertDir)
+	if err = certificateProvider.Ensure(context.Background()); err != nil {
+		return err
+	}
+	if err = mgr.Add(certificateProvider); err != nil {
+		return err
+	}
+	return istiowebhook.SetupIstioWebhookWithManager(mgr)
+}
+
 func createManager(flagVar *FlagVar) (manager.Manager, error) {
 	// TODO(hx2): rework this message into something useful, I haven't copied the flag over
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
@@ -173,6 +201,7 @@ func createManager(flagVar *FlagVar) (manager.Manager, error) {
 	webhookServer := webhook.NewServer(webhook.Options{
 		TLSOpts: []func(*tls.Config){disableHTTP2},
 		Port:    WebhookServiceDefaultPort,
+		CertDir: webhookCertDir,
 	})
 
 	return ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
@@ -196,6 +225,7 @@ func createManager(flagVar *FlagVar) (manager.Manager, error) {
 					&v1.StatefulSet{},
 					&v1.ReplicaSet{},
 					&corev1.Pod{}, // this is required for the sidecar restart when listing pods with limit
+					&corev1.Secret{},
 				},
 			},
 		},
@@ -209,6 +239,9 @@ func defineFlagVar() *FlagVar {
 	flag.BoolVar(&flagVar.enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.BoolVar(&flagVar.enableWebhooks, "enable-webhooks", true,
+		"Enable the validating webhook for the Istio CR. "+
+			"The operator provides a self-signed serving certificate for the webhook server and injects its CA into the webhook configuration.")
 	flag.IntVar(&flagVar.rateLimiterBurst, "rate-limiter-burst", rateLimiterBurstDefault,
 		"Indicates the burst value for the bucket rate limiter.")
 	flag.IntVar(&flagVar.rateLimiterFrequency, "rate-limiter-frequency", rateLimiterFrequencyDefault,
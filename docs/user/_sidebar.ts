@@ -3,14 +3,25 @@ export default [
     { text: 'Restart of Workloads in the Istio Service Mesh', link: './00-05-restart-of-workloads-in-service-mesh.md' },
     { text: 'Enabling Istio Sidecar Injection', link: './tutorials/01-40-enable-sidecar-injection.md' },
     { text: 'Istio Version', link: './00-10-istio-version.md' },
+    { text: 'Canary Upgrade of the Istio Control Plane', link: './00-60-canary-upgrade.md' },
     { text: 'Istio Proxy as Native Sidecar Container', link: './00-20-istio-proxy-as-native-sidecar.md' },
+    { text: 'Configure the Lifecycle of Istio Sidecar Proxies', link: './00-22-sidecar-proxy-lifecycle.md' },
     { text: 'Configure Istio CA Certificate', link: './00-25-plug-in-istio-ca.md' },
+    { text: 'Rotation of the Self-Signed Istio Root CA', link: './00-26-root-ca-rotation.md' },
     { text: 'Istio Trust Domain', link: './00-30-trust-domain.md'},
+    { text: 'Mutual TLS Mode', link: './00-65-mtls-mode.md' },
+    { text: 'Additional Istio Ingress Gateways', link: './00-80-additional-ingress-gateways.md' },
+    { text: 'Configure the Istio Ingress Gateway Service', link: './00-85-ingress-gateway-service.md' },
+    { text: 'Schedule Istio Components', link: './00-90-schedule-istio-components.md' },
+    { text: 'Configure PodDisruptionBudgets of Istio Components', link: './00-95-pod-disruption-budgets.md' },
     { text: 'DNS Proxying', link: './00-35-dns-proxying.md' },
     { text: 'Configure Observability for the Istio Service Mesh', link: './00-40-enable-istio-access-logs.md' },
+    { text: 'Configure Tracing in the Istio Custom Resource', link: './00-70-configure-tracing.md' },
+    { text: 'Configure Access Logging in the Istio Custom Resource', link: './00-75-configure-access-logging.md' },
     ] },
   { text: 'Istio Custom Resource', link: './04-00-istio-custom-resource' },
   { text: 'Network Policies', link: './00-50-network-policies.md' },
+  { text: 'Plan Istio Custom Resource Changes', link: './00-55-plan-istio-cr-changes.md' },
   { text: 'Tutorials', link: './tutorials/README', collapsed: true, items: [
     { text: 'Expose Workloads Using oauth2-proxy', link: './tutorials/01-10-external-authorization-provider' },
     { text: 'Expose Workloads Using Gateway API', link: './tutorials/01-20-expose-httbin-gateway-api' },
@@ -21,6 +21,9 @@ const (
 	NlbTargetTypeInstance     = "instance"
 	SchemeAnnotation          = "service.beta.kubernetes.io/aws-load-balancer-scheme"
 	InternetFacingScheme      = "internet-facing"
+	InternalScheme            = "internal"
+	InternalAnnotation        = "service.beta.kubernetes.io/aws-load-balancer-internal"
+	InternalValue             = "true"
 
 	istioIngressNamespace   = "istio-system"
 	istioIngressServiceName = "istio-ingressgateway"
@@ -85,6 +88,20 @@ func (s *LB) Annotations() map[string]string {
 	}
 }
 
+func (s *LB) InternalAnnotations() map[string]string {
+	if s.lbType == NLB {
+		// The scheme annotation overrides the internet-facing scheme set in Annotations
+		return map[string]string{
+			SchemeAnnotation:   InternalScheme,
+			InternalAnnotation: InternalValue,
+		}
+	}
+
+	return map[string]string{
+		InternalAnnotation: InternalValue,
+	}
+}
+
 type Factory struct {
 	inputs factory.Inputs
 	lb     *LB
@@ -118,6 +118,43 @@ func TestFactory_MakeLB(t *testing.T) {
 	}
 }
 
+func TestFactory_MakeLBInternalAnnotations(t *testing.T) {
+	tests := []struct {
+		name       string
+		objs       []client.Object
+		wantAnnots map[string]string
+	}{
+		{
+			name: "NLB overrides the internet-facing scheme",
+			objs: nil,
+			wantAnnots: map[string]string{
+				aws.SchemeAnnotation:   aws.InternalScheme,
+				aws.InternalAnnotation: aws.InternalValue,
+			},
+		},
+		{
+			name: "ELB uses the internal annotation",
+			objs: []client.Object{elbDeprecatedCM()},
+			wantAnnots: map[string]string{
+				aws.InternalAnnotation: aws.InternalValue,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newFakeClient(t, tt.objs...)
+
+			f, err := aws.NewFactory(context.Background(), c, factory.Inputs{})
+			require.NoError(t, err)
+
+			lb := f.LB()
+			require.NotNil(t, lb)
+			assert.Equal(t, tt.wantAnnots, lb.InternalAnnotations())
+		})
+	}
+}
+
 func TestFactory_MakeNeedsProxyProtocol(t *testing.T) {
 	tests := []struct {
 		name             string
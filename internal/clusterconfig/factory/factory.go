@@ -2,6 +2,9 @@ package factory
 
 type LB interface {
 	Annotations() map[string]string
+	// InternalAnnotations returns the annotations that make the load balancer reachable only from the network of the cluster.
+	// A nil map means that the provider doesn't support internal load balancers.
+	InternalAnnotations() map[string]string
 }
 
 type CNI interface {
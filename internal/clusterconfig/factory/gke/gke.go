@@ -15,13 +15,29 @@ func (CNI) CNIValues() map[string]interface{} {
 	}
 }
 
+const (
+	lbTypeAnnotation = "networking.gke.io/load-balancer-type"
+	internalLBType   = "Internal"
+)
+
+// LB doesn't customize the load balancer by default, because GKE provisions it without additional annotations.
+type LB struct{}
+
+func (LB) Annotations() map[string]string { return nil }
+
+func (LB) InternalAnnotations() map[string]string {
+	return map[string]string{
+		lbTypeAnnotation: internalLBType,
+	}
+}
+
 type Factory struct {
 	inputs factory.Inputs
 }
 
 func NewFactory(in factory.Inputs) *Factory { return &Factory{inputs: in} }
 
-func (f *Factory) LB() factory.LB           { return nil }
+func (f *Factory) LB() factory.LB           { return LB{} }
 func (f *Factory) CNI() factory.CNI         { return CNI{} }
 func (f *Factory) NeedsProxyProtocol() bool { return false }
 func (f *Factory) DualStackEnabled() bool   { return f.inputs.DualStackEnabled }
@@ -37,7 +37,10 @@ func TestFactory(t *testing.T) {
 			f := gke.NewFactory(tt.inputs)
 			require.NotNil(t, f)
 
-			assert.Nil(t, f.LB())
+			lb := f.LB()
+			require.NotNil(t, lb)
+			assert.Nil(t, lb.Annotations())
+			assert.Equal(t, map[string]string{"networking.gke.io/load-balancer-type": "Internal"}, lb.InternalAnnotations())
 			cni := f.CNI()
 			require.NotNil(t, cni)
 			assert.NotEmpty(t, cni.CNIValues())
@@ -5,6 +5,7 @@ import "github.com/kyma-project/istio/operator/internal/clusterconfig/factory"
 const (
 	proxyProtocolAnnotation = "loadbalancer.openstack.org/proxy-protocol"
 	proxyProtocolVersion    = "v1"
+	internalLBAnnotation    = "service.beta.kubernetes.io/openstack-internal-load-balancer"
 )
 
 type LB struct {
@@ -20,6 +21,12 @@ func (s LB) Annotations() map[string]string {
 	return nil
 }
 
+func (s LB) InternalAnnotations() map[string]string {
+	return map[string]string{
+		internalLBAnnotation: "true",
+	}
+}
+
 type Factory struct {
 	inputs factory.Inputs
 }
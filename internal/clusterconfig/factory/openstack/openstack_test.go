@@ -35,6 +35,9 @@ func TestFactory_MakeLB(t *testing.T) {
 			lb := f.LB()
 			require.NotNil(t, lb)
 			assert.Equal(t, tt.wantAnnots, lb.Annotations())
+			assert.Equal(t, map[string]string{
+				"service.beta.kubernetes.io/openstack-internal-load-balancer": "true",
+			}, lb.InternalAnnotations())
 		})
 	}
 }
@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	operatorv1alpha2 "github.com/kyma-project/istio/operator/api/v1alpha2"
+	"github.com/kyma-project/istio/operator/pkg/labels"
+)
+
+// CertificateAuthoritySecretEventHandler is a controller-runtime EventHandler that triggers reconciliation of the Istio CR whenever
+// the Secret referenced in spec.config.certificateAuthority changes or the istio-system/cacerts Secret managed by the Istio module is deleted.
+type CertificateAuthoritySecretEventHandler struct {
+	Client client.Client
+}
+
+func (h CertificateAuthoritySecretEventHandler) isCertificateAuthoritySecret(ctx context.Context, obj client.Object) bool {
+	istioCRs := operatorv1alpha2.IstioList{}
+	if err := h.Client.List(ctx, &istioCRs, client.InNamespace(namespace)); err != nil {
+		controllerruntime.Log.Error(err, "Could not list Istio CRs to check the certificate authority Secret")
+		return false
+	}
+
+	for _, istioCR := range istioCRs.Items {
+		ca := istioCR.Spec.Config.CertificateAuthority
+		if ca != nil && ca.SecretRef.Namespace == obj.GetNamespace() && ca.SecretRef.Name == obj.GetName() {
+			return true
+		}
+	}
+	return false
+}
+
+func (h CertificateAuthoritySecretEventHandler) enqueue(w workqueue.TypedRateLimitingInterface[controllerruntime.Request]) {
+	w.Add(controllerruntime.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: "default"}})
+}
+
+func (h CertificateAuthoritySecretEventHandler) Create(ctx context.Context, ev event.TypedCreateEvent[client.Object], w workqueue.TypedRateLimitingInterface[controllerruntime.Request]) {
+	if h.isCertificateAuthoritySecret(ctx, ev.Object) {
+		h.enqueue(w)
+	}
+}
+
+func (h CertificateAuthoritySecretEventHandler) Update(ctx context.Context, ev event.TypedUpdateEvent[client.Object], w workqueue.TypedRateLimitingInterface[controllerruntime.Request]) {
+	if ev.ObjectOld.GetResourceVersion() != ev.ObjectNew.GetResourceVersion() && h.isCertificateAuthoritySecret(ctx, ev.ObjectNew) {
+		h.enqueue(w)
+	}
+}
+
+func (h CertificateAuthoritySecretEventHandler) Delete(ctx context.Context, ev event.TypedDeleteEvent[client.Object], w workqueue.TypedRateLimitingInterface[controllerruntime.Request]) {
+	if _, managed := ev.Object.GetAnnotations()[labels.CertificateAuthoritySource]; managed || h.isCertificateAuthoritySecret(ctx, ev.Object) {
+		h.enqueue(w)
+	}
+}
+
+func (h CertificateAuthoritySecretEventHandler) Generic(_ context.Context, _ event.TypedGenericEvent[client.Object], _ workqueue.TypedRateLimitingInterface[controllerruntime.Request]) {
+}
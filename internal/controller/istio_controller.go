@@ -24,7 +24,9 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/kyma-project/istio/operator/internal/images"
+	"github.com/kyma-project/istio/operator/internal/maintenancewindow"
 	istiocrmetrics "github.com/kyma-project/istio/operator/internal/metrics"
+	"github.com/kyma-project/istio/operator/internal/plan"
 	"github.com/kyma-project/istio/operator/internal/resources"
 
 	"github.com/kyma-project/istio/operator/internal/restarter"
@@ -38,6 +40,7 @@ import (
 	"k8s.io/client-go/util/retry"
 
 	"github.com/kyma-project/istio/operator/internal/clusterconfig"
+	"github.com/kyma-project/istio/operator/internal/clusterconfig/factory"
 	"github.com/kyma-project/istio/operator/internal/describederrors"
 	"github.com/kyma-project/istio/operator/internal/reconciliations/istio/configuration"
 	"github.com/kyma-project/istio/operator/internal/reconciliations/istioresources"
@@ -48,6 +51,7 @@ import (
 	"golang.org/x/time/rate"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -62,9 +66,14 @@ import (
 )
 
 const (
-	namespace                        = "kyma-system"
-	reconciliationRequeueTimeError   = 1 * time.Minute
-	reconciliationRequeueTimeWarning = 1 * time.Hour
+	namespace                              = validation.IstioCRNamespace
+	reconciliationRequeueTimeError         = 1 * time.Minute
+	reconciliationRequeueTimeWarning       = 1 * time.Hour
+	canaryUpgradeStageRequeueTime          = 1 * time.Minute
+	rootCARotationRequeueTime              = 1 * time.Minute
+	proxyRestartRolloutRequeueTime         = 30 * time.Second
+	certificateAuthorityInstallRequeueTime = 10 * time.Second
+	eventRecorderName                      = "istio-controller-manager"
 )
 
 type ControllerOptions struct {
@@ -77,16 +86,21 @@ func NewController(mgr manager.Manager, options ControllerOptions) *IstioReconci
 	logger := mgr.GetLogger().WithName("controllers").WithName("Istio")
 	merger := istiooperator.NewDefaultIstioMerger(logger)
 
-	statusHandler := status.NewStatusHandler(mgr.GetClient())
+	statusHandler := status.NewStatusHandler(mgr.GetClient()).WithEventRecorder(mgr.GetEventRecorder(eventRecorderName))
 	podsLister := pods.NewPods(mgr.GetClient(), &logger)
 	actionRestarter := restart.NewActionRestarter(mgr.GetClient(), &logger)
+	proxyRestarter := sidecars.NewProxyRestarter(mgr.GetClient(), podsLister, actionRestarter, &logger).WithMetrics(options.CRMetrics)
 	restarters := []restarter.Restarter{
+		restarter.NewCertificateAuthorityRestarter(mgr.GetClient(), statusHandler),
+		restarter.NewRootCARotationRestarter(mgr.GetClient(), proxyRestarter, podsLister, statusHandler),
 		restarter.NewIngressGatewayRestarter(mgr.GetClient(), []predicates.IngressGatewayPredicate{}, statusHandler),
+		restarter.NewAdditionalIngressGatewaysRestarter(mgr.GetClient(), []predicates.IngressGatewayPredicate{}, statusHandler),
+		restarter.NewEgressGatewayRestarter(mgr.GetClient(), []predicates.EgressGatewayPredicate{}, statusHandler),
 		restarter.NewSidecarsRestarter(
 			mgr.GetLogger(),
 			mgr.GetClient(),
 			&merger,
-			sidecars.NewProxyRestarter(mgr.GetClient(), podsLister, actionRestarter, &logger),
+			proxyRestarter,
 			statusHandler,
 			options.IstioImages,
 		),
@@ -101,6 +115,7 @@ func NewController(mgr manager.Manager, options ControllerOptions) *IstioReconci
 		istioResources:         istioresources.NewReconciler(mgr.GetClient()),
 		userResources:          userResources,
 		restarters:             restarters,
+		planner:                plan.NewPlanner(mgr.GetClient(), &merger, podsLister, options.IstioImages),
 		log:                    logger,
 		statusHandler:          statusHandler,
 		reconciliationInterval: options.ReconciliationInterval,
@@ -122,6 +137,7 @@ func (r *IstioReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		r.log.Error(err, "Could not get Istio CR")
 		return ctrl.Result{}, err
 	}
+	defer r.recordStateChange(&istioCR, istioCR.Status.State)
 
 	if r.crMetrics != nil {
 		r.crMetrics.UpdateIstioCRMetrics(&istioCR)
@@ -130,24 +146,51 @@ func (r *IstioReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 	}
 	r.statusHandler.SetCondition(&istioCR, operatorv1alpha2.NewReasonWithMessage(operatorv1alpha2.ConditionReasonReconcileUnknown))
 
+	validationStart := time.Now()
 	if err := r.validate(&istioCR); err != nil {
+		r.crMetrics.ObserveReconcilePhase(istiocrmetrics.PhaseValidation, validationStart, true)
 		return ctrl.Result{}, r.statusHandler.UpdateToError(ctx, &istioCR, err)
 	}
 
 	err := validation.ValidateAuthorizers(istioCR)
 	if err != nil {
+		r.crMetrics.ObserveReconcilePhase(istiocrmetrics.PhaseValidation, validationStart, true)
 		return r.terminateReconciliation(ctx, &istioCR, err, operatorv1alpha2.NewReasonWithMessage(operatorv1alpha2.ConditionReasonValidationFailed))
 	}
 
 	err = validation.ValidateProxyStatsMatcher(istioCR)
 	if err != nil {
+		r.crMetrics.ObserveReconcilePhase(istiocrmetrics.PhaseValidation, validationStart, true)
 		return r.terminateReconciliation(ctx, &istioCR, err, operatorv1alpha2.NewReasonWithMessage(operatorv1alpha2.ConditionReasonValidationFailed))
 	}
 
-	if istioCR.GetNamespace() != namespace {
-		errWrongNS := fmt.Errorf("istio CR is not in %s namespace", namespace)
-		return r.terminateReconciliation(ctx, &istioCR, describederrors.NewDescribedError(errWrongNS, "Stopped Istio CR reconciliation"),
-			operatorv1alpha2.NewReasonWithMessage(operatorv1alpha2.ConditionReasonReconcileFailed))
+	err = validation.ValidateAccessLogging(istioCR)
+	if err != nil {
+		r.crMetrics.ObserveReconcilePhase(istiocrmetrics.PhaseValidation, validationStart, true)
+		return r.terminateReconciliation(ctx, &istioCR, err, operatorv1alpha2.NewReasonWithMessage(operatorv1alpha2.ConditionReasonValidationFailed))
+	}
+
+	err = validation.ValidateTracing(istioCR)
+	if err != nil {
+		r.crMetrics.ObserveReconcilePhase(istiocrmetrics.PhaseValidation, validationStart, true)
+		return r.terminateReconciliation(ctx, &istioCR, err, operatorv1alpha2.NewReasonWithMessage(operatorv1alpha2.ConditionReasonValidationFailed))
+	}
+
+	err = validation.ValidateIngressGatewayService(istioCR)
+	if err != nil {
+		r.crMetrics.ObserveReconcilePhase(istiocrmetrics.PhaseValidation, validationStart, true)
+		return r.terminateReconciliation(ctx, &istioCR, err, operatorv1alpha2.NewReasonWithMessage(operatorv1alpha2.ConditionReasonValidationFailed))
+	}
+
+	err = validation.ValidatePodDisruptionBudgets(istioCR)
+	if err != nil {
+		r.crMetrics.ObserveReconcilePhase(istiocrmetrics.PhaseValidation, validationStart, true)
+		return r.terminateReconciliation(ctx, &istioCR, err, operatorv1alpha2.NewReasonWithMessage(operatorv1alpha2.ConditionReasonValidationFailed))
+	}
+	r.crMetrics.ObserveReconcilePhase(istiocrmetrics.PhaseValidation, validationStart, false)
+
+	if err := validation.ValidateNamespace(istioCR); err != nil {
+		return r.terminateReconciliation(ctx, &istioCR, err, operatorv1alpha2.NewReasonWithMessage(operatorv1alpha2.ConditionReasonReconcileFailed))
 	}
 
 	existingIstioCRs := &operatorv1alpha2.IstioList{}
@@ -190,7 +233,9 @@ func (r *IstioReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		}
 	}
 
+	buildFactoryStart := time.Now()
 	clusterStrategy, buildStrategyErr := clusterconfig.BuildFactory(ctx, r.Client)
+	r.crMetrics.ObserveReconcilePhase(istiocrmetrics.PhaseBuildFactory, buildFactoryStart, buildStrategyErr != nil)
 	if buildStrategyErr != nil {
 		return r.requeueReconciliation(ctx, &istioCR,
 			describederrors.NewDescribedError(buildStrategyErr, "Could not build cluster strategy"),
@@ -198,7 +243,13 @@ func (r *IstioReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 			reconciliationRequeueTimeError)
 	}
 
+	if plan.IsEnabled(&istioCR) && istioCR.DeletionTimestamp.IsZero() {
+		return r.reconcilePlan(ctx, &istioCR, clusterStrategy)
+	}
+
+	installStart := time.Now()
 	istioImageVersion, installationErr := r.istioInstallation.Reconcile(ctx, &istioCR, r.statusHandler, r.istioImages, clusterStrategy)
+	r.crMetrics.ObserveReconcilePhase(istiocrmetrics.PhaseInstall, installStart, installationErr != nil)
 	if installationErr != nil {
 		return r.requeueReconciliation(ctx, &istioCR, installationErr,
 			operatorv1alpha2.NewReasonWithMessage(operatorv1alpha2.ConditionReasonIstioInstallUninstallFailed),
@@ -211,6 +262,7 @@ func (r *IstioReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		r.log.Info("End reconciliation because all finalizers have been removed")
 		return ctrl.Result{}, nil
 	}
+	r.crMetrics.SetInstalledIstioVersion(istioImageVersion.Version())
 
 	updateIstioTagErr := r.updateIstioTag(ctx, client.ObjectKeyFromObject(&istioCR), istioImageVersion.Tag())
 	if updateIstioTagErr != nil {
@@ -225,7 +277,9 @@ func (r *IstioReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		}
 	}
 
+	istioResourcesStart := time.Now()
 	resourcesErr := r.istioResources.Reconcile(ctx, istioCR, clusterStrategy)
+	r.crMetrics.ObserveReconcilePhase(istiocrmetrics.PhaseIstioResources, istioResourcesStart, resourcesErr != nil)
 	if resourcesErr != nil {
 		return r.requeueReconciliation(ctx, &istioCR, resourcesErr,
 			operatorv1alpha2.NewReasonWithMessage(operatorv1alpha2.ConditionReasonCRsReconcileFailed),
@@ -242,8 +296,10 @@ func (r *IstioReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		return ctrl.Result{}, nil
 	}
 
+	r.statusHandler.SetCondition(&istioCR, istioresources.MtlsReason(&istioCR))
+
 	reconciliationRequeueTime := reconciliationRequeueTimeError
-	err = restarter.Restart(ctx, &istioCR, r.restarters)
+	err = restarter.Restart(ctx, &istioCR, r.restarters, r.crMetrics)
 	if err != nil {
 		if err.Level() == describederrors.Warning {
 			reconciliationRequeueTime = reconciliationRequeueTimeWarning
@@ -273,6 +329,7 @@ func (r *IstioReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 				reconciliationRequeueTimeError,
 			)
 		}
+		r.statusHandler.RecordEvent(&istioCR, corev1.EventTypeWarning, operatorv1alpha2.ConditionReasonIngressTargetingUserResourceFound, userResErr.Error())
 		return r.requeueReconciliation(
 			ctx,
 			&istioCR,
@@ -282,9 +339,163 @@ func (r *IstioReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		)
 	}
 
+	if istioCR.IsRootCARotationInProgress() {
+		return r.requeueRootCARotation(ctx, &istioCR, istioImageVersion.Tag())
+	}
+
+	if isCertificateAuthorityInstallPending(&istioCR) {
+		return r.requeueCertificateAuthorityInstall(ctx, &istioCR, istioImageVersion.Tag())
+	}
+
+	if isProxyRestartRolloutPending(&istioCR) {
+		return r.requeueProxyRestartRolloutPending(ctx, &istioCR, istioImageVersion.Tag())
+	}
+
+	if isProxyRestartDeferred(&istioCR) {
+		return r.requeueProxyRestartDeferred(ctx, &istioCR, istioImageVersion.Tag())
+	}
+
+	if istioCR.Status.CanaryUpgrade != nil {
+		return r.requeueCanaryUpgrade(ctx, &istioCR, istioImageVersion.Tag())
+	}
+
 	return r.finishReconcile(ctx, &istioCR, istioImageVersion.Tag())
 }
 
+// requeueCanaryUpgrade requeues the request to continue with the next stage of the canary upgrade.
+func (r *IstioReconciler) requeueCanaryUpgrade(ctx context.Context, istioCR *operatorv1alpha2.Istio, istioTag string) (ctrl.Result, error) {
+	if err := r.updateLastAppliedConfiguration(ctx, client.ObjectKeyFromObject(istioCR), istioTag); err != nil {
+		describedErr := describederrors.NewDescribedError(err, "Error updating LastAppliedConfiguration")
+		return r.requeueReconciliation(ctx, istioCR, describedErr,
+			operatorv1alpha2.NewReasonWithMessage(operatorv1alpha2.ConditionReasonReconcileFailed),
+			reconciliationRequeueTimeError)
+	}
+
+	r.statusHandler.SetCondition(istioCR, operatorv1alpha2.NewReasonWithMessage(operatorv1alpha2.ConditionReasonCanaryUpgradeInProgress))
+	r.log.Info("Canary upgrade in progress", "target revision", istioCR.Status.CanaryUpgrade.TargetRevision,
+		"migrated namespaces", len(istioCR.Status.CanaryUpgrade.MigratedNamespaces))
+	return r.requeueReconciliationRestartNotFinished(ctx, istioCR, canaryUpgradeStageRequeueTime)
+}
+
+// requeueRootCARotation requeues the request to continue with the next phase of the root certificate authority rotation. If the restart
+// of customer proxies is deferred or waits for the rollout of restarted workloads, only the Istio tag of the lastAppliedConfiguration is updated, so that the deferred restarts are still
+// detected in the next maintenance window.
+func (r *IstioReconciler) requeueRootCARotation(ctx context.Context, istioCR *operatorv1alpha2.Istio, istioTag string) (ctrl.Result, error) {
+	updateLastApplied := r.updateLastAppliedConfiguration
+	if isProxyRestartDeferred(istioCR) || isProxyRestartRolloutPending(istioCR) {
+		updateLastApplied = r.updateIstioTag
+	}
+	if err := updateLastApplied(ctx, client.ObjectKeyFromObject(istioCR), istioTag); err != nil {
+		describedErr := describederrors.NewDescribedError(err, "Error updating LastAppliedConfiguration")
+		return r.requeueReconciliation(ctx, istioCR, describedErr,
+			operatorv1alpha2.NewReasonWithMessage(operatorv1alpha2.ConditionReasonReconcileFailed),
+			reconciliationRequeueTimeError)
+	}
+
+	r.log.Info("Root certificate authority rotation in progress", "phase", istioCR.Status.RootCARotation.Phase)
+	return r.requeueReconciliationRestartNotFinished(ctx, istioCR, rootCARotationRequeueTime)
+}
+
+func isProxyRestartDeferred(istioCR *operatorv1alpha2.Istio) bool {
+	return istioCR.Status.Conditions != nil &&
+		meta.IsStatusConditionTrue(*istioCR.Status.Conditions, string(operatorv1alpha2.ConditionTypeProxySidecarRestartDeferred))
+}
+
+// isProxyRestartRolloutPending returns true if the health gate of the proxy restart waits for the rollout of restarted workloads, or if
+// the restart of pods that are restarted one at a time waits for their workloads to become ready.
+func isProxyRestartRolloutPending(istioCR *operatorv1alpha2.Istio) bool {
+	if istioCR.Status.RestartHealthGate != nil && len(istioCR.Status.RestartHealthGate.PendingRollouts) > 0 {
+		return true
+	}
+	if istioCR.Status.Conditions == nil {
+		return false
+	}
+	condition := meta.FindStatusCondition(*istioCR.Status.Conditions, string(operatorv1alpha2.ConditionTypeProxySidecarRestartSucceeded))
+	return condition != nil && condition.Reason == string(operatorv1alpha2.ConditionReasonProxySidecarRestartInProgress)
+}
+
+// isCertificateAuthorityInstallPending returns true if the certificate authority changed after Istio was installed in this reconciliation,
+// so the installed proxy config hashes don't include its fingerprint yet.
+func isCertificateAuthorityInstallPending(istioCR *operatorv1alpha2.Istio) bool {
+	return istioCR.Status.ProxyConfigHashes != nil &&
+		istioCR.Status.ProxyConfigHashes.CertificateAuthorityFingerprint != istioCR.GetCertificateAuthorityFingerprint()
+}
+
+// requeueCertificateAuthorityInstall requeues the request to install Istio with the fingerprint of the changed certificate authority, which
+// restarts the proxies. Only the Istio tag of the lastAppliedConfiguration is updated, so that the proxies without proxy config hash annotation
+// are still restarted.
+func (r *IstioReconciler) requeueCertificateAuthorityInstall(ctx context.Context, istioCR *operatorv1alpha2.Istio, istioTag string) (ctrl.Result, error) {
+	if err := r.updateIstioTag(ctx, client.ObjectKeyFromObject(istioCR), istioTag); err != nil {
+		describedErr := describederrors.NewDescribedError(err, "Error updating LastAppliedConfiguration")
+		return r.requeueReconciliation(ctx, istioCR, describedErr,
+			operatorv1alpha2.NewReasonWithMessage(operatorv1alpha2.ConditionReasonReconcileFailed),
+			reconciliationRequeueTimeError)
+	}
+
+	r.log.Info("Proxy restart waits for the installation of the changed certificate authority")
+	return r.requeueReconciliationRestartNotFinished(ctx, istioCR, certificateAuthorityInstallRequeueTime)
+}
+
+// requeueProxyRestartRolloutPending requeues the request to check the rollout of the restarted workloads before further proxies are
+// restarted. Only the Istio tag of the lastAppliedConfiguration is updated, so that the remaining restarts are still detected.
+func (r *IstioReconciler) requeueProxyRestartRolloutPending(ctx context.Context, istioCR *operatorv1alpha2.Istio, istioTag string) (ctrl.Result, error) {
+	if err := r.updateIstioTag(ctx, client.ObjectKeyFromObject(istioCR), istioTag); err != nil {
+		describedErr := describederrors.NewDescribedError(err, "Error updating LastAppliedConfiguration")
+		return r.requeueReconciliation(ctx, istioCR, describedErr,
+			operatorv1alpha2.NewReasonWithMessage(operatorv1alpha2.ConditionReasonReconcileFailed),
+			reconciliationRequeueTimeError)
+	}
+
+	r.log.Info("Proxy restart waits for the rollout of restarted workloads")
+	return r.requeueReconciliationRestartNotFinished(ctx, istioCR, proxyRestartRolloutRequeueTime)
+}
+
+// requeueProxyRestartDeferred finishes the reconciliation while the restart of customer proxies is deferred and requeues the request
+// when the next maintenance window opens. Only the Istio tag of the lastAppliedConfiguration is updated, so that the deferred restarts
+// are still detected in the next maintenance window.
+func (r *IstioReconciler) requeueProxyRestartDeferred(ctx context.Context, istioCR *operatorv1alpha2.Istio, istioTag string) (ctrl.Result, error) {
+	if err := r.updateIstioTag(ctx, client.ObjectKeyFromObject(istioCR), istioTag); err != nil {
+		describedErr := describederrors.NewDescribedError(err, "Error updating LastAppliedConfiguration")
+		return r.requeueReconciliation(ctx, istioCR, describedErr,
+			operatorv1alpha2.NewReasonWithMessage(operatorv1alpha2.ConditionReasonReconcileFailed),
+			reconciliationRequeueTimeError)
+	}
+
+	requeueAfter := r.reconciliationInterval
+	nextWindow, err := maintenancewindow.NextOpening(istioCR.GetMaintenanceWindows(), time.Now())
+	if err != nil {
+		r.log.Error(err, "Failed to evaluate the next maintenance window")
+	} else if untilNextWindow := time.Until(nextWindow); untilNextWindow < requeueAfter {
+		requeueAfter = untilNextWindow
+	}
+
+	r.statusHandler.SetCondition(istioCR, operatorv1alpha2.NewReasonWithMessage(operatorv1alpha2.ConditionReasonReconcileSucceeded))
+	r.statusHandler.SetCondition(istioCR, operatorv1alpha2.NewReasonWithMessage(operatorv1alpha2.ConditionReasonIngressTargetingUserResourceNotFound))
+	if err := r.statusHandler.UpdateToReady(ctx, istioCR); err != nil {
+		r.log.Error(err, "Error during updating status to ready")
+		return ctrl.Result{}, err
+	}
+	r.log.Info("Reconcile finished with deferred proxy restart", "requeueAfter", requeueAfter)
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// reconcilePlan generates the plan for the Istio CR changes without applying them and requeues the request.
+func (r *IstioReconciler) reconcilePlan(ctx context.Context, istioCR *operatorv1alpha2.Istio, clusterStrategy factory.Factory) (ctrl.Result, error) {
+	if err := r.planner.Plan(ctx, istioCR, clusterStrategy); err != nil {
+		return r.requeueReconciliation(ctx, istioCR, err,
+			operatorv1alpha2.NewReasonWithMessage(operatorv1alpha2.ConditionReasonReconcileFailed),
+			reconciliationRequeueTimeError)
+	}
+
+	planErr := describederrors.NewDescribedError(errors.New("plan mode is enabled"),
+		fmt.Sprintf("Plan mode is enabled, the changes are not applied. The plan is available in the %s/%s ConfigMap", plan.ConfigMapNamespace, plan.ConfigMapName)).
+		DisableErrorWrap().
+		SetWarning()
+	return r.requeueReconciliation(ctx, istioCR, planErr,
+		operatorv1alpha2.NewReasonWithMessage(operatorv1alpha2.ConditionReasonPlanGenerated),
+		reconciliationRequeueTimeWarning)
+}
+
 // requeueReconciliation cancels the reconciliation and requeues the request.
 func (r *IstioReconciler) requeueReconciliation(ctx context.Context,
 	istioCR *operatorv1alpha2.Istio, err describederrors.DescribedError,
@@ -361,6 +572,8 @@ func (r *IstioReconciler) finishReconcile(ctx context.Context, istioCR *operator
 // +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=mutatingwebhookconfigurations;validatingwebhookconfigurations,verbs=create;deletecollection;delete;get;list;patch;update;watch
 // +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions.apiextensions.k8s.io;customresourcedefinitions,verbs=create;deletecollection;delete;get;list;patch;update;watch
 // +kubebuilder:rbac:groups=apps;extensions,resources=daemonsets;deployments;deployments/finalizers;replicasets;statefulsets,verbs=create;deletecollection;delete;get;list;patch;update;watch
+// +kubebuilder:rbac:groups=apps.kruise.io,resources=clonesets;daemonsets;statefulsets,verbs=get
+// +kubebuilder:rbac:groups=argoproj.io,resources=rollouts,verbs=get;patch
 // +kubebuilder:rbac:groups=authentication.istio.io,resources=*,verbs=create;deletecollection;delete;get;list;patch;update;watch
 // +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
 // +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
@@ -369,6 +582,7 @@ func (r *IstioReconciler) finishReconcile(ctx context.Context, istioCR *operator
 // +kubebuilder:rbac:groups=config.istio.io,resources=*,verbs=create;deletecollection;delete;get;list;patch;update;watch
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;create;update;patch
 // +kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups=extensions.istio.io,resources=*,verbs=create;deletecollection;delete;get;list;patch;update;watch
 // +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=*,verbs=get;watch;list
 // +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=backendtlspolicies/status;gatewayclasses/status;gateways/status;grpcroutes/status;httproutes/status;referencegrants/status;tcproutes/status;tlsroutes/status;udproutes/status;listenersets/status,verbs=update;patch
@@ -412,9 +626,11 @@ func (r *IstioReconciler) SetupWithManager(mgr ctrl.Manager, rateLimiter RateLim
 	}
 
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&operatorv1alpha2.Istio{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		For(&operatorv1alpha2.Istio{}, builder.WithPredicates(predicate.Or[client.Object](predicate.GenerationChangedPredicate{}, PlanModeChangedPredicate{}))).
 		Watches(&corev1.ConfigMap{}, ElbConfigMapEventHandler{}).
 		Watches(&corev1.ConfigMap{}, IstioFeaturesConfigMapEventHandler{}).
+		// Only the metadata of Secrets is cached, because the handler only compares their names and annotations
+		Watches(&corev1.Secret{}, CertificateAuthoritySecretEventHandler{Client: mgr.GetClient()}, builder.OnlyMetadata).
 		WithOptions(controller.Options{
 			RateLimiter: workqueue.NewTypedMaxOfRateLimiter[ctrl.Request](
 				workqueue.NewTypedItemExponentialFailureRateLimiter[ctrl.Request](rateLimiter.BaseDelay,
@@ -480,6 +696,29 @@ func (r *IstioReconciler) updateIstioTag(ctx context.Context, objectKey types.Na
 	})
 }
 
+// recordStateChange counts the state transition from the start to the end of the reconciliation and emits an event on the Istio CR
+// if the reconciliation changed its state. The intermediate Processing and Deleting states are not reported as events to avoid an event
+// for every reconciliation.
+func (r *IstioReconciler) recordStateChange(istioCR *operatorv1alpha2.Istio, previousState operatorv1alpha2.State) {
+	state := istioCR.Status.State
+	if state == previousState {
+		return
+	}
+	r.crMetrics.RecordStateTransition(previousState, state)
+	note := fmt.Sprintf("Istio CR state changed from %s to %s", previousState, state)
+	if istioCR.Status.Description != "" {
+		note = fmt.Sprintf("%s: %s", note, istioCR.Status.Description)
+	}
+
+	switch state {
+	case operatorv1alpha2.Ready:
+		r.statusHandler.RecordEvent(istioCR, corev1.EventTypeNormal, operatorv1alpha2.ConditionReasonReconcileSucceeded, note)
+	case operatorv1alpha2.Warning, operatorv1alpha2.Error:
+		r.statusHandler.RecordEvent(istioCR, corev1.EventTypeWarning, operatorv1alpha2.ConditionReasonReconcileFailed, note)
+	case operatorv1alpha2.Processing, operatorv1alpha2.Deleting:
+	}
+}
+
 func (r *IstioReconciler) setConditionForError(istioCR *operatorv1alpha2.Istio, reason operatorv1alpha2.ReasonWithMessage) {
 	if !operatorv1alpha2.IsReadyTypeCondition(reason) {
 		r.statusHandler.SetCondition(istioCR, operatorv1alpha2.NewReasonWithMessage(operatorv1alpha2.ConditionReasonReconcileFailed))
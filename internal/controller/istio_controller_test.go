@@ -23,7 +23,9 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	_ "istio.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -1109,6 +1111,337 @@ var _ = Describe("Istio Controller", func() {
 				Expect(updatedIstioCR.Status.State).To(Equal(operatorv1alpha2.Ready))
 			})
 		})
+		Context("Plan mode", func() {
+			It("should generate the plan and set warning status without installing Istio when plan mode is enabled", func() {
+				// given
+				istioCR := &operatorv1alpha2.Istio{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      istioCrName,
+						Namespace: testNamespace,
+						Annotations: map[string]string{
+							labels.PlanMode: "true",
+						},
+						Finalizers: []string{
+							"istios.operator.kyma-project.io/istio-installation",
+						},
+					},
+				}
+
+				fakeClient := createFakeClient(istioCR)
+				installation := &istioInstallationReconciliationMock{}
+				planner := &plannerMock{}
+				mockedRestarter := &restarterMock{}
+
+				sut := &IstioReconciler{
+					Client:                 fakeClient,
+					Scheme:                 getTestScheme(),
+					istioInstallation:      installation,
+					restarters:             []restarter.Restarter{mockedRestarter},
+					planner:                planner,
+					istioResources:         &istioResourcesReconciliationMock{},
+					userResources:          &UserResourcesMock{},
+					log:                    logr.Discard(),
+					statusHandler:          status.NewStatusHandler(fakeClient),
+					reconciliationInterval: testReconciliationInterval,
+				}
+
+				// when
+				result, err := sut.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: istioCrName}})
+
+				// then
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(result).Should(Equal(reconcile.Result{RequeueAfter: reconciliationRequeueTimeWarning}))
+				Expect(planner.planCalled).To(BeTrue())
+				Expect(installation.reconcileCalled).To(BeFalse())
+				Expect(mockedRestarter.RestartCalled()).To(BeFalse())
+
+				updatedIstioCR := operatorv1alpha2.Istio{}
+				err = fakeClient.Get(context.Background(), client.ObjectKeyFromObject(istioCR), &updatedIstioCR)
+				Expect(err).To(Not(HaveOccurred()))
+
+				Expect(updatedIstioCR.Status.State).Should(Equal(operatorv1alpha2.Warning))
+				Expect(updatedIstioCR.Status.Description).To(ContainSubstring("kyma-system/istio-plan"))
+				Expect(updatedIstioCR.Annotations).ToNot(HaveKey(labels.LastAppliedConfiguration))
+
+				Expect(updatedIstioCR.Status.Conditions).ToNot(BeNil())
+				Expect((*updatedIstioCR.Status.Conditions)[0].Type).To(Equal(string(operatorv1alpha2.ConditionTypeReady)))
+				Expect((*updatedIstioCR.Status.Conditions)[0].Reason).To(Equal(string(operatorv1alpha2.ConditionReasonPlanGenerated)))
+				Expect((*updatedIstioCR.Status.Conditions)[0].Status).To(Equal(metav1.ConditionFalse))
+			})
+
+			It("should set error status when generating the plan failed", func() {
+				// given
+				istioCR := &operatorv1alpha2.Istio{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      istioCrName,
+						Namespace: testNamespace,
+						Annotations: map[string]string{
+							labels.PlanMode: "true",
+						},
+					},
+				}
+
+				fakeClient := createFakeClient(istioCR)
+				installation := &istioInstallationReconciliationMock{}
+
+				sut := &IstioReconciler{
+					Client:            fakeClient,
+					Scheme:            getTestScheme(),
+					istioInstallation: installation,
+					restarters:        []restarter.Restarter{&restarterMock{}},
+					planner: &plannerMock{
+						err: describederrors.NewDescribedError(errors.New("render error"), "Could not render Istio operator configuration"),
+					},
+					istioResources:         &istioResourcesReconciliationMock{},
+					userResources:          &UserResourcesMock{},
+					log:                    logr.Discard(),
+					statusHandler:          status.NewStatusHandler(fakeClient),
+					reconciliationInterval: testReconciliationInterval,
+				}
+
+				// when
+				_, err := sut.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: istioCrName}})
+
+				// then
+				Expect(err).To(HaveOccurred())
+				Expect(installation.reconcileCalled).To(BeFalse())
+
+				updatedIstioCR := operatorv1alpha2.Istio{}
+				err = fakeClient.Get(context.Background(), client.ObjectKeyFromObject(istioCR), &updatedIstioCR)
+				Expect(err).To(Not(HaveOccurred()))
+
+				Expect(updatedIstioCR.Status.State).Should(Equal(operatorv1alpha2.Error))
+				Expect(updatedIstioCR.Status.Description).To(ContainSubstring("Could not render Istio operator configuration"))
+			})
+		})
+
+		Context("Canary upgrade", func() {
+			It("should restart proxies and requeue with processing status while the canary upgrade is in progress", func() {
+				// given
+				istioCR := &operatorv1alpha2.Istio{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      istioCrName,
+						Namespace: testNamespace,
+						Finalizers: []string{
+							"istios.operator.kyma-project.io/istio-installation",
+						},
+					},
+					Spec: operatorv1alpha2.IstioSpec{
+						UpgradeStrategy: &operatorv1alpha2.UpgradeStrategy{Mode: operatorv1alpha2.UpgradeModeCanary},
+					},
+					Status: operatorv1alpha2.IstioStatus{
+						CanaryUpgrade: &operatorv1alpha2.CanaryUpgradeStatus{
+							SourceRevision:     "default",
+							TargetRevision:     "1-16-0",
+							TargetVersion:      "1.16.0",
+							MigratedNamespaces: []string{"ns-a"},
+						},
+					},
+				}
+
+				fakeClient := createFakeClient(istioCR)
+				mockedRestarter := &restarterMock{}
+
+				sut := &IstioReconciler{
+					Client:                 fakeClient,
+					Scheme:                 getTestScheme(),
+					istioInstallation:      &istioInstallationReconciliationMock{},
+					restarters:             []restarter.Restarter{mockedRestarter},
+					istioResources:         &istioResourcesReconciliationMock{},
+					userResources:          &UserResourcesMock{},
+					log:                    logr.Discard(),
+					statusHandler:          status.NewStatusHandler(fakeClient),
+					reconciliationInterval: testReconciliationInterval,
+				}
+
+				// when
+				result, err := sut.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: istioCrName}})
+
+				// then
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(result).Should(Equal(reconcile.Result{RequeueAfter: canaryUpgradeStageRequeueTime}))
+				Expect(mockedRestarter.RestartCalled()).To(BeTrue())
+
+				updatedIstioCR := operatorv1alpha2.Istio{}
+				err = fakeClient.Get(context.Background(), client.ObjectKeyFromObject(istioCR), &updatedIstioCR)
+				Expect(err).To(Not(HaveOccurred()))
+
+				Expect(updatedIstioCR.Status.State).Should(Equal(operatorv1alpha2.Processing))
+				Expect(updatedIstioCR.Status.CanaryUpgrade).ToNot(BeNil())
+				Expect(updatedIstioCR.Status.CanaryUpgrade.MigratedNamespaces).To(ConsistOf("ns-a"))
+				Expect(updatedIstioCR.Annotations).To(HaveKey(labels.LastAppliedConfiguration))
+
+				Expect(updatedIstioCR.Status.Conditions).ToNot(BeNil())
+				Expect((*updatedIstioCR.Status.Conditions)[0].Reason).To(Equal(string(operatorv1alpha2.ConditionReasonCanaryUpgradeInProgress)))
+			})
+		})
+
+		Context("Deferred proxy restart", func() {
+			It("should finish the reconciliation without updating the applied configuration and requeue until the next maintenance window", func() {
+				// given
+				istioCR := &operatorv1alpha2.Istio{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      istioCrName,
+						Namespace: testNamespace,
+						Finalizers: []string{
+							"istios.operator.kyma-project.io/istio-installation",
+						},
+					},
+					Spec: operatorv1alpha2.IstioSpec{
+						CompatibilityMode: true,
+						ProxyRestart: &operatorv1alpha2.ProxyRestart{
+							MaintenanceWindows: []operatorv1alpha2.MaintenanceWindow{
+								{Schedule: "0 22 * * *", Duration: metav1.Duration{Duration: time.Hour}},
+							},
+						},
+					},
+				}
+
+				fakeClient := createFakeClient(istioCR)
+				mockedRestarter := &restarterMock{reason: operatorv1alpha2.ConditionReasonProxySidecarRestartDeferred}
+
+				sut := &IstioReconciler{
+					Client:                 fakeClient,
+					Scheme:                 getTestScheme(),
+					istioInstallation:      &istioInstallationReconciliationMock{},
+					restarters:             []restarter.Restarter{mockedRestarter},
+					istioResources:         &istioResourcesReconciliationMock{},
+					userResources:          &UserResourcesMock{},
+					log:                    logr.Discard(),
+					statusHandler:          status.NewStatusHandler(fakeClient),
+					reconciliationInterval: 48 * time.Hour,
+				}
+
+				// when
+				result, err := sut.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: istioCrName}})
+
+				// then
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+				Expect(result.RequeueAfter).To(BeNumerically("<=", 24*time.Hour))
+
+				updatedIstioCR := operatorv1alpha2.Istio{}
+				err = fakeClient.Get(context.Background(), client.ObjectKeyFromObject(istioCR), &updatedIstioCR)
+				Expect(err).To(Not(HaveOccurred()))
+
+				Expect(updatedIstioCR.Status.State).Should(Equal(operatorv1alpha2.Ready))
+				Expect(updatedIstioCR.Annotations).To(HaveKey(labels.LastAppliedConfiguration))
+				Expect(updatedIstioCR.Annotations[labels.LastAppliedConfiguration]).ToNot(ContainSubstring(`"compatibilityMode":true`))
+
+				Expect(updatedIstioCR.Status.Conditions).ToNot(BeNil())
+				deferredCondition := meta.FindStatusCondition(*updatedIstioCR.Status.Conditions, string(operatorv1alpha2.ConditionTypeProxySidecarRestartDeferred))
+				Expect(deferredCondition).ToNot(BeNil())
+				Expect(deferredCondition.Status).To(Equal(metav1.ConditionTrue))
+			})
+		})
+
+		Context("Proxy restart waiting for rollouts", func() {
+			It("should requeue without updating the applied configuration while the health gate waits for the rollout of restarted workloads", func() {
+				// given
+				istioCR := &operatorv1alpha2.Istio{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      istioCrName,
+						Namespace: testNamespace,
+						Finalizers: []string{
+							"istios.operator.kyma-project.io/istio-installation",
+						},
+					},
+					Spec: operatorv1alpha2.IstioSpec{
+						CompatibilityMode: true,
+						ProxyRestart: &operatorv1alpha2.ProxyRestart{
+							HealthGate: &operatorv1alpha2.RestartHealthGate{},
+						},
+					},
+					Status: operatorv1alpha2.IstioStatus{
+						RestartHealthGate: &operatorv1alpha2.RestartHealthGateStatus{
+							PendingRollouts: []operatorv1alpha2.WorkloadReference{{Kind: "Deployment", Namespace: "test-ns", Name: "dep1"}},
+						},
+					},
+				}
+
+				fakeClient := createFakeClient(istioCR)
+				mockedRestarter := &restarterMock{reason: operatorv1alpha2.ConditionReasonProxySidecarRestartInProgress}
+
+				sut := &IstioReconciler{
+					Client:                 fakeClient,
+					Scheme:                 getTestScheme(),
+					istioInstallation:      &istioInstallationReconciliationMock{},
+					restarters:             []restarter.Restarter{mockedRestarter},
+					istioResources:         &istioResourcesReconciliationMock{},
+					userResources:          &UserResourcesMock{},
+					log:                    logr.Discard(),
+					statusHandler:          status.NewStatusHandler(fakeClient),
+					reconciliationInterval: 10 * time.Hour,
+				}
+
+				// when
+				result, err := sut.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: istioCrName}})
+
+				// then
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(result.RequeueAfter).To(Equal(proxyRestartRolloutRequeueTime))
+
+				updatedIstioCR := operatorv1alpha2.Istio{}
+				err = fakeClient.Get(context.Background(), client.ObjectKeyFromObject(istioCR), &updatedIstioCR)
+				Expect(err).To(Not(HaveOccurred()))
+
+				Expect(updatedIstioCR.Status.State).Should(Equal(operatorv1alpha2.Processing))
+				Expect(updatedIstioCR.Annotations[labels.LastAppliedConfiguration]).ToNot(ContainSubstring(`"compatibilityMode":true`))
+				Expect(updatedIstioCR.Status.RestartHealthGate.PendingRollouts).To(HaveLen(1))
+			})
+		})
+
+		Context("Certificate authority changed after the installation", func() {
+			It("should requeue without updating the applied configuration until the proxy config hashes include the fingerprint", func() {
+				// given
+				istioCR := &operatorv1alpha2.Istio{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      istioCrName,
+						Namespace: testNamespace,
+						Finalizers: []string{
+							"istios.operator.kyma-project.io/istio-installation",
+						},
+					},
+					Spec: operatorv1alpha2.IstioSpec{
+						CompatibilityMode: true,
+					},
+					Status: operatorv1alpha2.IstioStatus{
+						CertificateAuthority: &operatorv1alpha2.CertificateAuthorityStatus{Fingerprint: "def"},
+						ProxyConfigHashes:    &operatorv1alpha2.ProxyConfigHashes{Sidecars: "sidecars", CertificateAuthorityFingerprint: "abc"},
+					},
+				}
+
+				fakeClient := createFakeClient(istioCR)
+
+				sut := &IstioReconciler{
+					Client:                 fakeClient,
+					Scheme:                 getTestScheme(),
+					istioInstallation:      &istioInstallationReconciliationMock{},
+					restarters:             []restarter.Restarter{&restarterMock{}},
+					istioResources:         &istioResourcesReconciliationMock{},
+					userResources:          &UserResourcesMock{},
+					log:                    logr.Discard(),
+					statusHandler:          status.NewStatusHandler(fakeClient),
+					reconciliationInterval: 10 * time.Hour,
+				}
+
+				// when
+				result, err := sut.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: istioCrName}})
+
+				// then
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(result.RequeueAfter).To(Equal(certificateAuthorityInstallRequeueTime))
+
+				updatedIstioCR := operatorv1alpha2.Istio{}
+				err = fakeClient.Get(context.Background(), client.ObjectKeyFromObject(istioCR), &updatedIstioCR)
+				Expect(err).To(Not(HaveOccurred()))
+
+				Expect(updatedIstioCR.Status.State).Should(Equal(operatorv1alpha2.Processing))
+				Expect(updatedIstioCR.Annotations[labels.LastAppliedConfiguration]).ToNot(ContainSubstring(`"compatibilityMode":true`))
+				Expect(updatedIstioCR.Annotations[labels.LastAppliedConfiguration]).ToNot(ContainSubstring("def"))
+			})
+		})
+
 		Context("LastAppliedConfiguration", func() {
 			It("should update LastAppliedConfiguration with istioTag version even if restarter is blocked", func() {
 				//given
@@ -1155,17 +1488,31 @@ type restarterMock struct {
 	err       describederrors.DescribedError
 	requeue   bool
 	restarted bool
+	reason    operatorv1alpha2.ConditionReason
 }
 
 func (i *restarterMock) RestartCalled() bool {
 	return i.restarted
 }
 
-func (i *restarterMock) Restart(_ context.Context, _ *operatorv1alpha2.Istio) describederrors.DescribedError {
+func (i *restarterMock) Restart(_ context.Context, istioCR *operatorv1alpha2.Istio) describederrors.DescribedError {
 	i.restarted = true
+	if i.reason != "" {
+		status.NewStatusHandler(nil).SetCondition(istioCR, operatorv1alpha2.NewReasonWithMessage(i.reason))
+	}
 	return i.err
 }
 
+type plannerMock struct {
+	err        describederrors.DescribedError
+	planCalled bool
+}
+
+func (p *plannerMock) Plan(_ context.Context, _ *operatorv1alpha2.Istio, _ factory.Factory) describederrors.DescribedError {
+	p.planCalled = true
+	return p.err
+}
+
 type istioResourcesReconciliationMock struct {
 	err describederrors.DescribedError
 }
@@ -1191,10 +1538,12 @@ func (p *shouldFailClient) List(ctx context.Context, list client.ObjectList, _ .
 }
 
 type istioInstallationReconciliationMock struct {
-	err describederrors.DescribedError
+	err             describederrors.DescribedError
+	reconcileCalled bool
 }
 
 func (i *istioInstallationReconciliationMock) Reconcile(_ context.Context, _ *operatorv1alpha2.Istio, _ status.Status, _ images.Images, _ factory.Factory) (istiooperator.IstioImageVersion, describederrors.DescribedError) {
+	i.reconcileCalled = true
 	version, err := istiooperator.NewIstioImageVersionFromTag("1.16.0-distroless")
 	if err != nil {
 		i.err = describederrors.NewDescribedError(err, "error creating IstioImageVersion")
@@ -1213,6 +1562,7 @@ type StatusMock struct {
 	updatedToErrorCalled      bool
 	setConditionCalled        bool
 	reasons                   []operatorv1alpha2.ReasonWithMessage
+	eventReasons              []operatorv1alpha2.ConditionReason
 }
 
 func NewStatusMock() *StatusMock {
@@ -1246,6 +1596,14 @@ func (s *StatusMock) SetCondition(_ *operatorv1alpha2.Istio, reason operatorv1al
 	s.reasons = append(s.reasons, reason)
 }
 
+func (s *StatusMock) RecordEvent(_ *operatorv1alpha2.Istio, _ string, reason operatorv1alpha2.ConditionReason, _ string) {
+	s.eventReasons = append(s.eventReasons, reason)
+}
+
+func (s *StatusMock) RecordObjectEvent(_ *operatorv1alpha2.Istio, _ runtime.Object, _ string, reason operatorv1alpha2.ConditionReason, _ string) {
+	s.eventReasons = append(s.eventReasons, reason)
+}
+
 func (s *StatusMock) GetConditions() []operatorv1alpha2.ReasonWithMessage {
 	return s.reasons
 }
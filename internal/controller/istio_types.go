@@ -16,6 +16,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kyma-project/istio/operator/internal/describederrors"
+	"github.com/kyma-project/istio/operator/internal/plan"
 	"github.com/kyma-project/istio/operator/internal/reconciliations/istio"
 	"github.com/kyma-project/istio/operator/internal/reconciliations/istioresources"
 	"github.com/kyma-project/istio/operator/internal/status"
@@ -34,6 +35,7 @@ type IstioReconciler struct {
 	istioResources         istioresources.ResourcesReconciliation
 	userResources          resources.UserResourcesFinder
 	restarters             []restarter.Restarter
+	planner                plan.Planner
 	log                    logr.Logger
 	statusHandler          status.Status
 	reconciliationInterval time.Duration
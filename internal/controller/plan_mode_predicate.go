@@ -0,0 +1,39 @@
+package controller
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/kyma-project/istio/operator/pkg/labels"
+)
+
+// PlanModeChangedPredicate is a controller-runtime predicate that returns true if the plan mode annotation of the Istio CR changed.
+// Changing annotations does not increase the generation of the Istio CR, so enabling or disabling plan mode would otherwise
+// not be reconciled until the next requeue.
+type PlanModeChangedPredicate struct {
+	predicate.Funcs
+}
+
+func (PlanModeChangedPredicate) Create(_ event.CreateEvent) bool {
+	return false
+}
+
+func (PlanModeChangedPredicate) Delete(_ event.DeleteEvent) bool {
+	return false
+}
+
+func (PlanModeChangedPredicate) Generic(_ event.GenericEvent) bool {
+	return false
+}
+
+func (PlanModeChangedPredicate) Update(e event.UpdateEvent) bool {
+	if e.ObjectOld == nil || e.ObjectNew == nil {
+		return false
+	}
+	return planModeAnnotation(e.ObjectOld) != planModeAnnotation(e.ObjectNew)
+}
+
+func planModeAnnotation(obj client.Object) string {
+	return obj.GetAnnotations()[labels.PlanMode]
+}
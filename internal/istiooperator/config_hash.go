@@ -0,0 +1,161 @@
+package istiooperator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	operatorv1alpha2 "github.com/kyma-project/istio/operator/api/v1alpha2"
+	"github.com/kyma-project/istio/operator/pkg/labels"
+)
+
+// ComputeProxyConfigHashes returns the hashes of the mesh configuration in the IstioOperator manifest that is relevant
+// for the proxies of the sidecars, the ingress gateways and the egress gateway.
+func ComputeProxyConfigHashes(manifest []byte) (operatorv1alpha2.ProxyConfigHashes, error) {
+	iop := map[string]interface{}{}
+	if err := yaml.Unmarshal(manifest, &iop); err != nil {
+		return operatorv1alpha2.ProxyConfigHashes{}, err
+	}
+	return computeProxyConfigHashes(iop)
+}
+
+func computeProxyConfigHashes(iop map[string]interface{}) (operatorv1alpha2.ProxyConfigHashes, error) {
+	meshConfig, _, err := unstructured.NestedMap(iop, "spec", "meshConfig")
+	if err != nil {
+		return operatorv1alpha2.ProxyConfigHashes{}, err
+	}
+	certificateAuthorityFingerprint, _, err := unstructured.NestedString(iop, "metadata", "annotations", labels.CertificateAuthorityFingerprint)
+	if err != nil {
+		return operatorv1alpha2.ProxyConfigHashes{}, err
+	}
+	defaultConfig, _, err := unstructured.NestedMap(meshConfig, "defaultConfig")
+	if err != nil {
+		return operatorv1alpha2.ProxyConfigHashes{}, err
+	}
+
+	// The gateway topology is only applied to the gateways and the sidecars don't need to be restarted when it changes.
+	sidecarsConfig := deepCopyConfig(defaultConfig)
+	delete(sidecarsConfig, "gatewayTopology")
+	sidecarsHash, err := hashConfig(withCertificateAuthority(map[string]interface{}{
+		"defaultConfig":         sidecarsConfig,
+		"enablePrometheusMerge": meshConfig["enablePrometheusMerge"],
+	}, certificateAuthorityFingerprint))
+	if err != nil {
+		return operatorv1alpha2.ProxyConfigHashes{}, err
+	}
+
+	// holdApplicationUntilProxyStarts is only applied to injected sidecars and has no effect on the gateways.
+	ingressGatewayConfig := deepCopyConfig(defaultConfig)
+	delete(ingressGatewayConfig, "holdApplicationUntilProxyStarts")
+	ingressGatewayHash, err := hashConfig(withCertificateAuthority(map[string]interface{}{
+		"defaultConfig": ingressGatewayConfig,
+		"trustDomain":   meshConfig["trustDomain"],
+	}, certificateAuthorityFingerprint))
+	if err != nil {
+		return operatorv1alpha2.ProxyConfigHashes{}, err
+	}
+
+	// The number of trusted proxies is only relevant for gateways that receive traffic from outside the cluster.
+	egressGatewayConfig := deepCopyConfig(ingressGatewayConfig)
+	unstructured.RemoveNestedField(egressGatewayConfig, "gatewayTopology", "numTrustedProxies")
+	egressGatewayHash, err := hashConfig(withCertificateAuthority(map[string]interface{}{
+		"defaultConfig": egressGatewayConfig,
+		"trustDomain":   meshConfig["trustDomain"],
+	}, certificateAuthorityFingerprint))
+	if err != nil {
+		return operatorv1alpha2.ProxyConfigHashes{}, err
+	}
+
+	return operatorv1alpha2.ProxyConfigHashes{
+		Sidecars:       sidecarsHash,
+		IngressGateway: ingressGatewayHash,
+		EgressGateway:  egressGatewayHash,
+
+		CertificateAuthorityFingerprint: certificateAuthorityFingerprint,
+	}, nil
+}
+
+// withCertificateAuthority adds the fingerprint of the custom certificate authority to the hashed configuration, so that the proxies are
+// restarted to get workload certificates signed by a changed certificate authority. Without a custom certificate authority the hashed
+// configuration is not changed.
+func withCertificateAuthority(config map[string]interface{}, fingerprint string) map[string]interface{} {
+	if fingerprint != "" {
+		config["certificateAuthority"] = fingerprint
+	}
+	return config
+}
+
+// stampProxyConfigHashes sets the proxy config hashes as annotation on the Pods of the gateways and the Pods with injected sidecars,
+// so that a changed proxy configuration is detected by comparing the annotation with the hash in the Istio CR status.
+// The fingerprint of the custom certificate authority is set as annotation on the IstioOperator, so that the hashes computed from the
+// manifest include it.
+func stampProxyConfigHashes(manifest []byte, certificateAuthorityFingerprint string) ([]byte, error) {
+	iop := map[string]interface{}{}
+	if err := yaml.Unmarshal(manifest, &iop); err != nil {
+		return nil, err
+	}
+
+	if certificateAuthorityFingerprint != "" {
+		if err := unstructured.SetNestedField(iop, certificateAuthorityFingerprint, "metadata", "annotations", labels.CertificateAuthorityFingerprint); err != nil {
+			return nil, err
+		}
+	}
+
+	hashes, err := computeProxyConfigHashes(iop)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stampGateways(iop, "ingressGateways", hashes.IngressGateway); err != nil {
+		return nil, err
+	}
+	if err := stampGateways(iop, "egressGateways", hashes.EgressGateway); err != nil {
+		return nil, err
+	}
+	if err := unstructured.SetNestedField(iop, hashes.Sidecars, "spec", "values", "sidecarInjectorWebhook", "injectedAnnotations", labels.ProxyConfigHash); err != nil {
+		return nil, err
+	}
+
+	return yaml.Marshal(iop)
+}
+
+func stampGateways(iop map[string]interface{}, component string, hash string) error {
+	gateways, found, err := unstructured.NestedSlice(iop, "spec", "components", component)
+	if err != nil || !found {
+		return err
+	}
+
+	for i, gateway := range gateways {
+		gatewayMap, ok := gateway.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("unexpected type %T of %s entry %d", gateway, component, i)
+		}
+		if err := unstructured.SetNestedField(gatewayMap, hash, "k8s", "podAnnotations", labels.ProxyConfigHash); err != nil {
+			return err
+		}
+	}
+
+	return unstructured.SetNestedSlice(iop, gateways, "spec", "components", component)
+}
+
+func hashConfig(config map[string]interface{}) (string, error) {
+	// json.Marshal sorts the keys of maps, so the same configuration always results in the same hash.
+	b, err := json.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func deepCopyConfig(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return map[string]interface{}{}
+	}
+	return runtime.DeepCopyJSON(m)
+}
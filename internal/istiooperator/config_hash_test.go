@@ -0,0 +1,240 @@
+package istiooperator_test
+
+import (
+	"os"
+	"path"
+	"strings"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	iopv1alpha1 "istio.io/istio/operator/pkg/apis"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kyma-project/istio/operator/api/v1alpha2"
+	"github.com/kyma-project/istio/operator/internal/clusterconfig"
+	"github.com/kyma-project/istio/operator/internal/images"
+	"github.com/kyma-project/istio/operator/internal/istiooperator"
+	"github.com/kyma-project/istio/operator/pkg/labels"
+)
+
+var _ = Describe("ComputeProxyConfigHashes", func() {
+	const manifest = `spec:
+  meshConfig:
+    trustDomain: cluster.local
+    enablePrometheusMerge: true
+    defaultConfig:
+      holdApplicationUntilProxyStarts: true
+      gatewayTopology:
+        numTrustedProxies: 1
+        forwardClientCertDetails: SANITIZE
+      proxyMetadata:
+        ISTIO_META_DNS_CAPTURE: "true"
+`
+
+	It("should return the same hashes regardless of the order of the fields", func() {
+		reordered := `spec:
+  meshConfig:
+    defaultConfig:
+      proxyMetadata:
+        ISTIO_META_DNS_CAPTURE: "true"
+      gatewayTopology:
+        forwardClientCertDetails: SANITIZE
+        numTrustedProxies: 1
+      holdApplicationUntilProxyStarts: true
+    enablePrometheusMerge: true
+    trustDomain: cluster.local
+`
+
+		hashes, err := istiooperator.ComputeProxyConfigHashes([]byte(manifest))
+		Expect(err).ShouldNot(HaveOccurred())
+		reorderedHashes, err := istiooperator.ComputeProxyConfigHashes([]byte(reordered))
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Expect(hashes.Sidecars).ToNot(BeEmpty())
+		Expect(hashes.IngressGateway).ToNot(BeEmpty())
+		Expect(hashes.EgressGateway).ToNot(BeEmpty())
+		Expect(reorderedHashes).To(Equal(hashes))
+	})
+
+	It("should change all hashes when the proxy metadata changes", func() {
+		hashes, err := istiooperator.ComputeProxyConfigHashes([]byte(manifest))
+		Expect(err).ShouldNot(HaveOccurred())
+
+		changedHashes, err := istiooperator.ComputeProxyConfigHashes(
+			[]byte(strings.Replace(manifest, `ISTIO_META_DNS_CAPTURE: "true"`, `ISTIO_META_DNS_CAPTURE: "false"`, 1)))
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Expect(changedHashes.Sidecars).ToNot(Equal(hashes.Sidecars))
+		Expect(changedHashes.IngressGateway).ToNot(Equal(hashes.IngressGateway))
+		Expect(changedHashes.EgressGateway).ToNot(Equal(hashes.EgressGateway))
+	})
+
+	It("should only change the ingress gateway hash when the number of trusted proxies changes", func() {
+		hashes, err := istiooperator.ComputeProxyConfigHashes([]byte(manifest))
+		Expect(err).ShouldNot(HaveOccurred())
+
+		changedHashes, err := istiooperator.ComputeProxyConfigHashes(
+			[]byte(strings.Replace(manifest, "numTrustedProxies: 1", "numTrustedProxies: 2", 1)))
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Expect(changedHashes.Sidecars).To(Equal(hashes.Sidecars))
+		Expect(changedHashes.IngressGateway).ToNot(Equal(hashes.IngressGateway))
+		Expect(changedHashes.EgressGateway).To(Equal(hashes.EgressGateway))
+	})
+
+	It("should only change the sidecars hash when Prometheus merge or holdApplicationUntilProxyStarts changes", func() {
+		hashes, err := istiooperator.ComputeProxyConfigHashes([]byte(manifest))
+		Expect(err).ShouldNot(HaveOccurred())
+
+		changedHashes, err := istiooperator.ComputeProxyConfigHashes(
+			[]byte(strings.Replace(strings.Replace(manifest, "enablePrometheusMerge: true", "enablePrometheusMerge: false", 1),
+				"holdApplicationUntilProxyStarts: true", "holdApplicationUntilProxyStarts: false", 1)))
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Expect(changedHashes.Sidecars).ToNot(Equal(hashes.Sidecars))
+		Expect(changedHashes.IngressGateway).To(Equal(hashes.IngressGateway))
+		Expect(changedHashes.EgressGateway).To(Equal(hashes.EgressGateway))
+	})
+
+	It("should change all hashes when the certificate authority fingerprint changes", func() {
+		withFingerprint := func(fingerprint string) []byte {
+			return []byte("metadata:\n  annotations:\n    " + labels.CertificateAuthorityFingerprint + ": " + fingerprint + "\n" + manifest)
+		}
+		hashes, err := istiooperator.ComputeProxyConfigHashes([]byte(manifest))
+		Expect(err).ShouldNot(HaveOccurred())
+
+		caHashes, err := istiooperator.ComputeProxyConfigHashes(withFingerprint("abc"))
+		Expect(err).ShouldNot(HaveOccurred())
+		changedCAHashes, err := istiooperator.ComputeProxyConfigHashes(withFingerprint("def"))
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Expect(hashes.CertificateAuthorityFingerprint).To(BeEmpty())
+		Expect(caHashes.CertificateAuthorityFingerprint).To(Equal("abc"))
+		Expect(caHashes.Sidecars).ToNot(Equal(hashes.Sidecars))
+		Expect(caHashes.IngressGateway).ToNot(Equal(hashes.IngressGateway))
+		Expect(caHashes.EgressGateway).ToNot(Equal(hashes.EgressGateway))
+		Expect(changedCAHashes.Sidecars).ToNot(Equal(caHashes.Sidecars))
+		Expect(changedCAHashes.IngressGateway).ToNot(Equal(caHashes.IngressGateway))
+		Expect(changedCAHashes.EgressGateway).ToNot(Equal(caHashes.EgressGateway))
+	})
+
+	It("should return hashes if the manifest has no mesh config", func() {
+		hashes, err := istiooperator.ComputeProxyConfigHashes([]byte("spec:\n  tag: 1.11.0\n"))
+
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(hashes.Sidecars).ToNot(BeEmpty())
+	})
+
+	It("should return an error if the manifest is invalid", func() {
+		_, err := istiooperator.ComputeProxyConfigHashes([]byte("spec: ["))
+
+		Expect(err).Should(HaveOccurred())
+	})
+})
+
+var _ = Describe("Proxy config hash stamping", func() {
+	pilot, _ := images.NewImage("docker.io/istio/pilot:1.27.1-distroless")
+	proxy, _ := images.NewImage("docker.io/istio/proxyv2:1.27.1-distroless")
+	install, _ := images.NewImage("docker.io/istio/cni:1.27.1-distroless")
+	ztunnel, _ := images.NewImage("docker.io/istio/ztunnel:1.27.1-distroless")
+
+	img := images.Images{
+		Registry:   "docker.io/istio",
+		Tag:        "1.27.1-distroless",
+		Pilot:      pilot,
+		ProxyV2:    proxy,
+		InstallCNI: install,
+		Ztunnel:    ztunnel,
+	}
+
+	istioCR := &v1alpha2.Istio{ObjectMeta: metav1.ObjectMeta{
+		Name:      "istio-test",
+		Namespace: "namespace",
+	}}
+
+	It("should annotate the gateways and the injected sidecars with the proxy config hashes", func() {
+		// given
+		sut := istiooperator.NewDefaultIstioMerger(logr.Discard())
+
+		// when
+		rendered, err := sut.Render(clusterconfig.Production, istioCR, clusterconfig.ClusterConfiguration{}, img)
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+		hashes, err := istiooperator.ComputeProxyConfigHashes(rendered)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		iop := iopv1alpha1.IstioOperator{}
+		Expect(yaml.Unmarshal(rendered, &iop)).Should(Succeed())
+		Expect(iop.Spec.Components.IngressGateways).ToNot(BeEmpty())
+		for _, gateway := range iop.Spec.Components.IngressGateways {
+			Expect(gateway.Kubernetes.PodAnnotations).To(HaveKeyWithValue(labels.ProxyConfigHash, hashes.IngressGateway))
+		}
+		Expect(iop.Spec.Components.EgressGateways).ToNot(BeEmpty())
+		for _, gateway := range iop.Spec.Components.EgressGateways {
+			Expect(gateway.Kubernetes.PodAnnotations).To(HaveKeyWithValue(labels.ProxyConfigHash, hashes.EgressGateway))
+		}
+
+		values := map[string]interface{}{}
+		Expect(yaml.Unmarshal(iop.Spec.Values, &values)).Should(Succeed())
+		injectedAnnotations := values["sidecarInjectorWebhook"].(map[string]interface{})["injectedAnnotations"].(map[string]interface{})
+		Expect(injectedAnnotations).To(HaveKeyWithValue(labels.ProxyConfigHash, hashes.Sidecars))
+		Expect(injectedAnnotations).To(HaveKeyWithValue("sidecar.istio.io/statsEvictionInterval", "6h"))
+	})
+
+	It("should include the fingerprint of the custom certificate authority in the proxy config hashes", func() {
+		// given
+		sut := istiooperator.NewDefaultIstioMerger(logr.Discard())
+		caIstioCR := istioCR.DeepCopy()
+		caIstioCR.Status.CertificateAuthority = &v1alpha2.CertificateAuthorityStatus{Fingerprint: "abc"}
+
+		// when
+		rendered, err := sut.Render(clusterconfig.Production, caIstioCR, clusterconfig.ClusterConfiguration{}, img)
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+		hashes, err := istiooperator.ComputeProxyConfigHashes(rendered)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(hashes.CertificateAuthorityFingerprint).To(Equal("abc"))
+
+		iop := iopv1alpha1.IstioOperator{}
+		Expect(yaml.Unmarshal(rendered, &iop)).Should(Succeed())
+		Expect(iop.Annotations).To(HaveKeyWithValue(labels.CertificateAuthorityFingerprint, "abc"))
+	})
+
+	It("should return the proxy config hashes of the last merged IstioOperator", func() {
+		// given
+		sut := istiooperator.NewDefaultIstioMerger(logr.Discard())
+		mergedIstioOperatorPath, err := sut.Merge(clusterconfig.Production, istioCR, clusterconfig.ClusterConfiguration{}, img)
+		Expect(err).ShouldNot(HaveOccurred())
+		merged, err := os.ReadFile(mergedIstioOperatorPath)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		// when
+		hashes, err := sut.GetProxyConfigHashes()
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+		expectedHashes, err := istiooperator.ComputeProxyConfigHashes(merged)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(hashes).To(Equal(expectedHashes))
+
+		err = os.Remove(mergedIstioOperatorPath)
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should return empty proxy config hashes if no IstioOperator was merged", func() {
+		// given
+		sut := istiooperator.NewDefaultIstioMerger(logr.Discard())
+		_ = os.Remove(path.Join("/tmp", istiooperator.MergedIstioOperatorFile))
+
+		// when
+		hashes, err := sut.GetProxyConfigHashes()
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(hashes).To(Equal(v1alpha2.ProxyConfigHashes{}))
+	})
+})
@@ -56,6 +56,9 @@ func (i *IstioImageVersion) Empty() bool {
 type Merger interface {
 	Merge(clusterSize clusterconfig.ClusterSize, istioCR *operatorv1alpha2.Istio,
 		overrides clusterconfig.ClusterConfiguration, istioImages images.Images, options ...operatorv1alpha2.MergeOption) (string, error)
+	Render(clusterSize clusterconfig.ClusterSize, istioCR *operatorv1alpha2.Istio,
+		overrides clusterconfig.ClusterConfiguration, istioImages images.Images, options ...operatorv1alpha2.MergeOption) ([]byte, error)
+	GetProxyConfigHashes() (operatorv1alpha2.ProxyConfigHashes, error)
 	GetIstioOperator(clusterSize clusterconfig.ClusterSize) (iopv1alpha1.IstioOperator, error)
 	GetIstioImageVersion() (IstioImageVersion, error)
 }
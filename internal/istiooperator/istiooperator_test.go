@@ -194,6 +194,69 @@ var _ = Describe("Merge", func() {
 	})
 })
 
+var _ = Describe("Render", func() {
+	numTrustedProxies := 2
+	istioCR := &v1alpha2.Istio{ObjectMeta: metav1.ObjectMeta{
+		Name:      "istio-test",
+		Namespace: "namespace",
+	},
+		Spec: v1alpha2.IstioSpec{
+			Config: v1alpha2.Config{
+				NumTrustedProxies: &numTrustedProxies,
+			},
+		},
+	}
+	pilot, _ := images.NewImage("docker.io/istio/pilot:1.27.1-distroless")
+	proxy, _ := images.NewImage("docker.io/istio/proxyv2:1.27.1-distroless")
+	install, _ := images.NewImage("docker.io/istio/cni:1.27.1-distroless")
+	ztunnel, _ := images.NewImage("docker.io/istio/ztunnel:1.27.1-distroless")
+
+	img := images.Images{
+		Registry:   "docker.io/istio",
+		Tag:        "1.27.1-distroless",
+		Pilot:      pilot,
+		ProxyV2:    proxy,
+		InstallCNI: install,
+		Ztunnel:    ztunnel,
+	}
+
+	It("should return the same manifest as Merge without writing the merged file", func() {
+		// given
+		sut := istiooperator.NewDefaultIstioMerger(logr.Discard())
+		_ = os.Remove(path.Join("/tmp", istiooperator.MergedIstioOperatorFile))
+
+		// when
+		rendered, err := sut.Render(clusterconfig.Production, istioCR, clusterconfig.ClusterConfiguration{}, img)
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+		_, statErr := os.Stat(path.Join("/tmp", istiooperator.MergedIstioOperatorFile))
+		Expect(os.IsNotExist(statErr)).To(BeTrue())
+
+		mergedIstioOperatorPath, err := sut.Merge(clusterconfig.Production, istioCR, clusterconfig.ClusterConfiguration{}, img)
+		Expect(err).ShouldNot(HaveOccurred())
+		merged, err := os.ReadFile(mergedIstioOperatorPath)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(rendered).To(Equal(merged))
+
+		err = os.Remove(mergedIstioOperatorPath)
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should return error when provided Unknown cluster size", func() {
+		// given
+		sut := istiooperator.NewDefaultIstioMerger(logr.Discard())
+
+		// when
+		rendered, err := sut.Render(clusterconfig.UnknownSize, istioCR, clusterconfig.ClusterConfiguration{}, img)
+
+		// then
+		Expect(err).Should(HaveOccurred())
+		Expect(err.Error()).To(Equal("unsupported cluster size"))
+		Expect(rendered).To(BeNil())
+	})
+})
+
 var _ = Describe("NewIstioImageVersionFromTag", func() {
 	It("should return IstioImageVersion for a correct semantic version", func() {
 		// when
@@ -16,41 +16,75 @@ import (
 
 func (m *IstioMerger) Merge(clusterSize clusterconfig.ClusterSize, istioCR *operatorv1alpha2.Istio,
 	overrides clusterconfig.ClusterConfiguration, istioImages images.Images, options ...operatorv1alpha2.MergeOption) (string, error) {
-	toBeInstalledIop, err := m.GetIstioOperator(clusterSize)
+	iopWithOverrides, err := m.Render(clusterSize, istioCR, overrides, istioImages, options...)
 	if err != nil {
 		return "", err
 	}
+	mergedIstioOperatorPath := path.Join(m.workingDir, MergedIstioOperatorFile)
+	err = os.WriteFile(mergedIstioOperatorPath, iopWithOverrides, 0o600)
+	if err != nil {
+		return "", err
+	}
+	m.log.V(2).Info(fmt.Sprintf("Deploying IstioOperator from %s:\n%s", mergedIstioOperatorPath, iopWithOverrides))
+	return mergedIstioOperatorPath, nil
+}
+
+// Render returns the IstioOperator manifest merged with the Istio CR, component images and cluster overrides
+// without writing it to the working directory.
+func (m *IstioMerger) Render(clusterSize clusterconfig.ClusterSize, istioCR *operatorv1alpha2.Istio,
+	overrides clusterconfig.ClusterConfiguration, istioImages images.Images, options ...operatorv1alpha2.MergeOption) ([]byte, error) {
+	toBeInstalledIop, err := m.GetIstioOperator(clusterSize)
+	if err != nil {
+		return nil, err
+	}
 
 	if isExperimentalEnabled() {
 		if err := ParseExperimentalFeatures(istioCR, &toBeInstalledIop); err != nil {
-			return "", err
+			return nil, err
 		}
 	}
 	mergedManifest, err := applyIstioCR(istioCR, toBeInstalledIop, options...)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	manifestWithComponentImages, err := images.MergeComponentImages(mergedManifest, istioImages)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	manifestWithOverridePullSecret, err := images.MergePullSecretEnv(manifestWithComponentImages)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	iopWithOverrides, err := clusterconfig.MergeOverrides(manifestWithOverridePullSecret, overrides)
+	manifestWithOverrides, err := clusterconfig.MergeOverrides(manifestWithOverridePullSecret, overrides)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	mergedIstioOperatorPath := path.Join(m.workingDir, MergedIstioOperatorFile)
-	err = os.WriteFile(mergedIstioOperatorPath, iopWithOverrides, 0o600)
+	return stampProxyConfigHashes(manifestWithOverrides, istioCR.GetCertificateAuthorityFingerprint())
+}
+
+// lastMergedIstioOperator returns the IstioOperator manifest that was last written by Merge.
+// If no manifest was merged since the operator started, nil is returned.
+func (m *IstioMerger) lastMergedIstioOperator() ([]byte, error) {
+	manifest, err := os.ReadFile(path.Join(m.workingDir, MergedIstioOperatorFile))
 	if err != nil {
-		return "", err
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
 	}
-	m.log.V(2).Info(fmt.Sprintf("Deploying IstioOperator from %s:\n%s", mergedIstioOperatorPath, iopWithOverrides))
-	return mergedIstioOperatorPath, nil
+	return manifest, nil
+}
+
+// GetProxyConfigHashes returns the proxy config hashes of the IstioOperator manifest that was last written by Merge.
+// If no manifest was merged since the operator started, empty hashes are returned.
+func (m *IstioMerger) GetProxyConfigHashes() (operatorv1alpha2.ProxyConfigHashes, error) {
+	manifest, err := m.lastMergedIstioOperator()
+	if err != nil || manifest == nil {
+		return operatorv1alpha2.ProxyConfigHashes{}, err
+	}
+	return ComputeProxyConfigHashes(manifest)
 }
 
 // ParseExperimentalFeatures parses experimental options defined in Istio CR
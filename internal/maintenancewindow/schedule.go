@@ -0,0 +1,18 @@
+package maintenancewindow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/robfig/cron/v3"
+)
+
+// parseSchedule parses a standard cron schedule with five fields: minute, hour, day of month, month, and day of week. Descriptors such as
+// `@daily` are supported as well. The time zone is configured with the time zone of the maintenance window, so the `TZ=` and `CRON_TZ=`
+// prefixes are rejected.
+func parseSchedule(spec string) (cron.Schedule, error) {
+	if strings.HasPrefix(spec, "TZ=") || strings.HasPrefix(spec, "CRON_TZ=") {
+		return nil, fmt.Errorf("schedule %q must not contain a time zone, use the time zone of the maintenance window instead", spec)
+	}
+	return cron.ParseStandard(spec)
+}
@@ -0,0 +1,83 @@
+package maintenancewindow
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	"github.com/kyma-project/istio/operator/api/v1alpha2"
+)
+
+type window struct {
+	schedule cron.Schedule
+	duration time.Duration
+	location *time.Location
+}
+
+func parseWindow(w v1alpha2.MaintenanceWindow) (window, error) {
+	s, err := parseSchedule(w.Schedule)
+	if err != nil {
+		return window{}, fmt.Errorf("invalid maintenance window schedule: %w", err)
+	}
+	if w.Duration.Duration <= 0 {
+		return window{}, fmt.Errorf("maintenance window with schedule %q must have a positive duration", w.Schedule)
+	}
+
+	location := time.UTC
+	if w.TimeZone != nil && *w.TimeZone != "" {
+		location, err = time.LoadLocation(*w.TimeZone)
+		if err != nil {
+			return window{}, fmt.Errorf("invalid maintenance window time zone %q: %w", *w.TimeZone, err)
+		}
+	}
+
+	return window{schedule: s, duration: w.Duration.Duration, location: location}, nil
+}
+
+// isOpen returns true if the window started within its duration before now.
+func (w window) isOpen(now time.Time) bool {
+	now = now.In(w.location)
+	start := w.schedule.Next(now.Add(-w.duration))
+	return !start.IsZero() && !start.After(now)
+}
+
+// IsOpen returns true if no maintenance windows are defined or if any of the windows is open at the given time.
+func IsOpen(windows []v1alpha2.MaintenanceWindow, now time.Time) (bool, error) {
+	if len(windows) == 0 {
+		return true, nil
+	}
+
+	for _, mw := range windows {
+		w, err := parseWindow(mw)
+		if err != nil {
+			return false, err
+		}
+		if w.isOpen(now) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// NextOpening returns the earliest time after now at which one of the maintenance windows opens.
+func NextOpening(windows []v1alpha2.MaintenanceWindow, now time.Time) (time.Time, error) {
+	var next time.Time
+	for _, mw := range windows {
+		w, err := parseWindow(mw)
+		if err != nil {
+			return time.Time{}, err
+		}
+		start := w.schedule.Next(now.In(w.location))
+		if !start.IsZero() && (next.IsZero() || start.Before(next)) {
+			next = start
+		}
+	}
+
+	if next.IsZero() {
+		return time.Time{}, errors.New("none of the maintenance windows opens within the next five years")
+	}
+	return next, nil
+}
@@ -0,0 +1,116 @@
+package maintenancewindow_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/kyma-project/istio/operator/api/v1alpha2"
+	"github.com/kyma-project/istio/operator/internal/maintenancewindow"
+)
+
+func window(schedule string, duration time.Duration) v1alpha2.MaintenanceWindow {
+	return v1alpha2.MaintenanceWindow{Schedule: schedule, Duration: metav1.Duration{Duration: duration}}
+}
+
+func TestIsOpen(t *testing.T) {
+	// 2024-01-03 is a Wednesday
+	wednesday := func(hour, minute int) time.Time {
+		return time.Date(2024, 1, 3, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name    string
+		windows []v1alpha2.MaintenanceWindow
+		now     time.Time
+		want    bool
+	}{
+		{name: "no windows", windows: nil, now: wednesday(12, 0), want: true},
+		{name: "at window start", windows: []v1alpha2.MaintenanceWindow{window("0 22 * * *", 2*time.Hour)}, now: wednesday(22, 0), want: true},
+		{name: "within window", windows: []v1alpha2.MaintenanceWindow{window("0 22 * * *", 2*time.Hour)}, now: wednesday(23, 59), want: true},
+		{name: "before window", windows: []v1alpha2.MaintenanceWindow{window("0 22 * * *", 2*time.Hour)}, now: wednesday(21, 59), want: false},
+		{name: "window spanning midnight", windows: []v1alpha2.MaintenanceWindow{window("0 23 * * *", 2*time.Hour)}, now: wednesday(0, 30), want: true},
+		{name: "at window end", windows: []v1alpha2.MaintenanceWindow{window("0 22 * * *", 2*time.Hour)}, now: wednesday(0, 0), want: false},
+		{name: "matching day of week range", windows: []v1alpha2.MaintenanceWindow{window("0 12 * * 1-5", time.Hour)}, now: wednesday(12, 30), want: true},
+		{name: "not matching day of week", windows: []v1alpha2.MaintenanceWindow{window("0 12 * * 0,6", time.Hour)}, now: wednesday(12, 30), want: false},
+		{name: "day of week name", windows: []v1alpha2.MaintenanceWindow{window("0 12 * * SUN", time.Hour)}, now: time.Date(2024, 1, 7, 12, 30, 0, 0, time.UTC), want: true},
+		{name: "descriptor", windows: []v1alpha2.MaintenanceWindow{window("@daily", time.Hour)}, now: wednesday(0, 30), want: true},
+		{name: "steps", windows: []v1alpha2.MaintenanceWindow{window("*/15 * * * *", 5*time.Minute)}, now: wednesday(10, 47), want: true},
+		{name: "outside steps", windows: []v1alpha2.MaintenanceWindow{window("*/15 * * * *", 5*time.Minute)}, now: wednesday(10, 50), want: false},
+		{name: "day of month or day of week", windows: []v1alpha2.MaintenanceWindow{window("0 12 1 * 3", time.Hour)}, now: wednesday(12, 0), want: true},
+		{
+			name:    "any of multiple windows",
+			windows: []v1alpha2.MaintenanceWindow{window("0 2 * * *", time.Hour), window("0 12 * * *", time.Hour)},
+			now:     wednesday(12, 10),
+			want:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			open, err := maintenancewindow.IsOpen(tt.windows, tt.now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, open)
+		})
+	}
+}
+
+func TestIsOpenWithTimeZone(t *testing.T) {
+	w := window("0 22 * * *", time.Hour)
+	w.TimeZone = ptr.To("Europe/Berlin")
+
+	// 22:00 in Berlin is 21:00 UTC in winter
+	open, err := maintenancewindow.IsOpen([]v1alpha2.MaintenanceWindow{w}, time.Date(2024, 1, 3, 21, 30, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.True(t, open)
+
+	open, err = maintenancewindow.IsOpen([]v1alpha2.MaintenanceWindow{w}, time.Date(2024, 1, 3, 22, 30, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.False(t, open)
+}
+
+func TestIsOpenInvalidWindow(t *testing.T) {
+	tests := []struct {
+		name   string
+		window v1alpha2.MaintenanceWindow
+	}{
+		{name: "missing field", window: window("0 22 * *", time.Hour)},
+		{name: "value out of range", window: window("0 24 * * *", time.Hour)},
+		{name: "invalid range", window: window("0 5-2 * * *", time.Hour)},
+		{name: "invalid step", window: window("*/0 * * * *", time.Hour)},
+		{name: "unknown name", window: window("0 22 * * MOO", time.Hour)},
+		{name: "day of week out of range", window: window("0 22 * * 7", time.Hour)},
+		{name: "time zone in schedule", window: window("CRON_TZ=Europe/Berlin 0 22 * * *", time.Hour)},
+		{name: "zero duration", window: window("0 22 * * *", 0)},
+		{name: "unknown time zone", window: v1alpha2.MaintenanceWindow{Schedule: "0 22 * * *", Duration: metav1.Duration{Duration: time.Hour}, TimeZone: ptr.To("Mars/Olympus")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := maintenancewindow.IsOpen([]v1alpha2.MaintenanceWindow{tt.window}, time.Now())
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNextOpening(t *testing.T) {
+	now := time.Date(2024, 1, 3, 12, 0, 0, 0, time.UTC)
+
+	next, err := maintenancewindow.NextOpening([]v1alpha2.MaintenanceWindow{
+		window("0 22 * * *", time.Hour),
+		window("30 14 * * 3", time.Hour),
+	}, now)
+	require.NoError(t, err)
+	assert.True(t, next.Equal(time.Date(2024, 1, 3, 14, 30, 0, 0, time.UTC)))
+
+	next, err = maintenancewindow.NextOpening([]v1alpha2.MaintenanceWindow{window("0 3 1 * *", time.Hour)}, now)
+	require.NoError(t, err)
+	assert.True(t, next.Equal(time.Date(2024, 2, 1, 3, 0, 0, 0, time.UTC)))
+
+	_, err = maintenancewindow.NextOpening([]v1alpha2.MaintenanceWindow{window("0 0 31 2 *", time.Hour)}, now)
+	assert.Error(t, err)
+}
@@ -8,12 +8,13 @@ import (
 )
 
 // IstioCRMetrics holds all the metrics related to the Istio CR.
-// It includes metrics for external authorization, configuration, and components.
-// The 'registered' field indicates whether the metrics have been registered with Prometheus.
+// It includes metrics for external authorization, configuration, and components, and the operational metrics of the reconciliation.
+// The operational metrics are not recorded if IstioCRMetrics is nil.
 type IstioCRMetrics struct {
 	extAuthMetrics   *extAuthMetrics
 	configMetrics    *configMetrics
 	componentMetrics *componentMetrics
+	reconcileMetrics *reconcileMetrics
 }
 
 type configMetrics struct {
@@ -23,6 +24,8 @@ type configMetrics struct {
 	forwardClientCertDetailsSetting *prometheus.GaugeVec
 	trustDomainConfigured           prometheus.Gauge
 	networkPoliciesEnabled          prometheus.Gauge
+	mtlsModeSetting                 *prometheus.GaugeVec
+	mtlsNamespacesConfigured        prometheus.Gauge
 }
 
 type componentMetrics struct {
@@ -82,6 +85,19 @@ func NewMetrics() *IstioCRMetrics {
 				Name: "istio_network_policies_enabled",
 				Help: "Indicates whether module network policies are enabled in the Istio CR (1 for enabled, 0 for disabled).",
 			}),
+			mtlsModeSetting: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Name: "istio_mtls_mode_setting",
+					Help: "Selected mesh-wide mTLS mode in the Istio CR (1 for selected mode, the others are 0)",
+				},
+				[]string{
+					"mode",
+				},
+			),
+			mtlsNamespacesConfigured: prometheus.NewGauge(prometheus.GaugeOpts{
+				Name: "istio_mtls_namespaces_configured",
+				Help: "Number of namespaces with an mTLS mode different from the mesh-wide one configured in the Istio CR.",
+			}),
 		},
 		componentMetrics: &componentMetrics{
 			egressGatewayEnabled: prometheus.NewGauge(prometheus.GaugeOpts{
@@ -93,6 +109,7 @@ func NewMetrics() *IstioCRMetrics {
 				Help: "Indicates whether the dns proxying is used in the Istio CR (1 for used, 0 for not used).",
 			}),
 		},
+		reconcileMetrics: newReconcileMetrics(),
 	}
 
 	ctrlmetrics.Registry.MustRegister(
@@ -101,6 +118,8 @@ func NewMetrics() *IstioCRMetrics {
 		crMetrics.extAuthMetrics.timeoutConfiguredNumberTotal,
 		crMetrics.configMetrics.compatibilityModeEnabled,
 		crMetrics.configMetrics.forwardClientCertDetailsSetting,
+		crMetrics.configMetrics.mtlsModeSetting,
+		crMetrics.configMetrics.mtlsNamespacesConfigured,
 		crMetrics.configMetrics.networkPoliciesEnabled,
 		crMetrics.configMetrics.numTrustedProxiesConfigured,
 		crMetrics.configMetrics.prometheusMergeEnabled,
@@ -108,6 +127,7 @@ func NewMetrics() *IstioCRMetrics {
 		crMetrics.componentMetrics.egressGatewayEnabled,
 		crMetrics.componentMetrics.dnsProxyEnabled,
 	)
+	ctrlmetrics.Registry.MustRegister(crMetrics.reconcileMetrics.collectors()...)
 
 	return crMetrics
 }
@@ -158,6 +178,11 @@ func (m *IstioCRMetrics) UpdateIstioCRMetrics(cr *v1alpha2.Istio) {
 		m.configMetrics.forwardClientCertDetailsSetting.WithLabelValues(string(v1alpha2.ForwardOnly)).Set(0)
 	}
 
+	m.configMetrics.mtlsModeSetting.WithLabelValues(string(v1alpha2.MtlsModeStrict)).Set(0)
+	m.configMetrics.mtlsModeSetting.WithLabelValues(string(v1alpha2.MtlsModePermissive)).Set(0)
+	m.configMetrics.mtlsModeSetting.WithLabelValues(string(cr.GetMtlsMode())).Set(1)
+	m.configMetrics.mtlsNamespacesConfigured.Set(float64(len(cr.GetMtlsNamespaces())))
+
 	if cr.Spec.Config.TrustDomain != nil && *cr.Spec.Config.TrustDomain != "" && *cr.Spec.Config.TrustDomain != "cluster.local" {
 		m.configMetrics.trustDomainConfigured.Set(1)
 	} else {
@@ -0,0 +1,123 @@
+package istiocrmetrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/kyma-project/istio/operator/api/v1alpha2"
+)
+
+const (
+	PhaseValidation     = "validation"
+	PhaseBuildFactory   = "build_factory"
+	PhaseInstall        = "install"
+	PhaseIstioResources = "istio_resources"
+
+	resultSuccess = "success"
+	resultError   = "error"
+)
+
+type reconcileMetrics struct {
+	reconcilePhaseDuration *prometheus.HistogramVec
+	proxyRestartedPods     *prometheus.CounterVec
+	proxyRestartFailures   *prometheus.CounterVec
+	installedIstioVersion  *prometheus.GaugeVec
+	stateTransitions       *prometheus.CounterVec
+}
+
+func newReconcileMetrics() *reconcileMetrics {
+	return &reconcileMetrics{
+		reconcilePhaseDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "istio_reconcile_phase_duration_seconds",
+				Help:    "Duration of the phases of the Istio CR reconciliation. Restarters are reported with their type name as phase.",
+				Buckets: []float64{0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30, 60, 120, 300, 600, 1200},
+			},
+			[]string{"phase", "result"},
+		),
+		proxyRestartedPods: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "istio_proxy_restarted_pods_total",
+				Help: "Number of Pods whose proxy sidecar restart was triggered, by the predicate that triggered the restart and the kind of the Pod owner.",
+			},
+			[]string{"predicate", "owner_kind"},
+		),
+		proxyRestartFailures: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "istio_proxy_restart_failures_total",
+				Help: "Number of workloads whose proxy sidecars could not be restarted or that did not become ready after the restart, by the predicate that triggered the restart and the kind of the workload.",
+			},
+			[]string{"predicate", "owner_kind"},
+		),
+		installedIstioVersion: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "istio_installed_version_info",
+				Help: "Istio version installed by the operator (1 for the installed version).",
+			},
+			[]string{"version"},
+		),
+		stateTransitions: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "istio_cr_state_transitions_total",
+				Help: "Number of transitions between the states of the Istio CR.",
+			},
+			[]string{"from", "to"},
+		),
+	}
+}
+
+func (m *reconcileMetrics) collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.reconcilePhaseDuration,
+		m.proxyRestartedPods,
+		m.proxyRestartFailures,
+		m.installedIstioVersion,
+		m.stateTransitions,
+	}
+}
+
+// ObserveReconcilePhase records the duration of a reconciliation phase that started at the given time.
+func (m *IstioCRMetrics) ObserveReconcilePhase(phase string, start time.Time, failed bool) {
+	if m == nil {
+		return
+	}
+	result := resultSuccess
+	if failed {
+		result = resultError
+	}
+	m.reconcileMetrics.reconcilePhaseDuration.WithLabelValues(phase, result).Observe(time.Since(start).Seconds())
+}
+
+// AddRestartedPods increases the number of Pods whose proxy sidecar restart was triggered.
+func (m *IstioCRMetrics) AddRestartedPods(predicate, ownerKind string, count int) {
+	if m == nil {
+		return
+	}
+	m.reconcileMetrics.proxyRestartedPods.WithLabelValues(predicate, ownerKind).Add(float64(count))
+}
+
+// AddRestartFailures increases the number of workloads whose proxy sidecars could not be restarted.
+func (m *IstioCRMetrics) AddRestartFailures(predicate, ownerKind string, count int) {
+	if m == nil {
+		return
+	}
+	m.reconcileMetrics.proxyRestartFailures.WithLabelValues(predicate, ownerKind).Add(float64(count))
+}
+
+// SetInstalledIstioVersion reports the given version as the only installed Istio version.
+func (m *IstioCRMetrics) SetInstalledIstioVersion(version string) {
+	if m == nil {
+		return
+	}
+	m.reconcileMetrics.installedIstioVersion.Reset()
+	m.reconcileMetrics.installedIstioVersion.WithLabelValues(version).Set(1)
+}
+
+// RecordStateTransition counts the transition of the Istio CR state, if the state changed.
+func (m *IstioCRMetrics) RecordStateTransition(from, to v1alpha2.State) {
+	if m == nil || from == to {
+		return
+	}
+	m.reconcileMetrics.stateTransitions.WithLabelValues(string(from), string(to)).Inc()
+}
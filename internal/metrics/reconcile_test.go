@@ -0,0 +1,59 @@
+package istiocrmetrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kyma-project/istio/operator/api/v1alpha2"
+)
+
+func newUnregisteredMetrics() *IstioCRMetrics {
+	return &IstioCRMetrics{reconcileMetrics: newReconcileMetrics()}
+}
+
+func TestRecordStateTransition(t *testing.T) {
+	m := newUnregisteredMetrics()
+
+	m.RecordStateTransition(v1alpha2.Processing, v1alpha2.Ready)
+	m.RecordStateTransition(v1alpha2.Processing, v1alpha2.Ready)
+	m.RecordStateTransition(v1alpha2.Ready, v1alpha2.Ready)
+
+	assert.InDelta(t, 2, testutil.ToFloat64(m.reconcileMetrics.stateTransitions.WithLabelValues("Processing", "Ready")), 0)
+	assert.Equal(t, 1, testutil.CollectAndCount(m.reconcileMetrics.stateTransitions))
+}
+
+func TestSetInstalledIstioVersion(t *testing.T) {
+	m := newUnregisteredMetrics()
+
+	m.SetInstalledIstioVersion("1.26.0")
+	m.SetInstalledIstioVersion("1.27.0")
+
+	assert.Equal(t, 1, testutil.CollectAndCount(m.reconcileMetrics.installedIstioVersion))
+	assert.InDelta(t, 1, testutil.ToFloat64(m.reconcileMetrics.installedIstioVersion.WithLabelValues("1.27.0")), 0)
+}
+
+func TestAddRestartedPodsAndFailures(t *testing.T) {
+	m := newUnregisteredMetrics()
+
+	m.AddRestartedPods("ImageResourcesPredicate", "ReplicaSet", 2)
+	m.AddRestartedPods("ImageResourcesPredicate", "ReplicaSet", 1)
+	m.AddRestartFailures("ImageResourcesPredicate", "Deployment", 1)
+
+	assert.InDelta(t, 3, testutil.ToFloat64(m.reconcileMetrics.proxyRestartedPods.WithLabelValues("ImageResourcesPredicate", "ReplicaSet")), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(m.reconcileMetrics.proxyRestartFailures.WithLabelValues("ImageResourcesPredicate", "Deployment")), 0)
+}
+
+func TestReconcileMetricsOfNilMetrics(t *testing.T) {
+	var m *IstioCRMetrics
+
+	assert.NotPanics(t, func() {
+		m.ObserveReconcilePhase(PhaseInstall, time.Now(), false)
+		m.AddRestartedPods("ImageResourcesPredicate", "ReplicaSet", 1)
+		m.AddRestartFailures("ImageResourcesPredicate", "Deployment", 1)
+		m.SetInstalledIstioVersion("1.27.0")
+		m.RecordStateTransition(v1alpha2.Processing, v1alpha2.Ready)
+	})
+}
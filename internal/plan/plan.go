@@ -0,0 +1,280 @@
+package plan
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/pmezard/go-difflib/difflib"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kyma-project/istio/operator/api/v1alpha2"
+	"github.com/kyma-project/istio/operator/internal/clusterconfig"
+	"github.com/kyma-project/istio/operator/internal/clusterconfig/factory"
+	"github.com/kyma-project/istio/operator/internal/describederrors"
+	"github.com/kyma-project/istio/operator/internal/images"
+	"github.com/kyma-project/istio/operator/internal/istiooperator"
+	"github.com/kyma-project/istio/operator/internal/reconciliations/istio"
+	"github.com/kyma-project/istio/operator/internal/reconciliations/istio/configuration"
+	"github.com/kyma-project/istio/operator/pkg/labels"
+	"github.com/kyma-project/istio/operator/pkg/lib/sidecars"
+	"github.com/kyma-project/istio/operator/pkg/lib/sidecars/pods"
+)
+
+const (
+	ConfigMapName      = "istio-plan"
+	ConfigMapNamespace = "kyma-system"
+
+	ManifestKey = istiooperator.MergedIstioOperatorFile
+	DiffKey     = "diff"
+	RestartsKey = "restarts"
+
+	// maxListedSidecars limits the number of Pods listed in the plan, so the ConfigMap stays within the size limit.
+	maxListedSidecars = 100
+	podsPerPage       = 30
+)
+
+// Restarts lists the restarts that applying the Istio CR would trigger.
+type Restarts struct {
+	IngressGateway bool     `json:"ingressGateway"`
+	Istiod         bool     `json:"istiod"`
+	CNI            bool     `json:"cni"`
+	SidecarsCount  int      `json:"sidecarsCount"`
+	Sidecars       []string `json:"sidecars,omitempty"`
+}
+
+// IsEnabled returns true if the Istio CR is annotated to run in plan mode.
+func IsEnabled(istioCR *v1alpha2.Istio) bool {
+	return istioCR.Annotations[labels.PlanMode] == "true"
+}
+
+type Planner interface {
+	Plan(ctx context.Context, istioCR *v1alpha2.Istio, clusterStrategy factory.Factory) describederrors.DescribedError
+}
+
+// IstioPlanner renders the IstioOperator for the Istio CR and evaluates the restarts it would trigger without
+// installing anything. The result is written to the istio-plan ConfigMap.
+type IstioPlanner struct {
+	client      client.Client
+	merger      istiooperator.Merger
+	podsLister  pods.Getter
+	istioImages images.Images
+}
+
+func NewPlanner(client client.Client, merger istiooperator.Merger, podsLister pods.Getter, istioImages images.Images) *IstioPlanner {
+	return &IstioPlanner{
+		client:      client,
+		merger:      merger,
+		podsLister:  podsLister,
+		istioImages: istioImages,
+	}
+}
+
+func (p *IstioPlanner) Plan(ctx context.Context, istioCR *v1alpha2.Istio, clusterStrategy factory.Factory) describederrors.DescribedError {
+	ctrl.Log.Info("Generating plan for Istio CR changes")
+
+	clusterSize, err := clusterconfig.EvaluateClusterSize(ctx, p.client)
+	if err != nil {
+		return describederrors.NewDescribedError(err, "Could not evaluate cluster size")
+	}
+
+	options, err := istio.GetMergeOptions(ctx, p.client, clusterStrategy)
+	if err != nil {
+		return describederrors.NewDescribedError(err, "Could not get Istio operator merge options")
+	}
+	// During a canary upgrade the installation renders the control plane as the target revision, see istio.installIstio
+	plannedOptions := slices.Clone(options)
+	if istioCR.Status.CanaryUpgrade != nil {
+		plannedOptions = append(plannedOptions, v1alpha2.WithRevision(istioCR.Status.CanaryUpgrade.TargetRevision))
+	}
+	manifest, err := p.merger.Render(clusterSize, istioCR, clusterconfig.ClusterConfigurationFromFactory(clusterStrategy), p.istioImages, plannedOptions...)
+	if err != nil {
+		return describederrors.NewDescribedError(err, "Could not render Istio operator configuration")
+	}
+
+	lastAppliedConfig, err := configuration.GetLastAppliedConfiguration(istioCR)
+	if err != nil {
+		return describederrors.NewDescribedError(err, "Could not get last applied configuration")
+	}
+
+	lastManifest, err := p.renderLastApplied(istioCR, lastAppliedConfig, clusterSize, clusterStrategy, options...)
+	if err != nil {
+		return describederrors.NewDescribedError(err, "Could not render last applied Istio operator configuration")
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(lastManifest)),
+		B:        difflib.SplitLines(string(manifest)),
+		FromFile: "last-applied/" + istiooperator.MergedIstioOperatorFile,
+		ToFile:   "planned/" + istiooperator.MergedIstioOperatorFile,
+		Context:  3,
+	})
+	if err != nil {
+		return describederrors.NewDescribedError(err, "Could not create Istio operator configuration diff")
+	}
+
+	restarts, err := p.evaluateRestarts(ctx, istioCR, lastAppliedConfig, clusterSize, lastManifest, manifest)
+	if err != nil {
+		return describederrors.NewDescribedError(err, "Could not evaluate restarts")
+	}
+
+	restartsYaml, err := yaml.Marshal(restarts)
+	if err != nil {
+		return describederrors.NewDescribedError(err, "Could not marshal restarts")
+	}
+
+	if err := p.writeConfigMap(ctx, istioCR, map[string]string{
+		ManifestKey: string(manifest),
+		DiffKey:     diff,
+		RestartsKey: string(restartsYaml),
+	}); err != nil {
+		return describederrors.NewDescribedError(err, "Could not write plan ConfigMap")
+	}
+
+	ctrl.Log.Info("Plan for Istio CR changes generated", "configmap", fmt.Sprintf("%s/%s", ConfigMapNamespace, ConfigMapName))
+	return nil
+}
+
+// renderLastApplied renders the IstioOperator for the Istio CR spec stored in the lastAppliedConfiguration annotation, so that the diff
+// is based on the state of the cluster and not on the state of the operator Pod. If the Istio CR was never applied, nil is returned.
+func (p *IstioPlanner) renderLastApplied(istioCR *v1alpha2.Istio, lastAppliedConfig configuration.AppliedConfig, clusterSize clusterconfig.ClusterSize,
+	clusterStrategy factory.Factory, options ...v1alpha2.MergeOption) ([]byte, error) {
+	if lastAppliedConfig.IstioTag == "" {
+		return nil, nil
+	}
+
+	lastAppliedCR := istioCR.DeepCopy()
+	lastAppliedCR.Spec = lastAppliedConfig.IstioSpec
+	return p.merger.Render(clusterSize, lastAppliedCR, clusterconfig.ClusterConfigurationFromFactory(clusterStrategy), p.istioImages, options...)
+}
+
+func (p *IstioPlanner) evaluateRestarts(ctx context.Context, istioCR *v1alpha2.Istio, lastAppliedConfig configuration.AppliedConfig,
+	clusterSize clusterconfig.ClusterSize, lastManifest, manifest []byte) (Restarts, error) {
+	restarts := Restarts{}
+
+	// Changing network policies restarts all control plane components, see restarter.NetworkPolicy
+	if lastAppliedConfig.NetworkPoliciesEnabled != istioCR.Spec.NetworkPoliciesEnabled {
+		restarts.IngressGateway = true
+		restarts.Istiod = true
+		restarts.CNI = true
+	}
+
+	// An Istio version update rolls out all control plane components with the new images
+	istioImageVersion, err := p.merger.GetIstioImageVersion()
+	if err != nil {
+		return restarts, err
+	}
+	if lastAppliedConfig.IstioTag != "" && lastAppliedConfig.IstioTag != istioImageVersion.Tag() {
+		restarts.IngressGateway = true
+		restarts.Istiod = true
+		restarts.CNI = true
+	}
+
+	// Istiod is rolled out by the installation if its component or values configuration changes
+	if lastManifest != nil {
+		istiodChanged, err := istiodConfigurationChanged(lastManifest, manifest)
+		if err != nil {
+			return restarts, err
+		}
+		if istiodChanged {
+			restarts.Istiod = true
+		}
+	}
+
+	plannedHashes, err := istiooperator.ComputeProxyConfigHashes(manifest)
+	if err != nil {
+		return restarts, err
+	}
+
+	// The ingress gateways are rolled out by the installation if their proxy config hash changes
+	appliedHashes := istioCR.Status.ProxyConfigHashes
+	if appliedHashes != nil && appliedHashes.IngressGateway != plannedHashes.IngressGateway {
+		restarts.IngressGateway = true
+	}
+
+	iop, err := p.merger.GetIstioOperator(clusterSize)
+	if err != nil {
+		return restarts, err
+	}
+	expectedResources, err := istioCR.GetProxyResources(iop)
+	if err != nil {
+		return restarts, err
+	}
+
+	preds, err := sidecars.GetRestartPredicates(ctx, p.client, p.istioImages.ProxyV2, expectedResources, plannedHashes, istioCR)
+	if err != nil {
+		return restarts, err
+	}
+
+	err = p.podsLister.GetPodsToRestart(ctx, preds, pods.NewPodsRestartLimits(podsPerPage), func(_ context.Context, page *corev1.PodList) error {
+		for _, pod := range page.Items {
+			restarts.SidecarsCount++
+			if len(restarts.Sidecars) < maxListedSidecars {
+				restarts.Sidecars = append(restarts.Sidecars, fmt.Sprintf("%s/%s", pod.Namespace, pod.Name))
+			}
+		}
+		return nil
+	})
+
+	return restarts, err
+}
+
+// istiodConfigurationChanged returns true if the istiod configuration of the IstioOperator manifests differs.
+func istiodConfigurationChanged(lastManifest, manifest []byte) (bool, error) {
+	lastPilot, err := getPilotConfiguration(lastManifest)
+	if err != nil {
+		return false, err
+	}
+	pilot, err := getPilotConfiguration(manifest)
+	if err != nil {
+		return false, err
+	}
+	return !reflect.DeepEqual(lastPilot, pilot), nil
+}
+
+func getPilotConfiguration(manifest []byte) ([]interface{}, error) {
+	iop := map[string]interface{}{}
+	if err := yaml.Unmarshal(manifest, &iop); err != nil {
+		return nil, err
+	}
+	component, _, err := unstructured.NestedFieldNoCopy(iop, "spec", "components", "pilot")
+	if err != nil {
+		return nil, err
+	}
+	values, _, err := unstructured.NestedFieldNoCopy(iop, "spec", "values", "pilot")
+	if err != nil {
+		return nil, err
+	}
+	return []interface{}{component, values}, nil
+}
+
+func (p *IstioPlanner) writeConfigMap(ctx context.Context, istioCR *v1alpha2.Istio, data map[string]string) error {
+	cm := corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ConfigMapName,
+			Namespace: ConfigMapNamespace,
+		},
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, p.client, &cm, func() error {
+		cm.Labels = labels.SetModuleLabels(cm.Labels)
+		cm.OwnerReferences = []metav1.OwnerReference{
+			{
+				APIVersion: istioCR.APIVersion,
+				Kind:       istioCR.Kind,
+				Name:       istioCR.Name,
+				UID:        istioCR.UID,
+			},
+		}
+		cm.Data = data
+		return nil
+	})
+	return err
+}
@@ -0,0 +1,301 @@
+package plan_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	iopv1alpha1 "istio.io/istio/operator/pkg/apis"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	operatorv1alpha2 "github.com/kyma-project/istio/operator/api/v1alpha2"
+	"github.com/kyma-project/istio/operator/internal/clusterconfig"
+	"github.com/kyma-project/istio/operator/internal/images"
+	"github.com/kyma-project/istio/operator/internal/istiooperator"
+	"github.com/kyma-project/istio/operator/internal/plan"
+	"github.com/kyma-project/istio/operator/internal/reconciliations/istio/configuration"
+	"github.com/kyma-project/istio/operator/pkg/labels"
+	"github.com/kyma-project/istio/operator/pkg/lib/sidecars/pods"
+	"github.com/kyma-project/istio/operator/pkg/lib/sidecars/test/helpers"
+)
+
+var _ = Describe("IsEnabled", func() {
+	It("should return true when plan mode annotation is set to true", func() {
+		istioCR := helpers.GetIstioCR("1.10.0")
+		istioCR.Annotations[labels.PlanMode] = "true"
+
+		Expect(plan.IsEnabled(&istioCR)).To(BeTrue())
+	})
+
+	It("should return false when plan mode annotation is not set", func() {
+		istioCR := helpers.GetIstioCR("1.10.0")
+
+		Expect(plan.IsEnabled(&istioCR)).To(BeFalse())
+	})
+
+	It("should return false when plan mode annotation is not set to true", func() {
+		istioCR := helpers.GetIstioCR("1.10.0")
+		istioCR.Annotations[labels.PlanMode] = "false"
+
+		Expect(plan.IsEnabled(&istioCR)).To(BeFalse())
+	})
+})
+
+var _ = Describe("Plan", func() {
+	ctx := context.Background()
+	logger := logr.Discard()
+	expectedImage := images.Image{Registry: "istio", Name: "proxyv2", Tag: "1.11.0"}
+
+	It("should write the rendered manifest, the diff and the restarts to the plan ConfigMap", func() {
+		// given
+		istioCR := helpers.GetIstioCR("1.10.0")
+		istioCR.Spec.Config.NumTrustedProxies = ptr.To(2)
+		istioCR.Status.ProxyConfigHashes = &operatorv1alpha2.ProxyConfigHashes{IngressGateway: "applied"}
+		pod := helpers.NewSidecarPodBuilder().SetName("app").SetNamespace("custom").Build()
+		c := createFakeClient(&istioCR, pod)
+		merger := mergerMock{
+			lastManifest:     []byte("spec:\n  tag: 1.10.0\n"),
+			renderedManifest: []byte("spec:\n  tag: 1.11.0\n"),
+		}
+		sut := plan.NewPlanner(c, merger, pods.NewPods(c, &logger), images.Images{ProxyV2: expectedImage})
+
+		// when
+		err := sut.Plan(ctx, &istioCR, nil)
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+
+		cm := corev1.ConfigMap{}
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: plan.ConfigMapNamespace, Name: plan.ConfigMapName}, &cm)).Should(Succeed())
+		Expect(cm.Labels).To(HaveKeyWithValue(labels.ModuleLabelKey, labels.ModuleLabelValue))
+		Expect(cm.Data[plan.ManifestKey]).To(Equal("spec:\n  tag: 1.11.0\n"))
+		Expect(cm.Data[plan.DiffKey]).To(ContainSubstring("-  tag: 1.10.0"))
+		Expect(cm.Data[plan.DiffKey]).To(ContainSubstring("+  tag: 1.11.0"))
+
+		restarts := plan.Restarts{}
+		Expect(yaml.Unmarshal([]byte(cm.Data[plan.RestartsKey]), &restarts)).Should(Succeed())
+		Expect(restarts.IngressGateway).To(BeTrue())
+		Expect(restarts.Istiod).To(BeTrue())
+		Expect(restarts.CNI).To(BeTrue())
+		Expect(restarts.SidecarsCount).To(Equal(1))
+		Expect(restarts.Sidecars).To(ConsistOf("custom/app"))
+	})
+
+	It("should not plan a restart of the ingress gateway when its proxy config hash didn't change", func() {
+		// given
+		renderedManifest := []byte("spec:\n  meshConfig:\n    trustDomain: cluster.local\n")
+		hashes, err := istiooperator.ComputeProxyConfigHashes(renderedManifest)
+		Expect(err).ShouldNot(HaveOccurred())
+		istioCR := helpers.GetIstioCR(expectedImage.Tag)
+		istioCR.Status.ProxyConfigHashes = &hashes
+		c := createFakeClient(&istioCR)
+		sut := plan.NewPlanner(c, mergerMock{renderedManifest: renderedManifest}, pods.NewPods(c, &logger), images.Images{ProxyV2: expectedImage})
+
+		// when
+		planErr := sut.Plan(ctx, &istioCR, nil)
+
+		// then
+		Expect(planErr).ShouldNot(HaveOccurred())
+
+		cm := corev1.ConfigMap{}
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: plan.ConfigMapNamespace, Name: plan.ConfigMapName}, &cm)).Should(Succeed())
+
+		restarts := plan.Restarts{}
+		Expect(yaml.Unmarshal([]byte(cm.Data[plan.RestartsKey]), &restarts)).Should(Succeed())
+		Expect(restarts.IngressGateway).To(BeFalse())
+	})
+
+	It("should diff against the last applied Istio CR when the operator has no merged manifest", func() {
+		// given
+		istioCR := helpers.GetIstioCR(expectedImage.Tag)
+		istioCR.Spec.Config.NumTrustedProxies = ptr.To(2)
+		c := createFakeClient(&istioCR)
+		merger := mergerMock{
+			lastManifest:     []byte("spec:\n  meshConfig:\n    numTrustedProxies: 1\n"),
+			renderedManifest: []byte("spec:\n  meshConfig:\n    numTrustedProxies: 2\n"),
+		}
+		sut := plan.NewPlanner(c, merger, pods.NewPods(c, &logger), images.Images{ProxyV2: expectedImage})
+
+		// when
+		err := sut.Plan(ctx, &istioCR, nil)
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+
+		cm := corev1.ConfigMap{}
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: plan.ConfigMapNamespace, Name: plan.ConfigMapName}, &cm)).Should(Succeed())
+		Expect(cm.Data[plan.DiffKey]).To(ContainSubstring("-    numTrustedProxies: 1"))
+		Expect(cm.Data[plan.DiffKey]).To(ContainSubstring("+    numTrustedProxies: 2"))
+		Expect(cm.Data[plan.DiffKey]).NotTo(ContainSubstring("+spec:"))
+
+		restarts := plan.Restarts{}
+		Expect(yaml.Unmarshal([]byte(cm.Data[plan.RestartsKey]), &restarts)).Should(Succeed())
+		Expect(restarts.Istiod).To(BeFalse())
+	})
+
+	It("should plan restart of istiod when its configuration changes", func() {
+		// given
+		istioCR := helpers.GetIstioCR(expectedImage.Tag)
+		istioCR.Spec.Config.NumTrustedProxies = ptr.To(2)
+		c := createFakeClient(&istioCR)
+		merger := mergerMock{
+			lastManifest:     []byte("spec:\n  components:\n    pilot:\n      k8s:\n        replicaCount: 1\n"),
+			renderedManifest: []byte("spec:\n  components:\n    pilot:\n      k8s:\n        replicaCount: 2\n"),
+		}
+		sut := plan.NewPlanner(c, merger, pods.NewPods(c, &logger), images.Images{ProxyV2: expectedImage})
+
+		// when
+		err := sut.Plan(ctx, &istioCR, nil)
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+
+		cm := corev1.ConfigMap{}
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: plan.ConfigMapNamespace, Name: plan.ConfigMapName}, &cm)).Should(Succeed())
+
+		restarts := plan.Restarts{}
+		Expect(yaml.Unmarshal([]byte(cm.Data[plan.RestartsKey]), &restarts)).Should(Succeed())
+		Expect(restarts.Istiod).To(BeTrue())
+		Expect(restarts.CNI).To(BeFalse())
+	})
+
+	It("should plan restart of all control plane components when network policies are enabled", func() {
+		// given
+		istioCR := helpers.GetIstioCR(expectedImage.Tag)
+		istioCR.Spec.NetworkPoliciesEnabled = true
+		c := createFakeClient(&istioCR)
+		sut := plan.NewPlanner(c, mergerMock{}, pods.NewPods(c, &logger), images.Images{ProxyV2: expectedImage})
+
+		// when
+		err := sut.Plan(ctx, &istioCR, nil)
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+
+		cm := corev1.ConfigMap{}
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: plan.ConfigMapNamespace, Name: plan.ConfigMapName}, &cm)).Should(Succeed())
+
+		restarts := plan.Restarts{}
+		Expect(yaml.Unmarshal([]byte(cm.Data[plan.RestartsKey]), &restarts)).Should(Succeed())
+		Expect(restarts.IngressGateway).To(BeTrue())
+		Expect(restarts.Istiod).To(BeTrue())
+		Expect(restarts.CNI).To(BeTrue())
+		Expect(restarts.SidecarsCount).To(Equal(0))
+	})
+
+	It("should render the target revision of the control plane during a canary upgrade", func() {
+		// given
+		istioCR := helpers.GetIstioCR(expectedImage.Tag)
+		istioCR.Status.CanaryUpgrade = &operatorv1alpha2.CanaryUpgradeStatus{SourceRevision: "default", TargetRevision: "1-11-0", TargetVersion: "1.11.0"}
+		c := createFakeClient(&istioCR)
+		merger := mergerMock{
+			renderedManifest:  []byte("spec:\n  revision: \"\"\n"),
+			revisionManifests: map[string][]byte{"1-11-0": []byte("spec:\n  revision: 1-11-0\n")},
+		}
+		sut := plan.NewPlanner(c, merger, pods.NewPods(c, &logger), images.Images{ProxyV2: expectedImage})
+
+		// when
+		err := sut.Plan(ctx, &istioCR, nil)
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+
+		cm := corev1.ConfigMap{}
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: plan.ConfigMapNamespace, Name: plan.ConfigMapName}, &cm)).Should(Succeed())
+		Expect(cm.Data[plan.ManifestKey]).To(Equal("spec:\n  revision: 1-11-0\n"))
+	})
+
+	It("should return an error and not write the plan ConfigMap when rendering fails", func() {
+		// given
+		istioCR := helpers.GetIstioCR(expectedImage.Tag)
+		c := createFakeClient(&istioCR)
+		sut := plan.NewPlanner(c, mergerMock{renderErr: errors.New("render error")}, pods.NewPods(c, &logger), images.Images{ProxyV2: expectedImage})
+
+		// when
+		err := sut.Plan(ctx, &istioCR, nil)
+
+		// then
+		Expect(err).Should(HaveOccurred())
+		Expect(err.Description()).To(ContainSubstring("Could not render Istio operator configuration"))
+
+		cm := corev1.ConfigMap{}
+		getErr := c.Get(ctx, types.NamespacedName{Namespace: plan.ConfigMapNamespace, Name: plan.ConfigMapName}, &cm)
+		Expect(getErr).Should(HaveOccurred())
+	})
+})
+
+func createFakeClient(objects ...client.Object) client.Client {
+	err := operatorv1alpha2.AddToScheme(scheme.Scheme)
+	Expect(err).ShouldNot(HaveOccurred())
+	err = corev1.AddToScheme(scheme.Scheme)
+	Expect(err).ShouldNot(HaveOccurred())
+
+	return fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(objects...).
+		WithIndex(&corev1.Pod{}, "status.phase", helpers.FakePodStatusPhaseIndexer).
+		Build()
+}
+
+type mergerMock struct {
+	lastManifest     []byte
+	renderedManifest []byte
+	// revisionManifests are returned instead of renderedManifest if the Istio CR is rendered as one of the revisions.
+	revisionManifests map[string][]byte
+	renderErr         error
+}
+
+func (m mergerMock) Merge(_ clusterconfig.ClusterSize, _ *operatorv1alpha2.Istio, _ clusterconfig.ClusterConfiguration, _ images.Images, _ ...operatorv1alpha2.MergeOption) (string, error) {
+	return "", errors.New("merge must not be called in plan mode")
+}
+
+// Render returns lastManifest for the Istio CR spec stored in the lastAppliedConfiguration annotation, the manifest of the revision
+// if the Istio CR is rendered as a revision in revisionManifests, and renderedManifest otherwise.
+func (m mergerMock) Render(_ clusterconfig.ClusterSize, istioCR *operatorv1alpha2.Istio, _ clusterconfig.ClusterConfiguration, _ images.Images, options ...operatorv1alpha2.MergeOption) ([]byte, error) {
+	if m.renderErr != nil {
+		return nil, m.renderErr
+	}
+	mergeOptions := operatorv1alpha2.MergeOptions{}
+	for _, option := range options {
+		option(&mergeOptions)
+	}
+	if manifest, found := m.revisionManifests[mergeOptions.Revision]; found {
+		return manifest, nil
+	}
+	lastAppliedConfig, err := configuration.GetLastAppliedConfiguration(istioCR)
+	if err != nil {
+		return nil, err
+	}
+	if m.lastManifest != nil && reflect.DeepEqual(istioCR.Spec, lastAppliedConfig.IstioSpec) {
+		return m.lastManifest, nil
+	}
+	return m.renderedManifest, nil
+}
+
+func (m mergerMock) GetProxyConfigHashes() (operatorv1alpha2.ProxyConfigHashes, error) {
+	return operatorv1alpha2.ProxyConfigHashes{}, nil
+}
+
+func (m mergerMock) GetIstioOperator(_ clusterconfig.ClusterSize) (iopv1alpha1.IstioOperator, error) {
+	iop := iopv1alpha1.IstioOperator{}
+	istioOperator, err := os.ReadFile("../istiooperator/istio-operator.yaml")
+	if err == nil {
+		err = yaml.Unmarshal(istioOperator, &iop)
+	}
+	return iop, err
+}
+
+func (m mergerMock) GetIstioImageVersion() (istiooperator.IstioImageVersion, error) {
+	return istiooperator.NewIstioImageVersionFromTag("1.11.0")
+}
@@ -0,0 +1,122 @@
+package preflight
+
+import (
+	// ginkgo is not dot-imported, because its Report type collides with the Report of this package
+	"github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/kyma-project/istio/operator/internal/resources"
+)
+
+var _ = ginkgo.Describe("analyzeResource", func() {
+	newResource := func(kind, version string, appliedAPIVersions ...string) resources.Resource {
+		return resources.Resource{
+			ResourceMeta:       resources.ResourceMeta{Name: "test", Namespace: "test-ns"},
+			GVK:                schema.GroupVersionKind{Group: "networking.istio.io", Version: version, Kind: kind},
+			AppliedAPIVersions: appliedAPIVersions,
+		}
+	}
+
+	ginkgo.It("should report resources applied with a deprecated API version", func() {
+		findings := analyzeResource(newResource("VirtualService", "v1", "networking.istio.io/v1beta1"), "1.27.0")
+
+		Expect(findings).To(HaveLen(1))
+		Expect(findings[0].Kind).To(Equal("VirtualService"))
+		Expect(findings[0].Message).To(ContainSubstring("deprecated API version networking.istio.io/v1beta1"))
+		Expect(findings[0].Message).To(ContainSubstring("Use networking.istio.io/v1 instead"))
+	})
+
+	ginkgo.It("should not report resources applied with a stable API version", func() {
+		findings := analyzeResource(newResource("VirtualService", "v1", "networking.istio.io/v1"), "1.27.0")
+
+		Expect(findings).To(BeEmpty())
+	})
+
+	ginkgo.It("should not report EnvoyFilters applied with v1alpha3 as deprecated, because there is no stable version", func() {
+		findings := analyzeResource(newResource("EnvoyFilter", "v1alpha3", "networking.istio.io/v1alpha3"), "1.27.0")
+
+		Expect(findings).To(BeEmpty())
+	})
+
+	ginkgo.It("should report EnvoyFilters whose config patches only match other proxy versions", func() {
+		resource := newResource("EnvoyFilter", "v1alpha3")
+		resource.Spec = envoyFilterSpec(map[string]interface{}{
+			"match": map[string]interface{}{"proxy": map[string]interface{}{"proxyVersion": `^1\.26.*`}},
+		})
+
+		findings := analyzeResource(resource, "1.27.0")
+
+		Expect(findings).To(HaveLen(1))
+		Expect(findings[0].Message).To(ContainSubstring(`matches the proxy version "^1\\.26.*", which doesn't match Istio 1.27.0`))
+	})
+
+	ginkgo.It("should not report EnvoyFilters whose config patches match the target proxy version", func() {
+		resource := newResource("EnvoyFilter", "v1alpha3")
+		resource.Spec = envoyFilterSpec(map[string]interface{}{
+			"match": map[string]interface{}{"proxy": map[string]interface{}{"proxyVersion": `^1\.2[67].*`}},
+		})
+
+		findings := analyzeResource(resource, "1.27.0")
+
+		Expect(findings).To(BeEmpty())
+	})
+
+	ginkgo.It("should report EnvoyFilters using removed filter names", func() {
+		resource := newResource("EnvoyFilter", "v1alpha3")
+		resource.Spec = envoyFilterSpec(map[string]interface{}{
+			"match": map[string]interface{}{"listener": map[string]interface{}{"filterChain": map[string]interface{}{
+				"filter": map[string]interface{}{"name": "envoy.http_connection_manager", "subFilter": map[string]interface{}{"name": "envoy.router"}},
+			}}},
+			"patch": map[string]interface{}{"operation": "INSERT_BEFORE", "value": map[string]interface{}{"name": "envoy.filters.http.lua"}},
+		})
+
+		findings := analyzeResource(resource, "1.27.0")
+
+		Expect(findings).To(HaveLen(2))
+		Expect(findings[0].Message).To(ContainSubstring("filter name envoy.http_connection_manager"))
+		Expect(findings[0].Message).To(ContainSubstring("Use envoy.filters.network.http_connection_manager instead"))
+		Expect(findings[1].Message).To(ContainSubstring("filter name envoy.router"))
+	})
+
+	ginkgo.It("should report EnvoyFilters using types of the Envoy v2 API", func() {
+		resource := newResource("EnvoyFilter", "v1alpha3")
+		resource.Spec = envoyFilterSpec(map[string]interface{}{
+			"patch": map[string]interface{}{"operation": "MERGE", "value": map[string]interface{}{
+				"typed_config": map[string]interface{}{"@type": "type.googleapis.com/envoy.config.filter.network.http_connection_manager.v2.HttpConnectionManager"},
+			}},
+		})
+
+		findings := analyzeResource(resource, "1.27.0")
+
+		Expect(findings).To(HaveLen(1))
+		Expect(findings[0].Message).To(ContainSubstring("Envoy v2 API"))
+	})
+
+	ginkgo.It("should not report EnvoyFilters using types of the Envoy v3 API", func() {
+		resource := newResource("EnvoyFilter", "v1alpha3")
+		resource.Spec = envoyFilterSpec(map[string]interface{}{
+			"patch": map[string]interface{}{"operation": "MERGE", "value": map[string]interface{}{
+				"typed_config": map[string]interface{}{"@type": "type.googleapis.com/envoy.extensions.filters.network.http_connection_manager.v3.HttpConnectionManager"},
+			}},
+		})
+
+		findings := analyzeResource(resource, "1.27.0")
+
+		Expect(findings).To(BeEmpty())
+	})
+
+	ginkgo.It("should not report WasmPlugins", func() {
+		findings := analyzeResource(newResource("WasmPlugin", "v1alpha1"), "1.27.0")
+
+		Expect(findings).To(BeEmpty())
+	})
+})
+
+func envoyFilterSpec(configPatches ...map[string]interface{}) map[string]interface{} {
+	patches := make([]interface{}, 0, len(configPatches))
+	for _, configPatch := range configPatches {
+		patches = append(patches, configPatch)
+	}
+	return map[string]interface{}{"configPatches": patches}
+}
@@ -0,0 +1,255 @@
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kyma-project/istio/operator/api/v1alpha2"
+	"github.com/kyma-project/istio/operator/internal/resources"
+	"github.com/kyma-project/istio/operator/pkg/labels"
+)
+
+const (
+	ConfigMapName      = "istio-upgrade-preflight"
+	ConfigMapNamespace = "kyma-system"
+
+	CurrentVersionKey = "currentVersion"
+	TargetVersionKey  = "targetVersion"
+	FindingsCountKey  = "findingsCount"
+	FindingsKey       = "findings"
+
+	// maxListedFindings limits the number of findings listed in the report, so the ConfigMap stays within the size limit.
+	maxListedFindings = 100
+)
+
+//nolint:gochecknoglobals // static lookup tables of the analyser
+var (
+	// deprecatedAPIVersions maps the deprecated Istio API versions to the stable API versions replacing them.
+	deprecatedAPIVersions = map[string]string{
+		"networking.istio.io/v1alpha3": "networking.istio.io/v1",
+		"networking.istio.io/v1beta1":  "networking.istio.io/v1",
+		"security.istio.io/v1beta1":    "security.istio.io/v1",
+		"telemetry.istio.io/v1alpha1":  "telemetry.istio.io/v1",
+	}
+	// kindsWithoutStableVersion are only served in a non-stable API version, so using it is not reported as deprecated.
+	kindsWithoutStableVersion = map[string]bool{
+		"EnvoyFilter": true,
+		"ProxyConfig": true,
+		"WasmPlugin":  true,
+	}
+	// removedFilterNames maps the deprecated Envoy filter names that Envoy doesn't support anymore to the names replacing them.
+	removedFilterNames = map[string]string{
+		"envoy.buffer":                  "envoy.filters.http.buffer",
+		"envoy.cors":                    "envoy.filters.http.cors",
+		"envoy.ext_authz":               "envoy.filters.http.ext_authz",
+		"envoy.fault":                   "envoy.filters.http.fault",
+		"envoy.grpc_web":                "envoy.filters.http.grpc_web",
+		"envoy.health_check":            "envoy.filters.http.health_check",
+		"envoy.http_connection_manager": "envoy.filters.network.http_connection_manager",
+		"envoy.listener.http_inspector": "envoy.filters.listener.http_inspector",
+		"envoy.listener.original_dst":   "envoy.filters.listener.original_dst",
+		"envoy.listener.tls_inspector":  "envoy.filters.listener.tls_inspector",
+		"envoy.lua":                     "envoy.filters.http.lua",
+		"envoy.ratelimit":               "envoy.filters.http.ratelimit",
+		"envoy.rbac":                    "envoy.filters.http.rbac",
+		"envoy.router":                  "envoy.filters.http.router",
+		"envoy.tcp_proxy":               "envoy.filters.network.tcp_proxy",
+	}
+)
+
+// Finding describes an Istio resource created by the user that is likely to break on the target Istio version.
+type Finding struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Message   string `json:"message"`
+}
+
+// Report contains the findings of the pre-flight check for the upgrade from the current to the target Istio version.
+type Report struct {
+	CurrentVersion string
+	TargetVersion  string
+	Findings       []Finding
+}
+
+// Analyze inspects the Istio resources created by the user and reports the ones that are likely to break when Istio is upgraded
+// to the target version.
+func Analyze(ctx context.Context, k8sClient client.Client, currentVersion, targetVersion string) (Report, error) {
+	report := Report{CurrentVersion: currentVersion, TargetVersion: targetVersion}
+
+	finder, err := resources.NewIstioResourcesFinder(ctx, k8sClient, ctrl.Log)
+	if err != nil {
+		return report, err
+	}
+	userResources, err := finder.FindUserCreatedIstioResources()
+	if err != nil {
+		return report, err
+	}
+
+	for _, resource := range userResources {
+		report.Findings = append(report.Findings, analyzeResource(resource, targetVersion)...)
+	}
+	return report, nil
+}
+
+func analyzeResource(resource resources.Resource, targetVersion string) []Finding {
+	var findings []Finding
+	newFinding := func(message string) Finding {
+		return Finding{Kind: resource.GVK.Kind, Namespace: resource.Namespace, Name: resource.Name, Message: message}
+	}
+
+	if resource.GVK.Kind == "EnvoyFilter" {
+		for _, message := range analyzeEnvoyFilter(resource.Spec, targetVersion) {
+			findings = append(findings, newFinding(message))
+		}
+	}
+
+	if kindsWithoutStableVersion[resource.GVK.Kind] {
+		return findings
+	}
+	for _, apiVersion := range resource.AppliedAPIVersions {
+		if stableAPIVersion, deprecated := deprecatedAPIVersions[apiVersion]; deprecated {
+			findings = append(findings, newFinding(fmt.Sprintf("Resource was applied with the deprecated API version %s, which may be removed in a future Istio version. Use %s instead",
+				apiVersion, stableAPIVersion)))
+			break
+		}
+	}
+	return findings
+}
+
+// analyzeEnvoyFilter returns the messages for the config patches of an EnvoyFilter that are known to break with the target version:
+// patches that only match proxy versions other than the target version and patches that use removed Envoy filter names or
+// removed Envoy v2 API types.
+func analyzeEnvoyFilter(spec map[string]interface{}, targetVersion string) []string {
+	var messages []string
+	addMessage := func(message string) {
+		if !slices.Contains(messages, message) {
+			messages = append(messages, message)
+		}
+	}
+
+	configPatches, _, _ := unstructured.NestedSlice(spec, "configPatches")
+	for _, configPatch := range configPatches {
+		patch, ok := configPatch.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		proxyVersion, _, _ := unstructured.NestedString(patch, "match", "proxy", "proxyVersion")
+		if proxyVersion != "" {
+			if matcher, err := regexp.Compile(proxyVersion); err == nil && !matcher.MatchString(targetVersion) {
+				addMessage(fmt.Sprintf("Config patch matches the proxy version %q, which doesn't match Istio %s. The patch isn't applied after the upgrade",
+					proxyVersion, targetVersion))
+			}
+		}
+
+		for _, name := range filterNames(patch) {
+			if replacement, removed := removedFilterNames[name]; removed {
+				addMessage(fmt.Sprintf("Config patch uses the filter name %s, which is not supported by Envoy anymore. Use %s instead", name, replacement))
+			}
+		}
+
+		for _, typeURL := range typeURLs(patch["patch"]) {
+			if isRemovedEnvoyType(typeURL) {
+				addMessage(fmt.Sprintf("Config patch uses the type %s of the Envoy v2 API, which is not supported by Envoy anymore. Use the corresponding v3 type instead", typeURL))
+			}
+		}
+	}
+	return messages
+}
+
+// filterNames returns the filter names that the config patch matches and the names in the value of the patch.
+func filterNames(patch map[string]interface{}) []string {
+	var names []string
+	for _, path := range [][]string{
+		{"match", "listener", "filterChain", "filter", "name"},
+		{"match", "listener", "filterChain", "filter", "subFilter", "name"},
+		{"patch", "value", "name"},
+	} {
+		if name, found, _ := unstructured.NestedString(patch, path...); found {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// typeURLs returns the types of all typed configs in the given value.
+func typeURLs(value interface{}) []string {
+	var urls []string
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			nested := v[key]
+			if typeURL, ok := nested.(string); ok && (key == "@type" || key == "type_url" || key == "typeUrl") {
+				urls = append(urls, typeURL)
+				continue
+			}
+			urls = append(urls, typeURLs(nested)...)
+		}
+	case []interface{}:
+		for _, nested := range v {
+			urls = append(urls, typeURLs(nested)...)
+		}
+	}
+	return urls
+}
+
+func isRemovedEnvoyType(typeURL string) bool {
+	typeName := typeURL[strings.LastIndex(typeURL, "/")+1:]
+	if !strings.HasPrefix(typeName, "envoy.") {
+		return false
+	}
+	return strings.HasPrefix(typeName, "envoy.api.v2.") || strings.HasPrefix(typeName, "envoy.config.filter.") ||
+		strings.Contains(typeName, ".v2.") || strings.Contains(typeName, ".v2alpha.")
+}
+
+// WriteReport writes the report to the istio-upgrade-preflight ConfigMap.
+func WriteReport(ctx context.Context, k8sClient client.Client, istioCR *v1alpha2.Istio, report Report) error {
+	listedFindings := report.Findings
+	if len(listedFindings) > maxListedFindings {
+		listedFindings = listedFindings[:maxListedFindings]
+	}
+	findings, err := yaml.Marshal(listedFindings)
+	if err != nil {
+		return err
+	}
+
+	cm := corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ConfigMapName,
+			Namespace: ConfigMapNamespace,
+		},
+	}
+	_, err = controllerutil.CreateOrUpdate(ctx, k8sClient, &cm, func() error {
+		cm.Labels = labels.SetModuleLabels(cm.Labels)
+		cm.OwnerReferences = []metav1.OwnerReference{
+			{
+				APIVersion: istioCR.APIVersion,
+				Kind:       istioCR.Kind,
+				Name:       istioCR.Name,
+				UID:        istioCR.UID,
+			},
+		}
+		cm.Data = map[string]string{
+			CurrentVersionKey: report.CurrentVersion,
+			TargetVersionKey:  report.TargetVersion,
+			FindingsCountKey:  strconv.Itoa(len(report.Findings)),
+			FindingsKey:       string(findings),
+		}
+		return nil
+	})
+	return err
+}
@@ -0,0 +1,110 @@
+package preflight_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	networkingv1 "istio.io/client-go/pkg/apis/networking/v1"
+	networkingv1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kyma-project/istio/operator/api/v1alpha2"
+	"github.com/kyma-project/istio/operator/internal/preflight"
+)
+
+var _ = Describe("Analyze", func() {
+	ctx := context.Background()
+
+	It("should report no findings if there are no resources created by the user", func() {
+		// when
+		report, err := preflight.Analyze(ctx, createFakeClient(), "1.26.0", "1.27.0")
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(report.CurrentVersion).To(Equal("1.26.0"))
+		Expect(report.TargetVersion).To(Equal("1.27.0"))
+		Expect(report.Findings).To(BeEmpty())
+	})
+
+	It("should report EnvoyFilters created by the user that break on the target version, but not the ones managed by the module", func() {
+		// given
+		c := createFakeClient(
+			versionPinnedEnvoyFilter("custom-filter", "test-ns"),
+			&networkingv1alpha3.EnvoyFilter{ObjectMeta: metav1.ObjectMeta{Name: "unaffected-filter", Namespace: "test-ns"}},
+			versionPinnedEnvoyFilter("kyma-referer", "istio-system"),
+		)
+
+		// when
+		report, err := preflight.Analyze(ctx, c, "1.26.0", "1.27.0")
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(report.Findings).To(HaveLen(1))
+		Expect(report.Findings[0].Kind).To(Equal("EnvoyFilter"))
+		Expect(report.Findings[0].Namespace).To(Equal("test-ns"))
+		Expect(report.Findings[0].Name).To(Equal("custom-filter"))
+		Expect(report.Findings[0].Message).To(ContainSubstring("Istio 1.27.0"))
+	})
+})
+
+var _ = Describe("WriteReport", func() {
+	It("should write the report to the ConfigMap", func() {
+		// given
+		c := createFakeClient()
+		istioCR := &v1alpha2.Istio{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "kyma-system", UID: "uid"}}
+		report := preflight.Report{
+			CurrentVersion: "1.26.0",
+			TargetVersion:  "1.27.0",
+			Findings:       []preflight.Finding{{Kind: "EnvoyFilter", Namespace: "test-ns", Name: "custom-filter", Message: "message"}},
+		}
+
+		// when
+		err := preflight.WriteReport(context.Background(), c, istioCR, report)
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+		cm := corev1.ConfigMap{}
+		Expect(c.Get(context.Background(), types.NamespacedName{Namespace: preflight.ConfigMapNamespace, Name: preflight.ConfigMapName}, &cm)).Should(Succeed())
+		Expect(cm.Data[preflight.CurrentVersionKey]).To(Equal("1.26.0"))
+		Expect(cm.Data[preflight.TargetVersionKey]).To(Equal("1.27.0"))
+		Expect(cm.Data[preflight.FindingsCountKey]).To(Equal("1"))
+		Expect(cm.OwnerReferences).To(HaveLen(1))
+
+		var findings []preflight.Finding
+		Expect(yaml.Unmarshal([]byte(cm.Data[preflight.FindingsKey]), &findings)).Should(Succeed())
+		Expect(findings).To(Equal(report.Findings))
+	})
+})
+
+func createFakeClient(objects ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	Expect(corev1.AddToScheme(scheme)).Should(Succeed())
+	Expect(networkingv1alpha3.AddToScheme(scheme)).Should(Succeed())
+	Expect(networkingv1.AddToScheme(scheme)).Should(Succeed())
+	Expect(v1alpha2.AddToScheme(scheme)).Should(Succeed())
+
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+}
+
+func versionPinnedEnvoyFilter(name, namespace string) *unstructured.Unstructured {
+	envoyFilter := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"configPatches": []interface{}{
+				map[string]interface{}{"match": map[string]interface{}{"proxy": map[string]interface{}{"proxyVersion": `^1\.26.*`}}},
+			},
+		},
+	}}
+	envoyFilter.SetAPIVersion("networking.istio.io/v1alpha3")
+	envoyFilter.SetKind("EnvoyFilter")
+	envoyFilter.SetName(name)
+	envoyFilter.SetNamespace(namespace)
+	return envoyFilter
+}
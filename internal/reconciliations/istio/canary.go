@@ -0,0 +1,248 @@
+package istio
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/masterminds/semver"
+	"istio.io/istio/istioctl/pkg/tag"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha2 "github.com/kyma-project/istio/operator/api/v1alpha2"
+	"github.com/kyma-project/istio/operator/internal/describederrors"
+	"github.com/kyma-project/istio/operator/internal/istiooperator"
+	"github.com/kyma-project/istio/operator/internal/status"
+	"github.com/kyma-project/istio/operator/pkg/lib/gatherer"
+)
+
+const (
+	injectionLabelKey   = "istio-injection"
+	injectionLabelValue = "enabled"
+)
+
+// revisionName returns the name of the Istio revision that is installed for the Istio version during a canary upgrade, e.g. 1-27-1 for 1.27.1.
+func revisionName(istioImageVersion istiooperator.IstioImageVersion) string {
+	return strings.ReplaceAll(istioImageVersion.Version(), ".", "-")
+}
+
+// getInstalledRevision returns the revision of the installed Istio control plane. If more than one revision is installed,
+// the revision with the highest Istio version is returned. An empty string is returned if the control plane is installed without revision.
+func getInstalledRevision(ctx context.Context, k8sClient client.Client) (string, error) {
+	revisions, err := gatherer.ListInstalledIstioRevisions(ctx, k8sClient)
+	if err != nil {
+		return "", err
+	}
+
+	installedRevision := ""
+	var installedVersion *semver.Version
+	for revision, version := range revisions {
+		if installedVersion == nil || version.GreaterThan(installedVersion) ||
+			(version.Equal(installedVersion) && revision < installedRevision) {
+			installedRevision = revision
+			installedVersion = version
+		}
+	}
+
+	if installedRevision == tag.DefaultRevisionName {
+		return "", nil
+	}
+	return installedRevision, nil
+}
+
+// isCanaryUpgradeRequired returns true if the Istio CR requests a canary upgrade and the Istio version differs from the last applied one.
+func isCanaryUpgradeRequired(istioCR *operatorv1alpha2.Istio, lastAppliedIstioTag string, istioImageVersion istiooperator.IstioImageVersion) (bool, error) {
+	if !istioCR.IsCanaryUpgradeEnabled() || istioCR.Status.CanaryUpgrade != nil || lastAppliedIstioTag == "" {
+		return false, nil
+	}
+
+	lastAppliedVersion, err := istiooperator.NewIstioImageVersionFromTag(lastAppliedIstioTag)
+	if err != nil {
+		return false, err
+	}
+
+	return lastAppliedVersion.Version() != istioImageVersion.Version(), nil
+}
+
+// startCanaryUpgrade records the installed revision as the source and the revision of the Istio version as the target of the canary upgrade.
+func startCanaryUpgrade(ctx context.Context, k8sClient client.Client, istioCR *operatorv1alpha2.Istio, istioImageVersion istiooperator.IstioImageVersion) error {
+	sourceRevision, err := getInstalledRevision(ctx, k8sClient)
+	if err != nil {
+		return err
+	}
+	if sourceRevision == "" {
+		sourceRevision = tag.DefaultRevisionName
+	}
+
+	targetRevision := revisionName(istioImageVersion)
+	if sourceRevision == targetRevision {
+		return fmt.Errorf("target revision %s of the canary upgrade is already installed", targetRevision)
+	}
+
+	istioCR.Status.CanaryUpgrade = &operatorv1alpha2.CanaryUpgradeStatus{
+		SourceRevision: sourceRevision,
+		TargetRevision: targetRevision,
+		TargetVersion:  istioImageVersion.Version(),
+	}
+	ctrl.Log.Info("Starting canary upgrade", "source revision", sourceRevision, "target revision", targetRevision)
+
+	return nil
+}
+
+// reconcileCanaryUpgrade moves the next stage of namespaces to the target revision of the canary upgrade. The next stage is only
+// started when the proxies of all namespaces moved so far run the target Istio version. After all namespaces are moved,
+// the default revision tag is moved to the target revision and the source revision is removed.
+func reconcileCanaryUpgrade(ctx context.Context, k8sClient client.Client, istioCR *operatorv1alpha2.Istio, statusHandler status.Status,
+	istioClient libraryClient, istioImageVersion istiooperator.IstioImageVersion) describederrors.DescribedError {
+	canaryUpgrade := istioCR.Status.CanaryUpgrade
+
+	if err := verifyRevisionInstalled(ctx, k8sClient, canaryUpgrade.TargetRevision, istioImageVersion); err != nil {
+		statusHandler.SetCondition(istioCR, operatorv1alpha2.NewReasonWithMessage(operatorv1alpha2.ConditionReasonCanaryUpgradeFailed))
+		return describederrors.NewDescribedError(err, "Verifying target revision of the canary upgrade failed").SetCondition(false)
+	}
+
+	if len(canaryUpgrade.MigratedNamespaces) > 0 {
+		err := gatherer.VerifyInjectedPodsVersion(ctx, k8sClient, istioImageVersion.Version(), canaryUpgrade.MigratedNamespaces)
+		if err != nil {
+			ctrl.Log.Info("Waiting for the proxies of the migrated namespaces to run the target Istio version", "reason", err.Error())
+			statusHandler.SetCondition(istioCR, operatorv1alpha2.NewReasonWithMessage(operatorv1alpha2.ConditionReasonCanaryUpgradeInProgress))
+			return nil
+		}
+	}
+
+	namespaces, err := listNamespacesToMigrate(ctx, k8sClient)
+	if err != nil {
+		statusHandler.SetCondition(istioCR, operatorv1alpha2.NewReasonWithMessage(operatorv1alpha2.ConditionReasonCanaryUpgradeFailed))
+		return describederrors.NewDescribedError(err, "Could not list namespaces for the canary upgrade").SetCondition(false)
+	}
+
+	if len(namespaces) > 0 {
+		stage := namespaces[:min(len(namespaces), istioCR.GetNamespacesPerStage())]
+		for _, namespace := range stage {
+			if err := setNamespaceRevision(ctx, k8sClient, namespace, canaryUpgrade.TargetRevision); err != nil {
+				statusHandler.SetCondition(istioCR, operatorv1alpha2.NewReasonWithMessage(operatorv1alpha2.ConditionReasonCanaryUpgradeFailed))
+				return describederrors.NewDescribedError(err, "Could not move namespace to the target revision").SetCondition(false)
+			}
+			canaryUpgrade.MigratedNamespaces = append(canaryUpgrade.MigratedNamespaces, namespace)
+		}
+		ctrl.Log.Info("Moved namespaces to the target revision", "revision", canaryUpgrade.TargetRevision, "namespaces", stage)
+		statusHandler.SetCondition(istioCR, operatorv1alpha2.NewReasonWithMessage(operatorv1alpha2.ConditionReasonCanaryUpgradeInProgress))
+		return nil
+	}
+
+	return finishCanaryUpgrade(ctx, k8sClient, istioCR, statusHandler, istioClient)
+}
+
+// finishCanaryUpgrade moves the default revision tag to the target revision, restores the injection label of the migrated
+// namespaces and removes the source revision. The source revision is only removed after the proxies of all injected Pods in the cluster
+// run the target Istio version, since Pods outside the migrated namespaces, e.g. Pods injected by the default revision tag, can
+// still use the source revision.
+func finishCanaryUpgrade(ctx context.Context, k8sClient client.Client, istioCR *operatorv1alpha2.Istio, statusHandler status.Status,
+	istioClient libraryClient) describederrors.DescribedError {
+	canaryUpgrade := istioCR.Status.CanaryUpgrade
+
+	if err := istioClient.SetDefaultRevision(ctx, canaryUpgrade.TargetRevision); err != nil {
+		statusHandler.SetCondition(istioCR, operatorv1alpha2.NewReasonWithMessage(operatorv1alpha2.ConditionReasonCanaryUpgradeFailed))
+		return describederrors.NewDescribedError(err, "Could not move default revision tag to the target revision").SetCondition(false)
+	}
+
+	for _, namespace := range canaryUpgrade.MigratedNamespaces {
+		if err := restoreNamespaceInjection(ctx, k8sClient, namespace); err != nil {
+			statusHandler.SetCondition(istioCR, operatorv1alpha2.NewReasonWithMessage(operatorv1alpha2.ConditionReasonCanaryUpgradeFailed))
+			return describederrors.NewDescribedError(err, "Could not restore injection label of namespace").SetCondition(false)
+		}
+	}
+
+	if err := gatherer.VerifyAllInjectedPodsVersion(ctx, k8sClient, canaryUpgrade.TargetVersion); err != nil {
+		ctrl.Log.Info("Waiting for the proxies of all injected Pods to run the target Istio version", "reason", err.Error())
+		statusHandler.SetCondition(istioCR, operatorv1alpha2.NewReasonWithMessage(operatorv1alpha2.ConditionReasonCanaryUpgradeInProgress))
+		return nil
+	}
+
+	if err := istioClient.UninstallRevision(ctx, canaryUpgrade.SourceRevision); err != nil {
+		statusHandler.SetCondition(istioCR, operatorv1alpha2.NewReasonWithMessage(operatorv1alpha2.ConditionReasonCanaryUpgradeFailed))
+		return describederrors.NewDescribedError(err, "Could not remove source revision of the canary upgrade").SetCondition(false)
+	}
+
+	ctrl.Log.Info("Canary upgrade finished", "source revision", canaryUpgrade.SourceRevision, "target revision", canaryUpgrade.TargetRevision)
+	istioCR.Status.CanaryUpgrade = nil
+	statusHandler.SetCondition(istioCR, operatorv1alpha2.NewReasonWithMessage(operatorv1alpha2.ConditionReasonCanaryUpgradeSucceeded))
+
+	return nil
+}
+
+func verifyRevisionInstalled(ctx context.Context, k8sClient client.Client, revision string, istioImageVersion istiooperator.IstioImageVersion) error {
+	revisions, err := gatherer.ListInstalledIstioRevisions(ctx, k8sClient)
+	if err != nil {
+		return err
+	}
+
+	installedVersion, ok := revisions[revision]
+	if !ok {
+		return fmt.Errorf("istio revision %s is not installed", revision)
+	}
+
+	expectedVersion, err := semver.NewVersion(istioImageVersion.Version())
+	if err != nil {
+		return err
+	}
+	if !installedVersion.Equal(expectedVersion) {
+		return fmt.Errorf("istio revision %s has version %s instead of %s", revision, installedVersion.String(), expectedVersion.String())
+	}
+
+	return nil
+}
+
+// listNamespacesToMigrate returns the names of the namespaces that still use the injection of the default revision tag.
+func listNamespacesToMigrate(ctx context.Context, k8sClient client.Client) ([]string, error) {
+	namespaceList := v1.NamespaceList{}
+	if err := k8sClient.List(ctx, &namespaceList, client.MatchingLabels{injectionLabelKey: injectionLabelValue}); err != nil {
+		return nil, err
+	}
+
+	var namespaces []string
+	for _, namespace := range namespaceList.Items {
+		if namespace.DeletionTimestamp == nil {
+			namespaces = append(namespaces, namespace.Name)
+		}
+	}
+	slices.Sort(namespaces)
+
+	return namespaces, nil
+}
+
+// setNamespaceRevision replaces the injection label of the namespace with the revision label, so new Pods are injected by the given revision.
+func setNamespaceRevision(ctx context.Context, k8sClient client.Client, name, revision string) error {
+	ns := &v1.Namespace{}
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: name}, ns); err != nil {
+		return err
+	}
+
+	patch := client.MergeFrom(ns.DeepCopy())
+	delete(ns.Labels, injectionLabelKey)
+	ns.Labels = addToMap(ns.Labels, gatherer.RevisionLabelName, revision)
+
+	return k8sClient.Patch(ctx, ns, patch)
+}
+
+// restoreNamespaceInjection replaces the revision label of the namespace with the injection label, so new Pods are injected by the default revision tag.
+func restoreNamespaceInjection(ctx context.Context, k8sClient client.Client, name string) error {
+	ns := &v1.Namespace{}
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: name}, ns); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	patch := client.MergeFrom(ns.DeepCopy())
+	delete(ns.Labels, gatherer.RevisionLabelName)
+	ns.Labels = addToMap(ns.Labels, injectionLabelKey, injectionLabelValue)
+
+	return k8sClient.Patch(ctx, ns, patch)
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"istio.io/istio/istioctl/pkg/install/k8sversion"
+	"istio.io/istio/istioctl/pkg/tag"
 	"istio.io/istio/operator/pkg/uninstall"
 	"istio.io/istio/operator/pkg/util/progress"
 	"istio.io/istio/pkg/config/constants"
@@ -30,6 +31,8 @@ const DefaultTimeout = 6 * time.Minute
 type libraryClient interface {
 	Install(mergedIstioOperatorPath string) error
 	Uninstall(ctx context.Context) error
+	SetDefaultRevision(ctx context.Context, revision string) error
+	UninstallRevision(ctx context.Context, revision string) error
 }
 
 type Client struct {
@@ -87,18 +90,26 @@ func (c *Client) Install(mergedIstioOperatorPath string) error {
 	return nil
 }
 
-func (c *Client) Uninstall(ctx context.Context) error {
+func newIstioKubeClient() (kube.CLIClient, error) {
 	rc, err := kube.DefaultRestConfig("", "", func(config *rest.Config) {
 		config.QPS = 50
 		config.Burst = 100
 	})
 	if err != nil {
-		return fmt.Errorf("failed to create default REST config: %w", err)
+		return nil, fmt.Errorf("failed to create default REST config: %w", err)
 	}
 
 	kubeClient, err := kube.NewCLIClient(kube.NewClientConfigForRestConfig(rc))
 	if err != nil {
-		return fmt.Errorf("failed to create Istio kube client: %w", err)
+		return nil, fmt.Errorf("failed to create Istio kube client: %w", err)
+	}
+	return kubeClient, nil
+}
+
+func (c *Client) Uninstall(ctx context.Context) error {
+	kubeClient, err := newIstioKubeClient()
+	if err != nil {
+		return err
 	}
 
 	if err = k8sversion.IsK8VersionSupported(kubeClient, c.consoleLogger); err != nil {
@@ -149,6 +160,58 @@ func (c *Client) Uninstall(ctx context.Context) error {
 	return nil
 }
 
+// SetDefaultRevision points the default revision tag to the given revision, so the sidecar injection of namespaces
+// labeled with istio-injection=enabled is handled by this revision.
+func (c *Client) SetDefaultRevision(ctx context.Context, revision string) error {
+	kubeClient, err := newIstioKubeClient()
+	if err != nil {
+		return err
+	}
+
+	manifests, err := tag.Generate(ctx, kubeClient, &tag.GenerateOptions{
+		Tag:            tag.DefaultRevisionName,
+		Revision:       revision,
+		Overwrite:      true,
+		IstioNamespace: constants.IstioSystemNamespace,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to generate default revision tag: %w", err)
+	}
+
+	if err = tag.Create(kubeClient, manifests, constants.IstioSystemNamespace); err != nil {
+		return fmt.Errorf("failed to apply default revision tag: %w", err)
+	}
+
+	// The injection webhook of a control plane installed without revision would still inject the old proxies
+	if err = tag.DeactivateIstioInjectionWebhook(ctx, kubeClient.Kube()); err != nil {
+		return fmt.Errorf("failed to deactivate default injection webhook: %w", err)
+	}
+
+	ctrl.Log.Info("Default revision tag set", "revision", revision)
+	return nil
+}
+
+// UninstallRevision deletes the control plane resources of the given revision. Resources that are shared between
+// revisions are not deleted.
+func (c *Client) UninstallRevision(_ context.Context, revision string) error {
+	kubeClient, err := newIstioKubeClient()
+	if err != nil {
+		return err
+	}
+
+	objectsList, err := uninstall.GetPrunedResources(kubeClient, "", "", revision, false)
+	if err != nil {
+		return err
+	}
+
+	if err = uninstall.DeleteObjectsList(kubeClient, false, c.consoleLogger, objectsList); err != nil {
+		return fmt.Errorf("failed to delete control plane resources of revision %s: %w", revision, err)
+	}
+	ctrl.Log.Info("Deletion of Istio revision completed", "revision", revision)
+
+	return nil
+}
+
 func ConfigureIstioLogScopes() error {
 	o := istiolog.DefaultOptions()
 	o.SetDefaultOutputLevel(logScope, istiolog.WarnLevel)
@@ -13,6 +13,8 @@ import (
 type AppliedConfig struct {
 	v1alpha2.IstioSpec `json:",inline"`
 	IstioTag           string `json:"IstioTag"`
+	// CertificateAuthorityFingerprint is the fingerprint of the custom certificate authority the proxy sidecars were restarted with.
+	CertificateAuthorityFingerprint string `json:"certificateAuthorityFingerprint,omitempty"`
 }
 
 // UpdateLastAppliedConfiguration annotates the passed CR with LastAppliedConfiguration, which holds information about last applied
@@ -23,8 +25,9 @@ func UpdateLastAppliedConfiguration(istioCR *v1alpha2.Istio, istioTag string) er
 	}
 
 	newAppliedConfig := AppliedConfig{
-		IstioSpec: istioCR.Spec,
-		IstioTag:  istioTag,
+		IstioSpec:                       istioCR.Spec,
+		IstioTag:                        istioTag,
+		CertificateAuthorityFingerprint: istioCR.GetCertificateAuthorityFingerprint(),
 	}
 
 	config, err := json.Marshal(newAppliedConfig)
@@ -117,38 +120,8 @@ func UpdateIstioTag(istioCR *v1alpha2.Istio, istioTag string) error {
 	return nil
 }
 
-// UpdateLastAppliedIngressGatewayConfig updates only the ingress gateway restart-related config fields
-// (NumTrustedProxies, ForwardClientCertDetails, TrustDomain) in the lastAppliedConfiguration annotation.
-// This should be called after a successful ingress gateway restart to prevent unnecessary restarts
-// if reconciliation requeues early.
-func UpdateLastAppliedIngressGatewayConfig(istioCR *v1alpha2.Istio) error {
-	if len(istioCR.Annotations) == 0 {
-		istioCR.Annotations = map[string]string{}
-	}
-	appliedConfig := AppliedConfig{}
-	if lastAppliedConfiguration, ok := istioCR.Annotations[labels.LastAppliedConfiguration]; ok {
-		err := json.Unmarshal([]byte(lastAppliedConfiguration), &appliedConfig)
-		if err != nil {
-			return err
-		}
-	}
-
-	appliedConfig.Config.NumTrustedProxies = istioCR.Spec.Config.NumTrustedProxies
-	appliedConfig.Config.ForwardClientCertDetails = istioCR.Spec.Config.ForwardClientCertDetails
-	appliedConfig.Config.TrustDomain = istioCR.Spec.Config.TrustDomain
-
-	config, err := json.Marshal(appliedConfig)
-	if err != nil {
-		return err
-	}
-
-	istioCR.Annotations[labels.LastAppliedConfiguration] = string(config)
-	return nil
-}
-
-// UpdateLastAppliedProxyConfig updates only the proxy restart-related config fields
-// (CompatibilityMode, EnableDNSProxying - it also restarts the ingressgateway in Istio installation, but proxy restarts is the last one hence update should happen here)
-// in the lastAppliedConfiguration annotation.
+// UpdateLastAppliedProxyConfig updates only the certificate authority fingerprint in the lastAppliedConfiguration annotation.
+// Changes of the proxy configuration are detected by the proxy config hashes in the Istio CR status instead.
 // This should be called after a successful sidecar restart to prevent unnecessary restarts
 // if reconciliation requeues early.
 func UpdateLastAppliedProxyConfig(istioCR *v1alpha2.Istio) error {
@@ -163,9 +136,7 @@ func UpdateLastAppliedProxyConfig(istioCR *v1alpha2.Istio) error {
 		}
 	}
 
-	appliedConfig.CompatibilityMode = istioCR.Spec.CompatibilityMode
-	appliedConfig.Config.EnableDNSProxying = istioCR.Spec.Config.EnableDNSProxying
-	appliedConfig.Config.ProxyStatsMatcher = istioCR.Spec.Config.ProxyStatsMatcher
+	appliedConfig.CertificateAuthorityFingerprint = istioCR.GetCertificateAuthorityFingerprint()
 
 	config, err := json.Marshal(appliedConfig)
 	if err != nil {
@@ -115,88 +115,13 @@ var _ = Describe("Istio Configuration", func() {
 		})
 	})
 
-	Context("UpdateLastAppliedIngressGatewayConfig", func() {
-		It("should update ingress gateway config fields when annotation does not exist", func() {
-			// given
-			numTrustedProxies := 2
-			trustDomain := "cluster.local"
-			forwardClientCert := operatorv1alpha2.SanitizeSet
-			istioCR := operatorv1alpha2.Istio{Spec: operatorv1alpha2.IstioSpec{Config: operatorv1alpha2.Config{
-				NumTrustedProxies:        &numTrustedProxies,
-				TrustDomain:              &trustDomain,
-				ForwardClientCertDetails: &forwardClientCert,
-			}}}
-
-			// when
-			err := configuration.UpdateLastAppliedIngressGatewayConfig(&istioCR)
-
-			// then
-			Expect(err).ShouldNot(HaveOccurred())
-			Expect(istioCR.Annotations).To(Not(BeEmpty()))
-
-			appliedConfig, err := configuration.GetLastAppliedConfiguration(&istioCR)
-			Expect(err).ShouldNot(HaveOccurred())
-			Expect(*appliedConfig.Config.NumTrustedProxies).To(Equal(2))
-			Expect(*appliedConfig.Config.TrustDomain).To(Equal("cluster.local"))
-			Expect(*appliedConfig.Config.ForwardClientCertDetails).To(Equal(operatorv1alpha2.SanitizeSet))
-		})
-
-		It("should update only ingress gateway config fields preserving other fields", func() {
-			// given
-			numTrustedProxies := 3
-			istioCR := operatorv1alpha2.Istio{Spec: operatorv1alpha2.IstioSpec{Config: operatorv1alpha2.Config{
-				NumTrustedProxies: &numTrustedProxies,
-			}}}
-			istioCR.Annotations = map[string]string{}
-			istioCR.Annotations[lastAppliedConfiguration] = `{"config":{"numTrustedProxies":1,"telemetry":{"metrics":{}}},"IstioTag":"1.16.1-distroless","compatibilityMode":true}`
-
-			// when
-			err := configuration.UpdateLastAppliedIngressGatewayConfig(&istioCR)
-
-			// then
-			Expect(err).ShouldNot(HaveOccurred())
-
-			appliedConfig, err := configuration.GetLastAppliedConfiguration(&istioCR)
-			Expect(err).ShouldNot(HaveOccurred())
-			Expect(*appliedConfig.Config.NumTrustedProxies).To(Equal(3))
-			Expect(appliedConfig.IstioTag).To(Equal("1.16.1-distroless"))
-			Expect(appliedConfig.CompatibilityMode).To(BeTrue())
-		})
-	})
-
 	Context("UpdateLastAppliedProxyConfig", func() {
-		It("should update sidecar proxies when annotation does not exist", func() {
+		It("should update the certificate authority fingerprint from the status", func() {
 			// given
-			istioCR := operatorv1alpha2.Istio{Spec: operatorv1alpha2.IstioSpec{
-				Config: operatorv1alpha2.Config{
-					EnableDNSProxying: ptr.To(true),
-				},
-				CompatibilityMode: true,
-			}}
-
-			// when
-			err := configuration.UpdateLastAppliedProxyConfig(&istioCR)
-
-			// then
-			Expect(err).ShouldNot(HaveOccurred())
-			Expect(istioCR.Annotations).To(Not(BeEmpty()))
-
-			appliedConfig, err := configuration.GetLastAppliedConfiguration(&istioCR)
-			Expect(err).ShouldNot(HaveOccurred())
-			Expect(appliedConfig.CompatibilityMode).To(BeTrue())
-			Expect(*appliedConfig.Config.EnableDNSProxying).To(BeTrue())
-		})
-
-		It("should update only compatibility mode and enableDNSProxying preserving other fields", func() {
-			// given
-			istioCR := operatorv1alpha2.Istio{Spec: operatorv1alpha2.IstioSpec{
-				Config: operatorv1alpha2.Config{
-					EnableDNSProxying: ptr.To(false),
-				},
-				CompatibilityMode: true,
-			}}
+			istioCR := operatorv1alpha2.Istio{Spec: operatorv1alpha2.IstioSpec{}}
+			istioCR.Status.CertificateAuthority = &operatorv1alpha2.CertificateAuthorityStatus{Fingerprint: "def"}
 			istioCR.Annotations = map[string]string{}
-			istioCR.Annotations[lastAppliedConfiguration] = `{"config":{"enableDNSProxying":true, "numTrustedProxies":2,"telemetry":{"metrics":{}}},"IstioTag":"1.16.1-distroless","compatibilityMode":false}`
+			istioCR.Annotations[lastAppliedConfiguration] = `{"config":{"telemetry":{"metrics":{}}},"certificateAuthorityFingerprint":"abc","IstioTag":"1.16.1-distroless"}`
 
 			// when
 			err := configuration.UpdateLastAppliedProxyConfig(&istioCR)
@@ -206,21 +131,20 @@ var _ = Describe("Istio Configuration", func() {
 
 			appliedConfig, err := configuration.GetLastAppliedConfiguration(&istioCR)
 			Expect(err).ShouldNot(HaveOccurred())
-			Expect(appliedConfig.CompatibilityMode).To(BeTrue())
-			Expect(*appliedConfig.Config.EnableDNSProxying).To(BeFalse())
-			Expect(*appliedConfig.Config.NumTrustedProxies).To(Equal(2))
+			Expect(appliedConfig.CertificateAuthorityFingerprint).To(Equal("def"))
 			Expect(appliedConfig.IstioTag).To(Equal("1.16.1-distroless"))
 		})
 
-		It("should update only enableDNSProxying preserving other fields", func() {
+		It("should not update the proxy configuration fields that are covered by the proxy config hash", func() {
 			// given
 			istioCR := operatorv1alpha2.Istio{Spec: operatorv1alpha2.IstioSpec{
 				Config: operatorv1alpha2.Config{
 					EnableDNSProxying: ptr.To(false),
 				},
+				CompatibilityMode: true,
 			}}
 			istioCR.Annotations = map[string]string{}
-			istioCR.Annotations[lastAppliedConfiguration] = `{"config":{"enableDNSProxying":true, "numTrustedProxies":2,"telemetry":{"metrics":{}}},"IstioTag":"1.16.1-distroless","compatibilityMode":false}`
+			istioCR.Annotations[lastAppliedConfiguration] = `{"config":{"enableDNSProxying":true,"telemetry":{"metrics":{}}},"IstioTag":"1.16.1-distroless","compatibilityMode":false}`
 
 			// when
 			err := configuration.UpdateLastAppliedProxyConfig(&istioCR)
@@ -231,77 +155,7 @@ var _ = Describe("Istio Configuration", func() {
 			appliedConfig, err := configuration.GetLastAppliedConfiguration(&istioCR)
 			Expect(err).ShouldNot(HaveOccurred())
 			Expect(appliedConfig.CompatibilityMode).To(BeFalse())
-			Expect(*appliedConfig.Config.EnableDNSProxying).To(BeFalse())
-			Expect(*appliedConfig.Config.NumTrustedProxies).To(Equal(2))
-			Expect(appliedConfig.IstioTag).To(Equal("1.16.1-distroless"))
-		})
-
-		It("should update proxyStatsMatcher when annotation does not exist", func() {
-			// given
-			istioCR := operatorv1alpha2.Istio{Spec: operatorv1alpha2.IstioSpec{
-				Config: operatorv1alpha2.Config{
-					ProxyStatsMatcher: &operatorv1alpha2.ProxyStatsMatcher{
-						InclusionRegexps: []string{".*upstream_rq_retry.*"},
-					},
-				},
-			}}
-
-			// when
-			err := configuration.UpdateLastAppliedProxyConfig(&istioCR)
-
-			// then
-			Expect(err).ShouldNot(HaveOccurred())
-
-			appliedConfig, err := configuration.GetLastAppliedConfiguration(&istioCR)
-			Expect(err).ShouldNot(HaveOccurred())
-			Expect(appliedConfig.Config.ProxyStatsMatcher).NotTo(BeNil())
-			Expect(appliedConfig.Config.ProxyStatsMatcher.InclusionRegexps).To(ConsistOf(".*upstream_rq_retry.*"))
-		})
-
-		It("should update proxyStatsMatcher preserving other fields", func() {
-			// given
-			istioCR := operatorv1alpha2.Istio{Spec: operatorv1alpha2.IstioSpec{
-				Config: operatorv1alpha2.Config{
-					ProxyStatsMatcher: &operatorv1alpha2.ProxyStatsMatcher{
-						InclusionRegexps: []string{".*upstream_cx.*"},
-					},
-				},
-			}}
-			istioCR.Annotations = map[string]string{}
-			istioCR.Annotations[lastAppliedConfiguration] = `{"config":{"proxyStatsMatcher":{"inclusionRegexps":[".*upstream_rq_retry.*"]},"numTrustedProxies":2,"telemetry":{"metrics":{}}},"IstioTag":"1.16.1-distroless"}`
-
-			// when
-			err := configuration.UpdateLastAppliedProxyConfig(&istioCR)
-
-			// then
-			Expect(err).ShouldNot(HaveOccurred())
-
-			appliedConfig, err := configuration.GetLastAppliedConfiguration(&istioCR)
-			Expect(err).ShouldNot(HaveOccurred())
-			Expect(appliedConfig.Config.ProxyStatsMatcher).NotTo(BeNil())
-			Expect(appliedConfig.Config.ProxyStatsMatcher.InclusionRegexps).To(ConsistOf(".*upstream_cx.*"))
-			Expect(*appliedConfig.Config.NumTrustedProxies).To(Equal(2))
-			Expect(appliedConfig.IstioTag).To(Equal("1.16.1-distroless"))
-		})
-
-		It("should clear proxyStatsMatcher when removed from spec", func() {
-			// given
-			istioCR := operatorv1alpha2.Istio{Spec: operatorv1alpha2.IstioSpec{
-				Config: operatorv1alpha2.Config{},
-			}}
-			istioCR.Annotations = map[string]string{}
-			istioCR.Annotations[lastAppliedConfiguration] = `{"config":{"proxyStatsMatcher":{"inclusionRegexps":[".*upstream_rq_retry.*"]},"telemetry":{"metrics":{}}},"IstioTag":"1.16.1-distroless"}`
-
-			// when
-			err := configuration.UpdateLastAppliedProxyConfig(&istioCR)
-
-			// then
-			Expect(err).ShouldNot(HaveOccurred())
-
-			appliedConfig, err := configuration.GetLastAppliedConfiguration(&istioCR)
-			Expect(err).ShouldNot(HaveOccurred())
-			Expect(appliedConfig.Config.ProxyStatsMatcher).To(BeNil())
-			Expect(appliedConfig.IstioTag).To(Equal("1.16.1-distroless"))
+			Expect(*appliedConfig.Config.EnableDNSProxying).To(BeTrue())
 		})
 	})
 
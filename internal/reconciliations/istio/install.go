@@ -45,6 +45,7 @@ func installIstio(ctx context.Context, args installArgs) (istiooperator.IstioIma
 
 	ctrl.Log.Info("Starting Istio install", "istio version", istioImageVersion.Version())
 
+	lastAppliedIstioTag := ""
 	if _, ok := istioCR.Annotations[labels.LastAppliedConfiguration]; ok {
 		lastAppliedConfig, err := configuration.GetLastAppliedConfiguration(istioCR)
 		if err != nil {
@@ -57,6 +58,11 @@ func installIstio(ctx context.Context, args installArgs) (istiooperator.IstioIma
 			// We are already updating the condition, that's why we need to avoid another condition update by applying SetCondition(false)
 			return istioImageVersion, describederrors.NewDescribedError(err, "Istio version update is not allowed").SetWarning().SetCondition(false)
 		}
+		lastAppliedIstioTag = lastAppliedConfig.IstioTag
+	}
+
+	if err := checkUpgradePreflight(ctx, k8sClient, istioCR, statusHandler, lastAppliedIstioTag, istioImageVersion); err != nil {
+		return istioImageVersion, err
 	}
 
 	if !hasInstallationFinalizer(istioCR) {
@@ -66,9 +72,16 @@ func installIstio(ctx context.Context, args installArgs) (istiooperator.IstioIma
 		}
 	}
 
-	enableDualStack := clusterStrategy != nil && clusterStrategy.DualStackEnabled()
-	if enableDualStack {
-		ctrl.Log.Info("Istio is running with IPDualStack enabled")
+	// Adding the finalizer updates the Istio CR and overwrites its status, so the canary upgrade must be started afterwards
+	canaryUpgradeRequired, err := isCanaryUpgradeRequired(istioCR, lastAppliedIstioTag, istioImageVersion)
+	if err != nil {
+		return istioImageVersion, describederrors.NewDescribedError(err, "Istio install check failed")
+	}
+	if canaryUpgradeRequired {
+		if err = startCanaryUpgrade(ctx, k8sClient, istioCR, istioImageVersion); err != nil {
+			statusHandler.SetCondition(istioCR, operatorv1alpha2.NewReasonWithMessage(operatorv1alpha2.ConditionReasonCanaryUpgradeFailed))
+			return istioImageVersion, describederrors.NewDescribedError(err, "Could not start canary upgrade").SetCondition(false)
+		}
 	}
 
 	clusterConfiguration := clusterconfig.ClusterConfigurationFromFactory(clusterStrategy)
@@ -81,17 +94,12 @@ func installIstio(ctx context.Context, args installArgs) (istiooperator.IstioIma
 
 	ctrl.Log.Info("Installing Istio with", "profile", clusterSize.String())
 
-	var options []operatorv1alpha2.MergeOption
-	features, err := istiofeatures.Get(ctx, k8sClient)
+	options, err := GetMergeOptions(ctx, k8sClient, clusterStrategy)
 	if err != nil {
-		ctrl.Log.Info("Could not get Istio features, proceeding with default configuration", "error", err)
-	} else {
-		ctrl.Log.Info("Running with Istio features", "features", features)
-		options = append(options, operatorv1alpha2.WithFeatures(features))
+		return istioImageVersion, describederrors.NewDescribedError(err, "Could not get Istio operator merge options")
 	}
-
-	if enableDualStack {
-		options = append(options, operatorv1alpha2.WithDualStackEnabled())
+	if istioCR.Status.CanaryUpgrade != nil {
+		options = append(options, operatorv1alpha2.WithRevision(istioCR.Status.CanaryUpgrade.TargetRevision))
 	}
 	mergedIstioOperatorPath, err := iopMerger.Merge(clusterSize, istioCR, clusterConfiguration, istioImages, options...)
 	if err != nil {
@@ -119,6 +127,23 @@ func installIstio(ctx context.Context, args installArgs) (istiooperator.IstioIma
 		return istioImageVersion, describederrors.NewDescribedError(err, "could not update managed metadata")
 	}
 
+	// The proxies that were created with a different proxy configuration are restarted by comparing their annotation with these hashes
+	proxyConfigHashes, err := iopMerger.GetProxyConfigHashes()
+	if err != nil {
+		return istioImageVersion, describederrors.NewDescribedError(err, "Could not compute proxy config hashes")
+	}
+	istioCR.Status.ProxyConfigHashes = &proxyConfigHashes
+
+	if istioCR.Status.CanaryUpgrade != nil {
+		if err := reconcileCanaryUpgrade(ctx, k8sClient, istioCR, statusHandler, istioClient, istioImageVersion); err != nil {
+			return istioImageVersion, err
+		}
+		// The Pods of both revisions are running in istio-system until the canary upgrade is finished
+		if istioCR.Status.CanaryUpgrade != nil {
+			return istioImageVersion, nil
+		}
+	}
+
 	err = gatherer.VerifyIstioPodsVersion(ctx, k8sClient, istioImageVersion.Version())
 	if err != nil {
 		return istioImageVersion, describederrors.NewDescribedError(err, "Verifying Pod versions in istio-system namespace failed")
@@ -129,3 +154,37 @@ func installIstio(ctx context.Context, args installArgs) (istiooperator.IstioIma
 
 	return istioImageVersion, nil
 }
+
+// GetMergeOptions returns the options used to merge the Istio CR into the IstioOperator based on the Istio features,
+// the cluster strategy and the installed Istio revision.
+func GetMergeOptions(ctx context.Context, k8sClient client.Client, clusterStrategy factory.Factory) ([]operatorv1alpha2.MergeOption, error) {
+	var options []operatorv1alpha2.MergeOption
+	features, err := istiofeatures.Get(ctx, k8sClient)
+	if err != nil {
+		ctrl.Log.Info("Could not get Istio features, proceeding with default configuration", "error", err)
+	} else {
+		ctrl.Log.Info("Running with Istio features", "features", features)
+		options = append(options, operatorv1alpha2.WithFeatures(features))
+	}
+
+	if clusterStrategy != nil && clusterStrategy.DualStackEnabled() {
+		ctrl.Log.Info("Istio is running with IPDualStack enabled")
+		options = append(options, operatorv1alpha2.WithDualStackEnabled())
+	}
+
+	if clusterStrategy != nil {
+		if lb := clusterStrategy.LB(); lb != nil {
+			options = append(options, operatorv1alpha2.WithLoadBalancerAnnotations(lb.Annotations(), lb.InternalAnnotations()))
+		}
+	}
+
+	// After a canary upgrade the control plane is installed as a revision, which must be kept by later installations
+	revision, err := getInstalledRevision(ctx, k8sClient)
+	if err != nil {
+		return nil, err
+	}
+	if revision != "" {
+		options = append(options, operatorv1alpha2.WithRevision(revision))
+	}
+	return options, nil
+}
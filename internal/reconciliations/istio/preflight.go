@@ -0,0 +1,62 @@
+package istio
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha2 "github.com/kyma-project/istio/operator/api/v1alpha2"
+	"github.com/kyma-project/istio/operator/internal/describederrors"
+	"github.com/kyma-project/istio/operator/internal/istiooperator"
+	"github.com/kyma-project/istio/operator/internal/preflight"
+	"github.com/kyma-project/istio/operator/internal/status"
+)
+
+// checkUpgradePreflight reports the Istio resources created by the user that are likely to break on the new Istio version, if Istio is
+// upgraded. If the Istio CR requires the findings to be acknowledged, the upgrade is blocked until the target version is acknowledged.
+func checkUpgradePreflight(ctx context.Context, k8sClient client.Client, istioCR *operatorv1alpha2.Istio, statusHandler status.Status,
+	lastAppliedIstioTag string, istioImageVersion istiooperator.IstioImageVersion) describederrors.DescribedError {
+	// A started canary upgrade already runs the new Istio version, so it must not be blocked anymore.
+	if lastAppliedIstioTag == "" || istioCR.Status.CanaryUpgrade != nil {
+		return nil
+	}
+	lastAppliedVersion, err := istiooperator.NewIstioImageVersionFromTag(lastAppliedIstioTag)
+	if err != nil {
+		return describederrors.NewDescribedError(err, "Istio install check failed")
+	}
+	if lastAppliedVersion.Version() == istioImageVersion.Version() {
+		return nil
+	}
+
+	targetVersion := istioImageVersion.Version()
+	report, err := preflight.Analyze(ctx, k8sClient, lastAppliedVersion.Version(), targetVersion)
+	if err != nil {
+		return describederrors.NewDescribedError(err, "Could not run the upgrade pre-flight check")
+	}
+	if err := preflight.WriteReport(ctx, k8sClient, istioCR, report); err != nil {
+		return describederrors.NewDescribedError(err, "Could not write the upgrade pre-flight report")
+	}
+
+	if len(report.Findings) == 0 {
+		statusHandler.SetCondition(istioCR, operatorv1alpha2.NewReasonWithMessage(operatorv1alpha2.ConditionReasonUpgradePreflightFindingsNotFound))
+		return nil
+	}
+
+	message := fmt.Sprintf("Upgrade pre-flight check found %d Istio resource(s) that are likely to break on Istio %s. See the %s/%s ConfigMap for details",
+		len(report.Findings), targetVersion, preflight.ConfigMapNamespace, preflight.ConfigMapName)
+	statusHandler.SetCondition(istioCR, operatorv1alpha2.NewReasonWithMessage(operatorv1alpha2.ConditionReasonUpgradePreflightFindingsFound, message))
+	statusHandler.RecordEvent(istioCR, corev1.EventTypeWarning, operatorv1alpha2.ConditionReasonUpgradePreflightFindingsFound, message)
+
+	if istioCR.IsUpgradeBlockedByPreflight(targetVersion) {
+		statusHandler.SetCondition(istioCR, operatorv1alpha2.NewReasonWithMessage(operatorv1alpha2.ConditionReasonUpgradePreflightNotAcknowledged))
+		// We are already updating the condition, that's why we need to avoid another condition update by applying SetCondition(false)
+		return describederrors.NewDescribedError(
+			fmt.Errorf("upgrade to Istio %s is not acknowledged in spec.upgradeStrategy.preflight.acknowledgedVersion", targetVersion),
+			"Istio upgrade is blocked until the upgrade pre-flight findings are acknowledged").
+			SetWarning().
+			SetCondition(false)
+	}
+	return nil
+}
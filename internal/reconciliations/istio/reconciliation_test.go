@@ -33,6 +33,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/scheme"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -138,6 +139,42 @@ var _ = Describe("Installation reconciliation", func() {
 		Expect((*istioCR.Status.Conditions)[0].Status).To(Equal(metav1.ConditionFalse))
 	})
 
+	It("should set the proxy config hashes of the installed IstioOperator in the Istio CR status", func() {
+		// given
+		istioCR := operatorv1alpha2.Istio{ObjectMeta: metav1.ObjectMeta{
+			Name:            "default",
+			ResourceVersion: "1",
+			Annotations:     map[string]string{},
+		},
+			Status: operatorv1alpha2.IstioStatus{
+				State: operatorv1alpha2.Processing,
+				ProxyConfigHashes: &operatorv1alpha2.ProxyConfigHashes{
+					Sidecars: "old",
+				},
+			},
+		}
+
+		istiod := createPod("istiod", gatherer.IstioNamespace, "discovery", istioVersion, "kyma-project.io/module=istio")
+		istioNamespace := createNamespace("istio-system")
+		c := createFakeClient(&istioCR, istiod, istioNamespace)
+		mockClient := mockLibraryClient{}
+		hashes := operatorv1alpha2.ProxyConfigHashes{Sidecars: "sidecars", IngressGateway: "ingress", EgressGateway: "egress"}
+		installation := istio.Installation{
+			Client:      c,
+			IstioClient: &mockClient,
+			Merger:      MergerMock{tag: istioTag, proxyConfigHashes: hashes},
+		}
+		statusHandler := status.NewStatusHandler(c)
+
+		// when
+		_, err := installation.Reconcile(context.Background(), &istioCR, statusHandler, images.Images{Pilot: images.Image{Registry: "docker.io/istio", Name: "pilot", Tag: "1.10"}}, nil)
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(mockClient.installCalled).To(BeTrue())
+		Expect(istioCR.Status.ProxyConfigHashes).To(Equal(&hashes))
+	})
+
 	It("should label and annotate istio-system namespace after Istio installation without overriding existing labels and annotations", func() {
 		// given
 		numTrustedProxies := 1
@@ -304,6 +341,92 @@ var _ = Describe("Installation reconciliation", func() {
 		Expect(istioCR.Status.Conditions).ToNot(BeNil())
 	})
 
+	It("should block the upgrade if the pre-flight check found resources and the findings are not acknowledged", func() {
+		// given
+		istioCR := operatorv1alpha2.Istio{ObjectMeta: metav1.ObjectMeta{
+			Name:            "default",
+			ResourceVersion: "1",
+			Annotations: map[string]string{
+				labels.LastAppliedConfiguration: fmt.Sprintf(`{"config":{},"IstioTag":"%s"}`, istioTag),
+			},
+			Finalizers: []string{"istios.operator.kyma-project.io/istio-installation"},
+		},
+			Spec: operatorv1alpha2.IstioSpec{
+				UpgradeStrategy: &operatorv1alpha2.UpgradeStrategy{
+					Preflight: &operatorv1alpha2.UpgradePreflight{BlockUntilAcknowledged: true, AcknowledgedVersion: "1.16.1"},
+				},
+			},
+		}
+		envoyFilter := &networkingv1alpha3.EnvoyFilter{ObjectMeta: metav1.ObjectMeta{Name: "custom-filter", Namespace: "test-ns"}}
+		c := createFakeClient(&istioCR, envoyFilter)
+		mockClient := mockLibraryClient{}
+		installation := istio.Installation{
+			Client:      c,
+			IstioClient: &mockClient,
+			Merger:      MergerMock{tag: "1.17.0-distroless"},
+		}
+		statusHandler := status.NewStatusHandler(c)
+
+		// when
+		_, err := installation.Reconcile(context.Background(), &istioCR, statusHandler, images.Images{}, nil)
+
+		// then
+		Expect(err).Should(HaveOccurred())
+		Expect(err.Level()).To(Equal(describederrors.Warning))
+		Expect(err.ShouldSetCondition()).To(BeFalse())
+		Expect(err.Description()).To(ContainSubstring("Istio upgrade is blocked until the upgrade pre-flight findings are acknowledged"))
+		Expect(mockClient.installCalled).To(BeFalse())
+
+		readyCondition := meta.FindStatusCondition(*istioCR.Status.Conditions, string(operatorv1alpha2.ConditionTypeReady))
+		Expect(readyCondition.Reason).To(Equal(string(operatorv1alpha2.ConditionReasonUpgradePreflightNotAcknowledged)))
+		findingsCondition := meta.FindStatusCondition(*istioCR.Status.Conditions, string(operatorv1alpha2.ConditionTypeUpgradePreflightFindingsFound))
+		Expect(findingsCondition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(findingsCondition.Message).To(ContainSubstring("found 1 Istio resource(s) that are likely to break on Istio 1.17.0"))
+
+		report := corev1.ConfigMap{}
+		Expect(c.Get(context.Background(), types.NamespacedName{Namespace: "kyma-system", Name: "istio-upgrade-preflight"}, &report)).Should(Succeed())
+		Expect(report.Data["targetVersion"]).To(Equal("1.17.0"))
+		Expect(report.Data["findings"]).To(ContainSubstring("custom-filter"))
+	})
+
+	It("should upgrade if the findings of the pre-flight check are acknowledged for the target version", func() {
+		// given
+		istioCR := operatorv1alpha2.Istio{ObjectMeta: metav1.ObjectMeta{
+			Name:            "default",
+			ResourceVersion: "1",
+			Annotations: map[string]string{
+				labels.LastAppliedConfiguration: fmt.Sprintf(`{"config":{},"IstioTag":"%s"}`, istioTag),
+			},
+		},
+			Spec: operatorv1alpha2.IstioSpec{
+				UpgradeStrategy: &operatorv1alpha2.UpgradeStrategy{
+					Preflight: &operatorv1alpha2.UpgradePreflight{BlockUntilAcknowledged: true, AcknowledgedVersion: "1.17.0"},
+				},
+			},
+		}
+		envoyFilter := &networkingv1alpha3.EnvoyFilter{ObjectMeta: metav1.ObjectMeta{Name: "custom-filter", Namespace: "test-ns"}}
+		istiod := createPod("istiod", gatherer.IstioNamespace, "discovery", "1.17.0", "kyma-project.io/module=istio")
+		istioNamespace := createNamespace("istio-system")
+		igwDeployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "istio-system", Name: "istio-ingressgateway"}}
+		c := createFakeClient(&istioCR, envoyFilter, istiod, istioNamespace, igwDeployment)
+		mockClient := mockLibraryClient{}
+		installation := istio.Installation{
+			Client:      c,
+			IstioClient: &mockClient,
+			Merger:      MergerMock{tag: "1.17.0-distroless"},
+		}
+		statusHandler := status.NewStatusHandler(c)
+
+		// when
+		_, err := installation.Reconcile(context.Background(), &istioCR, statusHandler, images.Images{}, nil)
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(mockClient.installCalled).To(BeTrue())
+		findingsCondition := meta.FindStatusCondition(*istioCR.Status.Conditions, string(operatorv1alpha2.ConditionTypeUpgradePreflightFindingsFound))
+		Expect(findingsCondition.Status).To(Equal(metav1.ConditionTrue))
+	})
+
 	It("should execute install when only Istio image type has changed to debug", func() {
 		// given
 		numTrustedProxies := 1
@@ -896,12 +1019,234 @@ var _ = Describe("Installation reconciliation", func() {
 	})
 })
 
+var _ = Describe("Canary upgrade", func() {
+	const (
+		sourceVersion  = "1.16.0"
+		targetRevision = "1-16-1"
+	)
+
+	canaryIstioCR := func(canaryUpgrade *operatorv1alpha2.CanaryUpgradeStatus) operatorv1alpha2.Istio {
+		return operatorv1alpha2.Istio{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "default",
+				ResourceVersion: "1",
+				Annotations: map[string]string{
+					labels.LastAppliedConfiguration: fmt.Sprintf(`{"config":{},"IstioTag":"%s"}`, sourceVersion),
+				},
+			},
+			Spec: operatorv1alpha2.IstioSpec{
+				UpgradeStrategy: &operatorv1alpha2.UpgradeStrategy{Mode: operatorv1alpha2.UpgradeModeCanary},
+			},
+			Status: operatorv1alpha2.IstioStatus{
+				CanaryUpgrade: canaryUpgrade,
+			},
+		}
+	}
+
+	It("should install the target revision and move the first stage of namespaces to it", func() {
+		// given
+		istioCR := canaryIstioCR(nil)
+		c := createFakeClient(&istioCR,
+			createNamespace("istio-system"),
+			createIstiodDeployment("istiod", "default", sourceVersion),
+			createIstiodDeployment("istiod-"+targetRevision, targetRevision, istioVersion),
+			createInjectedNamespace("ns-b"),
+			createInjectedNamespace("ns-a"),
+		)
+		mockClient := mockLibraryClient{}
+		installation := istio.Installation{
+			Client:      c,
+			IstioClient: &mockClient,
+			Merger:      MergerMock{tag: istioTag},
+		}
+		statusHandler := status.NewStatusHandler(c)
+
+		// when
+		_, err := installation.Reconcile(context.Background(), &istioCR, statusHandler, images.Images{}, nil)
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(mockClient.installCalled).To(BeTrue())
+		Expect(mockClient.defaultRevision).To(BeEmpty())
+
+		Expect(istioCR.Status.CanaryUpgrade).ToNot(BeNil())
+		Expect(istioCR.Status.CanaryUpgrade.SourceRevision).To(Equal("default"))
+		Expect(istioCR.Status.CanaryUpgrade.TargetRevision).To(Equal(targetRevision))
+		Expect(istioCR.Status.CanaryUpgrade.TargetVersion).To(Equal(istioVersion))
+		Expect(istioCR.Status.CanaryUpgrade.MigratedNamespaces).To(ConsistOf("ns-a"))
+
+		ns := corev1.Namespace{}
+		Expect(c.Get(context.Background(), types.NamespacedName{Name: "ns-a"}, &ns)).Should(Succeed())
+		Expect(ns.Labels).To(HaveKeyWithValue("istio.io/rev", targetRevision))
+		Expect(ns.Labels).ToNot(HaveKey("istio-injection"))
+
+		Expect(c.Get(context.Background(), types.NamespacedName{Name: "ns-b"}, &ns)).Should(Succeed())
+		Expect(ns.Labels).To(HaveKeyWithValue("istio-injection", "enabled"))
+
+		Expect((*istioCR.Status.Conditions)[0].Reason).To(Equal(string(operatorv1alpha2.ConditionReasonCanaryUpgradeInProgress)))
+	})
+
+	It("should not move the next stage of namespaces while proxies of migrated namespaces run the source version", func() {
+		// given
+		istioCR := canaryIstioCR(&operatorv1alpha2.CanaryUpgradeStatus{
+			SourceRevision:     "default",
+			TargetRevision:     targetRevision,
+			TargetVersion:      istioVersion,
+			MigratedNamespaces: []string{"ns-a"},
+		})
+		c := createFakeClient(&istioCR,
+			createNamespace("istio-system"),
+			createIstiodDeployment("istiod", "default", sourceVersion),
+			createIstiodDeployment("istiod-"+targetRevision, targetRevision, istioVersion),
+			createNamespace("ns-a"),
+			createInjectedNamespace("ns-b"),
+			createPod("app", "ns-a", "istio-proxy", sourceVersion),
+		)
+		mockClient := mockLibraryClient{}
+		installation := istio.Installation{
+			Client:      c,
+			IstioClient: &mockClient,
+			Merger:      MergerMock{tag: istioTag},
+		}
+		statusHandler := status.NewStatusHandler(c)
+
+		// when
+		_, err := installation.Reconcile(context.Background(), &istioCR, statusHandler, images.Images{}, nil)
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(istioCR.Status.CanaryUpgrade.MigratedNamespaces).To(ConsistOf("ns-a"))
+
+		ns := corev1.Namespace{}
+		Expect(c.Get(context.Background(), types.NamespacedName{Name: "ns-b"}, &ns)).Should(Succeed())
+		Expect(ns.Labels).To(HaveKeyWithValue("istio-injection", "enabled"))
+		Expect((*istioCR.Status.Conditions)[0].Reason).To(Equal(string(operatorv1alpha2.ConditionReasonCanaryUpgradeInProgress)))
+	})
+
+	It("should move the default tag and remove the source revision when all namespaces are migrated", func() {
+		// given
+		istioCR := canaryIstioCR(&operatorv1alpha2.CanaryUpgradeStatus{
+			SourceRevision:     "default",
+			TargetRevision:     targetRevision,
+			TargetVersion:      istioVersion,
+			MigratedNamespaces: []string{"ns-a"},
+		})
+		migratedNamespace := createNamespace("ns-a")
+		migratedNamespace.Labels["istio.io/rev"] = targetRevision
+		c := createFakeClient(&istioCR,
+			createNamespace("istio-system"),
+			createPod("istiod", gatherer.IstioNamespace, "discovery", istioVersion, "kyma-project.io/module=istio"),
+			createIstiodDeployment("istiod", "default", sourceVersion),
+			createIstiodDeployment("istiod-"+targetRevision, targetRevision, istioVersion),
+			migratedNamespace,
+			createPod("app", "ns-a", "istio-proxy", istioVersion),
+		)
+		mockClient := mockLibraryClient{}
+		installation := istio.Installation{
+			Client:      c,
+			IstioClient: &mockClient,
+			Merger:      MergerMock{tag: istioTag},
+		}
+		statusHandler := status.NewStatusHandler(c)
+
+		// when
+		_, err := installation.Reconcile(context.Background(), &istioCR, statusHandler, images.Images{}, nil)
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(mockClient.defaultRevision).To(Equal(targetRevision))
+		Expect(mockClient.uninstalledRevision).To(Equal("default"))
+		Expect(istioCR.Status.CanaryUpgrade).To(BeNil())
+
+		ns := corev1.Namespace{}
+		Expect(c.Get(context.Background(), types.NamespacedName{Name: "ns-a"}, &ns)).Should(Succeed())
+		Expect(ns.Labels).To(HaveKeyWithValue("istio-injection", "enabled"))
+		Expect(ns.Labels).ToNot(HaveKey("istio.io/rev"))
+	})
+
+	It("should not remove the source revision while injected pods outside the migrated namespaces run the source version", func() {
+		// given
+		istioCR := canaryIstioCR(&operatorv1alpha2.CanaryUpgradeStatus{
+			SourceRevision:     "default",
+			TargetRevision:     targetRevision,
+			TargetVersion:      istioVersion,
+			MigratedNamespaces: []string{"ns-a"},
+		})
+		migratedNamespace := createNamespace("ns-a")
+		migratedNamespace.Labels["istio.io/rev"] = targetRevision
+		c := createFakeClient(&istioCR,
+			createNamespace("istio-system"),
+			createPod("istiod", gatherer.IstioNamespace, "discovery", istioVersion, "kyma-project.io/module=istio"),
+			createIstiodDeployment("istiod", "default", sourceVersion),
+			createIstiodDeployment("istiod-"+targetRevision, targetRevision, istioVersion),
+			migratedNamespace,
+			createNamespace("ns-b"),
+			createPod("app", "ns-a", "istio-proxy", istioVersion, "security.istio.io/tlsMode=istio"),
+			createPod("app", "ns-b", "istio-proxy", sourceVersion, "security.istio.io/tlsMode=istio"),
+		)
+		mockClient := mockLibraryClient{}
+		installation := istio.Installation{
+			Client:      c,
+			IstioClient: &mockClient,
+			Merger:      MergerMock{tag: istioTag},
+		}
+		statusHandler := status.NewStatusHandler(c)
+
+		// when
+		_, err := installation.Reconcile(context.Background(), &istioCR, statusHandler, images.Images{}, nil)
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(mockClient.defaultRevision).To(Equal(targetRevision))
+		Expect(mockClient.uninstalledRevision).To(BeEmpty())
+		Expect(istioCR.Status.CanaryUpgrade).ToNot(BeNil())
+		Expect((*istioCR.Status.Conditions)[0].Reason).To(Equal(string(operatorv1alpha2.ConditionReasonCanaryUpgradeInProgress)))
+	})
+
+	It("should fail when the target revision is not installed", func() {
+		// given
+		istioCR := canaryIstioCR(nil)
+		c := createFakeClient(&istioCR,
+			createNamespace("istio-system"),
+			createIstiodDeployment("istiod", "default", sourceVersion),
+			createInjectedNamespace("ns-a"),
+		)
+		mockClient := mockLibraryClient{}
+		installation := istio.Installation{
+			Client:      c,
+			IstioClient: &mockClient,
+			Merger:      MergerMock{tag: istioTag},
+		}
+		statusHandler := status.NewStatusHandler(c)
+
+		// when
+		_, err := installation.Reconcile(context.Background(), &istioCR, statusHandler, images.Images{}, nil)
+
+		// then
+		Expect(err).Should(HaveOccurred())
+		Expect(err.Description()).To(ContainSubstring("Verifying target revision of the canary upgrade failed"))
+		Expect((*istioCR.Status.Conditions)[0].Reason).To(Equal(string(operatorv1alpha2.ConditionReasonCanaryUpgradeFailed)))
+	})
+})
+
 type mockLibraryClient struct {
 	installCalled   bool
 	uninstallCalled bool
 	*istio.Client
-	installError   error
-	uninstallError error
+	installError        error
+	uninstallError      error
+	defaultRevision     string
+	uninstalledRevision string
+}
+
+func (c *mockLibraryClient) SetDefaultRevision(_ context.Context, revision string) error {
+	c.defaultRevision = revision
+	return nil
+}
+
+func (c *mockLibraryClient) UninstallRevision(_ context.Context, revision string) error {
+	c.uninstalledRevision = revision
+	return nil
 }
 
 func (c *mockLibraryClient) Install(_ string) error {
@@ -974,16 +1319,45 @@ func createNamespace(name string) *corev1.Namespace {
 	}
 }
 
+func createInjectedNamespace(name string) *corev1.Namespace {
+	ns := createNamespace(name)
+	ns.Labels["istio-injection"] = "enabled"
+	return ns
+}
+
+func createIstiodDeployment(name, revision, version string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: gatherer.IstioNamespace,
+			Labels: map[string]string{
+				"app":                      "istiod",
+				gatherer.RevisionLabelName: revision,
+				gatherer.VersionLabelName:  version,
+			},
+		},
+	}
+}
+
 type MergerMock struct {
 	mergeError            error
 	getIstioOperatorError error
 	tag                   string
+	proxyConfigHashes     operatorv1alpha2.ProxyConfigHashes
 }
 
 func (m MergerMock) Merge(_ clusterconfig.ClusterSize, _ *operatorv1alpha2.Istio, _ clusterconfig.ClusterConfiguration, _ images.Images, _ ...operatorv1alpha2.MergeOption) (string, error) {
 	return "mocked istio operator merge result", m.mergeError
 }
 
+func (m MergerMock) Render(_ clusterconfig.ClusterSize, _ *operatorv1alpha2.Istio, _ clusterconfig.ClusterConfiguration, _ images.Images, _ ...operatorv1alpha2.MergeOption) ([]byte, error) {
+	return []byte("mocked istio operator render result"), m.mergeError
+}
+
+func (m MergerMock) GetProxyConfigHashes() (operatorv1alpha2.ProxyConfigHashes, error) {
+	return m.proxyConfigHashes, nil
+}
+
 func (m MergerMock) GetIstioOperator(_ clusterconfig.ClusterSize) (iopv1alpha1.IstioOperator, error) {
 	iop := iopv1alpha1.IstioOperator{
 		Spec: iopv1alpha1.IstioOperatorSpec{
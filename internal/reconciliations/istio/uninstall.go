@@ -13,6 +13,7 @@ import (
 	"github.com/kyma-project/istio/operator/internal/status"
 	"github.com/kyma-project/istio/operator/pkg/lib/sidecars/remove"
 
+	corev1 "k8s.io/api/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -47,8 +48,16 @@ func uninstallIstio(ctx context.Context, args uninstallArgs) (istiooperator.Isti
 	if len(clientResources) > 0 {
 		funk.ForEach(clientResources, func(a resources.Resource) {
 			ctrl.Log.Info("Customer resource is blocking Istio deletion", a.GVK.Kind, fmt.Sprintf("%s/%s", a.Namespace, a.Name))
+			statusHandler.RecordObjectEvent(istioCR, &corev1.ObjectReference{
+				Kind:       a.GVK.Kind,
+				APIVersion: a.GVK.GroupVersion().String(),
+				Name:       a.Name,
+				Namespace:  a.Namespace,
+			}, corev1.EventTypeWarning, operatorv1alpha2.ConditionReasonIstioCRsDangling, "Resource is blocking the deletion of the Istio module")
 		})
 		statusHandler.SetCondition(istioCR, operatorv1alpha2.NewReasonWithMessage(operatorv1alpha2.ConditionReasonIstioCRsDangling))
+		statusHandler.RecordEvent(istioCR, corev1.EventTypeWarning, operatorv1alpha2.ConditionReasonIstioCRsDangling,
+			fmt.Sprintf("Deletion of the Istio module is blocked by %d customer resources", len(clientResources)))
 		return istioImageVersion, describederrors.NewDescribedError(fmt.Errorf("could not delete Istio module instance since there are %d customer resources present", len(clientResources)),
 			"There are Istio resources that block deletion. Please take a look at kyma-system/istio-controller-manager logs to see more information about the warning").
 			DisableErrorWrap().
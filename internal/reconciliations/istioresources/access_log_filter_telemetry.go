@@ -0,0 +1,77 @@
+package istioresources
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/kyma-project/istio/operator/api/v1alpha2"
+	"github.com/kyma-project/istio/operator/internal/resources"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/yaml"
+)
+
+//go:embed access_log_filter_telemetry.yaml
+var accessLogFilterTelemetry []byte
+
+// AccessLogFilterTelemetry applies the access log filter configured in the Istio CR to the default access log providers.
+// The mesh config does not support filtering access logs, so the filter is applied by a mesh-wide Telemetry resource.
+type AccessLogFilterTelemetry struct {
+	shouldDelete bool
+	providers    []string
+	filter       string
+}
+
+func NewAccessLogFilterTelemetry(accessLogging *v1alpha2.AccessLogging) AccessLogFilterTelemetry {
+	if accessLogging == nil || accessLogging.Filter == nil || len(accessLogging.DefaultProviders) == 0 {
+		return AccessLogFilterTelemetry{shouldDelete: true}
+	}
+	return AccessLogFilterTelemetry{providers: accessLogging.DefaultProviders, filter: *accessLogging.Filter}
+}
+
+func (t AccessLogFilterTelemetry) reconcile(ctx context.Context, k8sClient client.Client, _ metav1.OwnerReference, _ map[string]string) (controllerutil.OperationResult, error) {
+	if t.shouldDelete {
+		return resources.DeleteIfPresent(ctx, k8sClient, accessLogFilterTelemetry)
+	}
+
+	manifest, err := t.render()
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+	return resources.Apply(ctx, k8sClient, manifest, nil)
+}
+
+func (AccessLogFilterTelemetry) Name() string {
+	return "Telemetry/kyma-access-log-filter"
+}
+
+func (t AccessLogFilterTelemetry) render() ([]byte, error) {
+	var telemetry unstructured.Unstructured
+	err := yaml.Unmarshal(accessLogFilterTelemetry, &telemetry)
+	if err != nil {
+		return nil, err
+	}
+
+	providers := make([]interface{}, 0, len(t.providers))
+	for _, name := range t.providers {
+		providers = append(providers, map[string]interface{}{"name": name})
+	}
+
+	accessLogging := []interface{}{
+		map[string]interface{}{
+			"providers": providers,
+			"filter": map[string]interface{}{
+				"expression": t.filter,
+			},
+		},
+	}
+	err = unstructured.SetNestedSlice(telemetry.Object, accessLogging, "spec", "accessLogging")
+	if err != nil {
+		return nil, err
+	}
+
+	return yaml.Marshal(telemetry.Object)
+}
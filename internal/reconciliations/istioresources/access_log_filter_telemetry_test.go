@@ -0,0 +1,84 @@
+package istioresources
+
+import (
+	"context"
+
+	"github.com/kyma-project/istio/operator/api/v1alpha2"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+var _ = Describe("AccessLogFilterTelemetry", func() {
+	owner := metav1.OwnerReference{}
+	filter := "response.code >= 500"
+
+	getTelemetry := func(c client.Client) (*unstructured.Unstructured, error) {
+		var telemetry unstructured.Unstructured
+		telemetry.SetGroupVersionKind(schema.GroupVersionKind{Group: "telemetry.istio.io", Version: "v1", Kind: "Telemetry"})
+		err := c.Get(context.Background(), types.NamespacedName{Name: "kyma-access-log-filter", Namespace: "istio-system"}, &telemetry)
+		return &telemetry, err
+	}
+
+	It("should create the Telemetry with the default providers and the filter", func() {
+		c := createFakeClient()
+		sample := NewAccessLogFilterTelemetry(&v1alpha2.AccessLogging{
+			DefaultProviders: []string{"stdout-json", "tenant-json"},
+			Filter:           &filter,
+		})
+
+		changed, err := sample.reconcile(context.Background(), c, owner, nil)
+
+		Expect(err).To(Not(HaveOccurred()))
+		Expect(changed).To(Equal(controllerutil.OperationResultCreated))
+		telemetry, err := getTelemetry(c)
+		Expect(err).To(Not(HaveOccurred()))
+		Expect(telemetry.GetLabels()).To(HaveKeyWithValue("kyma-project.io/module", "istio"))
+
+		accessLogging, found, err := unstructured.NestedSlice(telemetry.Object, "spec", "accessLogging")
+		Expect(err).To(Not(HaveOccurred()))
+		Expect(found).To(BeTrue())
+		Expect(accessLogging).To(HaveLen(1))
+		Expect(accessLogging[0]).To(HaveKeyWithValue("providers", ConsistOf(
+			HaveKeyWithValue("name", "stdout-json"),
+			HaveKeyWithValue("name", "tenant-json"),
+		)))
+		Expect(accessLogging[0]).To(HaveKeyWithValue("filter", HaveKeyWithValue("expression", filter)))
+	})
+
+	It("should delete the Telemetry when the filter is removed", func() {
+		c := createFakeClient()
+		_, err := NewAccessLogFilterTelemetry(&v1alpha2.AccessLogging{
+			DefaultProviders: []string{"stdout-json"},
+			Filter:           &filter,
+		}).reconcile(context.Background(), c, owner, nil)
+		Expect(err).To(Not(HaveOccurred()))
+
+		changed, err := NewAccessLogFilterTelemetry(&v1alpha2.AccessLogging{
+			DefaultProviders: []string{"stdout-json"},
+		}).reconcile(context.Background(), c, owner, nil)
+
+		Expect(err).To(Not(HaveOccurred()))
+		Expect(changed).To(Equal(controllerutil.OperationResultUpdated))
+		_, err = getTelemetry(c)
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should do nothing when access logging is not configured", func() {
+		c := createFakeClient()
+
+		changed, err := NewAccessLogFilterTelemetry(nil).reconcile(context.Background(), c, owner, nil)
+
+		Expect(err).To(Not(HaveOccurred()))
+		Expect(changed).To(Equal(controllerutil.OperationResultNone))
+		_, err = getTelemetry(c)
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+	})
+})
@@ -3,29 +3,165 @@ package istioresources
 import (
 	"context"
 	_ "embed"
+	"fmt"
+	"slices"
+	"strings"
 
+	"github.com/kyma-project/istio/operator/api/v1alpha2"
 	"github.com/kyma-project/istio/operator/internal/resources"
+	"github.com/kyma-project/istio/operator/pkg/labels"
 
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/yaml"
 )
 
 //go:embed peer_authentication_mtls.yaml
 var paMtls []byte
 
+// NamespaceMtlsPeerAuthenticationName is the name of the PeerAuthentication the module creates in namespaces with an mTLS mode
+// different from the mesh-wide one. It differs from "default" to not overwrite namespace-wide PeerAuthentications created by users.
+const NamespaceMtlsPeerAuthenticationName = "kyma-mtls"
+
 type PeerAuthenticationMtls struct {
 	shouldDelete bool
+	mode         v1alpha2.MtlsMode
+	namespaces   []v1alpha2.MtlsNamespace
 }
 
-func NewPeerAuthenticationMtls(shouldDelete bool) PeerAuthenticationMtls {
-	return PeerAuthenticationMtls{shouldDelete: shouldDelete}
+func NewPeerAuthenticationMtls(shouldDelete bool, mode v1alpha2.MtlsMode, namespaces []v1alpha2.MtlsNamespace) PeerAuthenticationMtls {
+	if mode == "" {
+		mode = v1alpha2.MtlsModeStrict
+	}
+	return PeerAuthenticationMtls{shouldDelete: shouldDelete, mode: mode, namespaces: namespaces}
 }
 
-func (PeerAuthenticationMtls) reconcile(ctx context.Context, k8sClient client.Client, _ metav1.OwnerReference, _ map[string]string) (controllerutil.OperationResult, error) {
-	return resources.Apply(ctx, k8sClient, paMtls, nil)
+func (p PeerAuthenticationMtls) reconcile(ctx context.Context, k8sClient client.Client, _ metav1.OwnerReference, _ map[string]string) (controllerutil.OperationResult, error) {
+	manifest, err := renderPeerAuthentication(paMtls, "", p.mode)
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	endResult, err := resources.Apply(ctx, k8sClient, manifest, nil)
+	if err != nil {
+		return endResult, err
+	}
+
+	for _, ns := range p.namespaces {
+		var namespace corev1.Namespace
+		getErr := k8sClient.Get(ctx, client.ObjectKey{Name: ns.Name}, &namespace)
+		if getErr != nil {
+			if !k8serrors.IsNotFound(getErr) {
+				return controllerutil.OperationResultNone, getErr
+			}
+			ctrl.Log.Info("Skipping mTLS configuration of namespace that does not exist", "namespace", ns.Name)
+			continue
+		}
+
+		nsManifest, renderErr := renderPeerAuthentication(paMtls, ns.Name, ns.Mode)
+		if renderErr != nil {
+			return controllerutil.OperationResultNone, renderErr
+		}
+
+		result, applyErr := resources.Apply(ctx, k8sClient, nsManifest, nil)
+		if applyErr != nil {
+			return controllerutil.OperationResultNone, applyErr
+		}
+		if result != controllerutil.OperationResultNone {
+			endResult = result
+		}
+	}
+
+	deleted, err := p.deleteObsoleteNamespacePeerAuthentications(ctx, k8sClient)
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+	if deleted {
+		endResult = controllerutil.OperationResultUpdated
+	}
+
+	return endResult, nil
 }
 
 func (PeerAuthenticationMtls) Name() string {
 	return "PeerAuthentication/default"
 }
+
+// deleteObsoleteNamespacePeerAuthentications deletes the PeerAuthentications managed by the module in namespaces that were removed from the Istio CR.
+func (p PeerAuthenticationMtls) deleteObsoleteNamespacePeerAuthentications(ctx context.Context, k8sClient client.Client) (bool, error) {
+	var paList unstructured.UnstructuredList
+	paList.SetGroupVersionKind(schema.GroupVersionKind{Group: "security.istio.io", Version: "v1", Kind: "PeerAuthenticationList"})
+	err := k8sClient.List(ctx, &paList, client.MatchingLabels{labels.ModuleLabelKey: labels.ModuleLabelValue})
+	if err != nil {
+		return false, err
+	}
+
+	deleted := false
+	for _, pa := range paList.Items {
+		if pa.GetName() != NamespaceMtlsPeerAuthenticationName || p.isConfiguredNamespace(pa.GetNamespace()) {
+			continue
+		}
+
+		err = k8sClient.Delete(ctx, &pa)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return false, err
+		}
+		deleted = true
+	}
+
+	return deleted, nil
+}
+
+func (p PeerAuthenticationMtls) isConfiguredNamespace(namespace string) bool {
+	return slices.ContainsFunc(p.namespaces, func(ns v1alpha2.MtlsNamespace) bool {
+		return ns.Name == namespace
+	})
+}
+
+// renderPeerAuthentication returns the PeerAuthentication manifest with the given mTLS mode. If a namespace is given, the manifest
+// is rendered as the module-managed namespace-wide PeerAuthentication of this namespace.
+func renderPeerAuthentication(manifest []byte, namespace string, mode v1alpha2.MtlsMode) ([]byte, error) {
+	var pa unstructured.Unstructured
+	err := yaml.Unmarshal(manifest, &pa)
+	if err != nil {
+		return nil, err
+	}
+
+	if namespace != "" {
+		pa.SetName(NamespaceMtlsPeerAuthenticationName)
+		pa.SetNamespace(namespace)
+	}
+
+	err = unstructured.SetNestedField(pa.Object, string(mode), "spec", "mtls", "mode")
+	if err != nil {
+		return nil, err
+	}
+
+	return yaml.Marshal(pa.Object)
+}
+
+// MtlsReason returns the condition reason describing the mTLS mode configured in the Istio CR.
+func MtlsReason(istioCR *v1alpha2.Istio) v1alpha2.ReasonWithMessage {
+	if istioCR.GetMtlsMode() == v1alpha2.MtlsModePermissive {
+		return v1alpha2.NewReasonWithMessage(v1alpha2.ConditionReasonPermissiveMtlsConfigured, "PERMISSIVE mTLS mode is configured for the whole service mesh")
+	}
+
+	var permissiveNamespaces []string
+	for _, ns := range istioCR.GetMtlsNamespaces() {
+		if ns.Mode == v1alpha2.MtlsModePermissive {
+			permissiveNamespaces = append(permissiveNamespaces, ns.Name)
+		}
+	}
+	if len(permissiveNamespaces) > 0 {
+		return v1alpha2.NewReasonWithMessage(v1alpha2.ConditionReasonPermissiveMtlsConfigured,
+			fmt.Sprintf("PERMISSIVE mTLS mode is configured for namespaces: %s", strings.Join(permissiveNamespaces, ", ")))
+	}
+
+	return v1alpha2.NewReasonWithMessage(v1alpha2.ConditionReasonStrictMtlsEnforced)
+}
@@ -3,12 +3,17 @@ package istioresources
 import (
 	"context"
 
+	"github.com/kyma-project/istio/operator/api/v1alpha2"
 	"github.com/kyma-project/istio/operator/internal/resources"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	securityv1 "istio.io/client-go/pkg/apis/security/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/yaml"
 )
@@ -24,7 +29,7 @@ var _ = Describe("Apply", func() {
 
 	It("should return created if no resource was present", func() {
 		client := createFakeClient()
-		sample := NewPeerAuthenticationMtls(true)
+		sample := NewPeerAuthenticationMtls(true, v1alpha2.MtlsModeStrict, nil)
 
 		//when
 		changed, err := sample.reconcile(context.Background(), client, owner, templateValues)
@@ -53,7 +58,7 @@ var _ = Describe("Apply", func() {
 
 	It("should return not changed if no change was applied", func() {
 		client := createFakeClient()
-		sample := NewPeerAuthenticationMtls(true)
+		sample := NewPeerAuthenticationMtls(true, v1alpha2.MtlsModeStrict, nil)
 
 		//when
 		changed, err := sample.reconcile(context.Background(), client, owner, templateValues)
@@ -71,7 +76,7 @@ var _ = Describe("Apply", func() {
 
 		// then
 		// we check in the second reconciliation that nothing changed
-		sample = NewPeerAuthenticationMtls(true)
+		sample = NewPeerAuthenticationMtls(true, v1alpha2.MtlsModeStrict, nil)
 		changed, err = sample.reconcile(context.Background(), client, owner, templateValues)
 		Expect(err).To(Not(HaveOccurred()))
 		Expect(changed).To(Equal(controllerutil.OperationResultNone))
@@ -91,7 +96,7 @@ var _ = Describe("Apply", func() {
 		p.Spec.Mtls.Mode = 0
 		client := createFakeClient(&p)
 
-		sample := NewPeerAuthenticationMtls(true)
+		sample := NewPeerAuthenticationMtls(true, v1alpha2.MtlsModeStrict, nil)
 
 		//when
 		changed, err := sample.reconcile(context.Background(), client, owner, templateValues)
@@ -109,3 +114,126 @@ var _ = Describe("Apply", func() {
 		Expect(s.Items[0].Annotations[resources.DisclaimerKey]).To(Not(BeNil()))
 	})
 })
+
+var _ = Describe("mTLS mode", func() {
+	owner := metav1.OwnerReference{}
+
+	getPeerAuthentication := func(c client.Client, name, namespace string) (*securityv1.PeerAuthentication, error) {
+		var pa securityv1.PeerAuthentication
+		err := c.Get(context.Background(), types.NamespacedName{Name: name, Namespace: namespace}, &pa)
+		return &pa, err
+	}
+
+	It("should apply the configured mesh-wide mode", func() {
+		c := createFakeClient()
+		sample := NewPeerAuthenticationMtls(false, v1alpha2.MtlsModePermissive, nil)
+
+		_, err := sample.reconcile(context.Background(), c, owner, nil)
+
+		Expect(err).To(Not(HaveOccurred()))
+		pa, err := getPeerAuthentication(c, "default", "istio-system")
+		Expect(err).To(Not(HaveOccurred()))
+		Expect(pa.Spec.Mtls.Mode.String()).To(Equal("PERMISSIVE"))
+	})
+
+	It("should default to STRICT mode when no mode is given", func() {
+		c := createFakeClient()
+		sample := NewPeerAuthenticationMtls(false, "", nil)
+
+		_, err := sample.reconcile(context.Background(), c, owner, nil)
+
+		Expect(err).To(Not(HaveOccurred()))
+		pa, err := getPeerAuthentication(c, "default", "istio-system")
+		Expect(err).To(Not(HaveOccurred()))
+		Expect(pa.Spec.Mtls.Mode.String()).To(Equal("STRICT"))
+	})
+
+	It("should create a module-managed PeerAuthentication for each configured namespace", func() {
+		c := createFakeClient(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "legacy"}})
+		sample := NewPeerAuthenticationMtls(false, v1alpha2.MtlsModeStrict, []v1alpha2.MtlsNamespace{
+			{Name: "legacy", Mode: v1alpha2.MtlsModePermissive},
+		})
+
+		changed, err := sample.reconcile(context.Background(), c, owner, nil)
+
+		Expect(err).To(Not(HaveOccurred()))
+		Expect(changed).To(Equal(controllerutil.OperationResultCreated))
+		pa, err := getPeerAuthentication(c, NamespaceMtlsPeerAuthenticationName, "legacy")
+		Expect(err).To(Not(HaveOccurred()))
+		Expect(pa.Spec.Mtls.Mode.String()).To(Equal("PERMISSIVE"))
+		Expect(pa.GetLabels()).To(HaveKeyWithValue("kyma-project.io/module", "istio"))
+		Expect(pa.Annotations[resources.DisclaimerKey]).To(Not(BeEmpty()))
+	})
+
+	It("should skip namespaces that do not exist", func() {
+		c := createFakeClient()
+		sample := NewPeerAuthenticationMtls(false, v1alpha2.MtlsModeStrict, []v1alpha2.MtlsNamespace{
+			{Name: "not-existing", Mode: v1alpha2.MtlsModePermissive},
+		})
+
+		_, err := sample.reconcile(context.Background(), c, owner, nil)
+
+		Expect(err).To(Not(HaveOccurred()))
+		_, err = getPeerAuthentication(c, NamespaceMtlsPeerAuthenticationName, "not-existing")
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should delete the module-managed PeerAuthentication of a namespace removed from the configuration", func() {
+		c := createFakeClient(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "legacy"}})
+		_, err := NewPeerAuthenticationMtls(false, v1alpha2.MtlsModeStrict, []v1alpha2.MtlsNamespace{
+			{Name: "legacy", Mode: v1alpha2.MtlsModePermissive},
+		}).reconcile(context.Background(), c, owner, nil)
+		Expect(err).To(Not(HaveOccurred()))
+
+		changed, err := NewPeerAuthenticationMtls(false, v1alpha2.MtlsModeStrict, nil).reconcile(context.Background(), c, owner, nil)
+
+		Expect(err).To(Not(HaveOccurred()))
+		Expect(changed).To(Equal(controllerutil.OperationResultUpdated))
+		_, err = getPeerAuthentication(c, NamespaceMtlsPeerAuthenticationName, "legacy")
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		_, err = getPeerAuthentication(c, "default", "istio-system")
+		Expect(err).To(Not(HaveOccurred()))
+	})
+
+	It("should not delete PeerAuthentications created by users", func() {
+		userPa := &securityv1.PeerAuthentication{ObjectMeta: metav1.ObjectMeta{Name: NamespaceMtlsPeerAuthenticationName, Namespace: "legacy"}}
+		c := createFakeClient(userPa)
+
+		_, err := NewPeerAuthenticationMtls(false, v1alpha2.MtlsModeStrict, nil).reconcile(context.Background(), c, owner, nil)
+
+		Expect(err).To(Not(HaveOccurred()))
+		_, err = getPeerAuthentication(c, NamespaceMtlsPeerAuthenticationName, "legacy")
+		Expect(err).To(Not(HaveOccurred()))
+	})
+})
+
+var _ = Describe("MtlsReason", func() {
+	It("should return StrictMtlsEnforced when mTLS is not configured", func() {
+		istioCR := &v1alpha2.Istio{}
+
+		Expect(MtlsReason(istioCR).Reason).To(Equal(v1alpha2.ConditionReasonStrictMtlsEnforced))
+	})
+
+	It("should return PermissiveMtlsConfigured when the mesh-wide mode is PERMISSIVE", func() {
+		istioCR := &v1alpha2.Istio{Spec: v1alpha2.IstioSpec{Config: v1alpha2.Config{
+			Mtls: &v1alpha2.Mtls{Mode: v1alpha2.MtlsModePermissive},
+		}}}
+
+		Expect(MtlsReason(istioCR).Reason).To(Equal(v1alpha2.ConditionReasonPermissiveMtlsConfigured))
+	})
+
+	It("should list the namespaces in PERMISSIVE mode", func() {
+		istioCR := &v1alpha2.Istio{Spec: v1alpha2.IstioSpec{Config: v1alpha2.Config{
+			Mtls: &v1alpha2.Mtls{Mode: v1alpha2.MtlsModeStrict, Namespaces: []v1alpha2.MtlsNamespace{
+				{Name: "legacy-a", Mode: v1alpha2.MtlsModePermissive},
+				{Name: "strict", Mode: v1alpha2.MtlsModeStrict},
+				{Name: "legacy-b", Mode: v1alpha2.MtlsModePermissive},
+			}},
+		}}}
+
+		reason := MtlsReason(istioCR)
+
+		Expect(reason.Reason).To(Equal(v1alpha2.ConditionReasonPermissiveMtlsConfigured))
+		Expect(reason.Message).To(Equal("PERMISSIVE mTLS mode is configured for namespaces: legacy-a, legacy-b"))
+	})
+})
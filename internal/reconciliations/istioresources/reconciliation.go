@@ -83,7 +83,8 @@ func getResources(clusterStrategy factory.Factory, istioCR v1alpha2.Istio, featu
 		}
 	}
 	istioResources := []Resource{
-		NewPeerAuthenticationMtls(false),
+		NewPeerAuthenticationMtls(false, istioCR.GetMtlsMode(), istioCR.GetMtlsNamespaces()),
+		NewAccessLogFilterTelemetry(istioCR.Spec.Config.AccessLogging),
 		NewNetworkPolicies(!istioCR.Spec.NetworkPoliciesEnabled),
 		NewVPA(false),
 		NewControlPlaneVPA(!features.EnableControlPlaneVPA),
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"slices"
 
 	_ "embed"
 
@@ -28,6 +29,10 @@ type ResourceMeta struct {
 type Resource struct {
 	ResourceMeta
 	GVK schema.GroupVersionKind
+	// AppliedAPIVersions contains the API versions with which the resource was created or updated, based on its managed fields.
+	AppliedAPIVersions []string
+	// Spec contains the spec of the resource.
+	Spec map[string]interface{}
 }
 
 type ResourceConfiguration struct {
@@ -100,6 +105,10 @@ func (i *IstioResourcesFinder) FindUserCreatedIstioResources() ([]Resource, erro
 					Name:      item.GetName(),
 					Namespace: item.GetNamespace(),
 				},
+				AppliedAPIVersions: appliedAPIVersions(item),
+			}
+			if spec, found, _ := unstructured.NestedMap(item.Object, "spec"); found {
+				res.Spec = spec
 			}
 			managed, resourceErr := contains(resource.ControlledList, res.ResourceMeta)
 			if resourceErr != nil {
@@ -112,6 +121,17 @@ func (i *IstioResourcesFinder) FindUserCreatedIstioResources() ([]Resource, erro
 	}
 	return userResources, nil
 }
+
+func appliedAPIVersions(item unstructured.Unstructured) []string {
+	var apiVersions []string
+	for _, managedField := range item.GetManagedFields() {
+		if managedField.APIVersion != "" && !slices.Contains(apiVersions, managedField.APIVersion) {
+			apiVersions = append(apiVersions, managedField.APIVersion)
+		}
+	}
+	return apiVersions
+}
+
 func contains(s []ResourceMeta, e ResourceMeta) (bool, error) {
 	for _, r := range s {
 		matchName, err := regexp.MatchString(r.Name, e.Name)
@@ -9,6 +9,7 @@ import (
 	. "github.com/onsi/gomega"
 	networkingv1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -103,6 +104,19 @@ var _ = Describe("Resources", func() {
 		))
 })
 
+var _ = Describe("appliedAPIVersions", func() {
+	It("should return the distinct API versions of the managed fields", func() {
+		item := unstructured.Unstructured{}
+		item.SetManagedFields([]metav1.ManagedFieldsEntry{
+			{Manager: "kubectl", APIVersion: "networking.istio.io/v1beta1"},
+			{Manager: "kubectl-edit", APIVersion: "networking.istio.io/v1beta1"},
+			{Manager: "helm", APIVersion: "networking.istio.io/v1"},
+		})
+
+		Expect(appliedAPIVersions(item)).To(Equal([]string{"networking.istio.io/v1beta1", "networking.istio.io/v1"}))
+	})
+})
+
 var _ = Describe("IstioResourcesFinder", func() {
 	It("should succeed when reading controlled resources list configuration", func() {
 		_, err := NewIstioResourcesFinder(context.Background(), nil, logr.Logger{})
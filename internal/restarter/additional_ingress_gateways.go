@@ -0,0 +1,63 @@
+package restarter
+
+import (
+	"context"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kyma-project/istio/operator/api/v1alpha2"
+	"github.com/kyma-project/istio/operator/internal/describederrors"
+	"github.com/kyma-project/istio/operator/internal/restarter/predicates"
+	"github.com/kyma-project/istio/operator/internal/status"
+)
+
+// AdditionalIngressGatewaysRestarter restarts the additional Istio Ingress Gateways configured in the Istio CR
+// when one of the restart predicates of the default Istio Ingress Gateway requires it. Like the default Istio Ingress Gateway,
+// they are rolled out with the installation when their proxy config hash changes.
+type AdditionalIngressGatewaysRestarter struct {
+	client        client.Client
+	predicates    []predicates.IngressGatewayPredicate
+	statusHandler status.Status
+}
+
+func NewAdditionalIngressGatewaysRestarter(client client.Client, predicates []predicates.IngressGatewayPredicate, statusHandler status.Status) *AdditionalIngressGatewaysRestarter {
+	return &AdditionalIngressGatewaysRestarter{
+		client:        client,
+		predicates:    predicates,
+		statusHandler: statusHandler,
+	}
+}
+
+func (r *AdditionalIngressGatewaysRestarter) Restart(ctx context.Context, istioCR *v1alpha2.Istio) describederrors.DescribedError {
+	gateways := istioCR.GetAdditionalIngressGateways()
+	if len(gateways) == 0 {
+		return nil
+	}
+
+	ctrl.Log.Info("Restarting additional Istio Ingress Gateways")
+
+	for _, predicate := range r.predicates {
+		evaluator, err := predicate.NewIngressGatewayEvaluator(ctx)
+		if err != nil {
+			return describederrors.NewDescribedError(err, "Could not create additional Ingress Gateways restart evaluator")
+		}
+
+		if !evaluator.RequiresIngressGatewayRestart() {
+			continue
+		}
+
+		for _, gateway := range gateways {
+			err = restartGatewayDeployment(ctx, r.client, gateway.Name)
+			if err != nil {
+				r.statusHandler.SetCondition(istioCR, v1alpha2.NewReasonWithMessage(v1alpha2.ConditionReasonIngressGatewayRestartFailed))
+				return describederrors.NewDescribedError(err, "Failed to restart additional Ingress Gateway "+gateway.Name)
+			}
+		}
+		// All gateways were restarted, so the remaining predicates don't need to be evaluated
+		break
+	}
+
+	ctrl.Log.Info("Successfully restarted additional Istio Ingress Gateways")
+	return nil
+}
@@ -0,0 +1,108 @@
+package restarter_test
+
+import (
+	"context"
+	"time"
+
+	operatorv1alpha2 "github.com/kyma-project/istio/operator/api/v1alpha2"
+	"github.com/kyma-project/istio/operator/internal/restarter"
+	"github.com/kyma-project/istio/operator/internal/restarter/predicates"
+	"github.com/kyma-project/istio/operator/internal/status"
+	"github.com/kyma-project/istio/operator/pkg/lib/annotations"
+	"github.com/kyma-project/istio/operator/pkg/lib/gatherer"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Additional Istio Ingress Gateways restart", func() {
+	newIstioCR := func(gatewayNames ...string) *operatorv1alpha2.Istio {
+		istioCR := &operatorv1alpha2.Istio{ObjectMeta: metav1.ObjectMeta{
+			Name:            "default",
+			ResourceVersion: "1",
+			Annotations:     map[string]string{},
+		},
+			Spec: operatorv1alpha2.IstioSpec{
+				Components: &operatorv1alpha2.Components{},
+			},
+		}
+		for _, name := range gatewayNames {
+			istioCR.Spec.Components.AdditionalIngressGateways = append(istioCR.Spec.Components.AdditionalIngressGateways, operatorv1alpha2.AdditionalIngressGateway{Name: name})
+		}
+		return istioCR
+	}
+
+	It("should restart all additional ingress gateways when predicate requires restart", func() {
+		// given
+		istioCR := newIstioCR("public-ingressgateway", "internal-ingressgateway")
+		publicDep := createGatewayDep("public-ingressgateway", time.Now().Add(-time.Hour))
+		internalDep := createGatewayDep("internal-ingressgateway", time.Now().Add(-time.Hour))
+		fakeClient := createFakeClient(istioCR, publicDep, internalDep)
+		gatewaysRestarter := restarter.NewAdditionalIngressGatewaysRestarter(fakeClient, []predicates.IngressGatewayPredicate{mockIgPredicate{shouldRestart: true}}, status.NewStatusHandler(fakeClient))
+
+		// when
+		err := gatewaysRestarter.Restart(context.Background(), istioCR)
+
+		// then
+		Expect(err).Should(Not(HaveOccurred()))
+		for _, name := range []string{"public-ingressgateway", "internal-ingressgateway"} {
+			dep := appsv1.Deployment{}
+			Expect(fakeClient.Get(context.Background(), client.ObjectKey{Namespace: gatherer.IstioNamespace, Name: name}, &dep)).Should(Succeed())
+			Expect(annotations.HasRestartAnnotation(dep.Spec.Template.Annotations)).To(BeTrue())
+		}
+	})
+
+	It("should not restart additional ingress gateways when predicate does not require it", func() {
+		// given
+		istioCR := newIstioCR("public-ingressgateway")
+		publicDep := createGatewayDep("public-ingressgateway", time.Now().Add(-time.Hour))
+		fakeClient := createFakeClient(istioCR, publicDep)
+		gatewaysRestarter := restarter.NewAdditionalIngressGatewaysRestarter(fakeClient, []predicates.IngressGatewayPredicate{mockIgPredicate{shouldRestart: false}}, status.NewStatusHandler(fakeClient))
+
+		// when
+		err := gatewaysRestarter.Restart(context.Background(), istioCR)
+
+		// then
+		Expect(err).Should(Not(HaveOccurred()))
+		dep := appsv1.Deployment{}
+		Expect(fakeClient.Get(context.Background(), client.ObjectKey{Namespace: gatherer.IstioNamespace, Name: "public-ingressgateway"}, &dep)).Should(Succeed())
+		Expect(annotations.HasRestartAnnotation(dep.Spec.Template.Annotations)).To(BeFalse())
+	})
+
+	It("should not evaluate predicates when no additional ingress gateways are configured", func() {
+		// given
+		istioCR := newIstioCR()
+		fakeClient := createFakeClient(istioCR)
+		restartCounter := &countingPredicate{shouldRestart: true}
+		gatewaysRestarter := restarter.NewAdditionalIngressGatewaysRestarter(fakeClient, []predicates.IngressGatewayPredicate{restartCounter}, status.NewStatusHandler(fakeClient))
+
+		// when
+		err := gatewaysRestarter.Restart(context.Background(), istioCR)
+
+		// then
+		Expect(err).Should(Not(HaveOccurred()))
+		Expect(restartCounter.count).To(Equal(0))
+	})
+
+	It("should not fail when the Deployment of an additional ingress gateway is not created yet", func() {
+		// given
+		istioCR := newIstioCR("public-ingressgateway")
+		fakeClient := createFakeClient(istioCR)
+		gatewaysRestarter := restarter.NewAdditionalIngressGatewaysRestarter(fakeClient, []predicates.IngressGatewayPredicate{mockIgPredicate{shouldRestart: true}}, status.NewStatusHandler(fakeClient))
+
+		// when
+		err := gatewaysRestarter.Restart(context.Background(), istioCR)
+
+		// then
+		Expect(err).Should(Not(HaveOccurred()))
+	})
+})
+
+func createGatewayDep(name string, creationTimestamp time.Time) *appsv1.Deployment {
+	dep := createIngressGatewayDep(creationTimestamp)
+	dep.Name = name
+	dep.Spec.Template.Labels = map[string]string{"app": name}
+	return dep
+}
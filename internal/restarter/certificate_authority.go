@@ -0,0 +1,275 @@
+package restarter
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kyma-project/istio/operator/api/v1alpha2"
+	"github.com/kyma-project/istio/operator/internal/describederrors"
+	"github.com/kyma-project/istio/operator/internal/status"
+	"github.com/kyma-project/istio/operator/pkg/labels"
+	"github.com/kyma-project/istio/operator/pkg/lib/annotations"
+	"github.com/kyma-project/istio/operator/pkg/lib/sidecars/retry"
+)
+
+const (
+	certificateAuthoritySecretNamespace = "istio-system"
+	certificateAuthoritySecretName      = "cacerts"
+
+	caCertKey    = "ca-cert.pem"
+	caKeyKey     = "ca-key.pem"
+	rootCertKey  = "root-cert.pem"
+	certChainKey = "cert-chain.pem"
+)
+
+// CertificateAuthorityRestarter puts the custom certificate authority configured in the Istio CR in place as the istio-system/cacerts Secret
+// and restarts istiod when the certificate authority changes. The fingerprint of the certificate authority is included in the proxy config hashes,
+// so the proxies are restarted after the next installation of Istio applied the changed fingerprint.
+type CertificateAuthorityRestarter struct {
+	client        client.Client
+	statusHandler status.Status
+}
+
+func NewCertificateAuthorityRestarter(client client.Client, statusHandler status.Status) *CertificateAuthorityRestarter {
+	return &CertificateAuthorityRestarter{
+		client:        client,
+		statusHandler: statusHandler,
+	}
+}
+
+func (r *CertificateAuthorityRestarter) Restart(ctx context.Context, istioCR *v1alpha2.Istio) describederrors.DescribedError {
+	var caStatus *v1alpha2.CertificateAuthorityStatus
+	var changed bool
+	var err describederrors.DescribedError
+	if istioCR.Spec.Config.CertificateAuthority == nil {
+		changed, err = r.removeManagedSecret(ctx)
+	} else {
+		caStatus, changed, err = r.applySecret(ctx, istioCR.Spec.Config.CertificateAuthority.SecretRef)
+	}
+	if err != nil {
+		r.statusHandler.SetCondition(istioCR, v1alpha2.NewReasonWithMessage(v1alpha2.ConditionReasonCertificateAuthorityFailed, err.Description()))
+		return err
+	}
+
+	if changed {
+		if restartErr := restartIstiodRevisions(ctx, r.client); restartErr != nil {
+			r.statusHandler.SetCondition(istioCR, v1alpha2.NewReasonWithMessage(v1alpha2.ConditionReasonCertificateAuthorityFailed))
+			return describederrors.NewDescribedError(restartErr, "Failed to restart istiod after the certificate authority update")
+		}
+		r.statusHandler.SetCondition(istioCR, v1alpha2.NewReasonWithMessage(v1alpha2.ConditionReasonCertificateAuthorityUpdated))
+	}
+
+	if !equality.Semantic.DeepEqual(istioCR.Status.CertificateAuthority, caStatus) {
+		istioCR.Status.CertificateAuthority = caStatus
+		// The lastAppliedConfiguration is updated from the stored Istio CR after the restart of the proxies, so the fingerprint must be stored first.
+		if updateErr := r.statusHandler.UpdateToProcessing(ctx, istioCR); updateErr != nil {
+			return describederrors.NewDescribedError(updateErr, "Failed to update the certificate authority status")
+		}
+	}
+
+	return nil
+}
+
+// applySecret validates the referenced Secret and copies it to the istio-system/cacerts Secret. It returns whether the istio-system/cacerts Secret changed.
+func (r *CertificateAuthorityRestarter) applySecret(ctx context.Context, ref v1alpha2.SecretReference) (*v1alpha2.CertificateAuthorityStatus, bool, describederrors.DescribedError) {
+	source := corev1.Secret{}
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, &source)
+	if err != nil {
+		return nil, false, describederrors.NewDescribedError(err, fmt.Sprintf("Could not get the certificate authority Secret %s/%s", ref.Namespace, ref.Name))
+	}
+
+	caStatus, err := parseCertificateAuthority(source.Data, time.Now())
+	if err != nil {
+		return nil, false, describederrors.NewDescribedError(err, fmt.Sprintf("Invalid certificate authority Secret %s/%s", ref.Namespace, ref.Name))
+	}
+
+	sourceName := fmt.Sprintf("%s/%s", ref.Namespace, ref.Name)
+	data := map[string][]byte{
+		caCertKey:    source.Data[caCertKey],
+		caKeyKey:     source.Data[caKeyKey],
+		rootCertKey:  source.Data[rootCertKey],
+		certChainKey: source.Data[certChainKey],
+	}
+
+	target := corev1.Secret{}
+	err = r.client.Get(ctx, types.NamespacedName{Namespace: certificateAuthoritySecretNamespace, Name: certificateAuthoritySecretName}, &target)
+	if k8sErrors.IsNotFound(err) {
+		target = corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   certificateAuthoritySecretNamespace,
+				Name:        certificateAuthoritySecretName,
+				Labels:      labels.SetModuleLabels(nil),
+				Annotations: map[string]string{labels.CertificateAuthoritySource: sourceName},
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: data,
+		}
+		if createErr := r.client.Create(ctx, &target); createErr != nil {
+			return nil, false, describederrors.NewDescribedError(createErr, "Could not create the istio-system/cacerts Secret")
+		}
+		ctrl.Log.Info("Created the certificate authority Secret", "source", sourceName)
+		return caStatus, true, nil
+	}
+	if err != nil {
+		return nil, false, describederrors.NewDescribedError(err, "Could not get the istio-system/cacerts Secret")
+	}
+
+	_, rotating := target.Annotations[labels.RootCARotationPhase]
+	if !rotating && target.Annotations[labels.CertificateAuthoritySource] == sourceName && equality.Semantic.DeepEqual(target.Data, data) {
+		return caStatus, false, nil
+	}
+
+	target.Labels = labels.SetModuleLabels(target.Labels)
+	if target.Annotations == nil {
+		target.Annotations = map[string]string{}
+	}
+	target.Annotations[labels.CertificateAuthoritySource] = sourceName
+	// The Secret might have been created by a rotation of the self-signed root certificate authority, which is aborted now.
+	delete(target.Annotations, labels.RootCARotationPhase)
+	changed := !equality.Semantic.DeepEqual(target.Data, data)
+	target.Data = data
+	if updateErr := r.client.Update(ctx, &target); updateErr != nil {
+		return nil, false, describederrors.NewDescribedError(updateErr, "Could not update the istio-system/cacerts Secret")
+	}
+	ctrl.Log.Info("Updated the certificate authority Secret", "source", sourceName, "changed", changed)
+	return caStatus, changed, nil
+}
+
+// removeManagedSecret deletes the istio-system/cacerts Secret if it was created by the Istio module, so that istiod uses a self-signed root
+// certificate again. A Secret created manually is kept.
+func (r *CertificateAuthorityRestarter) removeManagedSecret(ctx context.Context) (bool, describederrors.DescribedError) {
+	target := corev1.Secret{}
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: certificateAuthoritySecretNamespace, Name: certificateAuthoritySecretName}, &target)
+	if k8sErrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, describederrors.NewDescribedError(err, "Could not get the istio-system/cacerts Secret")
+	}
+	if _, managed := target.Annotations[labels.CertificateAuthoritySource]; !managed {
+		return false, nil
+	}
+
+	if deleteErr := r.client.Delete(ctx, &target); client.IgnoreNotFound(deleteErr) != nil {
+		return false, describederrors.NewDescribedError(deleteErr, "Could not delete the istio-system/cacerts Secret")
+	}
+	ctrl.Log.Info("Deleted the certificate authority Secret")
+	return true, nil
+}
+
+// parseCertificateAuthority validates the certificate authority in the Secret data and returns its status.
+func parseCertificateAuthority(data map[string][]byte, now time.Time) (*v1alpha2.CertificateAuthorityStatus, error) {
+	for _, key := range []string{caCertKey, caKeyKey, rootCertKey, certChainKey} {
+		if len(data[key]) == 0 {
+			return nil, fmt.Errorf("the Secret doesn't contain %s", key)
+		}
+	}
+
+	if _, err := tls.X509KeyPair(data[caCertKey], data[caKeyKey]); err != nil {
+		return nil, fmt.Errorf("%s doesn't match %s: %w", caKeyKey, caCertKey, err)
+	}
+
+	caCerts, err := parseCertificates(data[caCertKey])
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", caCertKey, err)
+	}
+	caCert := caCerts[0]
+	if !caCert.IsCA || (caCert.KeyUsage != 0 && caCert.KeyUsage&x509.KeyUsageCertSign == 0) {
+		return nil, fmt.Errorf("%s isn't allowed to sign certificates", caCertKey)
+	}
+	if now.Before(caCert.NotBefore) || now.After(caCert.NotAfter) {
+		return nil, fmt.Errorf("%s is only valid from %s to %s", caCertKey, caCert.NotBefore.Format(time.RFC3339), caCert.NotAfter.Format(time.RFC3339))
+	}
+
+	rootCerts, err := parseCertificates(data[rootCertKey])
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", rootCertKey, err)
+	}
+	chainCerts, err := parseCertificates(data[certChainKey])
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", certChainKey, err)
+	}
+
+	roots := x509.NewCertPool()
+	for _, cert := range rootCerts {
+		roots.AddCert(cert)
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range chainCerts {
+		intermediates.AddCert(cert)
+	}
+	_, err = caCert.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s can't be verified with %s and %s: %w", caCertKey, certChainKey, rootCertKey, err)
+	}
+
+	fingerprint := sha256.Sum256(bytes.Join([][]byte{data[caCertKey], data[certChainKey], data[rootCertKey]}, nil))
+	return &v1alpha2.CertificateAuthorityStatus{
+		Subject:     caCert.Subject.String(),
+		NotAfter:    metav1.NewTime(caCert.NotAfter),
+		Fingerprint: hex.EncodeToString(fingerprint[:]),
+	}, nil
+}
+
+func parseCertificates(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("no PEM encoded certificate found")
+	}
+	return certs, nil
+}
+
+// restartIstiodRevisions restarts the istiod Deployments of all revisions, so that istiod loads the new certificate authority.
+func restartIstiodRevisions(ctx context.Context, c client.Client) error {
+	deployments := appsv1.DeploymentList{}
+	err := c.List(ctx, &deployments, client.InNamespace(istiodNamespace), client.MatchingLabels{"app": "istiod"})
+	if err != nil {
+		return err
+	}
+
+	for _, deployment := range deployments.Items {
+		ctrl.Log.Info("Restarting istiod", "deployment", deployment.Name)
+		err = retry.OnError(retry.DefaultRetry, func() error {
+			patch := client.StrategicMergeFrom(deployment.DeepCopy())
+			deployment.Spec.Template.Annotations = annotations.AddRestartAnnotation(deployment.Spec.Template.Annotations)
+			return c.Patch(ctx, &deployment, patch)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
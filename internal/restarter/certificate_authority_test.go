@@ -0,0 +1,245 @@
+package restarter_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	operatorv1alpha2 "github.com/kyma-project/istio/operator/api/v1alpha2"
+	"github.com/kyma-project/istio/operator/internal/restarter"
+	"github.com/kyma-project/istio/operator/internal/status"
+	"github.com/kyma-project/istio/operator/pkg/labels"
+	"github.com/kyma-project/istio/operator/pkg/lib/annotations"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Certificate authority restart", func() {
+	It("should create the cacerts Secret, restart istiod and set the certificate authority status", func() {
+		// given
+		istioCR := createIstioCRWithCertificateAuthority()
+		caSecret := createCertificateAuthoritySecret(time.Now().Add(24 * time.Hour))
+		istiod := createIstiodDeployment()
+		fakeClient := createFakeClient(istioCR, caSecret, istiod)
+		caRestarter := restarter.NewCertificateAuthorityRestarter(fakeClient, status.NewStatusHandler(fakeClient))
+
+		// when
+		err := caRestarter.Restart(context.Background(), istioCR)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		cacerts := v1.Secret{}
+		Expect(fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "istio-system", Name: "cacerts"}, &cacerts)).To(Succeed())
+		Expect(cacerts.Data).To(Equal(caSecret.Data))
+		Expect(cacerts.Annotations).To(HaveKeyWithValue(labels.CertificateAuthoritySource, "custom-ca/ca-secret"))
+
+		Expect(fakeClient.Get(context.Background(), client.ObjectKeyFromObject(istiod), istiod)).To(Succeed())
+		Expect(annotations.HasRestartAnnotation(istiod.Spec.Template.Annotations)).To(BeTrue())
+
+		Expect(istioCR.Status.CertificateAuthority).ToNot(BeNil())
+		Expect(istioCR.Status.CertificateAuthority.Subject).To(Equal("CN=Intermediate CA"))
+		Expect(istioCR.Status.CertificateAuthority.Fingerprint).ToNot(BeEmpty())
+		Expect((*istioCR.Status.Conditions)[0].Reason).To(Equal(string(operatorv1alpha2.ConditionReasonCertificateAuthorityUpdated)))
+
+		storedCR := operatorv1alpha2.Istio{}
+		Expect(fakeClient.Get(context.Background(), client.ObjectKeyFromObject(istioCR), &storedCR)).To(Succeed())
+		Expect(storedCR.GetCertificateAuthorityFingerprint()).To(Equal(istioCR.Status.CertificateAuthority.Fingerprint))
+	})
+
+	It("should not restart istiod if the cacerts Secret is up to date", func() {
+		// given
+		istioCR := createIstioCRWithCertificateAuthority()
+		caSecret := createCertificateAuthoritySecret(time.Now().Add(24 * time.Hour))
+		cacerts := &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "istio-system",
+				Name:        "cacerts",
+				Annotations: map[string]string{labels.CertificateAuthoritySource: "custom-ca/ca-secret"},
+			},
+			Data: caSecret.Data,
+		}
+		istiod := createIstiodDeployment()
+		fakeClient := createFakeClient(istioCR, caSecret, cacerts, istiod)
+		caRestarter := restarter.NewCertificateAuthorityRestarter(fakeClient, status.NewStatusHandler(fakeClient))
+
+		// when
+		err := caRestarter.Restart(context.Background(), istioCR)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(fakeClient.Get(context.Background(), client.ObjectKeyFromObject(istiod), istiod)).To(Succeed())
+		Expect(annotations.HasRestartAnnotation(istiod.Spec.Template.Annotations)).To(BeFalse())
+		Expect(istioCR.Status.CertificateAuthority).ToNot(BeNil())
+	})
+
+	It("should return an error and not create the cacerts Secret if the certificate authority is expired", func() {
+		// given
+		istioCR := createIstioCRWithCertificateAuthority()
+		caSecret := createCertificateAuthoritySecret(time.Now().Add(-time.Hour))
+		fakeClient := createFakeClient(istioCR, caSecret, createIstiodDeployment())
+		caRestarter := restarter.NewCertificateAuthorityRestarter(fakeClient, status.NewStatusHandler(fakeClient))
+
+		// when
+		err := caRestarter.Restart(context.Background(), istioCR)
+
+		// then
+		Expect(err).To(HaveOccurred())
+		Expect(err.Description()).To(ContainSubstring("Invalid certificate authority Secret custom-ca/ca-secret"))
+		Expect((*istioCR.Status.Conditions)[0].Reason).To(Equal(string(operatorv1alpha2.ConditionReasonCertificateAuthorityFailed)))
+
+		getErr := fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "istio-system", Name: "cacerts"}, &v1.Secret{})
+		Expect(k8serrors.IsNotFound(getErr)).To(BeTrue())
+	})
+
+	It("should return an error if the Secret doesn't contain the root certificate", func() {
+		// given
+		istioCR := createIstioCRWithCertificateAuthority()
+		caSecret := createCertificateAuthoritySecret(time.Now().Add(24 * time.Hour))
+		delete(caSecret.Data, "root-cert.pem")
+		fakeClient := createFakeClient(istioCR, caSecret, createIstiodDeployment())
+		caRestarter := restarter.NewCertificateAuthorityRestarter(fakeClient, status.NewStatusHandler(fakeClient))
+
+		// when
+		err := caRestarter.Restart(context.Background(), istioCR)
+
+		// then
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("root-cert.pem"))
+	})
+
+	It("should delete the managed cacerts Secret and restart istiod if the certificate authority was removed", func() {
+		// given
+		istioCR := createIstioCRWithCertificateAuthority()
+		istioCR.Spec.Config.CertificateAuthority = nil
+		istioCR.Status.CertificateAuthority = &operatorv1alpha2.CertificateAuthorityStatus{Fingerprint: "abc"}
+		cacerts := &v1.Secret{ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "istio-system",
+			Name:        "cacerts",
+			Annotations: map[string]string{labels.CertificateAuthoritySource: "custom-ca/ca-secret"},
+		}}
+		istiod := createIstiodDeployment()
+		fakeClient := createFakeClient(istioCR, cacerts, istiod)
+		caRestarter := restarter.NewCertificateAuthorityRestarter(fakeClient, status.NewStatusHandler(fakeClient))
+
+		// when
+		err := caRestarter.Restart(context.Background(), istioCR)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		getErr := fakeClient.Get(context.Background(), client.ObjectKeyFromObject(cacerts), &v1.Secret{})
+		Expect(k8serrors.IsNotFound(getErr)).To(BeTrue())
+
+		Expect(fakeClient.Get(context.Background(), client.ObjectKeyFromObject(istiod), istiod)).To(Succeed())
+		Expect(annotations.HasRestartAnnotation(istiod.Spec.Template.Annotations)).To(BeTrue())
+		Expect(istioCR.Status.CertificateAuthority).To(BeNil())
+	})
+
+	It("should not delete a cacerts Secret that isn't managed by the Istio module", func() {
+		// given
+		istioCR := createIstioCRWithCertificateAuthority()
+		istioCR.Spec.Config.CertificateAuthority = nil
+		cacerts := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "istio-system", Name: "cacerts"}}
+		istiod := createIstiodDeployment()
+		fakeClient := createFakeClient(istioCR, cacerts, istiod)
+		caRestarter := restarter.NewCertificateAuthorityRestarter(fakeClient, status.NewStatusHandler(fakeClient))
+
+		// when
+		err := caRestarter.Restart(context.Background(), istioCR)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fakeClient.Get(context.Background(), client.ObjectKeyFromObject(cacerts), &v1.Secret{})).To(Succeed())
+
+		Expect(fakeClient.Get(context.Background(), client.ObjectKeyFromObject(istiod), istiod)).To(Succeed())
+		Expect(annotations.HasRestartAnnotation(istiod.Spec.Template.Annotations)).To(BeFalse())
+	})
+})
+
+func createIstioCRWithCertificateAuthority() *operatorv1alpha2.Istio {
+	return &operatorv1alpha2.Istio{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "default",
+			Namespace:       "kyma-system",
+			ResourceVersion: "1",
+		},
+		Spec: operatorv1alpha2.IstioSpec{
+			Config: operatorv1alpha2.Config{
+				CertificateAuthority: &operatorv1alpha2.CertificateAuthority{
+					SecretRef: operatorv1alpha2.SecretReference{Namespace: "custom-ca", Name: "ca-secret"},
+				},
+			},
+		},
+	}
+}
+
+func createIstiodDeployment() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "istiod",
+			Namespace: "istio-system",
+			Labels:    map[string]string{"app": "istiod"},
+		},
+	}
+}
+
+// createCertificateAuthoritySecret creates a Secret with an intermediate certificate authority signed by a self-signed root certificate authority.
+func createCertificateAuthoritySecret(notAfter time.Time) *v1.Secret {
+	notBefore := time.Now().Add(-48 * time.Hour)
+
+	rootKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).ToNot(HaveOccurred())
+	rootTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Root CA"},
+		NotBefore:             notBefore,
+		NotAfter:              time.Now().Add(365 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	rootDER, err := x509.CreateCertificate(rand.Reader, rootTemplate, rootTemplate, &rootKey.PublicKey, rootKey)
+	Expect(err).ToNot(HaveOccurred())
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).ToNot(HaveOccurred())
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(2),
+		Subject:               pkix.Name{CommonName: "Intermediate CA"},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, rootTemplate, &caKey.PublicKey, rootKey)
+	Expect(err).ToNot(HaveOccurred())
+	caKeyDER, err := x509.MarshalECPrivateKey(caKey)
+	Expect(err).ToNot(HaveOccurred())
+
+	rootPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: rootDER})
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER})
+
+	return &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "custom-ca", Name: "ca-secret"},
+		Data: map[string][]byte{
+			"ca-cert.pem":    caPEM,
+			"ca-key.pem":     pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: caKeyDER}),
+			"root-cert.pem":  rootPEM,
+			"cert-chain.pem": append(append([]byte{}, caPEM...), rootPEM...),
+		},
+	}
+}
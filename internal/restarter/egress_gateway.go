@@ -0,0 +1,61 @@
+package restarter
+
+import (
+	"context"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kyma-project/istio/operator/api/v1alpha2"
+	"github.com/kyma-project/istio/operator/internal/describederrors"
+	"github.com/kyma-project/istio/operator/internal/restarter/predicates"
+	"github.com/kyma-project/istio/operator/internal/status"
+)
+
+const egressDeploymentName string = "istio-egressgateway"
+
+// EgressGatewayRestarter restarts the Istio Egress Gateway when one of its restart predicates requires it. The Istio Egress Gateway is not
+// restarted if it doesn't exist.
+// Changes of the proxy configuration and of the custom certificate authority roll out the Istio Egress Gateway with the installation,
+// because they change the proxy config hash annotation of its Pods, so they don't need to be recorded in the lastAppliedConfiguration
+// by this restarter.
+type EgressGatewayRestarter struct {
+	client        client.Client
+	predicates    []predicates.EgressGatewayPredicate
+	statusHandler status.Status
+}
+
+func NewEgressGatewayRestarter(client client.Client, predicates []predicates.EgressGatewayPredicate, statusHandler status.Status) *EgressGatewayRestarter {
+	return &EgressGatewayRestarter{
+		client:        client,
+		predicates:    predicates,
+		statusHandler: statusHandler,
+	}
+}
+
+func (r *EgressGatewayRestarter) Restart(ctx context.Context, istioCR *v1alpha2.Istio) describederrors.DescribedError {
+	ctrl.Log.Info("Restarting Istio Egress Gateway")
+
+	for _, predicate := range r.predicates {
+		evaluator, err := predicate.NewEgressGatewayEvaluator(ctx)
+		if err != nil {
+			return describederrors.NewDescribedError(err, "Could not create Egress Gateway restart evaluator")
+		}
+
+		if !evaluator.RequiresEgressGatewayRestart() {
+			continue
+		}
+
+		err = restartGatewayDeployment(ctx, r.client, egressDeploymentName)
+		if err != nil {
+			r.statusHandler.SetCondition(istioCR, v1alpha2.NewReasonWithMessage(v1alpha2.ConditionReasonEgressGatewayRestartFailed))
+			return describederrors.NewDescribedError(err, "Failed to restart Egress Gateway")
+		}
+		// The Egress Gateway was restarted, so the remaining predicates don't need to be evaluated
+		break
+	}
+
+	r.statusHandler.SetCondition(istioCR, v1alpha2.NewReasonWithMessage(v1alpha2.ConditionReasonEgressGatewayRestartSucceeded))
+	ctrl.Log.Info("Successfully restarted Istio Egress Gateway")
+	return nil
+}
@@ -0,0 +1,96 @@
+package restarter_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha2 "github.com/kyma-project/istio/operator/api/v1alpha2"
+	"github.com/kyma-project/istio/operator/internal/restarter"
+	"github.com/kyma-project/istio/operator/internal/restarter/predicates"
+	"github.com/kyma-project/istio/operator/internal/status"
+	"github.com/kyma-project/istio/operator/pkg/labels"
+	"github.com/kyma-project/istio/operator/pkg/lib/annotations"
+)
+
+var _ = Describe("Istio Egress Gateway restart", func() {
+	It("should restart the egress gateway when a predicate requires restart", func() {
+		// given
+		istioCR := createIstioCR()
+		egDep := createGatewayDep("istio-egressgateway", time.Now().Add(-time.Hour))
+		fakeClient := createFakeClient(istioCR, egDep)
+		egRestarter := restarter.NewEgressGatewayRestarter(fakeClient, []predicates.EgressGatewayPredicate{mockEgPredicate{shouldRestart: true}}, status.NewStatusHandler(fakeClient))
+
+		// when
+		err := egRestarter.Restart(context.Background(), istioCR)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fakeClient.Get(context.Background(), client.ObjectKeyFromObject(egDep), egDep)).To(Succeed())
+		Expect(annotations.HasRestartAnnotation(egDep.Spec.Template.Annotations)).To(BeTrue())
+		Expect((*istioCR.Status.Conditions)[0].Reason).To(Equal(string(operatorv1alpha2.ConditionReasonEgressGatewayRestartSucceeded)))
+	})
+
+	It("should not restart the egress gateway when no predicate requires restart", func() {
+		// given
+		istioCR := createIstioCR()
+		egDep := createGatewayDep("istio-egressgateway", time.Now().Add(-time.Hour))
+		fakeClient := createFakeClient(istioCR, egDep)
+		egRestarter := restarter.NewEgressGatewayRestarter(fakeClient, []predicates.EgressGatewayPredicate{mockEgPredicate{shouldRestart: false}}, status.NewStatusHandler(fakeClient))
+
+		// when
+		err := egRestarter.Restart(context.Background(), istioCR)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fakeClient.Get(context.Background(), client.ObjectKeyFromObject(egDep), egDep)).To(Succeed())
+		Expect(annotations.HasRestartAnnotation(egDep.Spec.Template.Annotations)).To(BeFalse())
+	})
+
+	It("should not restart the egress gateway when the certificate authority changed, because the installation rolls it out", func() {
+		// given
+		istioCR := createIstioCR()
+		istioCR.Status.CertificateAuthority = &operatorv1alpha2.CertificateAuthorityStatus{Fingerprint: "def"}
+		istioCR.Annotations[labels.LastAppliedConfiguration] = `{"certificateAuthorityFingerprint":"abc","IstioTag":"1.16.1-distroless"}`
+		egDep := createGatewayDep("istio-egressgateway", time.Now().Add(-time.Hour))
+		fakeClient := createFakeClient(istioCR, egDep)
+		egRestarter := restarter.NewEgressGatewayRestarter(fakeClient, []predicates.EgressGatewayPredicate{}, status.NewStatusHandler(fakeClient))
+
+		// when
+		err := egRestarter.Restart(context.Background(), istioCR)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fakeClient.Get(context.Background(), client.ObjectKeyFromObject(egDep), egDep)).To(Succeed())
+		Expect(annotations.HasRestartAnnotation(egDep.Spec.Template.Annotations)).To(BeFalse())
+	})
+
+	It("should not fail when the egress gateway is not installed", func() {
+		// given
+		istioCR := createIstioCR()
+		fakeClient := createFakeClient(istioCR)
+		egRestarter := restarter.NewEgressGatewayRestarter(fakeClient, []predicates.EgressGatewayPredicate{mockEgPredicate{shouldRestart: true}}, status.NewStatusHandler(fakeClient))
+
+		// when
+		err := egRestarter.Restart(context.Background(), istioCR)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect((*istioCR.Status.Conditions)[0].Reason).To(Equal(string(operatorv1alpha2.ConditionReasonEgressGatewayRestartSucceeded)))
+	})
+})
+
+type mockEgPredicate struct {
+	shouldRestart bool
+}
+
+func (m mockEgPredicate) RequiresEgressGatewayRestart() bool {
+	return m.shouldRestart
+}
+
+func (m mockEgPredicate) NewEgressGatewayEvaluator(_ context.Context) (predicates.EgressGatewayRestartEvaluator, error) {
+	return m, nil
+}
@@ -5,13 +5,11 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kyma-project/istio/operator/api/v1alpha2"
 	"github.com/kyma-project/istio/operator/internal/describederrors"
-	"github.com/kyma-project/istio/operator/internal/reconciliations/istio/configuration"
 	"github.com/kyma-project/istio/operator/internal/restarter/predicates"
 	"github.com/kyma-project/istio/operator/internal/status"
 	"github.com/kyma-project/istio/operator/pkg/lib/annotations"
@@ -25,6 +23,9 @@ const (
 	ingressDeploymentName string = "istio-ingressgateway"
 )
 
+// IngressGatewayRestarter restarts the Istio Ingress Gateway when one of its restart predicates requires it. Changes of the proxy configuration
+// and of the custom certificate authority roll out the Istio Ingress Gateway with the installation, because they change the proxy config hash
+// annotation of its Pods, so they don't need to be recorded in the lastAppliedConfiguration by this restarter.
 type IngressGatewayRestarter struct {
 	client        client.Client
 	predicates    []predicates.IngressGatewayPredicate
@@ -42,8 +43,7 @@ func NewIngressGatewayRestarter(client client.Client, predicates []predicates.In
 func (r *IngressGatewayRestarter) Restart(ctx context.Context, istioCR *v1alpha2.Istio) describederrors.DescribedError {
 	ctrl.Log.Info("Restarting Istio Ingress Gateway")
 
-	allPredicates := append(r.predicates, predicates.NewIngressGatewayRestartPredicate(istioCR))
-	for _, predicate := range allPredicates {
+	for _, predicate := range r.predicates {
 		evaluator, err := predicate.NewIngressGatewayEvaluator(ctx)
 		if err != nil {
 			return describederrors.NewDescribedError(err, "Could not create Ingress Gateway restart evaluator")
@@ -58,39 +58,22 @@ func (r *IngressGatewayRestarter) Restart(ctx context.Context, istioCR *v1alpha2
 		}
 	}
 
-	// Update lastAppliedConfiguration with ingress gateway config to prevent unnecessary restarts
-	// if reconciliation requeues early
-	if err := r.updateLastAppliedConfiguration(ctx, istioCR); err != nil {
-		ctrl.Log.Error(err, "Failed to update lastAppliedConfiguration after ingress gateway restart")
-		// We don't fail the reconciliation here, as the restart was successful
-		// The worst case is that the restart might be triggered again on next reconciliation
-	}
-
 	r.statusHandler.SetCondition(istioCR, v1alpha2.NewReasonWithMessage(v1alpha2.ConditionReasonIngressGatewayRestartSucceeded))
 	ctrl.Log.Info("Successfully restarted Istio Ingress Gateway")
 	return nil
 }
 
-func (r *IngressGatewayRestarter) updateLastAppliedConfiguration(ctx context.Context, istioCR *v1alpha2.Istio) error {
-	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		currentIstioCR := v1alpha2.Istio{}
-		if err := r.client.Get(ctx, types.NamespacedName{Name: istioCR.Name, Namespace: istioCR.Namespace}, &currentIstioCR); err != nil {
-			return err
-		}
-		if err := configuration.UpdateLastAppliedIngressGatewayConfig(&currentIstioCR); err != nil {
-			return err
-		}
-		return r.client.Update(ctx, &currentIstioCR)
-	})
+func restartIngressGateway(ctx context.Context, k8sClient client.Client) error {
+	return restartGatewayDeployment(ctx, k8sClient, ingressDeploymentName)
 }
 
-func restartIngressGateway(ctx context.Context, k8sClient client.Client) error {
-	ctrl.Log.Info("Restarting istio-ingressgateway")
+func restartGatewayDeployment(ctx context.Context, k8sClient client.Client, name string) error {
+	ctrl.Log.Info("Restarting gateway", "name", name)
 
 	deployment := appsv1.Deployment{}
-	err := k8sClient.Get(ctx, types.NamespacedName{Namespace: ingressNamespace, Name: ingressDeploymentName}, &deployment)
+	err := k8sClient.Get(ctx, types.NamespacedName{Namespace: ingressNamespace, Name: name}, &deployment)
 	if err != nil {
-		// If ingress gateway deployment is missing, we should not fail, as it may have not yet been created
+		// If gateway deployment is missing, we should not fail, as it may have not yet been created
 		// In that case, the upcoming creation of the deployment will do the same thing as we would require from the restart
 		if k8sErrors.IsNotFound(err) {
 			return nil
@@ -104,7 +87,7 @@ func restartIngressGateway(ctx context.Context, k8sClient client.Client) error {
 	err = sidecarretry.OnError(sidecarretry.DefaultRetry, func() error {
 		err = k8sClient.Patch(ctx, &deployment, patch)
 		if err != nil {
-			ctrl.Log.Info("Retrying ingress gateway restart")
+			ctrl.Log.Info("Retrying gateway restart", "name", name)
 			return err
 		}
 		return nil
@@ -112,7 +95,7 @@ func restartIngressGateway(ctx context.Context, k8sClient client.Client) error {
 	if err != nil {
 		return err
 	}
-	ctrl.Log.Info("istio-ingressgateway restarted")
+	ctrl.Log.Info("Gateway restarted", "name", name)
 
 	return nil
 }
@@ -143,47 +143,6 @@ var _ = Describe("Istio Ingress Gateway restart", func() {
 		Expect(restartCounter.count).To(Equal(5))
 	})
 
-	It("should update lastAppliedConfiguration with ingress gateway config after restart", func() {
-		// given
-		numTrustedProxies := 2
-		trustDomain := "cluster.local"
-		istioCR := &operatorv1alpha2.Istio{ObjectMeta: metav1.ObjectMeta{
-			Name:            "default",
-			Namespace:       "kyma-system",
-			ResourceVersion: "1",
-			Annotations:     map[string]string{},
-		},
-			Spec: operatorv1alpha2.IstioSpec{
-				Config: operatorv1alpha2.Config{
-					NumTrustedProxies: &numTrustedProxies,
-					TrustDomain:       &trustDomain,
-				},
-			},
-		}
-
-		istiod := createPod("istiod", gatherer.IstioNamespace, "discovery", "1.16.1")
-		igDep := createIngressGatewayDep(time.Now().Add(-time.Hour))
-		igPod := createIgPodWithCreationTimestamp("istio-ingressgateway", gatherer.IstioNamespace, "discovery", "1.16.1", time.Now().Add(-time.Hour))
-		fakeClient := createFakeClient(istioCR, istiod, igPod, igDep)
-		statusHandler := status.NewStatusHandler(fakeClient)
-		igRestarter := restarter.NewIngressGatewayRestarter(fakeClient, []predicates.IngressGatewayPredicate{mockIgPredicate{shouldRestart: true}}, statusHandler)
-
-		//when
-		err := igRestarter.Restart(context.Background(), istioCR)
-
-		//then
-		Expect(err).Should(Not(HaveOccurred()))
-
-		// Verify lastAppliedConfiguration was updated
-		updatedIstioCR := &operatorv1alpha2.Istio{}
-		e := fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "kyma-system", Name: "default"}, updatedIstioCR)
-		Expect(e).Should(Not(HaveOccurred()))
-
-		Expect(updatedIstioCR.Annotations).To(HaveKey("operator.kyma-project.io/lastAppliedConfiguration"))
-		Expect(updatedIstioCR.Annotations["operator.kyma-project.io/lastAppliedConfiguration"]).To(ContainSubstring(`"numTrustedProxies":2`))
-		Expect(updatedIstioCR.Annotations["operator.kyma-project.io/lastAppliedConfiguration"]).To(ContainSubstring(`"trustDomain":"cluster.local"`))
-	})
-
 })
 
 func createIngressGatewayDep(creationTimestamp time.Time) *appsv1.Deployment {
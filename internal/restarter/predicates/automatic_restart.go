@@ -0,0 +1,176 @@
+package predicates
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kyma-project/istio/operator/api/v1alpha2"
+	"github.com/kyma-project/istio/operator/pkg/labels"
+)
+
+const replicaSetListLimit = 500
+
+// AutomaticRestartPredicate matches the pods whose proxy sidecars may be restarted automatically. Pods in namespaces and pods owned by
+// Deployments, StatefulSets, or DaemonSets labeled with `operator.kyma-project.io/proxy-restart: disabled` are not matched.
+// In the opt-in mode of the Istio CR, only pods in namespaces labeled with `operator.kyma-project.io/proxy-restart: enabled` are matched.
+type AutomaticRestartPredicate struct {
+	optIn              bool
+	enabledNamespaces  map[string]bool
+	disabledNamespaces map[string]bool
+	// disabledOwners contains the keys of the opted-out workloads and of the ReplicaSets owned by opted-out Deployments.
+	disabledOwners map[string]bool
+}
+
+func NewAutomaticRestartPredicate(ctx context.Context, c client.Client, istioCR *v1alpha2.Istio) (*AutomaticRestartPredicate, error) {
+	p := &AutomaticRestartPredicate{
+		optIn:              istioCR.IsProxyRestartOptIn(),
+		enabledNamespaces:  map[string]bool{},
+		disabledNamespaces: map[string]bool{},
+		disabledOwners:     map[string]bool{},
+	}
+
+	namespaces := &v1.NamespaceList{}
+	if err := c.List(ctx, namespaces, client.HasLabels{labels.ProxyRestartLabelKey}); err != nil {
+		return nil, fmt.Errorf("failed to list namespaces labeled with %s: %w", labels.ProxyRestartLabelKey, err)
+	}
+	for _, namespace := range namespaces.Items {
+		switch namespace.Labels[labels.ProxyRestartLabelKey] {
+		case labels.ProxyRestartLabelEnabled:
+			p.enabledNamespaces[namespace.Name] = true
+		case labels.ProxyRestartLabelDisabled:
+			p.disabledNamespaces[namespace.Name] = true
+		}
+	}
+
+	if err := p.addDisabledOwners(ctx, c); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (p *AutomaticRestartPredicate) addDisabledOwners(ctx context.Context, c client.Client) error {
+	disabled := client.MatchingLabels{labels.ProxyRestartLabelKey: labels.ProxyRestartLabelDisabled}
+
+	deployments := &appsv1.DeploymentList{}
+	if err := c.List(ctx, deployments, disabled); err != nil {
+		return fmt.Errorf("failed to list opted-out Deployments: %w", err)
+	}
+	for _, deployment := range deployments.Items {
+		p.disabledOwners[ownerKey("Deployment", deployment.Namespace, deployment.Name)] = true
+	}
+
+	statefulSets := &appsv1.StatefulSetList{}
+	if err := c.List(ctx, statefulSets, disabled); err != nil {
+		return fmt.Errorf("failed to list opted-out StatefulSets: %w", err)
+	}
+	for _, statefulSet := range statefulSets.Items {
+		p.disabledOwners[ownerKey("StatefulSet", statefulSet.Namespace, statefulSet.Name)] = true
+	}
+
+	daemonSets := &appsv1.DaemonSetList{}
+	if err := c.List(ctx, daemonSets, disabled); err != nil {
+		return fmt.Errorf("failed to list opted-out DaemonSets: %w", err)
+	}
+	for _, daemonSet := range daemonSets.Items {
+		p.disabledOwners[ownerKey("DaemonSet", daemonSet.Namespace, daemonSet.Name)] = true
+	}
+
+	// The pods of a Deployment are owned by its ReplicaSets, so the ReplicaSets of opted-out Deployments are opted out as well.
+	// The ReplicaSets are only listed in the namespaces of opted-out Deployments.
+	namespaces := map[string]bool{}
+	for _, deployment := range deployments.Items {
+		if !namespaces[deployment.Namespace] {
+			namespaces[deployment.Namespace] = true
+			if err := p.addDisabledReplicaSets(ctx, c, deployment.Namespace); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *AutomaticRestartPredicate) addDisabledReplicaSets(ctx context.Context, c client.Client, namespace string) error {
+	continueToken := ""
+	for {
+		replicaSets := &appsv1.ReplicaSetList{}
+		listOps := []client.ListOption{client.InNamespace(namespace), client.Limit(replicaSetListLimit)}
+		if continueToken != "" {
+			listOps = append(listOps, client.Continue(continueToken))
+		}
+		if err := c.List(ctx, replicaSets, listOps...); err != nil {
+			return fmt.Errorf("failed to list ReplicaSets in namespace %s: %w", namespace, err)
+		}
+		for _, replicaSet := range replicaSets.Items {
+			for _, owner := range replicaSet.OwnerReferences {
+				if owner.Kind == "Deployment" && p.disabledOwners[ownerKey(owner.Kind, replicaSet.Namespace, owner.Name)] {
+					p.disabledOwners[ownerKey("ReplicaSet", replicaSet.Namespace, replicaSet.Name)] = true
+				}
+			}
+		}
+
+		continueToken = replicaSets.Continue
+		if continueToken == "" {
+			return nil
+		}
+	}
+}
+
+func ownerKey(kind, namespace, name string) string {
+	return fmt.Sprintf("%s/%s/%s", kind, namespace, name)
+}
+
+// HasExclusions returns true if the predicate may exclude pods from the automatic restart.
+func (p AutomaticRestartPredicate) HasExclusions() bool {
+	return p.optIn || len(p.disabledNamespaces) > 0 || len(p.disabledOwners) > 0
+}
+
+func (p AutomaticRestartPredicate) Matches(pod v1.Pod) bool {
+	if p.disabledNamespaces[pod.Namespace] {
+		return false
+	}
+	if p.optIn && !p.enabledNamespaces[pod.Namespace] {
+		return false
+	}
+	for _, owner := range pod.OwnerReferences {
+		if p.disabledOwners[ownerKey(owner.Kind, pod.Namespace, owner.Name)] {
+			return false
+		}
+	}
+	return true
+}
+
+func (p AutomaticRestartPredicate) MustMatch() bool {
+	return true
+}
+
+func (p AutomaticRestartPredicate) Name() string {
+	return "AutomaticRestartPredicate"
+}
+
+// ManualRestart returns a predicate matching the pods that are excluded from the automatic restart.
+func (p *AutomaticRestartPredicate) ManualRestart() *ManualRestartPredicate {
+	return &ManualRestartPredicate{automaticRestart: p}
+}
+
+// ManualRestartPredicate matches the pods whose proxy sidecars must be restarted manually, because they are excluded from the automatic restart.
+type ManualRestartPredicate struct {
+	automaticRestart *AutomaticRestartPredicate
+}
+
+func (p ManualRestartPredicate) Matches(pod v1.Pod) bool {
+	return !p.automaticRestart.Matches(pod)
+}
+
+func (p ManualRestartPredicate) MustMatch() bool {
+	return true
+}
+
+func (p ManualRestartPredicate) Name() string {
+	return "ManualRestartPredicate"
+}
@@ -0,0 +1,147 @@
+package predicates
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	operatorv1alpha2 "github.com/kyma-project/istio/operator/api/v1alpha2"
+	"github.com/kyma-project/istio/operator/pkg/labels"
+)
+
+var _ = Describe("Automatic Restart Predicate", func() {
+	ctx := context.Background()
+
+	namespaceWithLabel := func(name, value string) *v1.Namespace {
+		return &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{labels.ProxyRestartLabelKey: value}}}
+	}
+	podOwnedBy := func(namespace, ownerKind, ownerName string) v1.Pod {
+		return v1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:            "test-pod",
+			Namespace:       namespace,
+			OwnerReferences: []metav1.OwnerReference{{Kind: ownerKind, Name: ownerName}},
+		}}
+	}
+	disabledLabels := map[string]string{labels.ProxyRestartLabelKey: labels.ProxyRestartLabelDisabled}
+
+	It("should match all pods and have no exclusions if nothing opted out", func() {
+		predicate, err := NewAutomaticRestartPredicate(ctx, makeClientWithObjects(), &operatorv1alpha2.Istio{})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(predicate.HasExclusions()).To(BeFalse())
+		Expect(predicate.Matches(podOwnedBy("default", "StatefulSet", "sts"))).To(BeTrue())
+	})
+
+	It("should not match pods in namespaces that opted out", func() {
+		c := makeClientWithObjects(namespaceWithLabel("opted-out", labels.ProxyRestartLabelDisabled))
+
+		predicate, err := NewAutomaticRestartPredicate(ctx, c, &operatorv1alpha2.Istio{})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(predicate.HasExclusions()).To(BeTrue())
+		Expect(predicate.Matches(podOwnedBy("opted-out", "StatefulSet", "sts"))).To(BeFalse())
+		Expect(predicate.Matches(podOwnedBy("default", "StatefulSet", "sts"))).To(BeTrue())
+	})
+
+	It("should not match pods of StatefulSets and DaemonSets that opted out", func() {
+		c := makeClientWithObjects(
+			&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "sts", Namespace: "default", Labels: disabledLabels}},
+			&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "ds", Namespace: "default", Labels: disabledLabels}},
+		)
+
+		predicate, err := NewAutomaticRestartPredicate(ctx, c, &operatorv1alpha2.Istio{})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(predicate.Matches(podOwnedBy("default", "StatefulSet", "sts"))).To(BeFalse())
+		Expect(predicate.Matches(podOwnedBy("default", "DaemonSet", "ds"))).To(BeFalse())
+		Expect(predicate.Matches(podOwnedBy("other", "StatefulSet", "sts"))).To(BeTrue())
+	})
+
+	It("should not match pods of ReplicaSets owned by Deployments that opted out", func() {
+		c := makeClientWithObjects(
+			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "dep", Namespace: "default", Labels: disabledLabels}},
+			&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+				Name:            "dep-123",
+				Namespace:       "default",
+				OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "dep"}},
+			}},
+			&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+				Name:            "other-123",
+				Namespace:       "default",
+				OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "other"}},
+			}},
+		)
+
+		predicate, err := NewAutomaticRestartPredicate(ctx, c, &operatorv1alpha2.Istio{})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(predicate.Matches(podOwnedBy("default", "ReplicaSet", "dep-123"))).To(BeFalse())
+		Expect(predicate.Matches(podOwnedBy("default", "ReplicaSet", "other-123"))).To(BeTrue())
+	})
+
+	It("should not match pods of ReplicaSets owned by a Deployment with the same name in another namespace", func() {
+		c := makeClientWithObjects(
+			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "dep", Namespace: "default", Labels: disabledLabels}},
+			&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+				Name:            "dep-123",
+				Namespace:       "default",
+				OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "dep"}},
+			}},
+			&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+				Name:            "dep-123",
+				Namespace:       "other",
+				OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "dep"}},
+			}},
+		)
+
+		predicate, err := NewAutomaticRestartPredicate(ctx, c, &operatorv1alpha2.Istio{})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(predicate.Matches(podOwnedBy("default", "ReplicaSet", "dep-123"))).To(BeFalse())
+		Expect(predicate.Matches(podOwnedBy("other", "ReplicaSet", "dep-123"))).To(BeTrue())
+	})
+
+	It("should only match pods in namespaces that opted in if the opt-in mode is configured", func() {
+		c := makeClientWithObjects(namespaceWithLabel("opted-in", labels.ProxyRestartLabelEnabled))
+		istioCR := &operatorv1alpha2.Istio{Spec: operatorv1alpha2.IstioSpec{
+			ProxyRestart: &operatorv1alpha2.ProxyRestart{Mode: operatorv1alpha2.ProxyRestartModeOptIn},
+		}}
+
+		predicate, err := NewAutomaticRestartPredicate(ctx, c, istioCR)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(predicate.HasExclusions()).To(BeTrue())
+		Expect(predicate.Matches(podOwnedBy("opted-in", "StatefulSet", "sts"))).To(BeTrue())
+		Expect(predicate.Matches(podOwnedBy("default", "StatefulSet", "sts"))).To(BeFalse())
+	})
+
+	It("should match the pods excluded from the automatic restart with the manual restart predicate", func() {
+		c := makeClientWithObjects(namespaceWithLabel("opted-out", labels.ProxyRestartLabelDisabled))
+
+		predicate, err := NewAutomaticRestartPredicate(ctx, c, &operatorv1alpha2.Istio{})
+
+		Expect(err).NotTo(HaveOccurred())
+		manualRestart := predicate.ManualRestart()
+		Expect(manualRestart.MustMatch()).To(BeTrue())
+		Expect(manualRestart.Matches(podOwnedBy("opted-out", "StatefulSet", "sts"))).To(BeTrue())
+		Expect(manualRestart.Matches(podOwnedBy("default", "StatefulSet", "sts"))).To(BeFalse())
+	})
+
+	It("should be a must match predicate", func() {
+		predicate, err := NewAutomaticRestartPredicate(ctx, makeClientWithObjects(), &operatorv1alpha2.Istio{})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(predicate.MustMatch()).To(BeTrue())
+	})
+})
+
+func makeClientWithObjects(objects ...client.Object) client.Client {
+	return fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(objects...).Build()
+}
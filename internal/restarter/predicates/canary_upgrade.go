@@ -0,0 +1,36 @@
+package predicates
+
+import (
+	"slices"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/kyma-project/istio/operator/api/v1alpha2"
+)
+
+// CanaryUpgradeRestartPredicate limits the restart of pods to the namespaces that were already moved to the target
+// revision of a running canary upgrade. Pods in the other namespaces would be injected by the source revision again.
+type CanaryUpgradeRestartPredicate struct {
+	canaryUpgrade *v1alpha2.CanaryUpgradeStatus
+}
+
+func NewCanaryUpgradeRestartPredicate(istioCR *v1alpha2.Istio) *CanaryUpgradeRestartPredicate {
+	return &CanaryUpgradeRestartPredicate{
+		canaryUpgrade: istioCR.Status.CanaryUpgrade,
+	}
+}
+
+func (p CanaryUpgradeRestartPredicate) Matches(pod v1.Pod) bool {
+	if p.canaryUpgrade == nil {
+		return true
+	}
+	return slices.Contains(p.canaryUpgrade.MigratedNamespaces, pod.Namespace)
+}
+
+func (p CanaryUpgradeRestartPredicate) MustMatch() bool {
+	return true
+}
+
+func (p CanaryUpgradeRestartPredicate) Name() string {
+	return "CanaryUpgradeRestartPredicate"
+}
@@ -0,0 +1,50 @@
+package predicates
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/kyma-project/istio/operator/api/v1alpha2"
+)
+
+var _ = Describe("Canary Upgrade Predicate", func() {
+	Context("Matches", func() {
+		It("should return true if no canary upgrade is in progress", func() {
+			predicate := NewCanaryUpgradeRestartPredicate(&v1alpha2.Istio{})
+			Expect(predicate.Matches(v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+				},
+			})).To(BeTrue())
+		})
+
+		It("should return true if pod is in a migrated namespace", func() {
+			predicate := NewCanaryUpgradeRestartPredicate(&v1alpha2.Istio{Status: v1alpha2.IstioStatus{
+				CanaryUpgrade: &v1alpha2.CanaryUpgradeStatus{MigratedNamespaces: []string{"migrated"}},
+			}})
+			Expect(predicate.Matches(v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "migrated",
+				},
+			})).To(BeTrue())
+		})
+
+		It("should return false if pod is not in a migrated namespace", func() {
+			predicate := NewCanaryUpgradeRestartPredicate(&v1alpha2.Istio{Status: v1alpha2.IstioStatus{
+				CanaryUpgrade: &v1alpha2.CanaryUpgradeStatus{MigratedNamespaces: []string{"migrated"}},
+			}})
+			Expect(predicate.Matches(v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+				},
+			})).To(BeFalse())
+		})
+	})
+
+	It("should be a must match predicate", func() {
+		Expect(NewCanaryUpgradeRestartPredicate(&v1alpha2.Istio{}).MustMatch()).To(BeTrue())
+	})
+})
@@ -0,0 +1,45 @@
+package predicates
+
+import (
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/kyma-project/istio/operator/api/v1alpha2"
+	"github.com/kyma-project/istio/operator/internal/reconciliations/istio/configuration"
+	"github.com/kyma-project/istio/operator/pkg/labels"
+)
+
+// CertificateAuthorityRestartPredicate restarts the proxies when the certificate authority that istiod uses changed, so that the proxies
+// get workload certificates signed by the new certificate authority. The fingerprint of the certificate authority is part of the proxy
+// config hashes, so the pods with a proxy config hash annotation are restarted by the ProxyConfigHashRestartPredicate and this predicate
+// only matches the pods that were injected before the annotation was introduced. The pods are only matched after the installation applied
+// the new fingerprint, so that the restarted pods are injected with the annotation and don't match again.
+type CertificateAuthorityRestartPredicate struct {
+	oldFingerprint       string
+	newFingerprint       string
+	installedFingerprint string
+}
+
+func NewCertificateAuthorityRestartPredicate(istioCR *v1alpha2.Istio, lastAppliedConfig configuration.AppliedConfig, installedFingerprint string) *CertificateAuthorityRestartPredicate {
+	return &CertificateAuthorityRestartPredicate{
+		oldFingerprint:       lastAppliedConfig.CertificateAuthorityFingerprint,
+		newFingerprint:       istioCR.GetCertificateAuthorityFingerprint(),
+		installedFingerprint: installedFingerprint,
+	}
+}
+
+// Matches returns true if the pod has no proxy config hash annotation, the certificate authority changed since the last applied
+// configuration and the installed proxy config hashes include the fingerprint of the new certificate authority.
+func (p CertificateAuthorityRestartPredicate) Matches(pod v1.Pod) bool {
+	if _, found := pod.Annotations[labels.ProxyConfigHash]; found {
+		return false
+	}
+	return p.oldFingerprint != p.newFingerprint && p.installedFingerprint == p.newFingerprint
+}
+
+func (p CertificateAuthorityRestartPredicate) MustMatch() bool {
+	return false
+}
+
+func (p CertificateAuthorityRestartPredicate) Name() string {
+	return "CertificateAuthorityRestartPredicate"
+}
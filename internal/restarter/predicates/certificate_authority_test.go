@@ -0,0 +1,58 @@
+package predicates
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	operatorv1alpha2 "github.com/kyma-project/istio/operator/api/v1alpha2"
+	"github.com/kyma-project/istio/operator/internal/reconciliations/istio/configuration"
+	"github.com/kyma-project/istio/operator/pkg/labels"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CertificateAuthority Predicate", func() {
+	It("should evaluate to false if the fingerprint is the same", func() {
+		predicate := CertificateAuthorityRestartPredicate{oldFingerprint: "abc", newFingerprint: "abc", installedFingerprint: "abc"}
+		Expect(predicate.Matches(v1.Pod{})).To(BeFalse())
+	})
+
+	It("should evaluate to true if the fingerprint differs and the new fingerprint is installed", func() {
+		predicate := CertificateAuthorityRestartPredicate{oldFingerprint: "abc", newFingerprint: "def", installedFingerprint: "def"}
+		Expect(predicate.Matches(v1.Pod{})).To(BeTrue())
+	})
+
+	It("should evaluate to false if the new fingerprint is not installed yet", func() {
+		predicate := CertificateAuthorityRestartPredicate{oldFingerprint: "abc", newFingerprint: "def", installedFingerprint: "abc"}
+		Expect(predicate.Matches(v1.Pod{})).To(BeFalse())
+	})
+
+	It("should evaluate to true if the custom certificate authority was removed", func() {
+		predicate := CertificateAuthorityRestartPredicate{oldFingerprint: "abc"}
+		Expect(predicate.Matches(v1.Pod{})).To(BeTrue())
+	})
+
+	It("should evaluate to false if the pod has a proxy config hash annotation", func() {
+		// given
+		predicate := CertificateAuthorityRestartPredicate{oldFingerprint: "abc", newFingerprint: "def", installedFingerprint: "def"}
+		pod := v1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{labels.ProxyConfigHash: "hash"}}}
+
+		// when
+		matches := predicate.Matches(pod)
+
+		// then
+		Expect(matches).To(BeFalse())
+	})
+
+	It("should take the fingerprints from the last applied configuration and the Istio CR status", func() {
+		istioCR := &operatorv1alpha2.Istio{Status: operatorv1alpha2.IstioStatus{
+			CertificateAuthority: &operatorv1alpha2.CertificateAuthorityStatus{Fingerprint: "def"},
+		}}
+
+		predicate := NewCertificateAuthorityRestartPredicate(istioCR, configuration.AppliedConfig{CertificateAuthorityFingerprint: "abc"}, "def")
+
+		Expect(predicate.oldFingerprint).To(Equal("abc"))
+		Expect(predicate.newFingerprint).To(Equal("def"))
+		Expect(predicate.installedFingerprint).To(Equal("def"))
+	})
+})
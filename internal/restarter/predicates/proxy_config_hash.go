@@ -0,0 +1,99 @@
+package predicates
+
+import (
+	"slices"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+
+	"github.com/kyma-project/istio/operator/api/v1alpha2"
+	"github.com/kyma-project/istio/operator/internal/reconciliations/istio/configuration"
+	"github.com/kyma-project/istio/operator/pkg/labels"
+)
+
+const (
+	prometheusMergePath = "/stats/prometheus"
+	prometheusMergePort = "15020"
+)
+
+// ProxyConfigHashRestartPredicate restarts the pods whose proxies were injected with a different proxy configuration than the one
+// that was installed last. The proxy configuration of a pod is identified by the hash in its proxy config hash annotation.
+// Pods that were injected before the annotation was introduced have no hash, so their proxy configuration is compared with the
+// lastAppliedConfiguration instead. After their restart, they have the annotation.
+type ProxyConfigHashRestartPredicate struct {
+	proxyConfigHash    string
+	proxyConfigChanged bool
+	prometheusMerge    bool
+}
+
+func NewProxyConfigHashRestartPredicate(istioCR *v1alpha2.Istio, lastAppliedConfig configuration.AppliedConfig, proxyConfigHash string) *ProxyConfigHashRestartPredicate {
+	return &ProxyConfigHashRestartPredicate{
+		proxyConfigHash:    proxyConfigHash,
+		proxyConfigChanged: proxyConfigChanged(lastAppliedConfig.IstioSpec, istioCR.Spec),
+		prometheusMerge:    istioCR.Spec.Config.Telemetry.Metrics.PrometheusMerge,
+	}
+}
+
+// Matches returns true if the annotation of the pod differs from the installed hash. If the pod has no annotation, it returns true
+// if the proxy configuration changed since the last applied configuration or the prometheusMerge annotations of the pod don't match
+// the configuration. If no hash was installed yet, no pod matches.
+func (p ProxyConfigHashRestartPredicate) Matches(pod v1.Pod) bool {
+	if p.proxyConfigHash == "" {
+		return false
+	}
+	hash, found := pod.Annotations[labels.ProxyConfigHash]
+	if !found {
+		return p.proxyConfigChanged || p.prometheusMergeChanged(pod)
+	}
+	return hash != p.proxyConfigHash
+}
+
+func (p ProxyConfigHashRestartPredicate) MustMatch() bool {
+	return false
+}
+
+func (p ProxyConfigHashRestartPredicate) Name() string {
+	return "ProxyConfigHashRestartPredicate"
+}
+
+// prometheusMergeChanged returns true if the prometheusMerge annotations of the pod are missing while prometheusMerge is enabled,
+// or present while it's disabled.
+func (p ProxyConfigHashRestartPredicate) prometheusMergeChanged(pod v1.Pod) bool {
+	hasPrometheusMergePath := pod.Annotations["prometheus.io/path"] == prometheusMergePath
+	hasPrometheusMergePort := pod.Annotations["prometheus.io/port"] == prometheusMergePort
+
+	if p.prometheusMerge {
+		return !hasPrometheusMergePath || !hasPrometheusMergePort
+	}
+	return hasPrometheusMergePath || hasPrometheusMergePort
+}
+
+// proxyConfigChanged returns true if the fields of the Istio CR that are applied to the proxy configuration of the sidecars changed.
+func proxyConfigChanged(oldSpec, newSpec v1alpha2.IstioSpec) bool {
+	if len(v1alpha2.ProxyMetaDataCompatibility) > 0 && oldSpec.CompatibilityMode != newSpec.CompatibilityMode {
+		return true
+	}
+	if !equality.Semantic.DeepEqual(oldSpec.Config.EnableDNSProxying, newSpec.Config.EnableDNSProxying) {
+		return true
+	}
+	if !slices.Equal(inclusionRegexps(oldSpec), inclusionRegexps(newSpec)) {
+		return true
+	}
+	return !equality.Semantic.DeepEqual(proxyLifecycle(oldSpec.Components), proxyLifecycle(newSpec.Components))
+}
+
+func inclusionRegexps(spec v1alpha2.IstioSpec) []string {
+	if spec.Config.ProxyStatsMatcher == nil {
+		return nil
+	}
+	regexps := slices.Clone(spec.Config.ProxyStatsMatcher.InclusionRegexps)
+	slices.Sort(regexps)
+	return regexps
+}
+
+func proxyLifecycle(components *v1alpha2.Components) v1alpha2.ProxyLifecycle {
+	if components == nil || components.Proxy == nil || components.Proxy.Lifecycle == nil {
+		return v1alpha2.ProxyLifecycle{}
+	}
+	return *components.Proxy.Lifecycle
+}
@@ -0,0 +1,123 @@
+package predicates_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/kyma-project/istio/operator/api/v1alpha2"
+	"github.com/kyma-project/istio/operator/internal/reconciliations/istio/configuration"
+	"github.com/kyma-project/istio/operator/internal/restarter/predicates"
+	"github.com/kyma-project/istio/operator/pkg/labels"
+)
+
+var _ = Describe("ProxyConfigHash Predicate", func() {
+	podWithHash := func(hash string) v1.Pod {
+		return v1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{labels.ProxyConfigHash: hash}}}
+	}
+
+	It("should evaluate to false if the pod was injected with the installed hash", func() {
+		predicate := predicates.NewProxyConfigHashRestartPredicate(&v1alpha2.Istio{}, configuration.AppliedConfig{}, "abc")
+
+		Expect(predicate.Matches(podWithHash("abc"))).To(BeFalse())
+	})
+
+	It("should evaluate to true if the pod was injected with a different hash", func() {
+		predicate := predicates.NewProxyConfigHashRestartPredicate(&v1alpha2.Istio{}, configuration.AppliedConfig{}, "abc")
+
+		Expect(predicate.Matches(podWithHash("def"))).To(BeTrue())
+	})
+
+	It("should evaluate to false if no hash was installed yet", func() {
+		predicate := predicates.NewProxyConfigHashRestartPredicate(&v1alpha2.Istio{}, configuration.AppliedConfig{}, "")
+
+		Expect(predicate.Matches(podWithHash("def"))).To(BeFalse())
+		Expect(predicate.Matches(v1.Pod{})).To(BeFalse())
+	})
+
+	Context("pod without proxy config hash annotation injected before the upgrade of the Istio module", func() {
+		It("should evaluate to false if the proxy configuration didn't change since the last applied configuration", func() {
+			// given
+			spec := v1alpha2.IstioSpec{Config: v1alpha2.Config{
+				EnableDNSProxying: ptr.To(true),
+				ProxyStatsMatcher: &v1alpha2.ProxyStatsMatcher{InclusionRegexps: []string{"b", "a"}},
+			}}
+			lastAppliedSpec := v1alpha2.IstioSpec{Config: v1alpha2.Config{
+				EnableDNSProxying: ptr.To(true),
+				ProxyStatsMatcher: &v1alpha2.ProxyStatsMatcher{InclusionRegexps: []string{"a", "b"}},
+			}}
+
+			// when
+			predicate := predicates.NewProxyConfigHashRestartPredicate(&v1alpha2.Istio{Spec: spec}, configuration.AppliedConfig{IstioSpec: lastAppliedSpec}, "abc")
+
+			// then
+			Expect(predicate.Matches(v1.Pod{})).To(BeFalse())
+		})
+
+		It("should evaluate to true if DNS proxying changed since the last applied configuration", func() {
+			// given
+			spec := v1alpha2.IstioSpec{Config: v1alpha2.Config{EnableDNSProxying: ptr.To(true)}}
+
+			// when
+			predicate := predicates.NewProxyConfigHashRestartPredicate(&v1alpha2.Istio{Spec: spec}, configuration.AppliedConfig{}, "abc")
+
+			// then
+			Expect(predicate.Matches(v1.Pod{})).To(BeTrue())
+		})
+
+		It("should evaluate to true if the proxy stats matcher changed since the last applied configuration", func() {
+			// given
+			spec := v1alpha2.IstioSpec{Config: v1alpha2.Config{
+				ProxyStatsMatcher: &v1alpha2.ProxyStatsMatcher{InclusionRegexps: []string{"a"}},
+			}}
+
+			// when
+			predicate := predicates.NewProxyConfigHashRestartPredicate(&v1alpha2.Istio{Spec: spec}, configuration.AppliedConfig{}, "abc")
+
+			// then
+			Expect(predicate.Matches(v1.Pod{})).To(BeTrue())
+		})
+
+		It("should evaluate to true if the proxy lifecycle changed since the last applied configuration", func() {
+			// given
+			spec := v1alpha2.IstioSpec{Components: &v1alpha2.Components{Proxy: &v1alpha2.ProxyComponent{
+				Lifecycle: &v1alpha2.ProxyLifecycle{HoldApplicationUntilProxyStarts: ptr.To(true)},
+			}}}
+
+			// when
+			predicate := predicates.NewProxyConfigHashRestartPredicate(&v1alpha2.Istio{Spec: spec}, configuration.AppliedConfig{}, "abc")
+
+			// then
+			Expect(predicate.Matches(v1.Pod{})).To(BeTrue())
+		})
+
+		It("should evaluate to true if prometheusMerge is enabled and the pod has no prometheusMerge annotations", func() {
+			// given
+			spec := v1alpha2.IstioSpec{Config: v1alpha2.Config{Telemetry: v1alpha2.Telemetry{Metrics: v1alpha2.Metrics{PrometheusMerge: true}}}}
+			lastAppliedSpec := spec
+
+			// when
+			predicate := predicates.NewProxyConfigHashRestartPredicate(&v1alpha2.Istio{Spec: spec}, configuration.AppliedConfig{IstioSpec: lastAppliedSpec}, "abc")
+
+			// then
+			Expect(predicate.Matches(v1.Pod{})).To(BeTrue())
+			Expect(predicate.Matches(v1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+				"prometheus.io/path": "/stats/prometheus",
+				"prometheus.io/port": "15020",
+			}}})).To(BeFalse())
+		})
+
+		It("should compare the hash after the pod was restarted with the annotation", func() {
+			// given
+			spec := v1alpha2.IstioSpec{Config: v1alpha2.Config{EnableDNSProxying: ptr.To(true)}}
+
+			// when
+			predicate := predicates.NewProxyConfigHashRestartPredicate(&v1alpha2.Istio{Spec: spec}, configuration.AppliedConfig{}, "abc")
+
+			// then
+			Expect(predicate.Matches(podWithHash("abc"))).To(BeFalse())
+		})
+	})
+})
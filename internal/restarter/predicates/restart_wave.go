@@ -0,0 +1,32 @@
+package predicates
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// RestartWavePredicate limits the restart of pods to the namespaces of a single restart wave.
+type RestartWavePredicate struct {
+	namespaces map[string]bool
+}
+
+func NewRestartWavePredicate(namespaces []string) *RestartWavePredicate {
+	namespaceSet := make(map[string]bool, len(namespaces))
+	for _, namespace := range namespaces {
+		namespaceSet[namespace] = true
+	}
+	return &RestartWavePredicate{
+		namespaces: namespaceSet,
+	}
+}
+
+func (p RestartWavePredicate) Matches(pod v1.Pod) bool {
+	return p.namespaces[pod.Namespace]
+}
+
+func (p RestartWavePredicate) MustMatch() bool {
+	return true
+}
+
+func (p RestartWavePredicate) Name() string {
+	return "RestartWavePredicate"
+}
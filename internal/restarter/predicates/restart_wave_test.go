@@ -0,0 +1,44 @@
+package predicates
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Restart Wave Predicate", func() {
+	Context("Matches", func() {
+		It("should return true if pod is in a namespace of the wave", func() {
+			predicate := NewRestartWavePredicate([]string{"wave-ns", "other-ns"})
+			Expect(predicate.Matches(v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "wave-ns",
+				},
+			})).To(BeTrue())
+		})
+
+		It("should return false if pod is not in a namespace of the wave", func() {
+			predicate := NewRestartWavePredicate([]string{"wave-ns"})
+			Expect(predicate.Matches(v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+				},
+			})).To(BeFalse())
+		})
+
+		It("should return false if the wave has no namespaces", func() {
+			predicate := NewRestartWavePredicate(nil)
+			Expect(predicate.Matches(v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+				},
+			})).To(BeFalse())
+		})
+	})
+
+	It("should be a must match predicate", func() {
+		Expect(NewRestartWavePredicate(nil).MustMatch()).To(BeTrue())
+	})
+})
@@ -0,0 +1,29 @@
+package predicates
+
+import (
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// TrustBundleRestartPredicate matches the proxies created before the given time, so that they get the trust bundle and the workload
+// certificates of the current phase of the root certificate authority rotation.
+type TrustBundleRestartPredicate struct {
+	restartedAt time.Time
+}
+
+func NewTrustBundleRestartPredicate(restartedAt time.Time) *TrustBundleRestartPredicate {
+	return &TrustBundleRestartPredicate{restartedAt: restartedAt}
+}
+
+func (p TrustBundleRestartPredicate) Matches(pod v1.Pod) bool {
+	return pod.CreationTimestamp.Time.Before(p.restartedAt)
+}
+
+func (p TrustBundleRestartPredicate) MustMatch() bool {
+	return false
+}
+
+func (p TrustBundleRestartPredicate) Name() string {
+	return "TrustBundleRestartPredicate"
+}
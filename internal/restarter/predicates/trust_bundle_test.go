@@ -0,0 +1,24 @@
+package predicates
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("TrustBundle Predicate", func() {
+	restartedAt := time.Now()
+
+	It("should evaluate to true if the pod was created before the proxies were restarted", func() {
+		pod := v1.Pod{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(restartedAt.Add(-time.Minute))}}
+		Expect(NewTrustBundleRestartPredicate(restartedAt).Matches(pod)).To(BeTrue())
+	})
+
+	It("should evaluate to false if the pod was created after the proxies were restarted", func() {
+		pod := v1.Pod{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(restartedAt.Add(time.Minute))}}
+		Expect(NewTrustBundleRestartPredicate(restartedAt).Matches(pod)).To(BeFalse())
+	})
+})
@@ -2,9 +2,12 @@ package restarter
 
 import (
 	"context"
+	"reflect"
+	"time"
 
 	operatorv1alpha2 "github.com/kyma-project/istio/operator/api/v1alpha2"
 	"github.com/kyma-project/istio/operator/internal/describederrors"
+	istiocrmetrics "github.com/kyma-project/istio/operator/internal/metrics"
 )
 
 // Restarter is an interface for restarting Istio components.
@@ -15,12 +18,14 @@ type Restarter interface {
 	Restart(ctx context.Context, istioCR *operatorv1alpha2.Istio) (describedError describederrors.DescribedError)
 }
 
-// Restart invokes the given restarters and returns the most severe error.
-func Restart(ctx context.Context, istioCR *operatorv1alpha2.Istio, restarters []Restarter) describederrors.DescribedError {
+// Restart invokes the given restarters and returns the most severe error. The duration of each restarter is recorded in the given metrics.
+func Restart(ctx context.Context, istioCR *operatorv1alpha2.Istio, restarters []Restarter, crMetrics *istiocrmetrics.IstioCRMetrics) describederrors.DescribedError {
 	var restarterErrs []describederrors.DescribedError
 
 	for _, r := range restarters {
+		start := time.Now()
 		err := r.Restart(ctx, istioCR)
+		crMetrics.ObserveReconcilePhase(restarterName(r), start, err != nil && err.Level() != describederrors.Warning)
 		if err != nil {
 			restarterErrs = append(restarterErrs, err)
 		}
@@ -28,3 +33,8 @@ func Restart(ctx context.Context, istioCR *operatorv1alpha2.Istio, restarters []
 
 	return describederrors.GetMostSevereErr(restarterErrs)
 }
+
+// restarterName returns the type name of the restarter, for example `SidecarRestarter`.
+func restarterName(r Restarter) string {
+	return reflect.Indirect(reflect.ValueOf(r)).Type().Name()
+}
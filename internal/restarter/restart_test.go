@@ -18,7 +18,7 @@ var _ = Describe("Restart", func() {
 		r2 := &restarterMock{}
 
 		// when
-		err := restarter.Restart(context.Background(), &operatorv1alpha2.Istio{}, []restarter.Restarter{r1, r2})
+		err := restarter.Restart(context.Background(), &operatorv1alpha2.Istio{}, []restarter.Restarter{r1, r2}, nil)
 
 		// then
 		Expect(err).ToNot(HaveOccurred())
@@ -26,7 +26,7 @@ var _ = Describe("Restart", func() {
 
 	It("should return nil if no restarters are provided", func() {
 		// when
-		err := restarter.Restart(context.Background(), &operatorv1alpha2.Istio{}, nil)
+		err := restarter.Restart(context.Background(), &operatorv1alpha2.Istio{}, nil, nil)
 
 		// then
 		Expect(err).ToNot(HaveOccurred())
@@ -37,7 +37,7 @@ var _ = Describe("Restart", func() {
 		r := &restarterMock{}
 
 		// when
-		err := restarter.Restart(context.Background(), &operatorv1alpha2.Istio{}, []restarter.Restarter{r})
+		err := restarter.Restart(context.Background(), &operatorv1alpha2.Istio{}, []restarter.Restarter{r}, nil)
 
 		// then
 		Expect(err).ToNot(HaveOccurred())
@@ -49,7 +49,7 @@ var _ = Describe("Restart", func() {
 		r := &restarterMock{err: describederrors.NewDescribedError(errors.New("restart error"), "")}
 
 		// when
-		err := restarter.Restart(context.Background(), &operatorv1alpha2.Istio{}, []restarter.Restarter{r})
+		err := restarter.Restart(context.Background(), &operatorv1alpha2.Istio{}, []restarter.Restarter{r}, nil)
 
 		// then
 		Expect(err).Should(MatchError("restart error"))
@@ -61,7 +61,7 @@ var _ = Describe("Restart", func() {
 		r2 := &restarterMock{err: describederrors.NewDescribedError(errors.New("restart warning"), "").SetWarning()}
 
 		// when
-		err := restarter.Restart(context.Background(), &operatorv1alpha2.Istio{}, []restarter.Restarter{r1, r2})
+		err := restarter.Restart(context.Background(), &operatorv1alpha2.Istio{}, []restarter.Restarter{r1, r2}, nil)
 
 		// then
 		Expect(err).Should(MatchError("restart error"))
@@ -73,7 +73,7 @@ var _ = Describe("Restart", func() {
 		r2 := &restarterMock{}
 
 		// when
-		err := restarter.Restart(context.Background(), &operatorv1alpha2.Istio{}, []restarter.Restarter{r1, r2})
+		err := restarter.Restart(context.Background(), &operatorv1alpha2.Istio{}, []restarter.Restarter{r1, r2}, nil)
 
 		// then
 		Expect(err).NotTo(HaveOccurred())
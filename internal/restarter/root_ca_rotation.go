@@ -0,0 +1,493 @@
+package restarter
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+
+	"istio.io/istio/security/pkg/pki/util"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kyma-project/istio/operator/api/v1alpha2"
+	"github.com/kyma-project/istio/operator/internal/describederrors"
+	"github.com/kyma-project/istio/operator/internal/restarter/predicates"
+	"github.com/kyma-project/istio/operator/internal/status"
+	"github.com/kyma-project/istio/operator/pkg/labels"
+	"github.com/kyma-project/istio/operator/pkg/lib/annotations"
+	"github.com/kyma-project/istio/operator/pkg/lib/sidecars"
+	"github.com/kyma-project/istio/operator/pkg/lib/sidecars/pods"
+	"github.com/kyma-project/istio/operator/pkg/lib/sidecars/restart"
+	"github.com/kyma-project/istio/operator/pkg/lib/sidecars/retry"
+)
+
+const (
+	selfSignedCASecretName   = "istio-ca-secret"
+	rootCARotationSecretName = "istio-ca-rotation"
+	proxyContainerName       = "istio-proxy"
+	rotationPodsPerPage      = 30
+)
+
+// RootCARotationRestarter rotates the self-signed root certificate authority of istiod before it expires. To avoid an mTLS outage,
+// the rotation runs in three phases, and all proxies are restarted in each phase:
+//   - TrustBundlePublished: istiod distributes a trust bundle with the old and the new root certificate, but still signs with the old root.
+//   - RootSwitched: istiod signs with the new root and still distributes the trust bundle with both root certificates.
+//   - OldRootRetired: istiod uses the new root as its self-signed root certificate, so the old root is no longer trusted.
+//
+// The trust bundle is published through the istio-system/cacerts Secret, which istiod prefers over its self-signed root certificate.
+type RootCARotationRestarter struct {
+	client         client.Client
+	proxyRestarter sidecars.ProxyRestarter
+	podsLister     pods.Getter
+	statusHandler  status.Status
+}
+
+func NewRootCARotationRestarter(client client.Client, proxyRestarter sidecars.ProxyRestarter, podsLister pods.Getter, statusHandler status.Status) *RootCARotationRestarter {
+	return &RootCARotationRestarter{
+		client:         client,
+		proxyRestarter: proxyRestarter,
+		podsLister:     podsLister,
+		statusHandler:  statusHandler,
+	}
+}
+
+type rootCertificate struct {
+	cert    *x509.Certificate
+	certPEM []byte
+	keyPEM  []byte
+}
+
+func (r *RootCARotationRestarter) Restart(ctx context.Context, istioCR *v1alpha2.Istio) describederrors.DescribedError {
+	selfSigned, err := r.usesSelfSignedRoot(ctx, istioCR)
+	if err != nil {
+		return r.fail(istioCR, err, "Could not evaluate the root certificate authority of istiod")
+	}
+	if !selfSigned {
+		return r.clear(ctx, istioCR)
+	}
+
+	oldRoot, err := r.getRootCertificate(ctx, selfSignedCASecretName)
+	if err != nil {
+		return r.fail(istioCR, err, "Could not read the self-signed root certificate of istiod")
+	}
+
+	if !istioCR.IsRootCARotationInProgress() {
+		istioCR.Status.RootCARotation = &v1alpha2.RootCARotationStatus{NotAfter: metav1.NewTime(oldRoot.cert.NotAfter)}
+		if time.Until(oldRoot.cert.NotAfter) > istioCR.GetRootCARenewBefore() {
+			r.statusHandler.SetCondition(istioCR, v1alpha2.NewReasonWithMessage(v1alpha2.ConditionReasonRootCARotationNotRequired,
+				fmt.Sprintf("Self-signed root certificate authority is valid until %s", oldRoot.cert.NotAfter.UTC().Format(time.RFC3339))))
+			return nil
+		}
+		ctrl.Log.Info("Starting the rotation of the self-signed root certificate authority", "notAfter", oldRoot.cert.NotAfter)
+		return r.publishTrustBundle(ctx, istioCR, oldRoot)
+	}
+
+	return r.continueRotation(ctx, istioCR, oldRoot)
+}
+
+// continueRotation restarts the proxies once istiod is ready and starts the next phase once all proxies are restarted.
+func (r *RootCARotationRestarter) continueRotation(ctx context.Context, istioCR *v1alpha2.Istio, oldRoot rootCertificate) describederrors.DescribedError {
+	rotation := istioCR.Status.RootCARotation
+
+	if rotation.ProxiesRestartedAt == nil {
+		ready, err := isIstiodReady(ctx, r.client)
+		if err != nil {
+			return r.fail(istioCR, err, "Could not evaluate the rollout of istiod")
+		}
+		if !ready {
+			r.setInProgress(istioCR, "waiting for istiod to be ready")
+			return nil
+		}
+
+		restartedAt := metav1.Now()
+		warnings, err := r.restartProxies(ctx, istioCR, restartedAt.Time)
+		if err != nil {
+			return r.fail(istioCR, err, "Could not restart the proxies")
+		}
+		rotation.ProxiesRestartedAt = &restartedAt
+		if updateErr := r.statusHandler.UpdateToProcessing(ctx, istioCR); updateErr != nil {
+			return r.fail(istioCR, updateErr, "Could not update the root certificate authority rotation status")
+		}
+		if message := sidecars.BuildWarningMessage(warnings, &ctrl.Log); message != "" {
+			r.setInProgress(istioCR, fmt.Sprintf("some proxies must be restarted manually: %s", message))
+			return nil
+		}
+		r.setInProgress(istioCR, "restarted the proxies")
+		return nil
+	}
+
+	remaining, err := r.countProxiesCreatedBefore(ctx, rotation.ProxiesRestartedAt.Time)
+	if err != nil {
+		return r.fail(istioCR, err, "Could not evaluate the restart of the proxies")
+	}
+	if remaining > 0 {
+		r.setInProgress(istioCR, fmt.Sprintf("waiting for %d Pod(s) created before %s to be restarted. Pods that aren't restarted automatically must be restarted manually",
+			remaining, rotation.ProxiesRestartedAt.UTC().Format(time.RFC3339)))
+		return nil
+	}
+
+	newRoot, err := r.getRootCertificate(ctx, rootCARotationSecretName)
+	if err != nil {
+		return r.fail(istioCR, err, "Could not read the new root certificate")
+	}
+
+	switch rotation.Phase {
+	case v1alpha2.RootCARotationPhaseTrustBundlePublished:
+		return r.switchRoot(ctx, istioCR, oldRoot, newRoot)
+	case v1alpha2.RootCARotationPhaseRootSwitched:
+		return r.retireOldRoot(ctx, istioCR, newRoot)
+	default:
+		return r.finish(ctx, istioCR, newRoot)
+	}
+}
+
+// publishTrustBundle generates the new root certificate and distributes it alongside the old one, while istiod still signs with the old root.
+func (r *RootCARotationRestarter) publishTrustBundle(ctx context.Context, istioCR *v1alpha2.Istio, oldRoot rootCertificate) describederrors.DescribedError {
+	newRoot, err := r.getRootCertificate(ctx, rootCARotationSecretName)
+	if k8sErrors.IsNotFound(err) {
+		newRoot, err = r.generateRootCertificate(ctx, oldRoot)
+	}
+	if err != nil {
+		return r.fail(istioCR, err, "Could not generate the new root certificate")
+	}
+
+	err = r.applySecret(ctx, certificateAuthoritySecretName, v1alpha2.RootCARotationPhaseTrustBundlePublished, map[string][]byte{
+		caCertKey:    oldRoot.certPEM,
+		caKeyKey:     oldRoot.keyPEM,
+		certChainKey: oldRoot.certPEM,
+		rootCertKey:  bytes.Join([][]byte{oldRoot.certPEM, newRoot.certPEM}, nil),
+	})
+	if err != nil {
+		return r.fail(istioCR, err, "Could not publish the trust bundle")
+	}
+
+	return r.enterPhase(ctx, istioCR, v1alpha2.RootCARotationPhaseTrustBundlePublished, oldRoot)
+}
+
+// switchRoot lets istiod sign with the new root certificate, while the old root certificate is still trusted.
+func (r *RootCARotationRestarter) switchRoot(ctx context.Context, istioCR *v1alpha2.Istio, oldRoot, newRoot rootCertificate) describederrors.DescribedError {
+	err := r.applySecret(ctx, certificateAuthoritySecretName, v1alpha2.RootCARotationPhaseRootSwitched, map[string][]byte{
+		caCertKey:    newRoot.certPEM,
+		caKeyKey:     newRoot.keyPEM,
+		certChainKey: newRoot.certPEM,
+		rootCertKey:  bytes.Join([][]byte{newRoot.certPEM, oldRoot.certPEM}, nil),
+	})
+	if err != nil {
+		return r.fail(istioCR, err, "Could not switch to the new root certificate")
+	}
+
+	return r.enterPhase(ctx, istioCR, v1alpha2.RootCARotationPhaseRootSwitched, newRoot)
+}
+
+// retireOldRoot stores the new root certificate as the self-signed root certificate of istiod and removes the trust bundle.
+func (r *RootCARotationRestarter) retireOldRoot(ctx context.Context, istioCR *v1alpha2.Istio, newRoot rootCertificate) describederrors.DescribedError {
+	caSecret := corev1.Secret{}
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: certificateAuthoritySecretNamespace, Name: selfSignedCASecretName}, &caSecret)
+	if err != nil {
+		return r.fail(istioCR, err, "Could not get the self-signed root certificate of istiod")
+	}
+	caSecret.Data[caCertKey] = newRoot.certPEM
+	caSecret.Data[caKeyKey] = newRoot.keyPEM
+	caSecret.Data[rootCertKey] = newRoot.certPEM
+	if err := r.client.Update(ctx, &caSecret); err != nil {
+		return r.fail(istioCR, err, "Could not store the new self-signed root certificate of istiod")
+	}
+
+	cacerts := corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: certificateAuthoritySecretNamespace, Name: certificateAuthoritySecretName}}
+	if err := r.client.Delete(ctx, &cacerts); client.IgnoreNotFound(err) != nil {
+		return r.fail(istioCR, err, "Could not remove the trust bundle")
+	}
+
+	return r.enterPhase(ctx, istioCR, v1alpha2.RootCARotationPhaseOldRootRetired, newRoot)
+}
+
+func (r *RootCARotationRestarter) finish(ctx context.Context, istioCR *v1alpha2.Istio, newRoot rootCertificate) describederrors.DescribedError {
+	if err := r.deleteRotationSecret(ctx); err != nil {
+		return r.fail(istioCR, err, "Could not remove the new root certificate after the rotation")
+	}
+
+	istioCR.Status.RootCARotation = &v1alpha2.RootCARotationStatus{NotAfter: metav1.NewTime(newRoot.cert.NotAfter)}
+	r.statusHandler.SetCondition(istioCR, v1alpha2.NewReasonWithMessage(v1alpha2.ConditionReasonRootCARotationSucceeded))
+	r.statusHandler.RecordEvent(istioCR, corev1.EventTypeNormal, v1alpha2.ConditionReasonRootCARotationSucceeded,
+		fmt.Sprintf("Rotated the self-signed root certificate authority, the new root certificate is valid until %s", newRoot.cert.NotAfter.UTC().Format(time.RFC3339)))
+	ctrl.Log.Info("Finished the rotation of the self-signed root certificate authority", "notAfter", newRoot.cert.NotAfter)
+	return nil
+}
+
+// enterPhase restarts istiod, so that it loads the certificates of the phase, and stores the phase in the status. The status is stored
+// immediately, as the certificates of the phase are already in place.
+func (r *RootCARotationRestarter) enterPhase(ctx context.Context, istioCR *v1alpha2.Istio, phase v1alpha2.RootCARotationPhase, signingRoot rootCertificate) describederrors.DescribedError {
+	if err := restartIstiodRevisions(ctx, r.client); err != nil {
+		return r.fail(istioCR, err, "Could not restart istiod")
+	}
+
+	istioCR.Status.RootCARotation = &v1alpha2.RootCARotationStatus{
+		Phase:    phase,
+		NotAfter: metav1.NewTime(signingRoot.cert.NotAfter),
+	}
+	if err := r.statusHandler.UpdateToProcessing(ctx, istioCR); err != nil {
+		return r.fail(istioCR, err, "Could not update the root certificate authority rotation status")
+	}
+
+	r.statusHandler.RecordEvent(istioCR, corev1.EventTypeNormal, v1alpha2.ConditionReasonRootCARotationInProgress,
+		fmt.Sprintf("Root certificate authority rotation entered phase %s", phase))
+	ctrl.Log.Info("Root certificate authority rotation entered the next phase", "phase", phase)
+	r.setInProgress(istioCR, "restarted istiod")
+	return nil
+}
+
+// clear removes the rotation status if istiod doesn't use a self-signed root certificate, for example, because a custom certificate authority
+// is configured. A rotation in progress is aborted.
+func (r *RootCARotationRestarter) clear(ctx context.Context, istioCR *v1alpha2.Istio) describederrors.DescribedError {
+	if istioCR.Status.RootCARotation == nil {
+		return nil
+	}
+
+	if err := r.deleteRotationSecret(ctx); err != nil {
+		return r.fail(istioCR, err, "Could not remove the new root certificate")
+	}
+	istioCR.Status.RootCARotation = nil
+	r.statusHandler.SetCondition(istioCR, v1alpha2.NewReasonWithMessage(v1alpha2.ConditionReasonRootCARotationNotRequired,
+		"Istio doesn't use a self-signed root certificate authority"))
+	return nil
+}
+
+func (r *RootCARotationRestarter) setInProgress(istioCR *v1alpha2.Istio, message string) {
+	r.statusHandler.SetCondition(istioCR, v1alpha2.NewReasonWithMessage(v1alpha2.ConditionReasonRootCARotationInProgress,
+		fmt.Sprintf("%s. Phase %s: %s", v1alpha2.ConditionReasonRootCARotationInProgressMessage, istioCR.Status.RootCARotation.Phase, message)))
+}
+
+func (r *RootCARotationRestarter) fail(istioCR *v1alpha2.Istio, err error, description string) describederrors.DescribedError {
+	describedErr := describederrors.NewDescribedError(err, description)
+	r.statusHandler.SetCondition(istioCR, v1alpha2.NewReasonWithMessage(v1alpha2.ConditionReasonRootCARotationFailed, describedErr.Description()))
+	return describedErr
+}
+
+// usesSelfSignedRoot returns true if istiod uses its self-signed root certificate or the trust bundle of a rotation in progress.
+func (r *RootCARotationRestarter) usesSelfSignedRoot(ctx context.Context, istioCR *v1alpha2.Istio) (bool, error) {
+	if istioCR.Spec.Config.CertificateAuthority != nil {
+		return false, nil
+	}
+
+	cacerts := corev1.Secret{}
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: certificateAuthoritySecretNamespace, Name: certificateAuthoritySecretName}, &cacerts)
+	if err == nil {
+		_, rotation := cacerts.Annotations[labels.RootCARotationPhase]
+		return rotation, nil
+	}
+	if !k8sErrors.IsNotFound(err) {
+		return false, err
+	}
+
+	err = r.client.Get(ctx, types.NamespacedName{Namespace: certificateAuthoritySecretNamespace, Name: selfSignedCASecretName}, &corev1.Secret{})
+	if k8sErrors.IsNotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (r *RootCARotationRestarter) getRootCertificate(ctx context.Context, secretName string) (rootCertificate, error) {
+	secret := corev1.Secret{}
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: certificateAuthoritySecretNamespace, Name: secretName}, &secret)
+	if err != nil {
+		return rootCertificate{}, err
+	}
+
+	certs, err := parseCertificates(secret.Data[caCertKey])
+	if err != nil {
+		return rootCertificate{}, fmt.Errorf("invalid %s in Secret %s: %w", caCertKey, secretName, err)
+	}
+	if len(secret.Data[caKeyKey]) == 0 {
+		return rootCertificate{}, fmt.Errorf("the Secret %s doesn't contain %s", secretName, caKeyKey)
+	}
+
+	return rootCertificate{cert: certs[0], certPEM: secret.Data[caCertKey], keyPEM: secret.Data[caKeyKey]}, nil
+}
+
+// generateRootCertificate generates a new self-signed root certificate with the validity, organization, and key type of the old one
+// and stores it until the rotation is finished.
+func (r *RootCARotationRestarter) generateRootCertificate(ctx context.Context, oldRoot rootCertificate) (rootCertificate, error) {
+	options := util.CertOptions{
+		TTL:          oldRoot.cert.NotAfter.Sub(oldRoot.cert.NotBefore),
+		IsCA:         true,
+		IsSelfSigned: true,
+	}
+	if len(oldRoot.cert.Subject.Organization) > 0 {
+		options.Org = oldRoot.cert.Subject.Organization[0]
+	}
+	switch key := oldRoot.cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		options.RSAKeySize = key.N.BitLen()
+	case *ecdsa.PublicKey:
+		options.ECSigAlg = util.EcdsaSigAlg
+		if key.Curve == elliptic.P384() {
+			options.ECCCurve = util.P384Curve
+		}
+	default:
+		return rootCertificate{}, errors.New("unsupported key type of the self-signed root certificate")
+	}
+
+	certPEM, keyPEM, err := util.GenCertKeyFromOptions(options)
+	if err != nil {
+		return rootCertificate{}, err
+	}
+	certs, err := parseCertificates(certPEM)
+	if err != nil {
+		return rootCertificate{}, err
+	}
+
+	secret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: certificateAuthoritySecretNamespace,
+			Name:      rootCARotationSecretName,
+			Labels:    labels.SetModuleLabels(nil),
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{caCertKey: certPEM, caKeyKey: keyPEM},
+	}
+	if err := r.client.Create(ctx, &secret); err != nil {
+		return rootCertificate{}, err
+	}
+	ctrl.Log.Info("Generated the new self-signed root certificate", "notAfter", certs[0].NotAfter)
+
+	return rootCertificate{cert: certs[0], certPEM: certPEM, keyPEM: keyPEM}, nil
+}
+
+func (r *RootCARotationRestarter) applySecret(ctx context.Context, name string, phase v1alpha2.RootCARotationPhase, data map[string][]byte) error {
+	secret := corev1.Secret{}
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: certificateAuthoritySecretNamespace, Name: name}, &secret)
+	if k8sErrors.IsNotFound(err) {
+		secret = corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   certificateAuthoritySecretNamespace,
+				Name:        name,
+				Labels:      labels.SetModuleLabels(nil),
+				Annotations: map[string]string{labels.RootCARotationPhase: string(phase)},
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: data,
+		}
+		return r.client.Create(ctx, &secret)
+	}
+	if err != nil {
+		return err
+	}
+
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	secret.Annotations[labels.RootCARotationPhase] = string(phase)
+	secret.Data = data
+	return r.client.Update(ctx, &secret)
+}
+
+func (r *RootCARotationRestarter) deleteRotationSecret(ctx context.Context) error {
+	secret := corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: certificateAuthoritySecretNamespace, Name: rootCARotationSecretName}}
+	return client.IgnoreNotFound(r.client.Delete(ctx, &secret))
+}
+
+// restartProxies restarts the proxy sidecars and the gateways created before the given time. Workloads that are excluded from automatic
+// restarts are not restarted, so they must be restarted manually before the rotation continues.
+func (r *RootCARotationRestarter) restartProxies(ctx context.Context, istioCR *v1alpha2.Istio, restartedAt time.Time) ([]restart.Warning, error) {
+	preds := []predicates.SidecarProxyPredicate{predicates.NewTrustBundleRestartPredicate(restartedAt)}
+	automaticRestart, err := predicates.NewAutomaticRestartPredicate(ctx, r.client, istioCR)
+	if err != nil {
+		return nil, err
+	}
+	if automaticRestart.HasExclusions() {
+		preds = append(preds, automaticRestart)
+	}
+
+	warnings, err := r.proxyRestarter.RestartWithPredicates(ctx, preds, pods.NewPodsRestartLimits(rotationPodsPerPage), false)
+	if err != nil {
+		return warnings, err
+	}
+
+	return warnings, restartGateways(ctx, r.client)
+}
+
+// countProxiesCreatedBefore returns the number of Pods with a proxy sidecar and gateway Pods that were created before the given time.
+func (r *RootCARotationRestarter) countProxiesCreatedBefore(ctx context.Context, restartedAt time.Time) (int, error) {
+	count := 0
+	preds := []predicates.SidecarProxyPredicate{predicates.NewTrustBundleRestartPredicate(restartedAt)}
+	err := r.podsLister.GetPodsToRestart(ctx, preds, pods.NewPodsRestartLimits(rotationPodsPerPage), func(_ context.Context, page *corev1.PodList) error {
+		count += len(page.Items)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	gatewayPods := corev1.PodList{}
+	if err := r.client.List(ctx, &gatewayPods, client.InNamespace(istiodNamespace)); err != nil {
+		return 0, err
+	}
+	for _, pod := range gatewayPods.Items {
+		if hasProxyContainer(pod.Spec) && pod.Status.Phase == corev1.PodRunning && pod.CreationTimestamp.Time.Before(restartedAt) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// restartGateways restarts the gateway Deployments in istio-system, which are identified by the istio-proxy container.
+func restartGateways(ctx context.Context, c client.Client) error {
+	deployments := appsv1.DeploymentList{}
+	if err := c.List(ctx, &deployments, client.InNamespace(istiodNamespace)); err != nil {
+		return err
+	}
+
+	for _, deployment := range deployments.Items {
+		if !hasProxyContainer(deployment.Spec.Template.Spec) {
+			continue
+		}
+		ctrl.Log.Info("Restarting gateway", "deployment", deployment.Name)
+		err := retry.OnError(retry.DefaultRetry, func() error {
+			patch := client.StrategicMergeFrom(deployment.DeepCopy())
+			deployment.Spec.Template.Annotations = annotations.AddRestartAnnotation(deployment.Spec.Template.Annotations)
+			return c.Patch(ctx, &deployment, patch)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func hasProxyContainer(spec corev1.PodSpec) bool {
+	for _, container := range spec.Containers {
+		if container.Name == proxyContainerName {
+			return true
+		}
+	}
+	return false
+}
+
+// isIstiodReady returns true if the rollout of the istiod Deployments of all revisions is finished.
+func isIstiodReady(ctx context.Context, c client.Client) (bool, error) {
+	deployments := appsv1.DeploymentList{}
+	err := c.List(ctx, &deployments, client.InNamespace(istiodNamespace), client.MatchingLabels{"app": "istiod"})
+	if err != nil {
+		return false, err
+	}
+
+	for _, deployment := range deployments.Items {
+		if !restart.IsDeploymentReady(&deployment) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,313 @@
+package restarter_test
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"istio.io/istio/security/pkg/pki/util"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	operatorv1alpha2 "github.com/kyma-project/istio/operator/api/v1alpha2"
+	"github.com/kyma-project/istio/operator/internal/restarter"
+	"github.com/kyma-project/istio/operator/internal/restarter/predicates"
+	"github.com/kyma-project/istio/operator/internal/status"
+	"github.com/kyma-project/istio/operator/pkg/labels"
+	"github.com/kyma-project/istio/operator/pkg/lib/annotations"
+	"github.com/kyma-project/istio/operator/pkg/lib/sidecars/pods"
+	"github.com/kyma-project/istio/operator/pkg/lib/sidecars/restart"
+)
+
+var _ = Describe("Root certificate authority rotation", func() {
+	It("should only report the expiry of the root certificate if it doesn't need to be rotated", func() {
+		// given
+		istioCR := createIstioCR()
+		selfSignedCA := createSelfSignedCASecret("istio-ca-secret", 365*24*time.Hour)
+		fakeClient := createFakeClient(istioCR, selfSignedCA, createIstiodDeployment())
+		rotationRestarter := restarter.NewRootCARotationRestarter(fakeClient, &rotationProxyRestarterMock{}, &rotationPodsMock{}, status.NewStatusHandler(fakeClient))
+
+		// when
+		err := rotationRestarter.Restart(context.Background(), istioCR)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(istioCR.Status.RootCARotation).ToNot(BeNil())
+		Expect(istioCR.Status.RootCARotation.Phase).To(Equal(operatorv1alpha2.RootCARotationPhaseNone))
+		Expect(istioCR.Status.RootCARotation.NotAfter.Time).To(BeTemporally(">", time.Now().Add(364*24*time.Hour)))
+		Expect((*istioCR.Status.Conditions)[0].Reason).To(Equal(string(operatorv1alpha2.ConditionReasonRootCARotationNotRequired)))
+		Expect(k8serrors.IsNotFound(fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "istio-system", Name: "cacerts"}, &v1.Secret{}))).To(BeTrue())
+	})
+
+	It("should publish the trust bundle with the old and the new root certificate if the root certificate expires soon", func() {
+		// given
+		istioCR := createIstioCR()
+		selfSignedCA := createSelfSignedCASecret("istio-ca-secret", 30*24*time.Hour)
+		istiod := createIstiodDeployment()
+		fakeClient := createFakeClient(istioCR, selfSignedCA, istiod)
+		rotationRestarter := restarter.NewRootCARotationRestarter(fakeClient, &rotationProxyRestarterMock{}, &rotationPodsMock{}, status.NewStatusHandler(fakeClient))
+
+		// when
+		err := rotationRestarter.Restart(context.Background(), istioCR)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		newRoot := v1.Secret{}
+		Expect(fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "istio-system", Name: "istio-ca-rotation"}, &newRoot)).To(Succeed())
+
+		cacerts := v1.Secret{}
+		Expect(fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "istio-system", Name: "cacerts"}, &cacerts)).To(Succeed())
+		Expect(cacerts.Annotations).To(HaveKeyWithValue(labels.RootCARotationPhase, string(operatorv1alpha2.RootCARotationPhaseTrustBundlePublished)))
+		Expect(cacerts.Data["ca-cert.pem"]).To(Equal(selfSignedCA.Data["ca-cert.pem"]))
+		Expect(cacerts.Data["root-cert.pem"]).To(Equal(bytes.Join([][]byte{selfSignedCA.Data["ca-cert.pem"], newRoot.Data["ca-cert.pem"]}, nil)))
+
+		Expect(fakeClient.Get(context.Background(), client.ObjectKeyFromObject(istiod), istiod)).To(Succeed())
+		Expect(annotations.HasRestartAnnotation(istiod.Spec.Template.Annotations)).To(BeTrue())
+
+		storedCR := operatorv1alpha2.Istio{}
+		Expect(fakeClient.Get(context.Background(), client.ObjectKeyFromObject(istioCR), &storedCR)).To(Succeed())
+		Expect(storedCR.IsRootCARotationInProgress()).To(BeTrue())
+		Expect(storedCR.Status.RootCARotation.Phase).To(Equal(operatorv1alpha2.RootCARotationPhaseTrustBundlePublished))
+		Expect(storedCR.Status.RootCARotation.ProxiesRestartedAt).To(BeNil())
+		Expect((*istioCR.Status.Conditions)[0].Reason).To(Equal(string(operatorv1alpha2.ConditionReasonRootCARotationInProgress)))
+	})
+
+	It("should not restart the proxies until istiod is ready", func() {
+		// given
+		istioCR := createIstioCRWithRootCARotation(operatorv1alpha2.RootCARotationPhaseTrustBundlePublished, nil)
+		proxyRestarter := &rotationProxyRestarterMock{}
+		fakeClient := createFakeClient(istioCR, createSelfSignedCASecret("istio-ca-secret", 30*24*time.Hour),
+			createRotationCACerts(operatorv1alpha2.RootCARotationPhaseTrustBundlePublished), createIstiodDeployment())
+		rotationRestarter := restarter.NewRootCARotationRestarter(fakeClient, proxyRestarter, &rotationPodsMock{}, status.NewStatusHandler(fakeClient))
+
+		// when
+		err := rotationRestarter.Restart(context.Background(), istioCR)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(proxyRestarter.called).To(BeFalse())
+		Expect(istioCR.Status.RootCARotation.ProxiesRestartedAt).To(BeNil())
+	})
+
+	It("should restart the proxies and gateways created before the restart once istiod is ready", func() {
+		// given
+		istioCR := createIstioCRWithRootCARotation(operatorv1alpha2.RootCARotationPhaseTrustBundlePublished, nil)
+		proxyRestarter := &rotationProxyRestarterMock{}
+		gateway := createGatewayDep("istio-ingressgateway", time.Now().Add(-time.Hour))
+		gateway.Spec.Template.Spec.Containers = []v1.Container{{Name: "istio-proxy"}}
+		fakeClient := createFakeClient(istioCR, createSelfSignedCASecret("istio-ca-secret", 30*24*time.Hour),
+			createRotationCACerts(operatorv1alpha2.RootCARotationPhaseTrustBundlePublished), createReadyIstiodDeployment(), gateway)
+		rotationRestarter := restarter.NewRootCARotationRestarter(fakeClient, proxyRestarter, &rotationPodsMock{}, status.NewStatusHandler(fakeClient))
+
+		// when
+		err := rotationRestarter.Restart(context.Background(), istioCR)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(proxyRestarter.called).To(BeTrue())
+		Expect(proxyRestarter.preds).To(HaveLen(1))
+		Expect(proxyRestarter.preds[0].Name()).To(Equal("TrustBundleRestartPredicate"))
+
+		Expect(fakeClient.Get(context.Background(), client.ObjectKeyFromObject(gateway), gateway)).To(Succeed())
+		Expect(annotations.HasRestartAnnotation(gateway.Spec.Template.Annotations)).To(BeTrue())
+
+		storedCR := operatorv1alpha2.Istio{}
+		Expect(fakeClient.Get(context.Background(), client.ObjectKeyFromObject(istioCR), &storedCR)).To(Succeed())
+		Expect(storedCR.Status.RootCARotation.ProxiesRestartedAt).ToNot(BeNil())
+	})
+
+	It("should wait until all proxies created before the restart are restarted", func() {
+		// given
+		istioCR := createIstioCRWithRootCARotation(operatorv1alpha2.RootCARotationPhaseTrustBundlePublished, ptr.To(metav1.Now()))
+		podsLister := &rotationPodsMock{pods: []v1.Pod{*createPod("application", "default", "istio-proxy", "1.0.0")}}
+		fakeClient := createFakeClient(istioCR, createSelfSignedCASecret("istio-ca-secret", 30*24*time.Hour),
+			createSelfSignedCASecret("istio-ca-rotation", 365*24*time.Hour),
+			createRotationCACerts(operatorv1alpha2.RootCARotationPhaseTrustBundlePublished), createReadyIstiodDeployment())
+		rotationRestarter := restarter.NewRootCARotationRestarter(fakeClient, &rotationProxyRestarterMock{}, podsLister, status.NewStatusHandler(fakeClient))
+
+		// when
+		err := rotationRestarter.Restart(context.Background(), istioCR)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(istioCR.Status.RootCARotation.Phase).To(Equal(operatorv1alpha2.RootCARotationPhaseTrustBundlePublished))
+		Expect((*istioCR.Status.Conditions)[0].Message).To(ContainSubstring("waiting for 1 Pod(s)"))
+	})
+
+	It("should switch to the new root certificate once all proxies trust it", func() {
+		// given
+		istioCR := createIstioCRWithRootCARotation(operatorv1alpha2.RootCARotationPhaseTrustBundlePublished, ptr.To(metav1.Now()))
+		newRoot := createSelfSignedCASecret("istio-ca-rotation", 365*24*time.Hour)
+		fakeClient := createFakeClient(istioCR, createSelfSignedCASecret("istio-ca-secret", 30*24*time.Hour), newRoot,
+			createRotationCACerts(operatorv1alpha2.RootCARotationPhaseTrustBundlePublished), createReadyIstiodDeployment())
+		rotationRestarter := restarter.NewRootCARotationRestarter(fakeClient, &rotationProxyRestarterMock{}, &rotationPodsMock{}, status.NewStatusHandler(fakeClient))
+
+		// when
+		err := rotationRestarter.Restart(context.Background(), istioCR)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		cacerts := v1.Secret{}
+		Expect(fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "istio-system", Name: "cacerts"}, &cacerts)).To(Succeed())
+		Expect(cacerts.Annotations).To(HaveKeyWithValue(labels.RootCARotationPhase, string(operatorv1alpha2.RootCARotationPhaseRootSwitched)))
+		Expect(cacerts.Data["ca-cert.pem"]).To(Equal(newRoot.Data["ca-cert.pem"]))
+		Expect(cacerts.Data["ca-key.pem"]).To(Equal(newRoot.Data["ca-key.pem"]))
+
+		Expect(istioCR.Status.RootCARotation.Phase).To(Equal(operatorv1alpha2.RootCARotationPhaseRootSwitched))
+		Expect(istioCR.Status.RootCARotation.ProxiesRestartedAt).To(BeNil())
+	})
+
+	It("should retire the old root certificate once all proxies use the new one", func() {
+		// given
+		istioCR := createIstioCRWithRootCARotation(operatorv1alpha2.RootCARotationPhaseRootSwitched, ptr.To(metav1.Now()))
+		newRoot := createSelfSignedCASecret("istio-ca-rotation", 365*24*time.Hour)
+		fakeClient := createFakeClient(istioCR, createSelfSignedCASecret("istio-ca-secret", 30*24*time.Hour), newRoot,
+			createRotationCACerts(operatorv1alpha2.RootCARotationPhaseRootSwitched), createReadyIstiodDeployment())
+		rotationRestarter := restarter.NewRootCARotationRestarter(fakeClient, &rotationProxyRestarterMock{}, &rotationPodsMock{}, status.NewStatusHandler(fakeClient))
+
+		// when
+		err := rotationRestarter.Restart(context.Background(), istioCR)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+
+		selfSignedCA := v1.Secret{}
+		Expect(fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "istio-system", Name: "istio-ca-secret"}, &selfSignedCA)).To(Succeed())
+		Expect(selfSignedCA.Data["ca-cert.pem"]).To(Equal(newRoot.Data["ca-cert.pem"]))
+		Expect(selfSignedCA.Data["ca-key.pem"]).To(Equal(newRoot.Data["ca-key.pem"]))
+		Expect(k8serrors.IsNotFound(fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "istio-system", Name: "cacerts"}, &v1.Secret{}))).To(BeTrue())
+
+		Expect(istioCR.Status.RootCARotation.Phase).To(Equal(operatorv1alpha2.RootCARotationPhaseOldRootRetired))
+	})
+
+	It("should finish the rotation once all proxies are restarted after the old root certificate is retired", func() {
+		// given
+		istioCR := createIstioCRWithRootCARotation(operatorv1alpha2.RootCARotationPhaseOldRootRetired, ptr.To(metav1.Now()))
+		newRoot := createSelfSignedCASecret("istio-ca-rotation", 365*24*time.Hour)
+		fakeClient := createFakeClient(istioCR, createSelfSignedCASecret("istio-ca-secret", 365*24*time.Hour), newRoot, createReadyIstiodDeployment())
+		rotationRestarter := restarter.NewRootCARotationRestarter(fakeClient, &rotationProxyRestarterMock{}, &rotationPodsMock{}, status.NewStatusHandler(fakeClient))
+
+		// when
+		err := rotationRestarter.Restart(context.Background(), istioCR)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(k8serrors.IsNotFound(fakeClient.Get(context.Background(), client.ObjectKeyFromObject(newRoot), &v1.Secret{}))).To(BeTrue())
+		Expect(istioCR.IsRootCARotationInProgress()).To(BeFalse())
+		Expect((*istioCR.Status.Conditions)[0].Reason).To(Equal(string(operatorv1alpha2.ConditionReasonRootCARotationSucceeded)))
+	})
+
+	It("should abort the rotation if a custom certificate authority is configured", func() {
+		// given
+		istioCR := createIstioCRWithCertificateAuthority()
+		istioCR.Status.RootCARotation = &operatorv1alpha2.RootCARotationStatus{Phase: operatorv1alpha2.RootCARotationPhaseTrustBundlePublished}
+		newRoot := createSelfSignedCASecret("istio-ca-rotation", 365*24*time.Hour)
+		fakeClient := createFakeClient(istioCR, createSelfSignedCASecret("istio-ca-secret", 30*24*time.Hour), newRoot)
+		rotationRestarter := restarter.NewRootCARotationRestarter(fakeClient, &rotationProxyRestarterMock{}, &rotationPodsMock{}, status.NewStatusHandler(fakeClient))
+
+		// when
+		err := rotationRestarter.Restart(context.Background(), istioCR)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(istioCR.Status.RootCARotation).To(BeNil())
+		Expect(k8serrors.IsNotFound(fakeClient.Get(context.Background(), client.ObjectKeyFromObject(newRoot), &v1.Secret{}))).To(BeTrue())
+		Expect((*istioCR.Status.Conditions)[0].Reason).To(Equal(string(operatorv1alpha2.ConditionReasonRootCARotationNotRequired)))
+	})
+
+	It("should not rotate a root certificate authority that is plugged in by the user", func() {
+		// given
+		istioCR := createIstioCR()
+		cacerts := createSelfSignedCASecret("cacerts", 30*24*time.Hour)
+		fakeClient := createFakeClient(istioCR, createSelfSignedCASecret("istio-ca-secret", 30*24*time.Hour), cacerts)
+		rotationRestarter := restarter.NewRootCARotationRestarter(fakeClient, &rotationProxyRestarterMock{}, &rotationPodsMock{}, status.NewStatusHandler(fakeClient))
+
+		// when
+		err := rotationRestarter.Restart(context.Background(), istioCR)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(istioCR.Status.RootCARotation).To(BeNil())
+		Expect(k8serrors.IsNotFound(fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "istio-system", Name: "istio-ca-rotation"}, &v1.Secret{}))).To(BeTrue())
+	})
+})
+
+func createIstioCRWithRootCARotation(phase operatorv1alpha2.RootCARotationPhase, proxiesRestartedAt *metav1.Time) *operatorv1alpha2.Istio {
+	istioCR := createIstioCR()
+	istioCR.Status.RootCARotation = &operatorv1alpha2.RootCARotationStatus{Phase: phase, ProxiesRestartedAt: proxiesRestartedAt}
+	return istioCR
+}
+
+// createSelfSignedCASecret creates a Secret with a self-signed root certificate in the format of the istio-system/istio-ca-secret Secret.
+func createSelfSignedCASecret(name string, ttl time.Duration) *v1.Secret {
+	certPEM, keyPEM, err := util.GenCertKeyFromOptions(util.CertOptions{
+		TTL:          ttl,
+		Org:          "cluster.local",
+		IsCA:         true,
+		IsSelfSigned: true,
+		ECSigAlg:     util.EcdsaSigAlg,
+	})
+	Expect(err).ToNot(HaveOccurred())
+
+	return &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "istio-system", Name: name},
+		Data: map[string][]byte{
+			"ca-cert.pem":   certPEM,
+			"ca-key.pem":    keyPEM,
+			"root-cert.pem": certPEM,
+		},
+	}
+}
+
+func createRotationCACerts(phase operatorv1alpha2.RootCARotationPhase) *v1.Secret {
+	cacerts := createSelfSignedCASecret("cacerts", 30*24*time.Hour)
+	cacerts.Annotations = map[string]string{labels.RootCARotationPhase: string(phase)}
+	return cacerts
+}
+
+func createReadyIstiodDeployment() *appsv1.Deployment {
+	istiod := createIstiodDeployment()
+	istiod.Spec.Replicas = ptr.To(int32(1))
+	istiod.Status = appsv1.DeploymentStatus{
+		ObservedGeneration: 1,
+		Replicas:           1,
+		UpdatedReplicas:    1,
+		AvailableReplicas:  1,
+	}
+	return istiod
+}
+
+type rotationProxyRestarterMock struct {
+	proxyRestarterMock
+	called bool
+	preds  []predicates.SidecarProxyPredicate
+}
+
+func (p *rotationProxyRestarterMock) RestartWithPredicates(_ context.Context, preds []predicates.SidecarProxyPredicate, _ *pods.RestartLimits, _ bool) ([]restart.Warning, error) {
+	p.called = true
+	p.preds = preds
+	return p.restartWarnings, p.err
+}
+
+type rotationPodsMock struct {
+	pods []v1.Pod
+}
+
+func (p *rotationPodsMock) GetPodsToRestart(ctx context.Context, _ []predicates.SidecarProxyPredicate, _ *pods.RestartLimits, restartFn func(context.Context, *v1.PodList) error) error {
+	if len(p.pods) == 0 {
+		return nil
+	}
+	return restartFn(ctx, &v1.PodList{Items: p.pods})
+}
+
+func (p *rotationPodsMock) GetAllInjectedPods(_ context.Context) (*v1.PodList, error) {
+	return &v1.PodList{Items: p.pods}, nil
+}
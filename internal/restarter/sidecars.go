@@ -2,14 +2,19 @@ package restarter
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
 
 	"github.com/kyma-project/istio/operator/api/v1alpha2"
 	"github.com/kyma-project/istio/operator/internal/describederrors"
 	"github.com/kyma-project/istio/operator/internal/images"
+	"github.com/kyma-project/istio/operator/internal/maintenancewindow"
 	"github.com/kyma-project/istio/operator/internal/reconciliations/istio/configuration"
 
 	"github.com/go-logr/logr"
@@ -21,6 +26,7 @@ import (
 	"github.com/kyma-project/istio/operator/internal/status"
 	"github.com/kyma-project/istio/operator/pkg/lib/gatherer"
 	"github.com/kyma-project/istio/operator/pkg/lib/sidecars"
+	"github.com/kyma-project/istio/operator/pkg/lib/sidecars/restart"
 )
 
 const errorDescription = "Error occurred during reconciliation of Istio Sidecars"
@@ -80,10 +86,14 @@ func (s *SidecarRestarter) Restart(ctx context.Context, istioCR *v1alpha2.Istio)
 
 	s.Log.Info("Running proxy sidecar reset", "expected image", expectedImage)
 
-	err = gatherer.VerifyIstioPodsVersion(ctx, s.Client, istioImageVersion.Version())
-	if err != nil {
-		s.StatusHandler.SetCondition(istioCR, v1alpha2.NewReasonWithMessage(v1alpha2.ConditionReasonProxySidecarRestartFailed))
-		return describederrors.NewDescribedError(err, "Verifying Pod versions in istio-system namespace failed")
+	// During a canary upgrade the Pods of the source and the target revision are running in istio-system. The installation
+	// already verified the version of the target revision.
+	if istioCR.Status.CanaryUpgrade == nil {
+		err = gatherer.VerifyIstioPodsVersion(ctx, s.Client, istioImageVersion.Version())
+		if err != nil {
+			s.StatusHandler.SetCondition(istioCR, v1alpha2.NewReasonWithMessage(v1alpha2.ConditionReasonProxySidecarRestartFailed))
+			return describederrors.NewDescribedError(err, "Verifying Pod versions in istio-system namespace failed")
+		}
 	}
 
 	expectedResources, err := istioCR.GetProxyResources(iop)
@@ -93,18 +103,56 @@ func (s *SidecarRestarter) Restart(ctx context.Context, istioCR *v1alpha2.Istio)
 		return describederrors.NewDescribedError(err, errorDescription)
 	}
 
+	previousProxyReset := istioCR.Status.ProxyReset
 	warnings, err := s.ProxyRestarter.RestartProxies(ctx, expectedImage, expectedResources, istioCR)
+	var deferredErr *sidecars.RestartDeferredError
+	if errors.As(err, &deferredErr) {
+		// The lastAppliedConfiguration is not updated, so that the deferred restarts are evaluated again in the next maintenance window
+		message := s.deferredMessage(istioCR, deferredErr)
+		s.StatusHandler.SetCondition(istioCR, v1alpha2.NewReasonWithMessage(v1alpha2.ConditionReasonProxySidecarRestartDeferred, message))
+		s.StatusHandler.RecordEvent(istioCR, corev1.EventTypeNormal, v1alpha2.ConditionReasonProxySidecarRestartDeferred, message)
+		return nil
+	}
+	var rolloutPendingErr *sidecars.RestartRolloutPendingError
+	if errors.As(err, &rolloutPendingErr) {
+		// The lastAppliedConfiguration is not updated, so that the remaining restarts are evaluated again after the rollouts finished
+		message := fmt.Sprintf("Proxy sidecar restart waits for the rollout of %d restarted workload(s)", rolloutPendingErr.PendingRollouts)
+		s.StatusHandler.SetCondition(istioCR, v1alpha2.NewReasonWithMessage(v1alpha2.ConditionReasonProxySidecarRestartInProgress, message))
+		return nil
+	}
+	var pendingErr *sidecars.RestartPendingError
+	if errors.As(err, &pendingErr) {
+		// The lastAppliedConfiguration is not updated, so that the pending restarts are evaluated again in the next reconciliation
+		message := fmt.Sprintf("Proxy sidecar restart of %d Pod(s) waits for their workloads to become ready", pendingErr.PendingPods)
+		s.StatusHandler.SetCondition(istioCR, v1alpha2.NewReasonWithMessage(v1alpha2.ConditionReasonProxySidecarRestartInProgress, message))
+		return nil
+	}
+	var healthGateErr *sidecars.RestartHealthGateError
+	if errors.As(err, &healthGateErr) {
+		// The tripped health gate is kept in the Istio CR status, so that no further workloads are restarted until the unhealthy
+		// workloads become ready
+		s.Log.Info("Proxy reset stopped by the health gate", "unhealthy workloads", len(healthGateErr.UnhealthyOwners))
+		message := fmt.Sprintf("Proxy sidecar restart was stopped: %s", healthGateErr.Error())
+		s.StatusHandler.SetCondition(istioCR, v1alpha2.NewReasonWithMessage(v1alpha2.ConditionReasonProxySidecarRestartFailed, message))
+		s.recordWorkloadEvents(istioCR, healthGateErr.UnhealthyOwners, v1alpha2.ConditionReasonProxySidecarRestartFailed)
+		s.StatusHandler.RecordEvent(istioCR, corev1.EventTypeWarning, v1alpha2.ConditionReasonProxySidecarRestartFailed, message)
+		return describederrors.NewDescribedError(err, "Proxy sidecar restart was stopped").SetWarning()
+	}
 	if err != nil {
 		s.Log.Error(err, "Failed to reset proxy")
 		s.StatusHandler.SetCondition(istioCR, v1alpha2.NewReasonWithMessage(v1alpha2.ConditionReasonProxySidecarRestartFailed))
 		return describederrors.NewDescribedError(err, errorDescription)
 	}
 
+	s.setRestartNotDeferred(istioCR)
+
 	warningMessage := sidecars.BuildWarningMessage(warnings, &s.Log)
 	if warningMessage != "" {
 		warningErr := describederrors.NewDescribedError(errors.New("could not restart one or more Istio-injected Pods"), "Some Pods with Istio sidecar injection failed to restart. To learn more about the warning, see kyma-system/istio-controller-manager logs").
 			SetWarning()
 		s.StatusHandler.SetCondition(istioCR, v1alpha2.NewReasonWithMessage(v1alpha2.ConditionReasonProxySidecarManualRestartRequired, warningMessage))
+		s.recordWorkloadEvents(istioCR, warnings, v1alpha2.ConditionReasonProxySidecarManualRestartRequired)
+		s.StatusHandler.RecordEvent(istioCR, corev1.EventTypeWarning, v1alpha2.ConditionReasonProxySidecarManualRestartRequired, warningMessage)
 		s.Log.Info(warningMessage)
 		return warningErr
 	}
@@ -115,10 +163,62 @@ func (s *SidecarRestarter) Restart(ctx context.Context, istioCR *v1alpha2.Istio)
 		s.Log.Error(err, "Failed to update lastAppliedConfiguration after sidecar restart")
 	}
 	s.StatusHandler.SetCondition(istioCR, v1alpha2.NewReasonWithMessage(v1alpha2.ConditionReasonProxySidecarRestartSucceeded))
+	// The proxy reset status is only replaced if Pods were matched in this run, so the event is not emitted for every reconciliation.
+	if proxyReset := istioCR.Status.ProxyReset; proxyReset != previousProxyReset && proxyReset != nil && proxyReset.RestartedPods > 0 {
+		s.StatusHandler.RecordEvent(istioCR, corev1.EventTypeNormal, v1alpha2.ConditionReasonProxySidecarRestartSucceeded,
+			fmt.Sprintf("Restarted proxy sidecars of %d Pod(s) to apply image %s", proxyReset.RestartedPods, proxyReset.TargetImage))
+	}
 
 	return nil
 }
 
+// recordWorkloadEvents emits an event on each workload of the given warnings, so that the workload owners see the event without
+// having access to the Istio CR.
+func (s *SidecarRestarter) recordWorkloadEvents(istioCR *v1alpha2.Istio, warnings []restart.Warning, reason v1alpha2.ConditionReason) {
+	for _, w := range warnings {
+		ref := workloadReference(w)
+		if ref == nil {
+			continue
+		}
+		s.StatusHandler.RecordObjectEvent(istioCR, ref, corev1.EventTypeWarning, reason, w.Message)
+	}
+}
+
+func workloadReference(w restart.Warning) *corev1.ObjectReference {
+	var apiVersion string
+	switch w.Kind {
+	case "Deployment", "StatefulSet", "DaemonSet", "ReplicaSet":
+		apiVersion = "apps/v1"
+	case "Pod", "ReplicationController":
+		apiVersion = "v1"
+	case "Job":
+		apiVersion = "batch/v1"
+	default:
+		return nil
+	}
+	return &corev1.ObjectReference{Kind: w.Kind, APIVersion: apiVersion, Name: w.Name, Namespace: w.Namespace}
+}
+
+func (s *SidecarRestarter) deferredMessage(istioCR *v1alpha2.Istio, deferredErr *sidecars.RestartDeferredError) string {
+	message := fmt.Sprintf("Proxy sidecar restart of %d customer workload Pod(s) is deferred until the next maintenance window", deferredErr.PendingPods)
+	nextWindow, err := maintenancewindow.NextOpening(istioCR.GetMaintenanceWindows(), time.Now())
+	if err != nil {
+		s.Log.Error(err, "Failed to evaluate the next maintenance window")
+		return message
+	}
+	return fmt.Sprintf("%s at %s", message, nextWindow.UTC().Format(time.RFC3339))
+}
+
+// setRestartNotDeferred sets the condition only if maintenance windows are configured or the restart was deferred before,
+// so that the condition is not added to Istio CRs that don't use maintenance windows.
+func (s *SidecarRestarter) setRestartNotDeferred(istioCR *v1alpha2.Istio) {
+	deferredBefore := istioCR.Status.Conditions != nil &&
+		meta.FindStatusCondition(*istioCR.Status.Conditions, string(v1alpha2.ConditionTypeProxySidecarRestartDeferred)) != nil
+	if len(istioCR.GetMaintenanceWindows()) > 0 || deferredBefore {
+		s.StatusHandler.SetCondition(istioCR, v1alpha2.NewReasonWithMessage(v1alpha2.ConditionReasonProxySidecarRestartNotDeferred))
+	}
+}
+
 func (s *SidecarRestarter) updateLastAppliedConfiguration(ctx context.Context, istioCR *v1alpha2.Istio) error {
 	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		currentIstioCR := v1alpha2.Istio{}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo/v2"
@@ -12,7 +13,9 @@ import (
 	networkingv1 "istio.io/client-go/pkg/apis/networking/v1"
 	iopv1alpha1 "istio.io/istio/operator/pkg/apis"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/kubectl/pkg/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -142,6 +145,26 @@ var _ = Describe("SidecarsRestarter reconciliation", func() {
 		Expect((*istioCr.Status.Conditions)[0].Status).To(Equal(metav1.ConditionFalse))
 	})
 
+	It("should not verify the version of Istio pods while a canary upgrade is in progress", func() {
+		// given
+		istioCr := createIstioCR()
+		istioCr.Status.CanaryUpgrade = &operatorv1alpha2.CanaryUpgradeStatus{SourceRevision: "default", TargetRevision: "1-16-1", TargetVersion: "1.16.1"}
+		istiodSource := createPod("istiod", gatherer.IstioNamespace, "discovery", "1.16.0", "kyma-project.io/module=istio")
+		istiodTarget := createPod("istiod-1-16-1", gatherer.IstioNamespace, "discovery", "1.16.1", "kyma-project.io/module=istio")
+		proxyRestarter := &proxyRestarterMock{}
+		fakeClient := createFakeClient(istioCr, istiodSource, istiodTarget)
+		statusHandler := status.NewStatusHandler(fakeClient)
+		sidecarsRestarter := restarter.NewSidecarsRestarter(logr.Discard(), fakeClient,
+			&MergerMock{"1.16.1-distroless"}, proxyRestarter, statusHandler, images.Images{})
+
+		// when
+		err := sidecarsRestarter.Restart(context.Background(), istioCr)
+
+		// then
+		Expect(err).Should(Not(HaveOccurred()))
+		Expect((*istioCr.Status.Conditions)[0].Reason).To(Equal(string(operatorv1alpha2.ConditionReasonProxySidecarRestartSucceeded)))
+	})
+
 	It("should succeed proxy reset when there is no warning or errors", func() {
 		// given
 		istioCr := createIstioCR()
@@ -206,9 +229,10 @@ var _ = Describe("SidecarsRestarter reconciliation", func() {
 		Expect((*istioCr.Status.Conditions)[0].Status).To(Equal(metav1.ConditionTrue))
 	})
 
-	It("should update lastAppliedConfiguration with compatibility mode after sidecar restart", func() {
+	It("should update lastAppliedConfiguration with the certificate authority fingerprint after sidecar restart", func() {
 		// given
 		istioCr := createIstioCRWithCompatibilityMode()
+		istioCr.Status.CertificateAuthority = &operatorv1alpha2.CertificateAuthorityStatus{Fingerprint: "abc"}
 		istiod := createPod("istiod", gatherer.IstioNamespace, "discovery", "1.16.1", "kyma-project.io/module=istio")
 		proxyRestarter := &proxyRestarterMock{}
 		fakeClient := createFakeClient(istioCr, istiod)
@@ -222,13 +246,212 @@ var _ = Describe("SidecarsRestarter reconciliation", func() {
 		// then
 		Expect(err).Should(Not(HaveOccurred()))
 
-		// Verify lastAppliedConfiguration was updated with compatibility mode
+		// Verify lastAppliedConfiguration was updated with the certificate authority fingerprint
 		updatedIstioCR := &operatorv1alpha2.Istio{}
 		e := fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "kyma-system", Name: "default"}, updatedIstioCR)
 		Expect(e).Should(Not(HaveOccurred()))
 
 		Expect(updatedIstioCR.Annotations).To(HaveKey("operator.kyma-project.io/lastAppliedConfiguration"))
-		Expect(updatedIstioCR.Annotations["operator.kyma-project.io/lastAppliedConfiguration"]).To(ContainSubstring(`"compatibilityMode":true`))
+		Expect(updatedIstioCR.Annotations["operator.kyma-project.io/lastAppliedConfiguration"]).To(ContainSubstring(`"certificateAuthorityFingerprint":"abc"`))
+	})
+
+	It("should set the deferred condition and not update lastAppliedConfiguration if the restart of Customer proxies is deferred", func() {
+		// given
+		istioCr := createIstioCRWithCompatibilityMode()
+		istioCr.Spec.ProxyRestart = &operatorv1alpha2.ProxyRestart{
+			MaintenanceWindows: []operatorv1alpha2.MaintenanceWindow{
+				{Schedule: "0 22 * * *", Duration: metav1.Duration{Duration: time.Hour}},
+			},
+		}
+		istiod := createPod("istiod", gatherer.IstioNamespace, "discovery", "1.16.1", "kyma-project.io/module=istio")
+		proxyRestarter := &proxyRestarterMock{err: &sidecars.RestartDeferredError{PendingPods: 3}}
+		fakeClient := createFakeClient(istioCr, istiod)
+		statusHandler := status.NewStatusHandler(fakeClient)
+		sidecarsRestarter := restarter.NewSidecarsRestarter(logr.Discard(), fakeClient,
+			&MergerMock{"1.16.1-distroless"}, proxyRestarter, statusHandler, images.Images{})
+
+		// when
+		err := sidecarsRestarter.Restart(context.Background(), istioCr)
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(*istioCr.Status.Conditions).To(HaveLen(1))
+		Expect((*istioCr.Status.Conditions)[0].Type).To(Equal(string(operatorv1alpha2.ConditionTypeProxySidecarRestartDeferred)))
+		Expect((*istioCr.Status.Conditions)[0].Reason).To(Equal(string(operatorv1alpha2.ConditionReasonProxySidecarRestartDeferred)))
+		Expect((*istioCr.Status.Conditions)[0].Message).To(ContainSubstring("Proxy sidecar restart of 3 customer workload Pod(s) is deferred until the next maintenance window at "))
+		Expect((*istioCr.Status.Conditions)[0].Status).To(Equal(metav1.ConditionTrue))
+
+		updatedIstioCR := &operatorv1alpha2.Istio{}
+		e := fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "kyma-system", Name: "default"}, updatedIstioCR)
+		Expect(e).Should(Not(HaveOccurred()))
+		Expect(updatedIstioCR.Annotations).ToNot(HaveKey("operator.kyma-project.io/lastAppliedConfiguration"))
+	})
+
+	It("should return a warning and set the failed condition with the unhealthy workloads if the health gate stopped the restart", func() {
+		// given
+		istioCr := createIstioCRWithCompatibilityMode()
+		istiod := createPod("istiod", gatherer.IstioNamespace, "discovery", "1.16.1", "kyma-project.io/module=istio")
+		proxyRestarter := &proxyRestarterMock{err: &sidecars.RestartHealthGateError{
+			UnhealthyOwners: []restart.Warning{{Name: "dep1", Namespace: "test-ns", Kind: "Deployment"}},
+		}}
+		fakeClient := createFakeClient(istioCr, istiod)
+		statusHandler := status.NewStatusHandler(fakeClient)
+		sidecarsRestarter := restarter.NewSidecarsRestarter(logr.Discard(), fakeClient,
+			&MergerMock{"1.16.1-distroless"}, proxyRestarter, statusHandler, images.Images{})
+
+		// when
+		err := sidecarsRestarter.Restart(context.Background(), istioCr)
+
+		// then
+		Expect(err).Should(HaveOccurred())
+		Expect(err.Level()).To(Equal(describederrors.Warning))
+		Expect(err.Description()).To(Equal("Proxy sidecar restart was stopped: the following workloads did not become ready after the restart: Deployment test-ns/dep1"))
+		Expect((*istioCr.Status.Conditions)[0].Type).To(Equal(string(operatorv1alpha2.ConditionTypeProxySidecarRestartSucceeded)))
+		Expect((*istioCr.Status.Conditions)[0].Reason).To(Equal(string(operatorv1alpha2.ConditionReasonProxySidecarRestartFailed)))
+		Expect((*istioCr.Status.Conditions)[0].Message).To(ContainSubstring("Deployment test-ns/dep1"))
+		Expect((*istioCr.Status.Conditions)[0].Status).To(Equal(metav1.ConditionFalse))
+
+		updatedIstioCR := &operatorv1alpha2.Istio{}
+		e := fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "kyma-system", Name: "default"}, updatedIstioCR)
+		Expect(e).Should(Not(HaveOccurred()))
+		Expect(updatedIstioCR.Annotations).ToNot(HaveKey("operator.kyma-project.io/lastAppliedConfiguration"))
+	})
+
+	It("should set the in progress condition without error if the restart waits for the rollout of restarted workloads", func() {
+		// given
+		istioCr := createIstioCRWithCompatibilityMode()
+		istiod := createPod("istiod", gatherer.IstioNamespace, "discovery", "1.16.1", "kyma-project.io/module=istio")
+		proxyRestarter := &proxyRestarterMock{err: &sidecars.RestartRolloutPendingError{PendingRollouts: 2}}
+		fakeClient := createFakeClient(istioCr, istiod)
+		statusHandler := status.NewStatusHandler(fakeClient)
+		sidecarsRestarter := restarter.NewSidecarsRestarter(logr.Discard(), fakeClient,
+			&MergerMock{"1.16.1-distroless"}, proxyRestarter, statusHandler, images.Images{})
+
+		// when
+		err := sidecarsRestarter.Restart(context.Background(), istioCr)
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect((*istioCr.Status.Conditions)[0].Type).To(Equal(string(operatorv1alpha2.ConditionTypeProxySidecarRestartSucceeded)))
+		Expect((*istioCr.Status.Conditions)[0].Reason).To(Equal(string(operatorv1alpha2.ConditionReasonProxySidecarRestartInProgress)))
+		Expect((*istioCr.Status.Conditions)[0].Message).To(Equal("Proxy sidecar restart waits for the rollout of 2 restarted workload(s)"))
+
+		updatedIstioCR := &operatorv1alpha2.Istio{}
+		e := fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "kyma-system", Name: "default"}, updatedIstioCR)
+		Expect(e).Should(Not(HaveOccurred()))
+		Expect(updatedIstioCR.Annotations).ToNot(HaveKey("operator.kyma-project.io/lastAppliedConfiguration"))
+	})
+
+	It("should set the in progress condition without error if the restart waits for workloads that restart their pods one at a time", func() {
+		// given
+		istioCr := createIstioCRWithCompatibilityMode()
+		istiod := createPod("istiod", gatherer.IstioNamespace, "discovery", "1.16.1", "kyma-project.io/module=istio")
+		proxyRestarter := &proxyRestarterMock{err: &sidecars.RestartPendingError{PendingPods: 3}}
+		fakeClient := createFakeClient(istioCr, istiod)
+		statusHandler := status.NewStatusHandler(fakeClient)
+		sidecarsRestarter := restarter.NewSidecarsRestarter(logr.Discard(), fakeClient,
+			&MergerMock{"1.16.1-distroless"}, proxyRestarter, statusHandler, images.Images{})
+
+		// when
+		err := sidecarsRestarter.Restart(context.Background(), istioCr)
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect((*istioCr.Status.Conditions)[0].Type).To(Equal(string(operatorv1alpha2.ConditionTypeProxySidecarRestartSucceeded)))
+		Expect((*istioCr.Status.Conditions)[0].Reason).To(Equal(string(operatorv1alpha2.ConditionReasonProxySidecarRestartInProgress)))
+		Expect((*istioCr.Status.Conditions)[0].Message).To(Equal("Proxy sidecar restart of 3 Pod(s) waits for their workloads to become ready"))
+
+		updatedIstioCR := &operatorv1alpha2.Istio{}
+		e := fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "kyma-system", Name: "default"}, updatedIstioCR)
+		Expect(e).Should(Not(HaveOccurred()))
+		Expect(updatedIstioCR.Annotations).ToNot(HaveKey("operator.kyma-project.io/lastAppliedConfiguration"))
+	})
+
+	It("should record events on the unhealthy workloads and the Istio CR if the health gate stopped the restart", func() {
+		// given
+		istioCr := createIstioCRWithCompatibilityMode()
+		istiod := createPod("istiod", gatherer.IstioNamespace, "discovery", "1.16.1", "kyma-project.io/module=istio")
+		proxyRestarter := &proxyRestarterMock{err: &sidecars.RestartHealthGateError{
+			UnhealthyOwners: []restart.Warning{
+				{Name: "dep1", Namespace: "test-ns", Kind: "Deployment", Message: "workload did not become ready"},
+				{Name: "unknown", Namespace: "test-ns", Kind: "n/a"},
+			},
+		}}
+		fakeClient := createFakeClient(istioCr, istiod)
+		recorder := events.NewFakeRecorder(10)
+		statusHandler := status.NewStatusHandler(fakeClient).WithEventRecorder(recorder)
+		sidecarsRestarter := restarter.NewSidecarsRestarter(logr.Discard(), fakeClient,
+			&MergerMock{"1.16.1-distroless"}, proxyRestarter, statusHandler, images.Images{})
+
+		// when
+		err := sidecarsRestarter.Restart(context.Background(), istioCr)
+
+		// then
+		Expect(err).Should(HaveOccurred())
+		Expect(recorder.Events).To(Receive(Equal("Warning ProxySidecarRestartFailed workload did not become ready")))
+		Expect(recorder.Events).To(Receive(HavePrefix("Warning ProxySidecarRestartFailed Proxy sidecar restart was stopped")))
+		Expect(recorder.Events).ToNot(Receive())
+	})
+
+	It("should record an event on the Istio CR if the restart of Customer proxies is deferred", func() {
+		// given
+		istioCr := createIstioCRWithCompatibilityMode()
+		istiod := createPod("istiod", gatherer.IstioNamespace, "discovery", "1.16.1", "kyma-project.io/module=istio")
+		proxyRestarter := &proxyRestarterMock{err: &sidecars.RestartDeferredError{PendingPods: 3}}
+		fakeClient := createFakeClient(istioCr, istiod)
+		recorder := events.NewFakeRecorder(10)
+		statusHandler := status.NewStatusHandler(fakeClient).WithEventRecorder(recorder)
+		sidecarsRestarter := restarter.NewSidecarsRestarter(logr.Discard(), fakeClient,
+			&MergerMock{"1.16.1-distroless"}, proxyRestarter, statusHandler, images.Images{})
+
+		// when
+		err := sidecarsRestarter.Restart(context.Background(), istioCr)
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(recorder.Events).To(Receive(ContainSubstring("Normal ProxySidecarRestartDeferred Proxy sidecar restart of 3 customer workload Pod(s) is deferred")))
+	})
+
+	It("should set the not deferred condition if the restart of Customer proxies was deferred before", func() {
+		// given
+		istioCr := createIstioCR()
+		istioCr.Status.Conditions = &[]metav1.Condition{
+			*operatorv1alpha2.ConditionFromReason(operatorv1alpha2.NewReasonWithMessage(operatorv1alpha2.ConditionReasonProxySidecarRestartDeferred)),
+		}
+		istiod := createPod("istiod", gatherer.IstioNamespace, "discovery", "1.16.1", "kyma-project.io/module=istio")
+		proxyRestarter := &proxyRestarterMock{}
+		fakeClient := createFakeClient(istioCr, istiod)
+		statusHandler := status.NewStatusHandler(fakeClient)
+		sidecarsRestarter := restarter.NewSidecarsRestarter(logr.Discard(), fakeClient,
+			&MergerMock{"1.16.1-distroless"}, proxyRestarter, statusHandler, images.Images{})
+
+		// when
+		err := sidecarsRestarter.Restart(context.Background(), istioCr)
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+		deferredCondition := meta.FindStatusCondition(*istioCr.Status.Conditions, string(operatorv1alpha2.ConditionTypeProxySidecarRestartDeferred))
+		Expect(deferredCondition).ToNot(BeNil())
+		Expect(deferredCondition.Reason).To(Equal(string(operatorv1alpha2.ConditionReasonProxySidecarRestartNotDeferred)))
+		Expect(deferredCondition.Status).To(Equal(metav1.ConditionFalse))
+	})
+
+	It("should not set the deferred condition if no maintenance windows are configured", func() {
+		// given
+		istioCr := createIstioCR()
+		istiod := createPod("istiod", gatherer.IstioNamespace, "discovery", "1.16.1", "kyma-project.io/module=istio")
+		proxyRestarter := &proxyRestarterMock{}
+		fakeClient := createFakeClient(istioCr, istiod)
+		statusHandler := status.NewStatusHandler(fakeClient)
+		sidecarsRestarter := restarter.NewSidecarsRestarter(logr.Discard(), fakeClient,
+			&MergerMock{"1.16.1-distroless"}, proxyRestarter, statusHandler, images.Images{})
+
+		// when
+		err := sidecarsRestarter.Restart(context.Background(), istioCr)
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(meta.FindStatusCondition(*istioCr.Status.Conditions, string(operatorv1alpha2.ConditionTypeProxySidecarRestartDeferred))).To(BeNil())
 	})
 })
 
@@ -314,6 +537,14 @@ func (m MergerMock) Merge(_ clusterconfig.ClusterSize, _ *operatorv1alpha2.Istio
 	return "mocked istio operator merge result", nil
 }
 
+func (m MergerMock) Render(_ clusterconfig.ClusterSize, _ *operatorv1alpha2.Istio, _ clusterconfig.ClusterConfiguration, _ images.Images, _ ...operatorv1alpha2.MergeOption) ([]byte, error) {
+	return []byte("mocked istio operator render result"), nil
+}
+
+func (m MergerMock) GetProxyConfigHashes() (operatorv1alpha2.ProxyConfigHashes, error) {
+	return operatorv1alpha2.ProxyConfigHashes{}, nil
+}
+
 func (m MergerMock) GetIstioOperator(_ clusterconfig.ClusterSize) (iopv1alpha1.IstioOperator, error) {
 	iop := iopv1alpha1.IstioOperator{}
 	istioOperator, err := os.ReadFile("../../internal/istiooperator/istio-operator.yaml")
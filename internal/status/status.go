@@ -7,6 +7,8 @@ import (
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -22,10 +24,18 @@ type Status interface {
 	UpdateToError(ctx context.Context, istioCR *operatorv1alpha2.Istio, err describederrors.DescribedError,
 		requeueAfter ...time.Duration) error
 	SetCondition(istioCR *operatorv1alpha2.Istio, reason operatorv1alpha2.ReasonWithMessage)
+	// RecordEvent records an event on the Istio CR.
+	RecordEvent(istioCR *operatorv1alpha2.Istio, eventType string, reason operatorv1alpha2.ConditionReason, note string)
+	// RecordObjectEvent records an event on an object affected by the reconciliation of the Istio CR, for example, a restarted workload.
+	// The event refers to the Istio CR as the related object.
+	RecordObjectEvent(istioCR *operatorv1alpha2.Istio, regarding runtime.Object, eventType string, reason operatorv1alpha2.ConditionReason, note string)
 }
 
+const eventAction = "Reconcile"
+
 type Handler struct {
-	client client.Client
+	client   client.Client
+	recorder events.EventRecorder
 }
 
 func NewStatusHandler(client client.Client) Handler {
@@ -34,6 +44,12 @@ func NewStatusHandler(client client.Client) Handler {
 	}
 }
 
+// WithEventRecorder returns a copy of the handler that records events with the given recorder. Without a recorder, no events are recorded.
+func (d Handler) WithEventRecorder(recorder events.EventRecorder) Handler {
+	d.recorder = recorder
+	return d
+}
+
 func (d Handler) update(ctx context.Context, istioCR *operatorv1alpha2.Istio) error {
 	newStatus := istioCR.Status
 	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
@@ -88,3 +104,18 @@ func (d Handler) SetCondition(istioCR *operatorv1alpha2.Istio, reason operatorv1
 		ctrl.Log.Error(errors.New("condition not found"), "Unable to find condition from reason", "reason", reason)
 	}
 }
+
+func (d Handler) RecordEvent(istioCR *operatorv1alpha2.Istio, eventType string, reason operatorv1alpha2.ConditionReason, note string) {
+	if d.recorder == nil {
+		return
+	}
+	d.recorder.Eventf(istioCR, nil, eventType, string(reason), eventAction, "%s", note)
+}
+
+func (d Handler) RecordObjectEvent(istioCR *operatorv1alpha2.Istio, regarding runtime.Object, eventType string,
+	reason operatorv1alpha2.ConditionReason, note string) {
+	if d.recorder == nil {
+		return
+	}
+	d.recorder.Eventf(regarding, istioCR, eventType, string(reason), eventAction, "%s", note)
+}
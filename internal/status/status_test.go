@@ -14,9 +14,11 @@ import (
 	networkingv1 "istio.io/client-go/pkg/apis/networking/v1"
 	securityv1 "istio.io/client-go/pkg/apis/security/v1"
 	"k8s.io/api/apps/v1beta1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	types2 "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -281,6 +283,54 @@ var _ = Describe("status", func() {
 			Expect((*cr.Status.Conditions)[1].Status).To(Equal(metav1.ConditionFalse))
 		})
 	})
+
+	Describe("RecordEvent", func() {
+		It("should record the event with the recorder", func() {
+			// given
+			cr := operatorv1alpha2.Istio{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			}
+			recorder := events.NewFakeRecorder(1)
+			handler := NewStatusHandler(createFakeClient(&cr)).WithEventRecorder(recorder)
+
+			// when
+			handler.RecordEvent(&cr, corev1.EventTypeWarning, operatorv1alpha2.ConditionReasonIstioCRsDangling, "blocked by 1 resource")
+
+			// then
+			Expect(recorder.Events).To(Receive(Equal("Warning IstioCustomResourcesDangling blocked by 1 resource")))
+		})
+
+		It("should not fail without a recorder", func() {
+			// given
+			cr := operatorv1alpha2.Istio{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			}
+			handler := NewStatusHandler(createFakeClient(&cr))
+
+			// when
+			handler.RecordEvent(&cr, corev1.EventTypeNormal, operatorv1alpha2.ConditionReasonReconcileSucceeded, "note")
+			handler.RecordObjectEvent(&cr, &corev1.ObjectReference{Kind: "Pod", Name: "p", Namespace: "default"},
+				corev1.EventTypeNormal, operatorv1alpha2.ConditionReasonReconcileSucceeded, "note")
+		})
+	})
+
+	Describe("RecordObjectEvent", func() {
+		It("should record the event with a note containing format verbs unchanged", func() {
+			// given
+			cr := operatorv1alpha2.Istio{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			}
+			recorder := events.NewFakeRecorder(1)
+			handler := NewStatusHandler(createFakeClient(&cr)).WithEventRecorder(recorder)
+
+			// when
+			handler.RecordObjectEvent(&cr, &corev1.ObjectReference{Kind: "Deployment", Name: "d", Namespace: "default"},
+				corev1.EventTypeWarning, operatorv1alpha2.ConditionReasonProxySidecarManualRestartRequired, "100% failed")
+
+			// then
+			Expect(recorder.Events).To(Receive(Equal("Warning ProxySidecarManualRestartRequired 100% failed")))
+		})
+	})
 })
 
 func createFakeClient(objects ...client.Object) client.Client {
@@ -0,0 +1,7 @@
+//go:build experimental
+
+package validation
+
+func isExperimentalEnabled() bool {
+	return true
+}
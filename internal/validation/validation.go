@@ -1,13 +1,37 @@
 package validation
 
 import (
+	"errors"
 	"fmt"
+	"maps"
+	"net"
 	"regexp"
+	"slices"
 
 	istioCR "github.com/kyma-project/istio/operator/api/v1alpha2"
 	"github.com/kyma-project/istio/operator/internal/describederrors"
 )
 
+// IstioCRNamespace is the only namespace in which the Istio CR is reconciled.
+const IstioCRNamespace = "kyma-system"
+
+// ValidateExperimental returns an error if the Istio CR contains experimental fields, but the operator is not built with experimental features.
+func ValidateExperimental(i istioCR.Istio) describederrors.DescribedError {
+	if i.Spec.Experimental != nil && !isExperimentalEnabled() {
+		return describederrors.NewDescribedError(errors.New("istio CR contains experimental feature"), "Experimental features are not supported in this image flavour").
+			SetCondition(false)
+	}
+	return nil
+}
+
+// ValidateNamespace returns an error if the Istio CR is not in the namespace reconciled by the operator.
+func ValidateNamespace(i istioCR.Istio) describederrors.DescribedError {
+	if i.Namespace != IstioCRNamespace {
+		return describederrors.NewDescribedError(fmt.Errorf("istio CR is not in %s namespace", IstioCRNamespace), "Stopped Istio CR reconciliation")
+	}
+	return nil
+}
+
 func ValidateAuthorizers(i istioCR.Istio) describederrors.DescribedError {
 	authorizersNameSet := make(map[string]bool)
 	for _, authorizer := range i.Spec.Config.Authorizers {
@@ -31,3 +55,169 @@ func ValidateProxyStatsMatcher(i istioCR.Istio) describederrors.DescribedError {
 	}
 	return nil
 }
+
+// ValidateAccessLogging returns an error if the access log providers collide with other extension providers, the default providers
+// reference an unknown provider, or the filter is set without a default provider.
+func ValidateAccessLogging(i istioCR.Istio) describederrors.DescribedError {
+	accessLogging := i.Spec.Config.AccessLogging
+	if accessLogging == nil {
+		return nil
+	}
+
+	reservedNames := map[string]bool{
+		istioCR.EnvoyAccessLogProviderName:      true,
+		istioCR.StdoutJSONAccessLogProviderName: true,
+		istioCR.KymaLogsAccessLogProviderName:   true,
+		istioCR.KymaTracesProviderName:          true,
+	}
+	for _, authorizer := range i.Spec.Config.Authorizers {
+		reservedNames[authorizer.Name] = true
+	}
+	if i.Spec.Config.Tracing != nil {
+		for _, provider := range i.Spec.Config.Tracing.Providers {
+			reservedNames[provider.Name] = true
+		}
+	}
+
+	knownProviders := map[string]bool{
+		istioCR.EnvoyAccessLogProviderName:      true,
+		istioCR.StdoutJSONAccessLogProviderName: true,
+		istioCR.KymaLogsAccessLogProviderName:   true,
+	}
+	for _, provider := range accessLogging.Providers {
+		if reservedNames[provider.Name] {
+			return describederrors.NewDescribedError(fmt.Errorf("%s is already used by another extension provider", provider.Name), "Access log provider name needs to be unique")
+		}
+		knownProviders[provider.Name] = true
+	}
+
+	for _, name := range accessLogging.DefaultProviders {
+		if !knownProviders[name] {
+			return describederrors.NewDescribedError(fmt.Errorf("%s is not a known access log provider", name), "Access log default providers reference an unknown provider")
+		}
+	}
+
+	if accessLogging.Filter != nil && len(accessLogging.DefaultProviders) == 0 {
+		return describederrors.NewDescribedError(errors.New("filter is set without default providers"), "Access log filter requires at least one default provider")
+	}
+
+	return nil
+}
+
+// ValidateTracing returns an error if the tracing providers collide with other extension providers.
+func ValidateTracing(i istioCR.Istio) describederrors.DescribedError {
+	tracing := i.Spec.Config.Tracing
+	if tracing == nil {
+		return nil
+	}
+
+	reservedNames := map[string]bool{
+		istioCR.EnvoyAccessLogProviderName:      true,
+		istioCR.StdoutJSONAccessLogProviderName: true,
+		istioCR.KymaLogsAccessLogProviderName:   true,
+		istioCR.KymaTracesProviderName:          true,
+	}
+	for _, authorizer := range i.Spec.Config.Authorizers {
+		reservedNames[authorizer.Name] = true
+	}
+	if i.Spec.Config.AccessLogging != nil {
+		for _, provider := range i.Spec.Config.AccessLogging.Providers {
+			reservedNames[provider.Name] = true
+		}
+	}
+
+	for _, provider := range tracing.Providers {
+		if reservedNames[provider.Name] {
+			return describederrors.NewDescribedError(fmt.Errorf("%s is already used by another extension provider", provider.Name), "Tracing provider name needs to be unique")
+		}
+	}
+
+	return nil
+}
+
+// defaultIngressGatewayPorts are the Service ports of Istio Ingress Gateway that are always exposed.
+var defaultIngressGatewayPorts = []int32{15021, 80, 443}
+
+// reservedIngressGatewayTargetPorts are the ports on which the Istio Ingress Gateway Pods already listen.
+var reservedIngressGatewayTargetPorts = []int32{8080, 8443, 15000, 15001, 15004, 15006, 15008, 15020, 15021, 15053, 15090}
+
+// ValidateIngressGatewayService returns an error if the Service configuration of Istio Ingress Gateway contains an invalid
+// source range or IP address, or a port that collides with another port of the gateway.
+func ValidateIngressGatewayService(i istioCR.Istio) describederrors.DescribedError {
+	if i.Spec.Components == nil || i.Spec.Components.IngressGateway == nil || i.Spec.Components.IngressGateway.Service == nil {
+		return nil
+	}
+	service := i.Spec.Components.IngressGateway.Service
+
+	for _, sourceRange := range service.LoadBalancerSourceRanges {
+		if _, _, err := net.ParseCIDR(sourceRange); err != nil {
+			return describederrors.NewDescribedError(fmt.Errorf("%q is not a valid CIDR: %w", sourceRange, err), "Ingress Gateway Service loadBalancerSourceRanges contains an invalid CIDR")
+		}
+	}
+
+	if service.LoadBalancerIP != nil && net.ParseIP(*service.LoadBalancerIP) == nil {
+		return describederrors.NewDescribedError(fmt.Errorf("%q is not a valid IP address", *service.LoadBalancerIP), "Ingress Gateway Service loadBalancerIP is invalid")
+	}
+
+	usedPorts := make(map[string]bool)
+	for _, port := range defaultIngressGatewayPorts {
+		usedPorts[fmt.Sprintf("%d/%s", port, istioCR.GatewayServicePortProtocolTCP)] = true
+	}
+	for _, port := range service.Ports {
+		protocol := port.Protocol
+		if protocol == "" {
+			protocol = istioCR.GatewayServicePortProtocolTCP
+		}
+		key := fmt.Sprintf("%d/%s", port.Port, protocol)
+		if usedPorts[key] {
+			return describederrors.NewDescribedError(fmt.Errorf("port %s is duplicated", key), "Ingress Gateway Service port needs to be unique")
+		}
+		usedPorts[key] = true
+
+		targetPort := port.Port
+		if port.TargetPort != nil {
+			targetPort = *port.TargetPort
+		}
+		if slices.Contains(reservedIngressGatewayTargetPorts, targetPort) {
+			return describederrors.NewDescribedError(fmt.Errorf("target port %d of port %s is used by Istio Ingress Gateway", targetPort, port.Name), "Ingress Gateway Service target port is reserved")
+		}
+	}
+
+	return nil
+}
+
+// ValidatePodDisruptionBudgets returns an error if a PodDisruptionBudget doesn't allow evicting at least one Pod of its component when
+// the component runs with the minimum replicas configured in its hpaSpec. Components without configured minimum replicas are checked
+// against the default minimum replicas of the cluster size during the installation.
+func ValidatePodDisruptionBudgets(i istioCR.Istio) describederrors.DescribedError {
+	if i.Spec.Components == nil {
+		return nil
+	}
+	components := i.Spec.Components
+
+	k8sConfigs := map[string]*istioCR.KubernetesResourcesConfig{}
+	if components.Pilot != nil {
+		k8sConfigs["istiod"] = components.Pilot.K8s
+	}
+	if components.IngressGateway != nil {
+		k8sConfigs[istioCR.DefaultIngressGatewayName] = components.IngressGateway.K8s
+	}
+	if components.EgressGateway != nil {
+		k8sConfigs["istio-egressgateway"] = components.EgressGateway.K8s
+	}
+	for _, gateway := range components.AdditionalIngressGateways {
+		k8sConfigs[gateway.Name] = gateway.K8s
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(k8sConfigs)) {
+		k8s := k8sConfigs[name]
+		if k8s == nil || k8s.PodDisruptionBudget == nil || k8s.HPASpec == nil || k8s.HPASpec.MinReplicas == nil {
+			continue
+		}
+		if err := k8s.PodDisruptionBudget.ValidateMinReplicas(name, *k8s.HPASpec.MinReplicas); err != nil {
+			return describederrors.NewDescribedError(err, "PodDisruptionBudget needs to allow evicting at least one Pod with the minimum replicas")
+		}
+	}
+
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/kyma-project/istio/operator/internal/validation"
 	"github.com/onsi/ginkgo/v2/types"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"testing"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -142,3 +143,328 @@ var _ = Describe("ValidateProxyStatsMatcher", func() {
 		Expect(err.Description()).To(ContainSubstring("ProxyStatsMatcher inclusionRegexps contains an invalid regular expression"))
 	})
 })
+
+var _ = Describe("ValidateAccessLogging", func() {
+	istioWithAccessLogging := func(accessLogging *istioCR.AccessLogging) istioCR.Istio {
+		return istioCR.Istio{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: istioCR.IstioSpec{
+				Config: istioCR.Config{
+					AccessLogging: accessLogging,
+				},
+			},
+		}
+	}
+	filter := "response.code >= 500"
+
+	It("should successfully validate when access logging is not configured", func() {
+		//when
+		err := validation.ValidateAccessLogging(istioWithAccessLogging(nil))
+		//then
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should successfully validate default providers referencing built-in and custom providers", func() {
+		//given
+		istioCr := istioWithAccessLogging(&istioCR.AccessLogging{
+			Providers: []istioCR.AccessLogProvider{
+				{Name: "tenant-json", File: &istioCR.FileAccessLogProvider{Path: "/dev/stdout"}},
+			},
+			DefaultProviders: []string{"stdout-json", "tenant-json"},
+			Filter:           &filter,
+		})
+		//when
+		err := validation.ValidateAccessLogging(istioCr)
+		//then
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should fail to validate when a provider uses a reserved name", func() {
+		//given
+		istioCr := istioWithAccessLogging(&istioCR.AccessLogging{
+			Providers: []istioCR.AccessLogProvider{
+				{Name: "kyma-logs", File: &istioCR.FileAccessLogProvider{}},
+			},
+		})
+		//when
+		err := validation.ValidateAccessLogging(istioCr)
+		//then
+		Expect(err).To(HaveOccurred())
+		Expect(err.Description()).To(ContainSubstring("Access log provider name needs to be unique"))
+	})
+
+	It("should fail to validate when a provider uses the name of an authorizer", func() {
+		//given
+		istioCr := istioWithAccessLogging(&istioCR.AccessLogging{
+			Providers: []istioCR.AccessLogProvider{
+				{Name: "oauth2-proxy", File: &istioCR.FileAccessLogProvider{}},
+			},
+		})
+		istioCr.Spec.Config.Authorizers = []*istioCR.Authorizer{{Name: "oauth2-proxy", Service: "oauth2-proxy", Port: 4180}}
+		//when
+		err := validation.ValidateAccessLogging(istioCr)
+		//then
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should fail to validate when a default provider is unknown", func() {
+		//given
+		istioCr := istioWithAccessLogging(&istioCR.AccessLogging{
+			DefaultProviders: []string{"not-existing"},
+		})
+		//when
+		err := validation.ValidateAccessLogging(istioCr)
+		//then
+		Expect(err).To(HaveOccurred())
+		Expect(err.Description()).To(ContainSubstring("Access log default providers reference an unknown provider"))
+	})
+
+	It("should fail to validate when the filter is set without default providers", func() {
+		//given
+		istioCr := istioWithAccessLogging(&istioCR.AccessLogging{
+			Filter: &filter,
+		})
+		//when
+		err := validation.ValidateAccessLogging(istioCr)
+		//then
+		Expect(err).To(HaveOccurred())
+		Expect(err.Description()).To(ContainSubstring("Access log filter requires at least one default provider"))
+	})
+})
+
+var _ = Describe("ValidateTracing", func() {
+	istioWithTracingProviders := func(providers ...istioCR.TracingProvider) istioCR.Istio {
+		return istioCR.Istio{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: istioCR.IstioSpec{
+				Config: istioCR.Config{
+					Tracing: &istioCR.Tracing{Providers: providers},
+				},
+			},
+		}
+	}
+
+	It("should successfully validate when tracing is not configured", func() {
+		//when
+		err := validation.ValidateTracing(istioCR.Istio{})
+		//then
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should successfully validate a provider with a unique name", func() {
+		//given
+		istioCr := istioWithTracingProviders(istioCR.TracingProvider{Name: "tenant-traces", Service: "otel-collector.observability.svc.cluster.local", Port: 4317})
+		//when
+		err := validation.ValidateTracing(istioCr)
+		//then
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should fail to validate when a provider uses a reserved name", func() {
+		//given
+		istioCr := istioWithTracingProviders(istioCR.TracingProvider{Name: "kyma-logs", Service: "otel-collector.observability.svc.cluster.local", Port: 4317})
+		//when
+		err := validation.ValidateTracing(istioCr)
+		//then
+		Expect(err).To(HaveOccurred())
+		Expect(err.Description()).To(ContainSubstring("Tracing provider name needs to be unique"))
+	})
+
+	It("should fail to validate when a provider uses the name of an authorizer", func() {
+		//given
+		istioCr := istioWithTracingProviders(istioCR.TracingProvider{Name: "oauth2-proxy", Service: "otel-collector.observability.svc.cluster.local", Port: 4317})
+		istioCr.Spec.Config.Authorizers = []*istioCR.Authorizer{{Name: "oauth2-proxy", Service: "oauth2-proxy", Port: 4180}}
+		//when
+		err := validation.ValidateTracing(istioCr)
+		//then
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should fail to validate when a provider uses the name of an access log provider", func() {
+		//given
+		istioCr := istioWithTracingProviders(istioCR.TracingProvider{Name: "tenant-otel", Service: "otel-collector.observability.svc.cluster.local", Port: 4317})
+		istioCr.Spec.Config.AccessLogging = &istioCR.AccessLogging{
+			Providers: []istioCR.AccessLogProvider{
+				{Name: "tenant-otel", File: &istioCR.FileAccessLogProvider{Path: "/dev/stdout"}},
+			},
+		}
+		//when
+		err := validation.ValidateTracing(istioCr)
+		//then
+		Expect(err).To(HaveOccurred())
+		Expect(err.Description()).To(ContainSubstring("Tracing provider name needs to be unique"))
+	})
+})
+
+var _ = Describe("ValidateIngressGatewayService", func() {
+	istioWithIngressGatewayService := func(service *istioCR.IngressGatewayService) istioCR.Istio {
+		return istioCR.Istio{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: istioCR.IstioSpec{
+				Components: &istioCR.Components{
+					IngressGateway: &istioCR.IngressGateway{Service: service},
+				},
+			},
+		}
+	}
+
+	It("should successfully validate when the Service is not configured", func() {
+		//when
+		err := validation.ValidateIngressGatewayService(istioWithIngressGatewayService(nil))
+		//then
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should successfully validate a valid Service configuration", func() {
+		//given
+		loadBalancerIP := "2001:db8::10"
+		targetPort := int32(8053)
+		istioCr := istioWithIngressGatewayService(&istioCR.IngressGatewayService{
+			Ports: []istioCR.GatewayServicePort{
+				{Name: "tcp-db", Port: 5432},
+				{Name: "udp-dns", Port: 53, TargetPort: &targetPort, Protocol: istioCR.GatewayServicePortProtocolUDP},
+				{Name: "udp-https", Port: 443, TargetPort: &targetPort, Protocol: istioCR.GatewayServicePortProtocolUDP},
+			},
+			LoadBalancerSourceRanges: []string{"203.0.113.0/24", "2001:db8::/32"},
+			LoadBalancerIP:           &loadBalancerIP,
+		})
+		//when
+		err := validation.ValidateIngressGatewayService(istioCr)
+		//then
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should fail to validate when a source range is not a valid CIDR", func() {
+		//given
+		istioCr := istioWithIngressGatewayService(&istioCR.IngressGatewayService{
+			LoadBalancerSourceRanges: []string{"203.0.113.0"},
+		})
+		//when
+		err := validation.ValidateIngressGatewayService(istioCr)
+		//then
+		Expect(err).To(HaveOccurred())
+		Expect(err.Description()).To(ContainSubstring("Ingress Gateway Service loadBalancerSourceRanges contains an invalid CIDR"))
+	})
+
+	It("should fail to validate when loadBalancerIP is not a valid IP address", func() {
+		//given
+		loadBalancerIP := "not-an-ip"
+		istioCr := istioWithIngressGatewayService(&istioCR.IngressGatewayService{
+			LoadBalancerIP: &loadBalancerIP,
+		})
+		//when
+		err := validation.ValidateIngressGatewayService(istioCr)
+		//then
+		Expect(err).To(HaveOccurred())
+		Expect(err.Description()).To(ContainSubstring("Ingress Gateway Service loadBalancerIP is invalid"))
+	})
+
+	It("should fail to validate when a port collides with a default port", func() {
+		//given
+		targetPort := int32(9443)
+		istioCr := istioWithIngressGatewayService(&istioCR.IngressGatewayService{
+			Ports: []istioCR.GatewayServicePort{
+				{Name: "tls", Port: 443, TargetPort: &targetPort},
+			},
+		})
+		//when
+		err := validation.ValidateIngressGatewayService(istioCr)
+		//then
+		Expect(err).To(HaveOccurred())
+		Expect(err.Description()).To(ContainSubstring("Ingress Gateway Service port needs to be unique"))
+	})
+
+	It("should fail to validate when a target port is used by the gateway", func() {
+		//given
+		targetPort := int32(15090)
+		istioCr := istioWithIngressGatewayService(&istioCR.IngressGatewayService{
+			Ports: []istioCR.GatewayServicePort{
+				{Name: "metrics", Port: 9090, TargetPort: &targetPort},
+			},
+		})
+		//when
+		err := validation.ValidateIngressGatewayService(istioCr)
+		//then
+		Expect(err).To(HaveOccurred())
+		Expect(err.Description()).To(ContainSubstring("Ingress Gateway Service target port is reserved"))
+	})
+})
+
+var _ = Describe("ValidatePodDisruptionBudgets", func() {
+	istioWithIstiodK8s := func(k8s *istioCR.KubernetesResourcesConfig) istioCR.Istio {
+		return istioCR.Istio{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: istioCR.IstioSpec{
+				Components: &istioCR.Components{
+					Pilot: &istioCR.IstioComponent{K8s: k8s},
+				},
+			},
+		}
+	}
+
+	It("should successfully validate when the PodDisruptionBudget allows evicting a Pod with the minimum replicas", func() {
+		//given
+		minReplicas := int32(3)
+		minAvailable := intstr.FromInt32(2)
+		istioCr := istioWithIstiodK8s(&istioCR.KubernetesResourcesConfig{
+			HPASpec:             &istioCR.HPASpec{MinReplicas: &minReplicas},
+			PodDisruptionBudget: &istioCR.PodDisruptionBudget{MinAvailable: &minAvailable},
+		})
+		//when
+		err := validation.ValidatePodDisruptionBudgets(istioCr)
+		//then
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should successfully validate when the minimum replicas are not configured", func() {
+		//given
+		minAvailable := intstr.FromInt32(1)
+		istioCr := istioWithIstiodK8s(&istioCR.KubernetesResourcesConfig{
+			PodDisruptionBudget: &istioCR.PodDisruptionBudget{MinAvailable: &minAvailable},
+		})
+		//when
+		err := validation.ValidatePodDisruptionBudgets(istioCr)
+		//then
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should fail to validate when the minimum replicas are lower than 2", func() {
+		//given
+		minReplicas := int32(1)
+		maxUnavailable := intstr.FromInt32(1)
+		istioCr := istioWithIstiodK8s(&istioCR.KubernetesResourcesConfig{
+			HPASpec:             &istioCR.HPASpec{MinReplicas: &minReplicas},
+			PodDisruptionBudget: &istioCR.PodDisruptionBudget{MaxUnavailable: &maxUnavailable},
+		})
+		//when
+		err := validation.ValidatePodDisruptionBudgets(istioCr)
+		//then
+		Expect(err).To(HaveOccurred())
+		Expect(err.Description()).To(ContainSubstring("podDisruptionBudget of istiod requires at least 2 minimum replicas, but the minimum replicas are 1"))
+	})
+
+	It("should fail to validate when the PodDisruptionBudget of an additional Ingress Gateway keeps all Pods available", func() {
+		//given
+		minReplicas := int32(2)
+		minAvailable := intstr.FromString("100%")
+		istioCr := istioCR.Istio{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Spec: istioCR.IstioSpec{
+				Components: &istioCR.Components{
+					AdditionalIngressGateways: []istioCR.AdditionalIngressGateway{{
+						Name: "internal-gateway",
+						K8s: &istioCR.KubernetesResourcesConfig{
+							HPASpec:             &istioCR.HPASpec{MinReplicas: &minReplicas},
+							PodDisruptionBudget: &istioCR.PodDisruptionBudget{MinAvailable: &minAvailable},
+						},
+					}},
+				},
+			},
+		}
+		//when
+		err := validation.ValidatePodDisruptionBudgets(istioCr)
+		//then
+		Expect(err).To(HaveOccurred())
+		Expect(err.Description()).To(ContainSubstring("podDisruptionBudget minAvailable of internal-gateway must be lower than the minimum replicas 2"))
+	})
+})
@@ -0,0 +1,300 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kyma-project/istio/operator/internal/validation"
+)
+
+const (
+	certificateSecretName    = "istio-webhook-server-cert"
+	webhookServiceName       = "istio-webhook-service"
+	webhookConfigurationName = "istio-validating-webhook-configuration"
+
+	caCertKey  = "ca.crt"
+	caKeyKey   = "ca.key"
+	tlsCertKey = corev1.TLSCertKey
+	tlsKeyKey  = corev1.TLSPrivateKeyKey
+
+	caValidity               = 10 * 365 * 24 * time.Hour
+	servingCertValidity      = 365 * 24 * time.Hour
+	certificateRenewBefore   = 30 * 24 * time.Hour
+	certificateCheckInterval = 12 * time.Hour
+)
+
+// CertificateProvider provides the serving certificate of the webhook server without depending on cert-manager. The certificate is
+// signed by a self-signed CA and kept in a Secret, so that all replicas of the operator serve a certificate of the same CA. The
+// certificate is written to the certificate directory of the webhook server and the CA is injected into the validating webhook
+// configuration. The serving certificate is renewed before it expires, while the CA is kept, so that the injected CA stays valid
+// for the replicas that didn't pick up the renewed certificate yet.
+type CertificateProvider struct {
+	client  client.Client
+	certDir string
+}
+
+func NewCertificateProvider(c client.Client, certDir string) *CertificateProvider {
+	return &CertificateProvider{
+		client:  c,
+		certDir: certDir,
+	}
+}
+
+// Start checks the certificate periodically until the context is cancelled. It implements manager.Runnable.
+func (p *CertificateProvider) Start(ctx context.Context) error {
+	log := ctrl.Log.WithName("webhook-certificate")
+	ticker := time.NewTicker(certificateCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := p.Ensure(ctx); err != nil {
+				log.Error(err, "Ensuring the serving certificate of the webhook server failed")
+			}
+		}
+	}
+}
+
+// NeedLeaderElection returns false, because every replica of the operator serves the webhook and needs the certificate files.
+func (p *CertificateProvider) NeedLeaderElection() bool {
+	return false
+}
+
+// Ensure creates or renews the certificate in the Secret, writes it to the certificate directory, and injects the CA into the
+// validating webhook configuration.
+func (p *CertificateProvider) Ensure(ctx context.Context) error {
+	secret, err := p.ensureSecret(ctx)
+	if err != nil {
+		return err
+	}
+	if err := writeCertificateFiles(p.certDir, secret.Data[tlsCertKey], secret.Data[tlsKeyKey]); err != nil {
+		return err
+	}
+	return p.injectCABundle(ctx, secret.Data[caCertKey])
+}
+
+func (p *CertificateProvider) ensureSecret(ctx context.Context) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	err := p.client.Get(ctx, client.ObjectKey{Namespace: validation.IstioCRNamespace, Name: certificateSecretName}, secret)
+	if apierrors.IsNotFound(err) {
+		data, generateErr := generateCertificate(nil, nil)
+		if generateErr != nil {
+			return nil, generateErr
+		}
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: validation.IstioCRNamespace, Name: certificateSecretName},
+			Type:       corev1.SecretTypeOpaque,
+			Data:       data,
+		}
+		createErr := p.client.Create(ctx, secret)
+		if apierrors.IsAlreadyExists(createErr) {
+			// Another replica created the Secret in the meantime.
+			return p.ensureSecret(ctx)
+		}
+		if createErr != nil {
+			return nil, fmt.Errorf("failed to create the webhook certificate secret: %w", createErr)
+		}
+		return secret, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the webhook certificate secret: %w", err)
+	}
+
+	caCert, caKey, err := parseCA(secret.Data[caCertKey], secret.Data[caKeyKey])
+	if err != nil || needsRenewal(caCert) {
+		caCert, caKey = nil, nil
+	} else if !needsServingCertRenewal(secret.Data[tlsCertKey]) {
+		return secret, nil
+	}
+
+	data, err := generateCertificate(caCert, caKey)
+	if err != nil {
+		return nil, err
+	}
+	secret.Data = data
+	if err := p.client.Update(ctx, secret); err != nil {
+		return nil, fmt.Errorf("failed to update the webhook certificate secret: %w", err)
+	}
+	return secret, nil
+}
+
+func (p *CertificateProvider) injectCABundle(ctx context.Context, caBundle []byte) error {
+	webhookConfiguration := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+	err := p.client.Get(ctx, client.ObjectKey{Name: webhookConfigurationName}, webhookConfiguration)
+	if apierrors.IsNotFound(err) {
+		ctrl.Log.WithName("webhook-certificate").Info("Validating webhook configuration not found, the CA is not injected", "name", webhookConfigurationName)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get the validating webhook configuration: %w", err)
+	}
+
+	patch := client.MergeFrom(webhookConfiguration.DeepCopy())
+	changed := false
+	for i := range webhookConfiguration.Webhooks {
+		if !bytes.Equal(webhookConfiguration.Webhooks[i].ClientConfig.CABundle, caBundle) {
+			webhookConfiguration.Webhooks[i].ClientConfig.CABundle = caBundle
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	if err := p.client.Patch(ctx, webhookConfiguration, patch); err != nil {
+		return fmt.Errorf("failed to inject the CA into the validating webhook configuration: %w", err)
+	}
+	return nil
+}
+
+// generateCertificate returns the Secret data with a serving certificate for the webhook service. The certificate is signed by the
+// given CA, or by a new self-signed CA if no CA is given.
+func generateCertificate(caCert *x509.Certificate, caKey *ecdsa.PrivateKey) (map[string][]byte, error) {
+	now := time.Now()
+	if caCert == nil || caKey == nil {
+		var err error
+		caKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate the CA key: %w", err)
+		}
+		caTemplate := &x509.Certificate{
+			SerialNumber:          randomSerialNumber(),
+			Subject:               pkix.Name{CommonName: "istio-webhook-ca"},
+			NotBefore:             now.Add(-time.Hour),
+			NotAfter:              now.Add(caValidity),
+			KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+			BasicConstraintsValid: true,
+			IsCA:                  true,
+		}
+		caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the CA certificate: %w", err)
+		}
+		caCert, err = x509.ParseCertificate(caDER)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the CA certificate: %w", err)
+		}
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate the serving certificate key: %w", err)
+	}
+	serviceHost := fmt.Sprintf("%s.%s.svc", webhookServiceName, validation.IstioCRNamespace)
+	template := &x509.Certificate{
+		SerialNumber: randomSerialNumber(),
+		Subject:      pkix.Name{CommonName: serviceHost},
+		DNSNames:     []string{serviceHost, serviceHost + ".cluster.local"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(servingCertValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the serving certificate: %w", err)
+	}
+
+	caKeyPEM, err := encodeKey(caKey)
+	if err != nil {
+		return nil, err
+	}
+	keyPEM, err := encodeKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return map[string][]byte{
+		caCertKey:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caCert.Raw}),
+		caKeyKey:   caKeyPEM,
+		tlsCertKey: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}),
+		tlsKeyKey:  keyPEM,
+	}, nil
+}
+
+func parseCA(certPEM, keyPEM []byte) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	cert, err := parseCertificate(certPEM)
+	if err != nil {
+		return nil, nil, err
+	}
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, nil, errors.New("the CA key is not PEM encoded")
+	}
+	key, err := x509.ParseECPrivateKey(block.Bytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse the CA key: %w", err)
+	}
+	return cert, key, nil
+}
+
+func parseCertificate(certPEM []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return nil, errors.New("the certificate is not PEM encoded")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+func needsRenewal(cert *x509.Certificate) bool {
+	return time.Until(cert.NotAfter) < certificateRenewBefore
+}
+
+func needsServingCertRenewal(certPEM []byte) bool {
+	cert, err := parseCertificate(certPEM)
+	return err != nil || needsRenewal(cert)
+}
+
+func encodeKey(key *ecdsa.PrivateKey) ([]byte, error) {
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode the private key: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), nil
+}
+
+func randomSerialNumber() *big.Int {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return big.NewInt(time.Now().UnixNano())
+	}
+	return serial
+}
+
+// writeCertificateFiles writes the certificate to the directory from which the webhook server loads it. The webhook server reloads
+// the certificate when the files change.
+func writeCertificateFiles(certDir string, cert, key []byte) error {
+	if err := os.MkdirAll(certDir, 0o700); err != nil {
+		return fmt.Errorf("failed to create the certificate directory: %w", err)
+	}
+	for name, content := range map[string][]byte{tlsCertKey: cert, tlsKeyKey: key} {
+		path := filepath.Join(certDir, name)
+		if existing, err := os.ReadFile(path); err == nil && bytes.Equal(existing, content) {
+			continue
+		}
+		if err := os.WriteFile(path, content, 0o600); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+	return nil
+}
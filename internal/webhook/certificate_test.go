@@ -0,0 +1,116 @@
+package webhook_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kyma-project/istio/operator/internal/webhook"
+)
+
+var _ = Describe("Webhook certificate provider", func() {
+	ctx := context.Background()
+
+	It("should create the certificate, write it to the certificate directory and inject the CA into the webhook configuration", func() {
+		// given
+		c := createCertificateFakeClient(createWebhookConfiguration())
+		certDir := GinkgoT().TempDir()
+		provider := webhook.NewCertificateProvider(c, certDir)
+
+		// when
+		err := provider.Ensure(ctx)
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+
+		secret := &corev1.Secret{}
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: "kyma-system", Name: "istio-webhook-server-cert"}, secret)).Should(Succeed())
+		Expect(secret.Data).To(HaveKey("ca.crt"))
+
+		cert, err := os.ReadFile(filepath.Join(certDir, corev1.TLSCertKey))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(cert).To(Equal(secret.Data[corev1.TLSCertKey]))
+		key, err := os.ReadFile(filepath.Join(certDir, corev1.TLSPrivateKeyKey))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(key).To(Equal(secret.Data[corev1.TLSPrivateKeyKey]))
+
+		webhookConfiguration := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+		Expect(c.Get(ctx, client.ObjectKey{Name: "istio-validating-webhook-configuration"}, webhookConfiguration)).Should(Succeed())
+		Expect(webhookConfiguration.Webhooks[0].ClientConfig.CABundle).To(Equal(secret.Data["ca.crt"]))
+	})
+
+	It("should keep a valid certificate", func() {
+		// given
+		c := createCertificateFakeClient(createWebhookConfiguration())
+		provider := webhook.NewCertificateProvider(c, GinkgoT().TempDir())
+		Expect(provider.Ensure(ctx)).Should(Succeed())
+		secret := &corev1.Secret{}
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: "kyma-system", Name: "istio-webhook-server-cert"}, secret)).Should(Succeed())
+
+		// when
+		err := webhook.NewCertificateProvider(c, GinkgoT().TempDir()).Ensure(ctx)
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+		renewed := &corev1.Secret{}
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: "kyma-system", Name: "istio-webhook-server-cert"}, renewed)).Should(Succeed())
+		Expect(renewed.Data).To(Equal(secret.Data))
+	})
+
+	It("should renew an invalid serving certificate with the existing CA", func() {
+		// given
+		c := createCertificateFakeClient(createWebhookConfiguration())
+		provider := webhook.NewCertificateProvider(c, GinkgoT().TempDir())
+		Expect(provider.Ensure(ctx)).Should(Succeed())
+		secret := &corev1.Secret{}
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: "kyma-system", Name: "istio-webhook-server-cert"}, secret)).Should(Succeed())
+		caCert := secret.Data["ca.crt"]
+		secret.Data[corev1.TLSCertKey] = []byte("invalid")
+		Expect(c.Update(ctx, secret)).Should(Succeed())
+
+		// when
+		err := provider.Ensure(ctx)
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+		renewed := &corev1.Secret{}
+		Expect(c.Get(ctx, client.ObjectKey{Namespace: "kyma-system", Name: "istio-webhook-server-cert"}, renewed)).Should(Succeed())
+		Expect(renewed.Data[corev1.TLSCertKey]).NotTo(Equal([]byte("invalid")))
+		Expect(renewed.Data["ca.crt"]).To(Equal(caCert))
+	})
+
+	It("should not fail if the webhook configuration doesn't exist", func() {
+		// given
+		provider := webhook.NewCertificateProvider(createCertificateFakeClient(), GinkgoT().TempDir())
+
+		// when
+		err := provider.Ensure(ctx)
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+})
+
+func createWebhookConfiguration() *admissionregistrationv1.ValidatingWebhookConfiguration {
+	return &admissionregistrationv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "istio-validating-webhook-configuration"},
+		Webhooks:   []admissionregistrationv1.ValidatingWebhook{{Name: "vistio.operator.kyma-project.io"}},
+	}
+}
+
+func createCertificateFakeClient(objects ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	Expect(clientgoscheme.AddToScheme(scheme)).Should(Succeed())
+
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+}
@@ -0,0 +1,103 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	operatorv1alpha2 "github.com/kyma-project/istio/operator/api/v1alpha2"
+	"github.com/kyma-project/istio/operator/internal/validation"
+)
+
+// +kubebuilder:webhook:path=/validate-operator-kyma-project-io-v1alpha2-istio,mutating=false,failurePolicy=fail,sideEffects=None,groups=operator.kyma-project.io,resources=istios,verbs=create;update,versions=v1alpha2,name=vistio.operator.kyma-project.io,admissionReviewVersions=v1
+
+// IstioValidator rejects Istio CRs that the reconciliation would put in the Error or Warning state because of their configuration.
+type IstioValidator struct {
+	Client client.Reader
+}
+
+// SetupIstioWebhookWithManager registers the validating webhook for the Istio CR in the webhook server of the manager.
+func SetupIstioWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &operatorv1alpha2.Istio{}).
+		WithValidator(&IstioValidator{Client: mgr.GetAPIReader()}).
+		Complete()
+}
+
+func (v *IstioValidator) ValidateCreate(ctx context.Context, istioCR *operatorv1alpha2.Istio) (admission.Warnings, error) {
+	errs := validateIstio(istioCR)
+
+	existingIstioCRs := &operatorv1alpha2.IstioList{}
+	if err := v.Client.List(ctx, existingIstioCRs, client.InNamespace(validation.IstioCRNamespace)); err != nil {
+		return nil, fmt.Errorf("unable to list Istio CRs: %w", err)
+	}
+	for _, existing := range existingIstioCRs.Items {
+		if existing.Name != istioCR.Name {
+			errs = append(errs, field.Forbidden(field.NewPath("metadata", "name"),
+				fmt.Sprintf("only one Istio CR is allowed, Istio CR %s already exists in %s namespace", existing.Name, existing.Namespace)))
+			break
+		}
+	}
+
+	return nil, toInvalidError(istioCR, errs)
+}
+
+func (v *IstioValidator) ValidateUpdate(_ context.Context, oldIstioCR, newIstioCR *operatorv1alpha2.Istio) (admission.Warnings, error) {
+	// The deletion of an Istio CR must not be blocked by a configuration that became invalid, e.g. after an upgrade of the operator.
+	if !newIstioCR.DeletionTimestamp.IsZero() {
+		return nil, nil
+	}
+	// Updates that don't change the spec, e.g. of labels, annotations or finalizers, must not be blocked by a configuration that
+	// became invalid either.
+	if equality.Semantic.DeepEqual(oldIstioCR.Spec, newIstioCR.Spec) {
+		return nil, nil
+	}
+	return nil, toInvalidError(newIstioCR, validateIstio(newIstioCR))
+}
+
+func (v *IstioValidator) ValidateDelete(_ context.Context, _ *operatorv1alpha2.Istio) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateIstio(istioCR *operatorv1alpha2.Istio) field.ErrorList {
+	var errs field.ErrorList
+
+	if err := validation.ValidateNamespace(*istioCR); err != nil {
+		errs = append(errs, field.Invalid(field.NewPath("metadata", "namespace"), istioCR.Namespace, err.Error()))
+	}
+	if err := validation.ValidateExperimental(*istioCR); err != nil {
+		errs = append(errs, field.Forbidden(field.NewPath("spec", "experimental"), err.Description()))
+	}
+	if err := validation.ValidateAuthorizers(*istioCR); err != nil {
+		errs = append(errs, field.Invalid(field.NewPath("spec", "config", "authorizers"), field.OmitValueType{}, err.Description()))
+	}
+	if err := validation.ValidateProxyStatsMatcher(*istioCR); err != nil {
+		errs = append(errs, field.Invalid(field.NewPath("spec", "config", "proxyStatsMatcher", "inclusionRegexps"), field.OmitValueType{}, err.Description()))
+	}
+	if err := validation.ValidateAccessLogging(*istioCR); err != nil {
+		errs = append(errs, field.Invalid(field.NewPath("spec", "config", "accessLogging"), field.OmitValueType{}, err.Description()))
+	}
+	if err := validation.ValidateTracing(*istioCR); err != nil {
+		errs = append(errs, field.Invalid(field.NewPath("spec", "config", "tracing", "providers"), field.OmitValueType{}, err.Description()))
+	}
+	if err := validation.ValidateIngressGatewayService(*istioCR); err != nil {
+		errs = append(errs, field.Invalid(field.NewPath("spec", "components", "ingressGateway", "service"), field.OmitValueType{}, err.Description()))
+	}
+	if err := validation.ValidatePodDisruptionBudgets(*istioCR); err != nil {
+		errs = append(errs, field.Invalid(field.NewPath("spec", "components"), field.OmitValueType{}, err.Description()))
+	}
+
+	return errs
+}
+
+func toInvalidError(istioCR *operatorv1alpha2.Istio, errs field.ErrorList) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(operatorv1alpha2.GroupVersion.WithKind("Istio").GroupKind(), istioCR.Name, errs)
+}
@@ -0,0 +1,197 @@
+//go:build !experimental
+
+package webhook_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	operatorv1alpha2 "github.com/kyma-project/istio/operator/api/v1alpha2"
+	"github.com/kyma-project/istio/operator/internal/webhook"
+)
+
+var _ = Describe("Istio validating webhook", func() {
+	ctx := context.Background()
+
+	It("should accept a valid Istio CR", func() {
+		// given
+		validator := &webhook.IstioValidator{Client: createFakeClient()}
+
+		// when
+		_, err := validator.ValidateCreate(ctx, createIstioCR("default", "kyma-system"))
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should reject an Istio CR that is not in the kyma-system namespace", func() {
+		// given
+		validator := &webhook.IstioValidator{Client: createFakeClient()}
+
+		// when
+		_, err := validator.ValidateCreate(ctx, createIstioCR("default", "default"))
+
+		// then
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("metadata.namespace"))
+		Expect(err.Error()).To(ContainSubstring("istio CR is not in kyma-system namespace"))
+	})
+
+	It("should reject a second Istio CR", func() {
+		// given
+		validator := &webhook.IstioValidator{Client: createFakeClient(createIstioCR("default", "kyma-system"))}
+
+		// when
+		_, err := validator.ValidateCreate(ctx, createIstioCR("second", "kyma-system"))
+
+		// then
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("only one Istio CR is allowed, Istio CR default already exists in kyma-system namespace"))
+	})
+
+	It("should reject an Istio CR with duplicated authorizer names", func() {
+		// given
+		validator := &webhook.IstioValidator{Client: createFakeClient()}
+		istioCR := createIstioCR("default", "kyma-system")
+		istioCR.Spec.Config.Authorizers = []*operatorv1alpha2.Authorizer{
+			{Name: "ext-authz", Service: "authz.default.svc.cluster.local", Port: 8080},
+			{Name: "ext-authz", Service: "other.default.svc.cluster.local", Port: 8080},
+		}
+
+		// when
+		_, err := validator.ValidateCreate(ctx, istioCR)
+
+		// then
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("spec.config.authorizers"))
+		Expect(err.Error()).To(ContainSubstring("Authorizer name needs to be unique: ext-authz is duplicated"))
+	})
+
+	It("should reject an Istio CR with a tracing provider that uses the name of an authorizer", func() {
+		// given
+		validator := &webhook.IstioValidator{Client: createFakeClient()}
+		istioCR := createIstioCR("default", "kyma-system")
+		istioCR.Spec.Config.Authorizers = []*operatorv1alpha2.Authorizer{
+			{Name: "ext-authz", Service: "authz.default.svc.cluster.local", Port: 8080},
+		}
+		istioCR.Spec.Config.Tracing = &operatorv1alpha2.Tracing{
+			Providers: []operatorv1alpha2.TracingProvider{
+				{Name: "ext-authz", Service: "otel-collector.observability.svc.cluster.local", Port: 4317},
+			},
+		}
+
+		// when
+		_, err := validator.ValidateCreate(ctx, istioCR)
+
+		// then
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("spec.config.tracing.providers"))
+		Expect(err.Error()).To(ContainSubstring("Tracing provider name needs to be unique: ext-authz is already used by another extension provider"))
+	})
+
+	It("should reject an Istio CR with a PodDisruptionBudget that doesn't allow evicting a Pod with the minimum replicas", func() {
+		// given
+		validator := &webhook.IstioValidator{Client: createFakeClient()}
+		istioCR := createIstioCR("default", "kyma-system")
+		minReplicas := int32(2)
+		maxUnavailable := intstr.FromString("0%")
+		istioCR.Spec.Components = &operatorv1alpha2.Components{
+			IngressGateway: &operatorv1alpha2.IngressGateway{K8s: &operatorv1alpha2.KubernetesResourcesConfig{
+				HPASpec:             &operatorv1alpha2.HPASpec{MinReplicas: &minReplicas},
+				PodDisruptionBudget: &operatorv1alpha2.PodDisruptionBudget{MaxUnavailable: &maxUnavailable},
+			}},
+		}
+
+		// when
+		_, err := validator.ValidateCreate(ctx, istioCR)
+
+		// then
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("spec.components"))
+		Expect(err.Error()).To(ContainSubstring("podDisruptionBudget maxUnavailable of istio-ingressgateway must allow at least one unavailable Pod"))
+	})
+
+	It("should reject an update with an invalid ProxyStatsMatcher regular expression", func() {
+		// given
+		validator := &webhook.IstioValidator{Client: createFakeClient()}
+		oldIstioCR := createIstioCR("default", "kyma-system")
+		newIstioCR := oldIstioCR.DeepCopy()
+		newIstioCR.Spec.Config.ProxyStatsMatcher = &operatorv1alpha2.ProxyStatsMatcher{InclusionRegexps: []string{"("}}
+
+		// when
+		_, err := validator.ValidateUpdate(ctx, oldIstioCR, newIstioCR)
+
+		// then
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("spec.config.proxyStatsMatcher.inclusionRegexps"))
+	})
+
+	It("should not reject an update that doesn't change the spec of an Istio CR with an invalid configuration", func() {
+		// given
+		validator := &webhook.IstioValidator{Client: createFakeClient()}
+		oldIstioCR := createIstioCR("default", "kyma-system")
+		oldIstioCR.Spec.Config.ProxyStatsMatcher = &operatorv1alpha2.ProxyStatsMatcher{InclusionRegexps: []string{"("}}
+		newIstioCR := oldIstioCR.DeepCopy()
+		newIstioCR.Labels = map[string]string{"test": "label"}
+
+		// when
+		_, err := validator.ValidateUpdate(ctx, oldIstioCR, newIstioCR)
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should reject experimental fields if the operator is not built with experimental features", func() {
+		// given
+		validator := &webhook.IstioValidator{Client: createFakeClient()}
+		istioCR := createIstioCR("default", "kyma-system")
+		istioCR.Spec.Experimental = &operatorv1alpha2.Experimental{}
+
+		// when
+		_, err := validator.ValidateCreate(ctx, istioCR)
+
+		// then
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("Experimental features are not supported in this image flavour"))
+	})
+
+	It("should not reject an update of an Istio CR that is being deleted", func() {
+		// given
+		validator := &webhook.IstioValidator{Client: createFakeClient()}
+		oldIstioCR := createIstioCR("default", "kyma-system")
+		newIstioCR := oldIstioCR.DeepCopy()
+		now := metav1.Now()
+		newIstioCR.DeletionTimestamp = &now
+		newIstioCR.Spec.Experimental = &operatorv1alpha2.Experimental{}
+
+		// when
+		_, err := validator.ValidateUpdate(ctx, oldIstioCR, newIstioCR)
+
+		// then
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+})
+
+func createIstioCR(name, namespace string) *operatorv1alpha2.Istio {
+	return &operatorv1alpha2.Istio{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+}
+
+func createFakeClient(objects ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	Expect(operatorv1alpha2.AddToScheme(scheme)).Should(Succeed())
+
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+}
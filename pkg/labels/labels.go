@@ -4,8 +4,22 @@ import "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 const (
 	LastAppliedConfiguration string = "operator.kyma-project.io/lastAppliedConfiguration"
+	PlanMode                 string = "operator.kyma-project.io/plan"
 	ModuleLabelKey           string = "kyma-project.io/module"
 	ModuleLabelValue         string = "istio"
+	// ProxyRestartLabelKey is set on namespaces and workloads to opt them in or out of the automatic restart of proxy sidecars.
+	ProxyRestartLabelKey      string = "operator.kyma-project.io/proxy-restart"
+	ProxyRestartLabelDisabled string = "disabled"
+	ProxyRestartLabelEnabled  string = "enabled"
+	// CertificateAuthoritySource is set on the istio-system/cacerts Secret managed by the Istio module and references the Secret it was copied from.
+	CertificateAuthoritySource string = "operator.kyma-project.io/certificate-authority-source"
+	// RootCARotationPhase is set on the istio-system/cacerts Secret that the Istio module creates during the rotation of the self-signed root certificate authority.
+	RootCARotationPhase string = "operator.kyma-project.io/root-ca-rotation-phase"
+	// ProxyConfigHash is set on Pods with Istio proxies and references the hash of the proxy configuration they were created with.
+	ProxyConfigHash string = "operator.kyma-project.io/proxy-config-hash"
+	// CertificateAuthorityFingerprint is set on the IstioOperator and references the fingerprint of the custom certificate authority
+	// that is included in the proxy config hashes.
+	CertificateAuthorityFingerprint string = "operator.kyma-project.io/certificate-authority-fingerprint"
 )
 
 func SetModuleLabels(labels map[string]string) map[string]string {
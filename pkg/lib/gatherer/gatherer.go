@@ -27,6 +27,11 @@ const (
 	IstioNamespace    string = "istio-system"
 
 	MinNumberOfMatches = 3
+
+	// The Istio sidecar injector sets this label on every Pod with an injected sidecar, so that the API server can filter the Pods.
+	istioTLSModeLabel     string = "security.istio.io/tlsMode"
+	istioComponentLabel   string = "operator.istio.io/component"
+	injectedPodsListLimit        = 500
 )
 
 // GetIstioCR fetches the Istio CR from the cluster using client with supplied name and namespace.
@@ -161,6 +166,68 @@ func VerifyIstioPodsVersion(ctx context.Context, kubeClient client.Client, istio
 	return nil
 }
 
+// VerifyInjectedPodsVersion verifies that the istio-proxy containers of all running Pods in the given namespaces use the expected Istio version.
+func VerifyInjectedPodsVersion(ctx context.Context, kubeClient client.Client, expectedVersion string, namespaces []string) error {
+	for _, namespace := range namespaces {
+		podList := v1.PodList{}
+		if err := kubeClient.List(ctx, &podList, client.InNamespace(namespace)); err != nil {
+			return err
+		}
+
+		if err := verifyProxyVersion(podList.Items, expectedVersion); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// VerifyAllInjectedPodsVersion verifies that the istio-proxy containers of all running Pods with an injected sidecar in the cluster use
+// the expected Istio version. Pods of the Istio components, e.g. the gateways, are not checked, because they are not injected.
+func VerifyAllInjectedPodsVersion(ctx context.Context, kubeClient client.Client, expectedVersion string) error {
+	continueToken := ""
+	for {
+		podList := v1.PodList{}
+		err := kubeClient.List(ctx, &podList, client.HasLabels{istioTLSModeLabel}, client.Limit(injectedPodsListLimit), client.Continue(continueToken))
+		if err != nil {
+			return err
+		}
+
+		injectedPods := slices.DeleteFunc(podList.Items, func(pod v1.Pod) bool {
+			_, isIstioComponent := pod.Labels[istioComponentLabel]
+			return isIstioComponent
+		})
+		if err := verifyProxyVersion(injectedPods, expectedVersion); err != nil {
+			return err
+		}
+
+		continueToken = podList.Continue
+		if continueToken == "" {
+			return nil
+		}
+	}
+}
+
+func verifyProxyVersion(pods []v1.Pod, expectedVersion string) error {
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || pod.Status.Phase != v1.PodRunning {
+			continue
+		}
+		for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+			if container.Name != "istio-proxy" {
+				continue
+			}
+			version, versionErr := getImageVersion(container.Image)
+			if versionErr != nil {
+				return versionErr
+			}
+			if version.String() != expectedVersion {
+				return fmt.Errorf("proxy version %s of Pod %s/%s does not match Istio version %s", version.String(), pod.Namespace, pod.Name, expectedVersion)
+			}
+		}
+	}
+	return nil
+}
+
 func getImageVersion(image string) (*semver.Version, error) {
 	matches := reference.ReferenceRegexp.FindStringSubmatch(image)
 	if len(matches) < MinNumberOfMatches {
@@ -339,6 +339,62 @@ var _ = Describe("Gatherer", func() {
 			Expect(version).To(Equal(""))
 		})
 	})
+
+	Context("VerifyInjectedPodsVersion", func() {
+		It("should succeed when the proxies of all Pods in the namespaces use the expected version", func() {
+			proxyPod := createPodWith("app", "migrated", "istio-proxy", "istio/proxyv2", "1.11.0-distroless", false)
+			otherPod := createPodWith("app", "other", "istio-proxy", "istio/proxyv2", "1.10.0", false)
+			client := createClientSet(proxyPod, otherPod)
+
+			err := gatherer.VerifyInjectedPodsVersion(context.TODO(), client, "1.11.0", []string{"migrated"})
+
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should return error when the proxy of a Pod in the namespaces uses another version", func() {
+			proxyPod := createPodWith("app", "migrated", "istio-proxy", "istio/proxyv2", "1.10.0", false)
+			client := createClientSet(proxyPod)
+
+			err := gatherer.VerifyInjectedPodsVersion(context.TODO(), client, "1.11.0", []string{"migrated"})
+
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("proxy version 1.10.0 of Pod migrated/app does not match Istio version 1.11.0"))
+		})
+
+		It("should ignore terminating Pods", func() {
+			proxyPod := createPodWith("app", "migrated", "istio-proxy", "istio/proxyv2", "1.10.0", true)
+			client := createClientSet(proxyPod)
+
+			err := gatherer.VerifyInjectedPodsVersion(context.TODO(), client, "1.11.0", []string{"migrated"})
+
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+	})
+
+	Context("VerifyAllInjectedPodsVersion", func() {
+		It("should return error when the proxy of an injected Pod in any namespace uses another version", func() {
+			proxyPod := createPodWith("app", "migrated", "istio-proxy", "istio/proxyv2", "1.11.0", false, "security.istio.io/tlsMode=istio")
+			otherPod := createPodWith("app", "other", "istio-proxy", "istio/proxyv2", "1.10.0", false, "security.istio.io/tlsMode=istio")
+			client := createClientSet(proxyPod, otherPod)
+
+			err := gatherer.VerifyAllInjectedPodsVersion(context.TODO(), client, "1.11.0")
+
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("proxy version 1.10.0 of Pod other/app does not match Istio version 1.11.0"))
+		})
+
+		It("should ignore Pods without injected sidecar and Istio components", func() {
+			proxyPod := createPodWith("app", "migrated", "istio-proxy", "istio/proxyv2", "1.11.0", false, "security.istio.io/tlsMode=istio")
+			notInjectedPod := createPodWith("app", "other", "istio-proxy", "istio/proxyv2", "1.10.0", false)
+			gatewayPod := createPodWith("istio-ingressgateway", "istio-system", "istio-proxy", "istio/proxyv2", "1.10.0", false,
+				"security.istio.io/tlsMode=istio", "operator.istio.io/component=IngressGateways")
+			client := createClientSet(proxyPod, notInjectedPod, gatewayPod)
+
+			err := gatherer.VerifyAllInjectedPodsVersion(context.TODO(), client, "1.11.0")
+
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+	})
 })
 
 func createClientSet(objects ...client.Object) client.Client {
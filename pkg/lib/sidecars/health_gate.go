@@ -0,0 +1,170 @@
+package sidecars
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kyma-project/istio/operator/api/v1alpha2"
+	"github.com/kyma-project/istio/operator/pkg/lib/sidecars/restart"
+)
+
+const rolloutNotReadyFormat = "rollout did not become ready within %s"
+
+// RestartHealthGateError is returned when the restart of proxies is stopped, because more restarted workloads than allowed by the
+// health gate configured in the Istio CR did not become ready.
+type RestartHealthGateError struct {
+	UnhealthyOwners []restart.Warning
+}
+
+func (e *RestartHealthGateError) Error() string {
+	owners := make([]string, 0, len(e.UnhealthyOwners))
+	for _, o := range e.UnhealthyOwners {
+		owners = append(owners, fmt.Sprintf("%s %s/%s", o.Kind, o.Namespace, o.Name))
+	}
+	return fmt.Sprintf("the following workloads did not become ready after the restart: %s", strings.Join(owners, ", "))
+}
+
+// RestartRolloutPendingError is returned when the restart of further proxies waits for the rollout of the restarted workloads.
+// The rollouts are checked again in the next reconciliation.
+type RestartRolloutPendingError struct {
+	PendingRollouts int
+}
+
+func (e *RestartRolloutPendingError) Error() string {
+	return fmt.Sprintf("restart of further proxies waits for the rollout of %d restarted workloads", e.PendingRollouts)
+}
+
+// healthGate holds the health gate configuration. The restarted workloads that the gate waits for are kept in the status of the
+// Istio CR, so that the rollouts are checked across reconciliations instead of blocking a single reconciliation.
+type healthGate struct {
+	timeout          time.Duration
+	failureThreshold int
+}
+
+func newHealthGate(istioCR *v1alpha2.Istio) *healthGate {
+	gate := istioCR.GetRestartHealthGate()
+	if gate == nil {
+		return nil
+	}
+	return &healthGate{
+		timeout:          gate.GetTimeout(),
+		failureThreshold: gate.GetFailureThreshold(),
+	}
+}
+
+// evaluateHealthGate checks the rollouts of the workloads restarted in previous reconciliations and updates the health gate status of
+// the Istio CR. Workloads that don't finish their rollout within the timeout are unhealthy until their rollout finishes. It returns a
+// RestartHealthGateError if more workloads than the failure threshold are unhealthy and a RestartRolloutPendingError if restarted
+// workloads are still rolling out.
+func (p *ProxyRestart) evaluateHealthGate(ctx context.Context, istioCR *v1alpha2.Istio, run *restartRun) error {
+	gateStatus := istioCR.Status.RestartHealthGate
+	if run.gate == nil || gateStatus == nil {
+		istioCR.Status.RestartHealthGate = nil
+		return nil
+	}
+
+	var unhealthy []v1alpha2.WorkloadReference
+	for _, workload := range gateStatus.UnhealthyWorkloads {
+		ready, err := p.healthChecker.IsRolloutReady(ctx, toRestartWorkload(workload))
+		if err != nil {
+			return err
+		}
+		if !ready {
+			unhealthy = append(unhealthy, workload)
+		}
+	}
+
+	timedOut := gateStatus.RolloutStartTime == nil || time.Since(gateStatus.RolloutStartTime.Time) >= run.gate.timeout
+	var pending []v1alpha2.WorkloadReference
+	for _, workload := range gateStatus.PendingRollouts {
+		ready, err := p.healthChecker.IsRolloutReady(ctx, toRestartWorkload(workload))
+		if err != nil {
+			return err
+		}
+		switch {
+		case ready:
+		case timedOut:
+			p.logger.Info("Rollout of restarted workload did not become ready", "name", workload.Name, "namespace", workload.Namespace, "kind", workload.Kind)
+			unhealthy = append(unhealthy, workload)
+			run.progress.addFailures([]restart.Warning{unhealthyWarning(workload, run.gate.timeout)},
+				map[string]string{workloadKey(workload.Kind, workload.Namespace, workload.Name): workload.Predicate})
+		default:
+			pending = append(pending, workload)
+		}
+	}
+
+	gateStatus.UnhealthyWorkloads = unhealthy
+	gateStatus.PendingRollouts = pending
+	if len(pending) == 0 {
+		gateStatus.RolloutStartTime = nil
+	}
+	gateStatus.Tripped = len(unhealthy) > run.gate.failureThreshold
+
+	if gateStatus.Tripped {
+		warnings := make([]restart.Warning, 0, len(unhealthy))
+		for _, workload := range unhealthy {
+			warnings = append(warnings, unhealthyWarning(workload, run.gate.timeout))
+		}
+		return &RestartHealthGateError{UnhealthyOwners: warnings}
+	}
+	if len(pending) > 0 {
+		return &RestartRolloutPendingError{PendingRollouts: len(pending)}
+	}
+	if len(unhealthy) == 0 {
+		istioCR.Status.RestartHealthGate = nil
+	}
+	return nil
+}
+
+// waitForRollouts records the owners of the restarted pods as pending rollouts in the health gate status of the Istio CR. It returns
+// a RestartRolloutPendingError, so that no further pods are restarted until the rollouts are checked in the next reconciliation.
+// Pods that are not restarted by a rollout, for example, pods owned by a Job, don't stop the restart. The predicate that triggered
+// the restart of a workload is kept with the workload, so that a failed rollout is reported with it.
+func (p *ProxyRestart) waitForRollouts(ctx context.Context, istioCR *v1alpha2.Istio, page *v1.PodList, workloadPredicates map[string]string) error {
+	owners, err := p.healthChecker.GetRolloutOwners(ctx, page)
+	if err != nil {
+		p.logger.Error(err, "Getting the rollout owners of restarted pods failed")
+		return err
+	}
+	if len(owners) == 0 {
+		return nil
+	}
+
+	gateStatus := istioCR.Status.RestartHealthGate
+	if gateStatus == nil {
+		gateStatus = &v1alpha2.RestartHealthGateStatus{}
+		istioCR.Status.RestartHealthGate = gateStatus
+	}
+	gateStatus.PendingRollouts = make([]v1alpha2.WorkloadReference, 0, len(owners))
+	for _, owner := range owners {
+		gateStatus.PendingRollouts = append(gateStatus.PendingRollouts, v1alpha2.WorkloadReference{
+			Kind:      owner.Kind,
+			Namespace: owner.Namespace,
+			Name:      owner.Name,
+			Predicate: workloadPredicates[workloadKey(owner.Kind, owner.Namespace, owner.Name)],
+		})
+	}
+	now := metav1.Now()
+	gateStatus.RolloutStartTime = &now
+
+	p.logger.Info("Waiting for the rollout of restarted workloads", "number of workloads", len(owners))
+	return &RestartRolloutPendingError{PendingRollouts: len(owners)}
+}
+
+func toRestartWorkload(workload v1alpha2.WorkloadReference) restart.Workload {
+	return restart.Workload{Kind: workload.Kind, Namespace: workload.Namespace, Name: workload.Name}
+}
+
+func unhealthyWarning(workload v1alpha2.WorkloadReference, timeout time.Duration) restart.Warning {
+	return restart.Warning{
+		Name:      workload.Name,
+		Namespace: workload.Namespace,
+		Kind:      workload.Kind,
+		Message:   fmt.Sprintf(rolloutNotReadyFormat, timeout),
+	}
+}
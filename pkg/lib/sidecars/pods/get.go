@@ -6,6 +6,7 @@ import (
 
 	"github.com/go-logr/logr"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -14,7 +15,12 @@ import (
 )
 
 const (
-	istioSidecarContainerName string = "istio-proxy"
+	istioComponentLabel          string = "operator.istio.io/component"
+	istioSidecarStatusAnnotation string = "sidecar.istio.io/status"
+	// The Istio sidecar injector sets this label on every Pod with an injected sidecar, so that the API server can filter the Pods.
+	istioTLSModeLabel string = "security.istio.io/tlsMode"
+
+	injectedPodsListLimit = 500
 )
 
 type RestartLimits struct {
@@ -94,21 +100,40 @@ func (p *Pods) GetPodsToRestart(ctx context.Context, preds []predicates.SidecarP
 	return nil
 }
 
+// GetAllInjectedPods returns the Pods with an injected Istio sidecar, except the Pods of the Istio gateways. Only the metadata of the Pods
+// is listed, because it is sufficient to restart the Pods through their owners.
 func (p *Pods) GetAllInjectedPods(ctx context.Context) (*v1.PodList, error) {
-	podList := &v1.PodList{}
 	outputPodList := &v1.PodList{}
-	outputPodList.Items = make([]v1.Pod, len(podList.Items))
+	continueToken := ""
 
-	err := retry.OnError(retry.DefaultRetry, func() error {
-		return p.k8sClient.List(ctx, podList, &client.ListOptions{})
-	})
-	if err != nil {
-		return podList, err
-	}
+	for {
+		podList := &metav1.PartialObjectMetadataList{}
+		podList.SetGroupVersionKind(v1.SchemeGroupVersion.WithKind("PodList"))
 
-	for _, pod := range podList.Items {
-		if containsSidecar(pod) {
-			outputPodList.Items = append(outputPodList.Items, pod)
+		err := retry.OnError(retry.DefaultRetry, func() error {
+			listOps := []client.ListOption{
+				client.HasLabels{istioTLSModeLabel},
+				client.Limit(injectedPodsListLimit),
+			}
+			if continueToken != "" {
+				listOps = append(listOps, client.Continue(continueToken))
+			}
+			return p.k8sClient.List(ctx, podList, listOps...)
+		})
+		if err != nil {
+			return outputPodList, err
+		}
+
+		for _, pod := range podList.Items {
+			if containsSidecar(pod.ObjectMeta) {
+				pod.ManagedFields = nil
+				outputPodList.Items = append(outputPodList.Items, v1.Pod{ObjectMeta: pod.ObjectMeta})
+			}
+		}
+
+		continueToken = podList.Continue
+		if continueToken == "" {
+			break
 		}
 	}
 
@@ -120,6 +145,7 @@ func listRunningPods(ctx context.Context, c client.Client, listLimit int, contin
 
 	err := retry.OnError(retry.DefaultRetry, func() error {
 		listOps := []client.ListOption{
+			client.HasLabels{istioTLSModeLabel},
 			client.MatchingFieldsSelector{Selector: fields.OneTermEqualSelector("status.phase", string(v1.PodRunning))},
 			client.Limit(listLimit),
 		}
@@ -153,22 +179,18 @@ func getSidecarPods(ctx context.Context, c client.Client, logger *logr.Logger, l
 	return podsWithSidecar, nil
 }
 
-func containsSidecar(pod v1.Pod) bool {
+func containsSidecar(pod metav1.ObjectMeta) bool {
 	// Exclude pods with label istio=ingressgateway or istio=egressgateway
 	// These pods are not meant to be restarted by this part of the code
 	// This function is used only for restart of the the user workloads during uninstalling Istio, so the sidecars are removed
 	if val, ok := pod.Labels["istio"]; ok && (val == "ingressgateway" || val == "egressgateway") {
 		return false
 	}
-	for _, container := range pod.Spec.Containers {
-		if container.Name == istioSidecarContainerName {
-			return true
-		}
-	}
-	for _, initContainer := range pod.Spec.InitContainers {
-		if initContainer.Name == istioSidecarContainerName {
-			return true
-		}
+	// Additional ingress gateways have custom istio labels, so they are identified by the component label set by the Istio installation
+	if val, ok := pod.Labels[istioComponentLabel]; ok && (val == "IngressGateways" || val == "EgressGateways") {
+		return false
 	}
-	return false
+	// The sidecar injector sets the status annotation for both regular and native sidecar containers
+	_, injected := pod.Annotations[istioSidecarStatusAnnotation]
+	return injected
 }
@@ -17,6 +17,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/kyma-project/istio/operator/api/v1alpha2"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -147,6 +148,19 @@ var _ = Describe("GetPodsToRestart", func() {
 					Expect(podList.Items).To(BeEmpty())
 				},
 			},
+			{
+				name: "Should ignore pod that has different image tag when it is not labeled with the istio tlsMode label",
+				c: createClientSet(
+					helpers.NewSidecarPodBuilder().
+						SetSidecarImageTag("1.12.0").
+						ReplacePodLabels(map[string]string{}).
+						Build(),
+				),
+				limits: pods.NewPodsRestartLimits(5),
+				assertFunc: func(podList *v1.PodList) {
+					Expect(podList.Items).To(BeEmpty())
+				},
+			},
 			{
 				name: "Should contain only one pod when there are multiple predicates that match the pod",
 				c: createClientSet(
@@ -461,6 +475,30 @@ var _ = Describe("GetAllInjectedPods", func() {
 			),
 			assertFunc: func(podList *v1.PodList) { Expect(podList.Items).To(HaveLen(1)) },
 		},
+		{
+			name: "Should return only the metadata of pod with istio sidecar",
+			c: createClientSet(
+				helpers.NewSidecarPodBuilder().
+					SetOwnerReference(metav1.OwnerReference{Kind: "ReplicaSet", Name: "owner"}).
+					Build(),
+			),
+			assertFunc: func(podList *v1.PodList) {
+				Expect(podList.Items).To(HaveLen(1))
+				Expect(podList.Items[0].Name).To(Equal("app"))
+				Expect(podList.Items[0].Namespace).To(Equal("custom"))
+				Expect(podList.Items[0].OwnerReferences).To(ConsistOf(metav1.OwnerReference{Kind: "ReplicaSet", Name: "owner"}))
+				Expect(podList.Items[0].Spec.Containers).To(BeEmpty())
+			},
+		},
+		{
+			name: "Should not return pod with istio sidecar that is not labeled with the istio tlsMode label",
+			c: createClientSet(
+				helpers.NewSidecarPodBuilder().
+					ReplacePodLabels(map[string]string{}).
+					Build(),
+			),
+			assertFunc: func(podList *v1.PodList) { Expect(podList.Items).To(BeEmpty()) },
+		},
 		{
 			name: "Should return pod with istio sidecar as init container",
 			c: createClientSet(
@@ -475,6 +513,13 @@ var _ = Describe("GetAllInjectedPods", func() {
 			),
 			assertFunc: func(podList *v1.PodList) { Expect(podList.Items).To(HaveLen(0)) },
 		},
+		{
+			name: "Should not return Pod that belongs to an additional ingress gateway",
+			c: createClientSet(
+				helpers.FixPodAdditionalIngressGateway("internal-ingressgateway"),
+			),
+			assertFunc: func(podList *v1.PodList) { Expect(podList.Items).To(HaveLen(0)) },
+		},
 		{
 			name: "Should not return Pod that belongs to istio-egressgateway",
 			c: createClientSet(
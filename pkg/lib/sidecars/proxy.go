@@ -4,17 +4,24 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/kyma-project/istio/operator/api/v1alpha2"
 	"github.com/kyma-project/istio/operator/internal/images"
 	"github.com/kyma-project/istio/operator/internal/istiofeatures"
+	"github.com/kyma-project/istio/operator/internal/maintenancewindow"
+	istiocrmetrics "github.com/kyma-project/istio/operator/internal/metrics"
 	"github.com/kyma-project/istio/operator/internal/reconciliations/istio/configuration"
 	"github.com/kyma-project/istio/operator/internal/restarter/predicates"
 
 	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/kyma-project/istio/operator/pkg/lib/sidecars/pods"
@@ -22,9 +29,164 @@ import (
 )
 
 const (
-	podsLimitToRestartPerPage = 30
+	podsLimitToRestartPerPage    = 30
+	manualRestartRequiredMessage = "pod sidecar was not restarted because the workload is excluded from automatic restarts."
 )
 
+// RestartDeferredError is returned when the restart of customer proxies is deferred, because none of the maintenance windows
+// configured in the Istio CR is open.
+type RestartDeferredError struct {
+	PendingPods int
+}
+
+func (e *RestartDeferredError) Error() string {
+	return fmt.Sprintf("restart of %d customer proxies is deferred until the next maintenance window", e.PendingPods)
+}
+
+// RestartPendingError is returned when the restart of proxies waits for their owners, for example, for a StatefulSet with the OnDelete
+// update strategy that didn't replace its deleted pod yet. The restart continues in the next reconciliation.
+type RestartPendingError struct {
+	PendingPods int
+}
+
+func (e *RestartPendingError) Error() string {
+	return fmt.Sprintf("restart of %d proxies waits for their workloads to become ready", e.PendingPods)
+}
+
+// restartProgress holds the progress of a single proxy restart that is reported in the proxyReset status of the Istio CR
+// and in the restart metrics.
+type restartProgress struct {
+	metrics             *istiocrmetrics.IstioCRMetrics
+	matchedPods         int
+	restartedPods       int
+	failuresByOwnerKind map[string]int
+	restartsByPredicate map[string]int
+}
+
+func newRestartProgress(metrics *istiocrmetrics.IstioCRMetrics) *restartProgress {
+	return &restartProgress{
+		metrics:             metrics,
+		failuresByOwnerKind: map[string]int{},
+		restartsByPredicate: map[string]int{},
+	}
+}
+
+// addPage records the pods of a restarted page. Only the pods whose restart didn't fail and isn't pending are counted as restarted.
+func (r *restartProgress) addPage(page *v1.PodList, preds []predicates.SidecarProxyPredicate, warnings []restart.Warning) {
+	failed := make(map[string]bool, len(warnings))
+	for _, w := range warnings {
+		failed[workloadKey(w.Kind, w.Namespace, w.Name)] = true
+	}
+
+	r.matchedPods += len(page.Items)
+	for _, pod := range page.Items {
+		predicate := getTriggeringPredicate(pod, preds)
+		r.restartsByPredicate[predicate]++
+		if isRestartFailed(pod, failed) {
+			continue
+		}
+		r.restartedPods++
+		r.metrics.AddRestartedPods(predicate, getOwnerKind(pod), 1)
+	}
+	r.addFailures(warnings, getWorkloadPredicates(page, preds))
+}
+
+// addFailures records the failed workloads. The predicate of a workload is looked up by its workload key in the given predicates.
+func (r *restartProgress) addFailures(warnings []restart.Warning, workloadPredicates map[string]string) {
+	for _, w := range warnings {
+		if w.Pending {
+			continue
+		}
+		predicate, found := workloadPredicates[workloadKey(w.Kind, w.Namespace, w.Name)]
+		if !found {
+			predicate = "unknown"
+		}
+		r.failuresByOwnerKind[w.Kind]++
+		r.metrics.AddRestartFailures(predicate, w.Kind, 1)
+	}
+}
+
+// isRestartFailed returns true if a warning was returned for the pod, for its owner, or for the Deployment owning its ReplicaSet.
+func isRestartFailed(pod v1.Pod, failed map[string]bool) bool {
+	for _, key := range getPodWorkloadKeys(pod) {
+		if failed[key] {
+			return true
+		}
+	}
+	return false
+}
+
+// getWorkloadPredicates returns the predicate that triggered the restart by the workload keys of the pods of the page. If the pods of a
+// workload were restarted by different predicates, the predicate of the first pod is returned.
+func getWorkloadPredicates(page *v1.PodList, preds []predicates.SidecarProxyPredicate) map[string]string {
+	workloadPredicates := map[string]string{}
+	for _, pod := range page.Items {
+		predicate := getTriggeringPredicate(pod, preds)
+		for _, key := range getPodWorkloadKeys(pod) {
+			if _, exists := workloadPredicates[key]; !exists {
+				workloadPredicates[key] = predicate
+			}
+		}
+	}
+	return workloadPredicates
+}
+
+// getPodWorkloadKeys returns the workload keys of the pod, of its owners, and of the Deployment owning its ReplicaSet.
+// The ReplicaSets of a Deployment are named after the Deployment with the pod template hash as suffix.
+func getPodWorkloadKeys(pod v1.Pod) []string {
+	keys := []string{workloadKey("Pod", pod.Namespace, pod.Name)}
+	for _, owner := range pod.OwnerReferences {
+		keys = append(keys, workloadKey(owner.Kind, pod.Namespace, owner.Name))
+		hash, hasHash := pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey]
+		if owner.Kind == "ReplicaSet" && hasHash && strings.HasSuffix(owner.Name, "-"+hash) {
+			deployment := strings.TrimSuffix(owner.Name, "-"+hash)
+			keys = append(keys, workloadKey("Deployment", pod.Namespace, deployment))
+		}
+	}
+	return keys
+}
+
+func workloadKey(kind, namespace, name string) string {
+	return fmt.Sprintf("%s/%s/%s", kind, namespace, name)
+}
+
+func getOwnerKind(pod v1.Pod) string {
+	if len(pod.OwnerReferences) == 0 {
+		return "none"
+	}
+	return pod.OwnerReferences[0].Kind
+}
+
+// getTriggeringPredicate returns the name of the first optional predicate matching the pod, as the optional predicates decide
+// whether a pod is restarted.
+func getTriggeringPredicate(pod v1.Pod, preds []predicates.SidecarProxyPredicate) string {
+	for _, predicate := range preds {
+		if !predicate.MustMatch() && predicate.Matches(pod) {
+			return predicate.Name()
+		}
+	}
+	return "unknown"
+}
+
+// restartRun holds the state shared by all pages restarted during a single proxy restart.
+type restartRun struct {
+	istioCR  *v1alpha2.Istio
+	gate     *healthGate
+	progress *restartProgress
+	options  restart.Options
+}
+
+// newRestartOptions returns the options of the action restarter configured in the Istio CR. Values that are not set are defaulted by the restarter.
+func newRestartOptions(istioCR *v1alpha2.Istio) restart.Options {
+	concurrency := istioCR.GetRestartConcurrency()
+	return restart.Options{
+		UnknownOwnerStrategy:              restart.UnknownOwnerStrategy(istioCR.GetUnknownOwnerStrategy()),
+		MaxConcurrentRestarts:             concurrency.GetMaxConcurrentRestarts(),
+		MaxConcurrentRestartsPerNamespace: concurrency.GetMaxConcurrentRestartsPerNamespace(),
+		RequestsPerSecond:                 concurrency.GetRequestsPerSecond(),
+	}
+}
+
 type ProxyRestarter interface {
 	RestartProxies(
 		ctx context.Context,
@@ -39,6 +201,8 @@ type ProxyRestart struct {
 	k8sClient       client.Client
 	podsLister      pods.Getter
 	actionRestarter restart.ActionRestarter
+	healthChecker   restart.RolloutHealthChecker
+	metrics         *istiocrmetrics.IstioCRMetrics
 	logger          *logr.Logger
 }
 
@@ -47,58 +211,85 @@ func NewProxyRestarter(c client.Client, podsLister pods.Getter, actionRestarter
 		k8sClient:       c,
 		podsLister:      podsLister,
 		actionRestarter: actionRestarter,
+		healthChecker:   restart.NewRolloutHealthChecker(c),
 		logger:          logger,
 	}
 }
 
+// WithMetrics returns a copy of the proxy restarter that records the restarted pods and the restart failures in the given metrics.
+func (p *ProxyRestart) WithMetrics(metrics *istiocrmetrics.IstioCRMetrics) *ProxyRestart {
+	restarter := *p
+	restarter.metrics = metrics
+	return &restarter
+}
+
 func (p *ProxyRestart) RestartProxies(
 	ctx context.Context,
 	expectedImage images.Image,
 	expectedResources v1.ResourceRequirements,
 	istioCR *v1alpha2.Istio,
 ) ([]restart.Warning, error) {
-	compatibiltyPredicate, err := predicates.NewCompatibilityRestartPredicate(istioCR)
+	preds, err := GetRestartPredicates(ctx, p.k8sClient, expectedImage, expectedResources, istioCR.GetProxyConfigHashes(), istioCR)
 	if err != nil {
-		p.logger.Error(err, "Failed to create restart compatibility predicate")
+		p.logger.Error(err, "Failed to create restart predicates")
 		return []restart.Warning{}, err
 	}
-	prometheusMergePredicate, err := predicates.NewPrometheusMergeRestartPredicate(ctx, p.k8sClient, istioCR)
+
+	automaticRestart, err := predicates.NewAutomaticRestartPredicate(ctx, p.k8sClient, istioCR)
 	if err != nil {
-		p.logger.Error(err, "Failed to create restart prometheusMerge predicate")
+		p.logger.Error(err, "Failed to create automatic restart predicate")
 		return []restart.Warning{}, err
 	}
-	enableDNSProxyingPredicate, err := predicates.NewEnableDNSProxyingRestartPredicate(istioCR)
-	if err != nil {
-		p.logger.Error(err, "Failed to create restart enableDNSProxying predicate")
+	customerPreds := preds
+	if automaticRestart.HasExclusions() {
+		customerPreds = append(slices.Clone(preds), automaticRestart)
+	}
+
+	run := &restartRun{istioCR: istioCR, gate: newHealthGate(istioCR), progress: newRestartProgress(p.metrics), options: newRestartOptions(istioCR)}
+
+	err = p.evaluateHealthGate(ctx, istioCR, run)
+	if isHealthGateStop(err) {
+		p.logger.Info("Restart of proxies waits for the health gate", "reason", err.Error())
+		p.setProxyResetStatusAfterHealthGateStop(ctx, istioCR, expectedImage, preds, customerPreds, run.progress)
 		return []restart.Warning{}, err
 	}
-	lastAppliedConfig, err := configuration.GetLastAppliedConfiguration(istioCR)
 	if err != nil {
-		p.logger.Error(err, "Failed to get last applied configuration")
+		p.logger.Error(err, "Failed to check the rollout of restarted workloads")
 		return []restart.Warning{}, err
 	}
-	proxyStatsMatcherPredicate := predicates.NewProxyStatsMatcherRestartPredicate(istioCR, lastAppliedConfig)
-	istioFeatures, err := istiofeatures.Get(ctx, p.k8sClient)
-	if err != nil && !apierrors.IsNotFound(err) {
-		p.logger.Error(err, "Failed to get Istio features")
+
+	err = p.restartKymaProxies(ctx, preds, run)
+	if isHealthGateStop(err) || isRestartPending(err) {
+		p.setProxyResetStatusAfterHealthGateStop(ctx, istioCR, expectedImage, preds, customerPreds, run.progress)
 		return []restart.Warning{}, err
 	}
-	preds := []predicates.SidecarProxyPredicate{
-		compatibiltyPredicate,
-		prometheusMergePredicate,
-		predicates.NewImageResourcesPredicate(expectedImage, expectedResources),
-		enableDNSProxyingPredicate,
-		proxyStatsMatcherPredicate,
-		predicates.NewCniRestartPredicate(istioFeatures.DisableCni),
+	if err != nil {
+		p.logger.Error(err, "Failed to restart Kyma proxies")
+		return []restart.Warning{}, err
 	}
 
-	err = p.restartKymaProxies(ctx, preds)
+	pendingPods, err := p.countDeferredCustomerProxies(ctx, customerPreds, istioCR)
 	if err != nil {
-		p.logger.Error(err, "Failed to restart Kyma proxies")
+		p.logger.Error(err, "Failed to evaluate maintenance windows")
 		return []restart.Warning{}, err
 	}
+	if pendingPods > 0 {
+		p.logger.Info("Restart of Customer proxies deferred until the next maintenance window", "pending pods", pendingPods)
+		setProxyResetStatus(istioCR, expectedImage, run.progress, pendingPods)
+		return []restart.Warning{}, &RestartDeferredError{PendingPods: pendingPods}
+	}
 
-	warnings, err := p.restartCustomerProxies(ctx, preds)
+	warnings, err := p.restartCustomerProxies(ctx, customerPreds, istioCR, run)
+	if isHealthGateStop(err) {
+		p.setProxyResetStatusAfterHealthGateStop(ctx, istioCR, expectedImage, preds, customerPreds, run.progress)
+		return warnings, err
+	}
+	warnings, pendingPods = splitPendingWarnings(warnings)
+	if err == nil && pendingPods > 0 {
+		p.logger.Info("Restart of Customer proxies waits for their workloads", "pending pods", pendingPods)
+		p.setProxyResetStatusAfterHealthGateStop(ctx, istioCR, expectedImage, preds, customerPreds, run.progress)
+		return warnings, &RestartPendingError{PendingPods: pendingPods}
+	}
 	if err != nil {
 		p.logger.Error(err, "failed to restart Customer proxies")
 		warnings = []restart.Warning{ // errors on Customer proxies are considered as a warning
@@ -109,28 +300,202 @@ func (p *ProxyRestart) RestartProxies(
 				Message:   "failed to restart Customer proxies",
 			},
 		}
+	} else if automaticRestart.HasExclusions() {
+		warnings = append(warnings, p.getManualRestartWarnings(ctx, append(slices.Clone(preds), automaticRestart.ManualRestart()))...)
 	}
 
+	setProxyResetStatus(istioCR, expectedImage, run.progress, 0)
 	return warnings, nil
 }
 
+// isHealthGateStop returns true if the health gate stopped the restart, because restarted workloads are still rolling out or too many
+// of them did not become ready.
+func isHealthGateStop(err error) bool {
+	var healthGateErr *RestartHealthGateError
+	var rolloutPendingErr *RestartRolloutPendingError
+	return errors.As(err, &healthGateErr) || errors.As(err, &rolloutPendingErr)
+}
+
+// isRestartPending returns true if the restart waits for the owners of pods that are restarted one at a time.
+func isRestartPending(err error) bool {
+	var pendingErr *RestartPendingError
+	return errors.As(err, &pendingErr)
+}
+
+// splitPendingWarnings returns the warnings without the pending ones and the number of pending warnings.
+func splitPendingWarnings(warnings []restart.Warning) ([]restart.Warning, int) {
+	remaining := make([]restart.Warning, 0, len(warnings))
+	for _, w := range warnings {
+		if !w.Pending {
+			remaining = append(remaining, w)
+		}
+	}
+	return remaining, len(warnings) - len(remaining)
+}
+
+// setProxyResetStatusAfterHealthGateStop sets the proxyReset status with the Kyma and customer pods that still match the restart
+// predicates as pending.
+func (p *ProxyRestart) setProxyResetStatusAfterHealthGateStop(
+	ctx context.Context,
+	istioCR *v1alpha2.Istio,
+	expectedImage images.Image,
+	preds []predicates.SidecarProxyPredicate,
+	customerPreds []predicates.SidecarProxyPredicate,
+	progress *restartProgress,
+) {
+	pendingKymaPods, err := p.countPodsToRestart(ctx, append(slices.Clone(preds), predicates.NewKymaWorkloadRestartPredicate()))
+	if err != nil {
+		p.logger.Error(err, "Failed to count pending pods after the restart was stopped")
+	}
+	pendingCustomerPods, err := p.countPodsToRestart(ctx, append(slices.Clone(customerPreds), predicates.NewCustomerWorkloadRestartPredicate()))
+	if err != nil {
+		p.logger.Error(err, "Failed to count pending pods after the restart was stopped")
+	}
+	setProxyResetStatus(istioCR, expectedImage, progress, pendingKymaPods+pendingCustomerPods)
+}
+
+// getManualRestartWarnings returns a warning for each customer pod that matches the restart predicates, but is excluded from the
+// automatic restart, so that it is reported as requiring a manual restart.
+func (p *ProxyRestart) getManualRestartWarnings(ctx context.Context, preds []predicates.SidecarProxyPredicate) []restart.Warning {
+	preds = append(preds, predicates.NewCustomerWorkloadRestartPredicate())
+	limits := pods.NewPodsRestartLimits(podsLimitToRestartPerPage)
+
+	var warnings []restart.Warning
+	err := p.podsLister.GetPodsToRestart(ctx, preds, limits, func(_ context.Context, page *v1.PodList) error {
+		for _, pod := range page.Items {
+			warnings = append(warnings, restart.Warning{
+				Name:      pod.Name,
+				Namespace: pod.Namespace,
+				Kind:      "Pod",
+				Message:   manualRestartRequiredMessage,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		p.logger.Error(err, "Failed to get pods that require a manual restart")
+		return []restart.Warning{}
+	}
+
+	if len(warnings) > 0 {
+		p.logger.Info("Customer proxies excluded from automatic restarts require a manual restart", "number of pods", len(warnings))
+	}
+	return warnings
+}
+
+// setProxyResetStatus reports the progress of the proxy restart in the Istio CR status. A restart without any matched or pending pods
+// doesn't replace the status of the last restart, but finishes it if it was still pending. The start time of a pending restart
+// to the same image is kept, so that the status covers the whole restart across reconciliations.
+func setProxyResetStatus(istioCR *v1alpha2.Istio, expectedImage images.Image, progress *restartProgress, pendingPods int) {
+	now := metav1.Now()
+	previous := istioCR.Status.ProxyReset
+	previousPending := previous != nil && previous.FinishTime == nil
+
+	if progress.matchedPods == 0 && pendingPods == 0 {
+		if previousPending {
+			previous.PendingPods = 0
+			previous.FinishTime = &now
+		}
+		return
+	}
+
+	status := &v1alpha2.ProxyResetStatus{
+		TargetImage:   expectedImage.String(),
+		MatchedPods:   progress.matchedPods,
+		RestartedPods: progress.restartedPods,
+		PendingPods:   pendingPods,
+		StartTime:     &now,
+	}
+	if len(progress.failuresByOwnerKind) > 0 {
+		status.FailuresByOwnerKind = progress.failuresByOwnerKind
+	}
+	if len(progress.restartsByPredicate) > 0 {
+		status.RestartsByPredicate = progress.restartsByPredicate
+	}
+	if previousPending && previous.TargetImage == status.TargetImage && previous.StartTime != nil {
+		status.StartTime = previous.StartTime
+	}
+	if pendingPods == 0 {
+		status.FinishTime = &now
+	}
+
+	istioCR.Status.ProxyReset = status
+}
+
+// GetRestartPredicates returns the predicates evaluating if a proxy sidecar must be restarted to apply the Istio CR
+// configuration and the expected image and resources.
+func GetRestartPredicates(
+	ctx context.Context,
+	k8sClient client.Client,
+	expectedImage images.Image,
+	expectedResources v1.ResourceRequirements,
+	proxyConfigHashes v1alpha2.ProxyConfigHashes,
+	istioCR *v1alpha2.Istio,
+) ([]predicates.SidecarProxyPredicate, error) {
+	lastAppliedConfig, err := configuration.GetLastAppliedConfiguration(istioCR)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last applied configuration: %w", err)
+	}
+	certificateAuthorityPredicate := predicates.NewCertificateAuthorityRestartPredicate(istioCR, lastAppliedConfig, proxyConfigHashes.CertificateAuthorityFingerprint)
+	istioFeatures, err := istiofeatures.Get(ctx, k8sClient)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get Istio features: %w", err)
+	}
+
+	return []predicates.SidecarProxyPredicate{
+		predicates.NewProxyConfigHashRestartPredicate(istioCR, lastAppliedConfig, proxyConfigHashes.Sidecars),
+		predicates.NewImageResourcesPredicate(expectedImage, expectedResources),
+		certificateAuthorityPredicate,
+		predicates.NewCniRestartPredicate(istioFeatures.DisableCni),
+		predicates.NewCanaryUpgradeRestartPredicate(istioCR),
+	}, nil
+}
+
 func (p *ProxyRestart) RestartWithPredicates(
 	ctx context.Context,
 	preds []predicates.SidecarProxyPredicate,
 	limits *pods.RestartLimits,
 	failOnError bool,
+) ([]restart.Warning, error) {
+	return p.restartWithPredicates(ctx, preds, limits, failOnError, nil)
+}
+
+// restartWithPredicates restarts the pods matching the predicates page by page. If a run is given, it restarts the pods with the
+// options of the run and records the progress of each page. If the run has a health gate, it stops after the first page whose pods
+// are restarted by a rollout, so that the rollout is checked in the next reconciliation before further pods are restarted.
+func (p *ProxyRestart) restartWithPredicates(
+	ctx context.Context,
+	preds []predicates.SidecarProxyPredicate,
+	limits *pods.RestartLimits,
+	failOnError bool,
+	run *restartRun,
 ) ([]restart.Warning, error) {
 	var allWarnings []restart.Warning
+	// A new action restarter is used for each call, so that an owner whose pods are listed in multiple pages is restarted only once.
+	options := restart.Options{}
+	if run != nil {
+		options = run.options
+	}
+	actionRestarter := p.actionRestarter.WithOptions(options)
 
 	err := p.podsLister.GetPodsToRestart(ctx, preds, limits, func(ctx context.Context, page *v1.PodList) error {
-		warnings, err := p.actionRestarter.Restart(ctx, page, failOnError)
+		warnings, err := actionRestarter.Restart(ctx, page, failOnError)
 		allWarnings = append(allWarnings, warnings...)
+		if run != nil {
+			run.progress.addPage(page, preds, warnings)
+		}
 		if err != nil {
 			p.logger.Error(err, "Restarting pods failed")
 			return err
 		}
+		if run != nil && run.gate != nil {
+			return p.waitForRollouts(ctx, run.istioCR, page, getWorkloadPredicates(page, preds))
+		}
 		return nil
 	})
+	if isHealthGateStop(err) {
+		return allWarnings, err
+	}
 	if err != nil {
 		p.logger.Error(err, "Getting pods to restart failed")
 		return allWarnings, err
@@ -139,21 +504,29 @@ func (p *ProxyRestart) RestartWithPredicates(
 	return allWarnings, nil
 }
 
-func (p *ProxyRestart) restartKymaProxies(ctx context.Context, preds []predicates.SidecarProxyPredicate) error {
+func (p *ProxyRestart) restartKymaProxies(ctx context.Context, preds []predicates.SidecarProxyPredicate, run *restartRun) error {
 	preds = append(preds, predicates.NewKymaWorkloadRestartPredicate())
 	limits := pods.NewPodsRestartLimits(podsLimitToRestartPerPage)
 
-	warnings, err := p.RestartWithPredicates(ctx, preds, limits, true)
+	warnings, err := p.restartWithPredicates(ctx, preds, limits, true, run)
+	if isHealthGateStop(err) {
+		return err
+	}
 	if err != nil {
 		p.logger.Error(err, "Failed to restart Kyma proxies")
 		return err
 	}
+	warnings, pendingPods := splitPendingWarnings(warnings)
 	warningMessage := BuildWarningMessage(warnings, p.logger)
 	if warningMessage != "" {
 		err = errors.New(warningMessage)
 		p.logger.Error(err, "Failed to restart Kyma proxies")
 		return err
 	}
+	if pendingPods > 0 {
+		p.logger.Info("Restart of Kyma proxies waits for their workloads", "pending pods", pendingPods)
+		return &RestartPendingError{PendingPods: pendingPods}
+	}
 
 	p.logger.Info("Kyma proxy restart completed")
 	return nil
@@ -180,17 +553,137 @@ func BuildWarningMessage(warnings []restart.Warning, logger *logr.Logger) string
 	return warningMessage
 }
 
-func (p *ProxyRestart) restartCustomerProxies(ctx context.Context, preds []predicates.SidecarProxyPredicate) ([]restart.Warning, error) {
+func (p *ProxyRestart) restartCustomerProxies(
+	ctx context.Context,
+	preds []predicates.SidecarProxyPredicate,
+	istioCR *v1alpha2.Istio,
+	run *restartRun,
+) ([]restart.Warning, error) {
 	preds = append(preds, predicates.NewCustomerWorkloadRestartPredicate())
 	limits := pods.NewPodsRestartLimits(podsLimitToRestartPerPage)
 
-	warnings, err := p.RestartWithPredicates(ctx, preds, limits, false)
+	waves, err := p.getRestartWaves(ctx, istioCR)
 	if err != nil {
-		p.logger.Error(err, "Failed to restart Customer proxies")
-		return warnings, err
+		p.logger.Error(err, "Failed to get restart waves")
+		return nil, err
+	}
+
+	if len(waves) == 0 {
+		warnings, err := p.restartWithPredicates(ctx, preds, limits, false, run)
+		if isHealthGateStop(err) {
+			return warnings, err
+		}
+		if err != nil {
+			p.logger.Error(err, "Failed to restart Customer proxies")
+			return warnings, err
+		}
+
+		p.logger.Info("Customer proxy restart completed")
+		return warnings, nil
+	}
+
+	var allWarnings []restart.Warning
+	for _, wave := range waves {
+		if len(wave.namespaces) == 0 {
+			continue
+		}
+
+		p.logger.Info("Restarting Customer proxies of wave", "wave", wave.name, "number of namespaces", len(wave.namespaces))
+		wavePreds := append(slices.Clone(preds), predicates.NewRestartWavePredicate(wave.namespaces))
+		warnings, err := p.restartWithPredicates(ctx, wavePreds, limits, false, run)
+		allWarnings = append(allWarnings, warnings...)
+		if isHealthGateStop(err) {
+			return allWarnings, err
+		}
+		if err != nil {
+			p.logger.Error(err, "Failed to restart Customer proxies", "wave", wave.name)
+			return allWarnings, err
+		}
+		if _, pendingPods := splitPendingWarnings(warnings); pendingPods > 0 {
+			p.logger.Info("Next restart wave waits for the pending restarts of the wave", "wave", wave.name, "pending pods", pendingPods)
+			return allWarnings, nil
+		}
 	}
 
 	p.logger.Info("Customer proxy restart completed")
 
-	return warnings, nil
+	return allWarnings, nil
+}
+
+// countDeferredCustomerProxies returns the number of customer pods whose restart must be deferred, because none of the
+// maintenance windows configured in the Istio CR is open.
+func (p *ProxyRestart) countDeferredCustomerProxies(
+	ctx context.Context,
+	preds []predicates.SidecarProxyPredicate,
+	istioCR *v1alpha2.Istio,
+) (int, error) {
+	open, err := maintenancewindow.IsOpen(istioCR.GetMaintenanceWindows(), time.Now())
+	if err != nil {
+		return 0, err
+	}
+	if open {
+		return 0, nil
+	}
+
+	return p.countPodsToRestart(ctx, append(slices.Clone(preds), predicates.NewCustomerWorkloadRestartPredicate()))
+}
+
+// countPodsToRestart returns the number of pods matching the predicates without restarting them.
+func (p *ProxyRestart) countPodsToRestart(ctx context.Context, preds []predicates.SidecarProxyPredicate) (int, error) {
+	limits := pods.NewPodsRestartLimits(podsLimitToRestartPerPage)
+
+	podsToRestart := 0
+	err := p.podsLister.GetPodsToRestart(ctx, preds, limits, func(_ context.Context, page *v1.PodList) error {
+		podsToRestart += len(page.Items)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return podsToRestart, nil
+}
+
+type restartWave struct {
+	name       string
+	namespaces []string
+}
+
+// getRestartWaves assigns every namespace to the first restart wave of the Istio CR whose selector matches the namespace labels.
+// The namespaces that don't match any wave are assigned to an additional last wave.
+func (p *ProxyRestart) getRestartWaves(ctx context.Context, istioCR *v1alpha2.Istio) ([]restartWave, error) {
+	configuredWaves := istioCR.GetRestartWaves()
+	if len(configuredWaves) == 0 {
+		return nil, nil
+	}
+
+	selectors := make([]labels.Selector, 0, len(configuredWaves))
+	waves := make([]restartWave, 0, len(configuredWaves)+1)
+	for _, wave := range configuredWaves {
+		selector, err := metav1.LabelSelectorAsSelector(&wave.NamespaceSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid namespace selector of restart wave %s: %w", wave.Name, err)
+		}
+		selectors = append(selectors, selector)
+		waves = append(waves, restartWave{name: wave.Name})
+	}
+	remaining := restartWave{name: "remaining namespaces"}
+
+	namespaces := &v1.NamespaceList{}
+	if err := p.k8sClient.List(ctx, namespaces); err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+
+	for _, namespace := range namespaces.Items {
+		index := slices.IndexFunc(selectors, func(selector labels.Selector) bool {
+			return selector.Matches(labels.Set(namespace.Labels))
+		})
+		if index < 0 {
+			remaining.namespaces = append(remaining.namespaces, namespace.Name)
+			continue
+		}
+		waves[index].namespaces = append(waves[index].namespaces, namespace.Name)
+	}
+
+	return append(waves, remaining), nil
 }
@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/kyma-project/istio/operator/api/v1alpha2"
 	"github.com/kyma-project/istio/operator/internal/images"
 	"github.com/kyma-project/istio/operator/internal/restarter/predicates"
 	"github.com/kyma-project/istio/operator/internal/tests"
@@ -22,6 +24,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -85,36 +88,34 @@ var _ = Describe("RestartProxies", func() {
 		Expect(podsListerMock.Called).To(Equal(2))
 
 		Expect(podsListerMock.Predicates).To(HaveLen(2))
-		Expect(podsListerMock.Predicates[0]).To(HaveLen(7))
-		Expect(podsListerMock.Predicates[0][0]).To(BeAssignableToTypeOf(&predicates.CompatibilityRestartPredicate{}))
-		Expect(podsListerMock.Predicates[0][1]).To(BeAssignableToTypeOf(&predicates.PrometheusMergeRestartPredicate{}))
-		Expect(podsListerMock.Predicates[0][2]).To(BeAssignableToTypeOf(&predicates.ImageResourcesPredicate{}))
-		Expect(podsListerMock.Predicates[0][3]).To(BeAssignableToTypeOf(&predicates.EnableDNSProxyingRestartPredicate{}))
-		Expect(podsListerMock.Predicates[0][4]).To(BeAssignableToTypeOf(&predicates.ProxyStatsMatcherRestartPredicate{}))
-		Expect(podsListerMock.Predicates[0][5]).To(BeAssignableToTypeOf(&predicates.CniRestartPredicate{}))
-		Expect(podsListerMock.Predicates[0][6]).To(BeAssignableToTypeOf(&predicates.KymaWorkloadRestartPredicate{}))
-
-		Expect(podsListerMock.Predicates[1]).To(HaveLen(7))
-		Expect(podsListerMock.Predicates[1][0]).To(BeAssignableToTypeOf(&predicates.CompatibilityRestartPredicate{}))
-		Expect(podsListerMock.Predicates[1][1]).To(BeAssignableToTypeOf(&predicates.PrometheusMergeRestartPredicate{}))
-		Expect(podsListerMock.Predicates[1][2]).To(BeAssignableToTypeOf(&predicates.ImageResourcesPredicate{}))
-		Expect(podsListerMock.Predicates[1][3]).To(BeAssignableToTypeOf(&predicates.EnableDNSProxyingRestartPredicate{}))
-		Expect(podsListerMock.Predicates[1][4]).To(BeAssignableToTypeOf(&predicates.ProxyStatsMatcherRestartPredicate{}))
-		Expect(podsListerMock.Predicates[1][5]).To(BeAssignableToTypeOf(&predicates.CniRestartPredicate{}))
-		Expect(podsListerMock.Predicates[1][6]).To(BeAssignableToTypeOf(&predicates.CustomerWorkloadRestartPredicate{}))
+		Expect(podsListerMock.Predicates[0]).To(HaveLen(6))
+		Expect(podsListerMock.Predicates[0][0]).To(BeAssignableToTypeOf(&predicates.ProxyConfigHashRestartPredicate{}))
+		Expect(podsListerMock.Predicates[0][1]).To(BeAssignableToTypeOf(&predicates.ImageResourcesPredicate{}))
+		Expect(podsListerMock.Predicates[0][2]).To(BeAssignableToTypeOf(&predicates.CertificateAuthorityRestartPredicate{}))
+		Expect(podsListerMock.Predicates[0][3]).To(BeAssignableToTypeOf(&predicates.CniRestartPredicate{}))
+		Expect(podsListerMock.Predicates[0][4]).To(BeAssignableToTypeOf(&predicates.CanaryUpgradeRestartPredicate{}))
+		Expect(podsListerMock.Predicates[0][5]).To(BeAssignableToTypeOf(&predicates.KymaWorkloadRestartPredicate{}))
+
+		Expect(podsListerMock.Predicates[1]).To(HaveLen(6))
+		Expect(podsListerMock.Predicates[1][0]).To(BeAssignableToTypeOf(&predicates.ProxyConfigHashRestartPredicate{}))
+		Expect(podsListerMock.Predicates[1][1]).To(BeAssignableToTypeOf(&predicates.ImageResourcesPredicate{}))
+		Expect(podsListerMock.Predicates[1][2]).To(BeAssignableToTypeOf(&predicates.CertificateAuthorityRestartPredicate{}))
+		Expect(podsListerMock.Predicates[1][3]).To(BeAssignableToTypeOf(&predicates.CniRestartPredicate{}))
+		Expect(podsListerMock.Predicates[1][4]).To(BeAssignableToTypeOf(&predicates.CanaryUpgradeRestartPredicate{}))
+		Expect(podsListerMock.Predicates[1][5]).To(BeAssignableToTypeOf(&predicates.CustomerWorkloadRestartPredicate{}))
 
 		Expect(podsListerMock.Limits).To(HaveLen(2))
 		Expect(podsListerMock.Limits[0].PodsPerPage).To(Equal(30))
 		Expect(podsListerMock.Limits[1].PodsPerPage).To(Equal(30))
 	})
 
-	It("should return error if compatibility predicate creation fails", func() {
+	It("should return error if restart predicates creation fails", func() {
 		// given
 		c := fakeClient()
 		podsListerMock := NewPodsMock()
 		expectedImage := images.Image{Registry: "istio", Name: "proxyv2", Tag: "1.1.0"}
 		istioCR := helpers.GetIstioCR(expectedImage.Tag)
-		istioCR.Annotations[labels.LastAppliedConfiguration] = "invalid-last-applied-configuration" // This should cause the compatibility predicate to fail
+		istioCR.Annotations[labels.LastAppliedConfiguration] = "invalid-last-applied-configuration" // This should cause the certificate authority predicate to fail
 		actionRestarter := restart.NewActionRestarter(c, &logger)
 		proxyRestarter := sidecars.NewProxyRestarter(c, podsListerMock, actionRestarter, &logger)
 
@@ -214,6 +215,586 @@ var _ = Describe("RestartProxies", func() {
 		Expect(err).ToNot(HaveOccurred())
 		Expect(warnings).To(BeEmpty())
 	})
+
+	It("should restart Customer proxies in the order of the restart waves", func() {
+		// given
+		c := fakeClient(
+			getNamespace("first-ns", map[string]string{"tier": "first"}),
+			getNamespace("second-ns", map[string]string{"tier": "second"}),
+			getNamespace("other-ns", nil),
+		)
+		podsListerMock := NewPodsMock()
+		expectedImage := images.Image{Registry: "istio", Name: "proxyv2", Tag: "1.1.0"}
+		istioCR := helpers.GetIstioCR(expectedImage.Tag)
+		istioCR.Spec.ProxyRestart = &v1alpha2.ProxyRestart{
+			Waves: []v1alpha2.RestartWave{
+				{Name: "second", NamespaceSelector: metav1.LabelSelector{MatchLabels: map[string]string{"tier": "second"}}},
+				{Name: "first", NamespaceSelector: metav1.LabelSelector{MatchLabels: map[string]string{"tier": "first"}}},
+			},
+		}
+		actionRestarter := restart.NewActionRestarter(c, &logger)
+		proxyRestarter := sidecars.NewProxyRestarter(c, podsListerMock, actionRestarter, &logger)
+
+		// when
+		warnings, err := proxyRestarter.RestartProxies(ctx, expectedImage, helpers.DefaultSidecarResources, &istioCR)
+
+		// then
+		Expect(err).ToNot(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+
+		Expect(podsListerMock.Called).To(Equal(4))
+		for call, namespace := range map[int]string{1: "second-ns", 2: "first-ns", 3: "other-ns"} {
+			wavePredicate := podsListerMock.Predicates[call][len(podsListerMock.Predicates[call])-1]
+			Expect(wavePredicate).To(BeAssignableToTypeOf(&predicates.RestartWavePredicate{}))
+			for _, other := range []string{"second-ns", "first-ns", "other-ns"} {
+				pod := v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: other}}
+				Expect(wavePredicate.Matches(pod)).To(Equal(other == namespace))
+			}
+		}
+	})
+
+	It("should defer restart of Customer proxies when no maintenance window is open", func() {
+		// given
+		pod := getPod("test-pods", "test-namespace", "podOwner", "ReplicaSet")
+		rsOwner := getReplicaSet("podOwner", "test-namespace", "rsOwner", "ReplicaSet")
+		rsOwnerRS := getReplicaSet("rsOwner", "test-namespace", "base", "ReplicaSet")
+
+		c := fakeClient(pod, rsOwner, rsOwnerRS)
+
+		podsLister := pods.NewPods(c, &logger)
+		expectedImage := images.Image{Registry: "istio", Name: "proxyv2", Tag: "1.1.0"}
+		istioCR := helpers.GetIstioCR(expectedImage.Tag)
+		istioCR.Spec.ProxyRestart = &v1alpha2.ProxyRestart{
+			MaintenanceWindows: []v1alpha2.MaintenanceWindow{
+				{Schedule: "0 0 31 2 *", Duration: metav1.Duration{Duration: time.Hour}},
+			},
+		}
+		actionRestarter := restart.NewActionRestarter(c, &logger)
+		proxyRestarter := sidecars.NewProxyRestarter(c, podsLister, actionRestarter, &logger)
+
+		// when
+		warnings, err := proxyRestarter.RestartProxies(ctx, expectedImage, helpers.DefaultSidecarResources, &istioCR)
+
+		// then
+		Expect(warnings).To(BeEmpty())
+		var deferredErr *sidecars.RestartDeferredError
+		Expect(errors.As(err, &deferredErr)).To(BeTrue())
+		Expect(deferredErr.PendingPods).To(Equal(1))
+		Expect(istioCR.Status.ProxyReset).NotTo(BeNil())
+		Expect(istioCR.Status.ProxyReset.MatchedPods).To(Equal(0))
+		Expect(istioCR.Status.ProxyReset.PendingPods).To(Equal(1))
+		Expect(istioCR.Status.ProxyReset.StartTime).NotTo(BeNil())
+		Expect(istioCR.Status.ProxyReset.FinishTime).To(BeNil())
+
+		err = c.Get(ctx, client.ObjectKey{Name: rsOwnerRS.Name, Namespace: rsOwnerRS.Namespace}, rsOwnerRS)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rsOwnerRS.Spec.Template.Annotations).ToNot(HaveKey("istio-operator.kyma-project.io/restartedAt"))
+	})
+
+	It("should restart Customer proxies when a maintenance window is open", func() {
+		// given
+		pod := getPod("test-pods", "test-namespace", "podOwner", "ReplicaSet")
+		rsOwner := getReplicaSet("podOwner", "test-namespace", "rsOwner", "ReplicaSet")
+		rsOwnerRS := getReplicaSet("rsOwner", "test-namespace", "base", "ReplicaSet")
+
+		c := fakeClient(pod, rsOwner, rsOwnerRS)
+
+		podsLister := pods.NewPods(c, &logger)
+		expectedImage := images.Image{Registry: "istio", Name: "proxyv2", Tag: "1.1.0"}
+		istioCR := helpers.GetIstioCR(expectedImage.Tag)
+		istioCR.Spec.ProxyRestart = &v1alpha2.ProxyRestart{
+			MaintenanceWindows: []v1alpha2.MaintenanceWindow{
+				{Schedule: "0 0 31 2 *", Duration: metav1.Duration{Duration: time.Hour}},
+				{Schedule: "* * * * *", Duration: metav1.Duration{Duration: time.Minute}},
+			},
+		}
+		actionRestarter := restart.NewActionRestarter(c, &logger)
+		proxyRestarter := sidecars.NewProxyRestarter(c, podsLister, actionRestarter, &logger)
+
+		// when
+		warnings, err := proxyRestarter.RestartProxies(ctx, expectedImage, helpers.DefaultSidecarResources, &istioCR)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+
+		err = c.Get(ctx, client.ObjectKey{Name: rsOwnerRS.Name, Namespace: rsOwnerRS.Namespace}, rsOwnerRS)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rsOwnerRS.Spec.Template.Annotations).To(HaveKey("istio-operator.kyma-project.io/restartedAt"))
+	})
+
+	It("should wait for the rollout of the restarted workloads in the next reconciliation if the health gate is configured", func() {
+		// given
+		pod := getPod("test-pod", "test-namespace", "rs1", "ReplicaSet")
+		rs := getReplicaSet("rs1", "test-namespace", "dep1", "Deployment")
+		dep := getUnavailableDeployment("dep1", "test-namespace")
+		c := fakeClient(pod, rs, dep)
+
+		podsLister := pods.NewPods(c, &logger)
+		expectedImage := images.Image{Registry: "istio", Name: "proxyv2", Tag: "1.1.0"}
+		istioCR := helpers.GetIstioCR(expectedImage.Tag)
+		istioCR.Spec.ProxyRestart = &v1alpha2.ProxyRestart{
+			HealthGate: &v1alpha2.RestartHealthGate{Timeout: &metav1.Duration{Duration: time.Hour}},
+		}
+		actionRestarter := restart.NewActionRestarter(c, &logger)
+		proxyRestarter := sidecars.NewProxyRestarter(c, podsLister, actionRestarter, &logger)
+
+		// when
+		_, err := proxyRestarter.RestartProxies(ctx, expectedImage, helpers.DefaultSidecarResources, &istioCR)
+
+		// then
+		var rolloutPendingErr *sidecars.RestartRolloutPendingError
+		Expect(errors.As(err, &rolloutPendingErr)).To(BeTrue())
+		Expect(rolloutPendingErr.PendingRollouts).To(Equal(1))
+		Expect(istioCR.Status.RestartHealthGate).NotTo(BeNil())
+		Expect(istioCR.Status.RestartHealthGate.PendingRollouts).To(ConsistOf(
+			v1alpha2.WorkloadReference{Kind: "Deployment", Namespace: "test-namespace", Name: "dep1"}))
+		Expect(istioCR.Status.RestartHealthGate.RolloutStartTime).NotTo(BeNil())
+		Expect(istioCR.Status.RestartHealthGate.Tripped).To(BeFalse())
+		Expect(istioCR.Status.ProxyReset.PendingPods).To(Equal(1))
+
+		err = c.Get(ctx, client.ObjectKeyFromObject(dep), dep)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dep.Spec.Template.Annotations).To(HaveKey("istio-operator.kyma-project.io/restartedAt"))
+	})
+
+	It("should keep the predicate that triggered the restart with the pending rollout of the restarted workload", func() {
+		// given
+		pod := getPod("test-pod", "test-namespace", "dep1-abc", "ReplicaSet")
+		pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey] = "abc"
+		rs := getReplicaSet("dep1-abc", "test-namespace", "dep1", "Deployment")
+		dep := getUnavailableDeployment("dep1", "test-namespace")
+		c := fakeClient(pod, rs, dep)
+
+		podsLister := pods.NewPods(c, &logger)
+		expectedImage := images.Image{Registry: "istio", Name: "proxyv2", Tag: "1.1.0"}
+		istioCR := helpers.GetIstioCR(expectedImage.Tag)
+		istioCR.Spec.ProxyRestart = &v1alpha2.ProxyRestart{
+			HealthGate: &v1alpha2.RestartHealthGate{Timeout: &metav1.Duration{Duration: time.Hour}},
+		}
+		actionRestarter := restart.NewActionRestarter(c, &logger)
+		proxyRestarter := sidecars.NewProxyRestarter(c, podsLister, actionRestarter, &logger)
+
+		// when
+		_, err := proxyRestarter.RestartProxies(ctx, expectedImage, helpers.DefaultSidecarResources, &istioCR)
+
+		// then
+		var rolloutPendingErr *sidecars.RestartRolloutPendingError
+		Expect(errors.As(err, &rolloutPendingErr)).To(BeTrue())
+		Expect(istioCR.Status.RestartHealthGate.PendingRollouts).To(ConsistOf(
+			v1alpha2.WorkloadReference{Kind: "Deployment", Namespace: "test-namespace", Name: "dep1", Predicate: "ImageResourcesPredicate"}))
+	})
+
+	It("should not restart further workloads while the rollout of restarted workloads is pending", func() {
+		// given
+		pod := getPod("test-pod", "test-namespace", "rs1", "ReplicaSet")
+		rs := getReplicaSet("rs1", "test-namespace", "dep1", "Deployment")
+		dep := getUnavailableDeployment("dep1", "test-namespace")
+		rolloutPending := getUnavailableDeployment("dep2", "test-namespace")
+		c := fakeClient(pod, rs, dep, rolloutPending)
+
+		podsLister := pods.NewPods(c, &logger)
+		expectedImage := images.Image{Registry: "istio", Name: "proxyv2", Tag: "1.1.0"}
+		istioCR := helpers.GetIstioCR(expectedImage.Tag)
+		istioCR.Spec.ProxyRestart = &v1alpha2.ProxyRestart{
+			HealthGate: &v1alpha2.RestartHealthGate{Timeout: &metav1.Duration{Duration: time.Hour}},
+		}
+		istioCR.Status.RestartHealthGate = &v1alpha2.RestartHealthGateStatus{
+			PendingRollouts:  []v1alpha2.WorkloadReference{{Kind: "Deployment", Namespace: "test-namespace", Name: "dep2"}},
+			RolloutStartTime: &metav1.Time{Time: time.Now().Add(-time.Minute)},
+		}
+		actionRestarter := restart.NewActionRestarter(c, &logger)
+		proxyRestarter := sidecars.NewProxyRestarter(c, podsLister, actionRestarter, &logger)
+
+		// when
+		_, err := proxyRestarter.RestartProxies(ctx, expectedImage, helpers.DefaultSidecarResources, &istioCR)
+
+		// then
+		var rolloutPendingErr *sidecars.RestartRolloutPendingError
+		Expect(errors.As(err, &rolloutPendingErr)).To(BeTrue())
+		Expect(istioCR.Status.RestartHealthGate.PendingRollouts).To(ConsistOf(
+			v1alpha2.WorkloadReference{Kind: "Deployment", Namespace: "test-namespace", Name: "dep2"}))
+
+		err = c.Get(ctx, client.ObjectKeyFromObject(dep), dep)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dep.Spec.Template.Annotations).NotTo(HaveKey("istio-operator.kyma-project.io/restartedAt"))
+	})
+
+	It("should trip the health gate if more restarted workloads than the failure threshold do not finish their rollout within the timeout", func() {
+		// given
+		pod := getPod("test-pod", "test-namespace", "rs1", "ReplicaSet")
+		rs := getReplicaSet("rs1", "test-namespace", "dep1", "Deployment")
+		dep := getUnavailableDeployment("dep1", "test-namespace")
+		c := fakeClient(pod, rs, dep)
+
+		podsLister := pods.NewPods(c, &logger)
+		expectedImage := images.Image{Registry: "istio", Name: "proxyv2", Tag: "1.1.0"}
+		istioCR := helpers.GetIstioCR(expectedImage.Tag)
+		istioCR.Spec.ProxyRestart = &v1alpha2.ProxyRestart{
+			HealthGate: &v1alpha2.RestartHealthGate{Timeout: &metav1.Duration{Duration: time.Minute}},
+		}
+		istioCR.Status.RestartHealthGate = &v1alpha2.RestartHealthGateStatus{
+			PendingRollouts:  []v1alpha2.WorkloadReference{{Kind: "Deployment", Namespace: "test-namespace", Name: "dep1"}},
+			RolloutStartTime: &metav1.Time{Time: time.Now().Add(-time.Hour)},
+		}
+		actionRestarter := restart.NewActionRestarter(c, &logger)
+		proxyRestarter := sidecars.NewProxyRestarter(c, podsLister, actionRestarter, &logger)
+
+		// when
+		_, err := proxyRestarter.RestartProxies(ctx, expectedImage, helpers.DefaultSidecarResources, &istioCR)
+
+		// then
+		var healthGateErr *sidecars.RestartHealthGateError
+		Expect(errors.As(err, &healthGateErr)).To(BeTrue())
+		Expect(healthGateErr.UnhealthyOwners).To(HaveLen(1))
+		Expect(healthGateErr.UnhealthyOwners[0].Name).To(Equal("dep1"))
+		Expect(err.Error()).To(Equal("the following workloads did not become ready after the restart: Deployment test-namespace/dep1"))
+		Expect(istioCR.Status.RestartHealthGate.Tripped).To(BeTrue())
+		Expect(istioCR.Status.RestartHealthGate.PendingRollouts).To(BeEmpty())
+		Expect(istioCR.Status.RestartHealthGate.UnhealthyWorkloads).To(ConsistOf(
+			v1alpha2.WorkloadReference{Kind: "Deployment", Namespace: "test-namespace", Name: "dep1"}))
+		Expect(istioCR.Status.ProxyReset).NotTo(BeNil())
+		Expect(istioCR.Status.ProxyReset.PendingPods).To(Equal(1))
+		Expect(istioCR.Status.ProxyReset.FailuresByOwnerKind).To(Equal(map[string]int{"Deployment": 1}))
+		Expect(istioCR.Status.ProxyReset.FinishTime).To(BeNil())
+
+		err = c.Get(ctx, client.ObjectKeyFromObject(dep), dep)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dep.Spec.Template.Annotations).NotTo(HaveKey("istio-operator.kyma-project.io/restartedAt"))
+	})
+
+	It("should keep the restart stopped while the tripped health gate has unhealthy workloads", func() {
+		// given
+		pod := getPod("test-pod", "test-namespace", "rs1", "ReplicaSet")
+		rs := getReplicaSet("rs1", "test-namespace", "dep1", "Deployment")
+		dep := getUnavailableDeployment("dep1", "test-namespace")
+		unhealthy := getUnavailableDeployment("dep2", "test-namespace")
+		c := fakeClient(pod, rs, dep, unhealthy)
+
+		podsLister := pods.NewPods(c, &logger)
+		expectedImage := images.Image{Registry: "istio", Name: "proxyv2", Tag: "1.1.0"}
+		istioCR := helpers.GetIstioCR(expectedImage.Tag)
+		istioCR.Spec.ProxyRestart = &v1alpha2.ProxyRestart{
+			HealthGate: &v1alpha2.RestartHealthGate{},
+		}
+		istioCR.Status.RestartHealthGate = &v1alpha2.RestartHealthGateStatus{
+			UnhealthyWorkloads: []v1alpha2.WorkloadReference{{Kind: "Deployment", Namespace: "test-namespace", Name: "dep2"}},
+			Tripped:            true,
+		}
+		actionRestarter := restart.NewActionRestarter(c, &logger)
+		proxyRestarter := sidecars.NewProxyRestarter(c, podsLister, actionRestarter, &logger)
+
+		// when
+		_, err := proxyRestarter.RestartProxies(ctx, expectedImage, helpers.DefaultSidecarResources, &istioCR)
+
+		// then
+		var healthGateErr *sidecars.RestartHealthGateError
+		Expect(errors.As(err, &healthGateErr)).To(BeTrue())
+		Expect(istioCR.Status.RestartHealthGate.Tripped).To(BeTrue())
+
+		err = c.Get(ctx, client.ObjectKeyFromObject(dep), dep)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dep.Spec.Template.Annotations).NotTo(HaveKey("istio-operator.kyma-project.io/restartedAt"))
+	})
+
+	It("should resume the restart when the unhealthy workloads of the tripped health gate become ready", func() {
+		// given
+		pod := getPod("test-pod", "test-namespace", "rs1", "ReplicaSet")
+		rs := getReplicaSet("rs1", "test-namespace", "dep1", "Deployment")
+		dep := getUnavailableDeployment("dep1", "test-namespace")
+		recovered := getUnavailableDeployment("dep2", "test-namespace")
+		recovered.Status.AvailableReplicas = 1
+		c := fakeClient(pod, rs, dep, recovered)
+
+		podsLister := pods.NewPods(c, &logger)
+		expectedImage := images.Image{Registry: "istio", Name: "proxyv2", Tag: "1.1.0"}
+		istioCR := helpers.GetIstioCR(expectedImage.Tag)
+		istioCR.Spec.ProxyRestart = &v1alpha2.ProxyRestart{
+			HealthGate: &v1alpha2.RestartHealthGate{},
+		}
+		istioCR.Status.RestartHealthGate = &v1alpha2.RestartHealthGateStatus{
+			UnhealthyWorkloads: []v1alpha2.WorkloadReference{{Kind: "Deployment", Namespace: "test-namespace", Name: "dep2"}},
+			Tripped:            true,
+		}
+		actionRestarter := restart.NewActionRestarter(c, &logger)
+		proxyRestarter := sidecars.NewProxyRestarter(c, podsLister, actionRestarter, &logger)
+
+		// when
+		_, err := proxyRestarter.RestartProxies(ctx, expectedImage, helpers.DefaultSidecarResources, &istioCR)
+
+		// then
+		var rolloutPendingErr *sidecars.RestartRolloutPendingError
+		Expect(errors.As(err, &rolloutPendingErr)).To(BeTrue())
+		Expect(istioCR.Status.RestartHealthGate.Tripped).To(BeFalse())
+		Expect(istioCR.Status.RestartHealthGate.UnhealthyWorkloads).To(BeEmpty())
+
+		err = c.Get(ctx, client.ObjectKeyFromObject(dep), dep)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dep.Spec.Template.Annotations).To(HaveKey("istio-operator.kyma-project.io/restartedAt"))
+	})
+
+	It("should not trip the health gate if the unhealthy workloads do not exceed the failure threshold", func() {
+		// given
+		pod := getPod("test-pod", "test-namespace", "rs1", "ReplicaSet")
+		rs := getReplicaSet("rs1", "test-namespace", "dep1", "Deployment")
+		dep := getUnavailableDeployment("dep1", "test-namespace")
+		c := fakeClient(pod, rs, dep)
+
+		podsLister := pods.NewPods(c, &logger)
+		expectedImage := images.Image{Registry: "istio", Name: "proxyv2", Tag: "1.1.0"}
+		istioCR := helpers.GetIstioCR(expectedImage.Tag)
+		istioCR.Spec.ProxyRestart = &v1alpha2.ProxyRestart{
+			HealthGate: &v1alpha2.RestartHealthGate{
+				Timeout:          &metav1.Duration{Duration: time.Minute},
+				FailureThreshold: ptr.To(1),
+			},
+		}
+		istioCR.Status.RestartHealthGate = &v1alpha2.RestartHealthGateStatus{
+			PendingRollouts:  []v1alpha2.WorkloadReference{{Kind: "Deployment", Namespace: "test-namespace", Name: "dep2"}},
+			RolloutStartTime: &metav1.Time{Time: time.Now().Add(-time.Hour)},
+		}
+		Expect(c.Create(ctx, getUnavailableDeployment("dep2", "test-namespace"))).To(Succeed())
+		actionRestarter := restart.NewActionRestarter(c, &logger)
+		proxyRestarter := sidecars.NewProxyRestarter(c, podsLister, actionRestarter, &logger)
+
+		// when
+		_, err := proxyRestarter.RestartProxies(ctx, expectedImage, helpers.DefaultSidecarResources, &istioCR)
+
+		// then
+		var healthGateErr *sidecars.RestartHealthGateError
+		Expect(errors.As(err, &healthGateErr)).To(BeFalse())
+		Expect(istioCR.Status.RestartHealthGate.Tripped).To(BeFalse())
+		Expect(istioCR.Status.RestartHealthGate.UnhealthyWorkloads).To(ConsistOf(
+			v1alpha2.WorkloadReference{Kind: "Deployment", Namespace: "test-namespace", Name: "dep2"}))
+
+		err = c.Get(ctx, client.ObjectKeyFromObject(dep), dep)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dep.Spec.Template.Annotations).To(HaveKey("istio-operator.kyma-project.io/restartedAt"))
+	})
+
+	It("should remove the health gate status if the health gate is no longer configured", func() {
+		// given
+		c := fakeClient()
+
+		podsLister := pods.NewPods(c, &logger)
+		expectedImage := images.Image{Registry: "istio", Name: "proxyv2", Tag: "1.1.0"}
+		istioCR := helpers.GetIstioCR(expectedImage.Tag)
+		istioCR.Status.RestartHealthGate = &v1alpha2.RestartHealthGateStatus{
+			UnhealthyWorkloads: []v1alpha2.WorkloadReference{{Kind: "Deployment", Namespace: "test-namespace", Name: "dep1"}},
+			Tripped:            true,
+		}
+		actionRestarter := restart.NewActionRestarter(c, &logger)
+		proxyRestarter := sidecars.NewProxyRestarter(c, podsLister, actionRestarter, &logger)
+
+		// when
+		_, err := proxyRestarter.RestartProxies(ctx, expectedImage, helpers.DefaultSidecarResources, &istioCR)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(istioCR.Status.RestartHealthGate).To(BeNil())
+	})
+
+	It("should not restart Customer proxies excluded from automatic restarts but report them as requiring a manual restart", func() {
+		// given
+		pod := getPod("test-pod", "opted-out", "podOwner", "ReplicaSet")
+		rsOwner := getReplicaSet("podOwner", "opted-out", "rsOwner", "ReplicaSet")
+		rsOwnerRS := getReplicaSet("rsOwner", "opted-out", "base", "ReplicaSet")
+		namespace := getNamespace("opted-out", map[string]string{labels.ProxyRestartLabelKey: labels.ProxyRestartLabelDisabled})
+
+		c := fakeClient(pod, rsOwner, rsOwnerRS, namespace)
+
+		podsLister := pods.NewPods(c, &logger)
+		expectedImage := images.Image{Registry: "istio", Name: "proxyv2", Tag: "1.1.0"}
+		istioCR := helpers.GetIstioCR(expectedImage.Tag)
+		actionRestarter := restart.NewActionRestarter(c, &logger)
+		proxyRestarter := sidecars.NewProxyRestarter(c, podsLister, actionRestarter, &logger)
+
+		// when
+		warnings, err := proxyRestarter.RestartProxies(ctx, expectedImage, helpers.DefaultSidecarResources, &istioCR)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(HaveLen(1))
+		Expect(warnings[0].Name).To(Equal("test-pod"))
+		Expect(warnings[0].Namespace).To(Equal("opted-out"))
+
+		err = c.Get(ctx, client.ObjectKey{Name: rsOwnerRS.Name, Namespace: rsOwnerRS.Namespace}, rsOwnerRS)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rsOwnerRS.Spec.Template.Annotations).ToNot(HaveKey("istio-operator.kyma-project.io/restartedAt"))
+	})
+
+	It("should only restart Customer proxies in namespaces that opted in if the opt-in mode is configured", func() {
+		// given
+		optedInPod := getPod("opted-in-pod", "opted-in", "podOwner", "ReplicaSet")
+		optedInRS := getReplicaSet("podOwner", "opted-in", "rsOwner", "ReplicaSet")
+		optedInRSOwner := getReplicaSet("rsOwner", "opted-in", "base", "ReplicaSet")
+		otherPod := getPod("other-pod", "other", "podOwner", "ReplicaSet")
+		otherRS := getReplicaSet("podOwner", "other", "rsOwner", "ReplicaSet")
+		otherRSOwner := getReplicaSet("rsOwner", "other", "base", "ReplicaSet")
+		namespace := getNamespace("opted-in", map[string]string{labels.ProxyRestartLabelKey: labels.ProxyRestartLabelEnabled})
+
+		c := fakeClient(optedInPod, optedInRS, optedInRSOwner, otherPod, otherRS, otherRSOwner, namespace)
+
+		podsLister := pods.NewPods(c, &logger)
+		expectedImage := images.Image{Registry: "istio", Name: "proxyv2", Tag: "1.1.0"}
+		istioCR := helpers.GetIstioCR(expectedImage.Tag)
+		istioCR.Spec.ProxyRestart = &v1alpha2.ProxyRestart{Mode: v1alpha2.ProxyRestartModeOptIn}
+		actionRestarter := restart.NewActionRestarter(c, &logger)
+		proxyRestarter := sidecars.NewProxyRestarter(c, podsLister, actionRestarter, &logger)
+
+		// when
+		warnings, err := proxyRestarter.RestartProxies(ctx, expectedImage, helpers.DefaultSidecarResources, &istioCR)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(HaveLen(1))
+		Expect(warnings[0].Name).To(Equal("other-pod"))
+
+		err = c.Get(ctx, client.ObjectKeyFromObject(optedInRSOwner), optedInRSOwner)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(optedInRSOwner.Spec.Template.Annotations).To(HaveKey("istio-operator.kyma-project.io/restartedAt"))
+
+		err = c.Get(ctx, client.ObjectKeyFromObject(otherRSOwner), otherRSOwner)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(otherRSOwner.Spec.Template.Annotations).ToNot(HaveKey("istio-operator.kyma-project.io/restartedAt"))
+	})
+
+	It("should report the progress of the restart in the proxyReset status", func() {
+		// given
+		pod := getPod("test-pods", "test-namespace", "podOwner", "ReplicaSet")
+		rsOwner := getReplicaSet("podOwner", "test-namespace", "rsOwner", "ReplicaSet")
+		rsOwnerRS := getReplicaSet("rsOwner", "test-namespace", "base", "ReplicaSet")
+		podWithoutOwner := getPod("pod-without-owner", "test-namespace", "", "")
+		podWithoutOwner.OwnerReferences = nil
+
+		c := fakeClient(pod, rsOwner, rsOwnerRS, podWithoutOwner)
+
+		podsLister := pods.NewPods(c, &logger)
+		expectedImage := images.Image{Registry: "istio", Name: "proxyv2", Tag: "1.1.0"}
+		istioCR := helpers.GetIstioCR(expectedImage.Tag)
+		actionRestarter := restart.NewActionRestarter(c, &logger)
+		proxyRestarter := sidecars.NewProxyRestarter(c, podsLister, actionRestarter, &logger)
+
+		// when
+		_, err := proxyRestarter.RestartProxies(ctx, expectedImage, helpers.DefaultSidecarResources, &istioCR)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(istioCR.Status.ProxyReset).NotTo(BeNil())
+		Expect(istioCR.Status.ProxyReset.TargetImage).To(Equal("istio/proxyv2:1.1.0"))
+		Expect(istioCR.Status.ProxyReset.MatchedPods).To(Equal(2))
+		Expect(istioCR.Status.ProxyReset.RestartedPods).To(Equal(1))
+		Expect(istioCR.Status.ProxyReset.PendingPods).To(Equal(0))
+		Expect(istioCR.Status.ProxyReset.FailuresByOwnerKind).To(Equal(map[string]int{"Pod": 1}))
+		Expect(istioCR.Status.ProxyReset.RestartsByPredicate).To(Equal(map[string]int{"ImageResourcesPredicate": 2}))
+		Expect(istioCR.Status.ProxyReset.StartTime).NotTo(BeNil())
+		Expect(istioCR.Status.ProxyReset.FinishTime).NotTo(BeNil())
+	})
+
+	It("should not count the pods of a Deployment that could not be restarted as restarted in the proxyReset status", func() {
+		// given
+		failedPod := getPod("failed-7d9f8-abcde", "test-namespace", "failed-7d9f8", "ReplicaSet")
+		failedPod.Labels["pod-template-hash"] = "7d9f8"
+		restartedPod := getPod("restarted-5c6b7-fghij", "test-namespace", "restarted-5c6b7", "ReplicaSet")
+		restartedPod.Labels["pod-template-hash"] = "5c6b7"
+
+		c := fakeClient(failedPod, restartedPod)
+
+		podsLister := pods.NewPods(c, &logger)
+		expectedImage := images.Image{Registry: "istio", Name: "proxyv2", Tag: "1.1.0"}
+		istioCR := helpers.GetIstioCR(expectedImage.Tag)
+		actionRestarter := NewActionRestartMock([]restart.Warning{{Name: "failed", Namespace: "test-namespace", Kind: "Deployment", Message: "failed to restart"}}, nil)
+		proxyRestarter := sidecars.NewProxyRestarter(c, podsLister, actionRestarter, &logger)
+
+		// when
+		_, err := proxyRestarter.RestartProxies(ctx, expectedImage, helpers.DefaultSidecarResources, &istioCR)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(istioCR.Status.ProxyReset).NotTo(BeNil())
+		Expect(istioCR.Status.ProxyReset.MatchedPods).To(Equal(2))
+		Expect(istioCR.Status.ProxyReset.RestartedPods).To(Equal(1))
+		Expect(istioCR.Status.ProxyReset.FailuresByOwnerKind).To(Equal(map[string]int{"Deployment": 1}))
+	})
+
+	It("should keep the start time of a pending restart to the same image", func() {
+		// given
+		pod := getPod("test-pods", "test-namespace", "podOwner", "ReplicaSet")
+		rsOwner := getReplicaSet("podOwner", "test-namespace", "rsOwner", "ReplicaSet")
+		rsOwnerRS := getReplicaSet("rsOwner", "test-namespace", "base", "ReplicaSet")
+
+		c := fakeClient(pod, rsOwner, rsOwnerRS)
+
+		podsLister := pods.NewPods(c, &logger)
+		expectedImage := images.Image{Registry: "istio", Name: "proxyv2", Tag: "1.1.0"}
+		istioCR := helpers.GetIstioCR(expectedImage.Tag)
+		startTime := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+		istioCR.Status.ProxyReset = &v1alpha2.ProxyResetStatus{
+			TargetImage: "istio/proxyv2:1.1.0",
+			PendingPods: 1,
+			StartTime:   &startTime,
+		}
+		actionRestarter := restart.NewActionRestarter(c, &logger)
+		proxyRestarter := sidecars.NewProxyRestarter(c, podsLister, actionRestarter, &logger)
+
+		// when
+		_, err := proxyRestarter.RestartProxies(ctx, expectedImage, helpers.DefaultSidecarResources, &istioCR)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(istioCR.Status.ProxyReset.StartTime).To(Equal(&startTime))
+		Expect(istioCR.Status.ProxyReset.PendingPods).To(Equal(0))
+		Expect(istioCR.Status.ProxyReset.FinishTime).NotTo(BeNil())
+	})
+
+	It("should not replace the proxyReset status of the last restart when no pod must be restarted", func() {
+		// given
+		c := fakeClient()
+
+		podsLister := pods.NewPods(c, &logger)
+		expectedImage := images.Image{Registry: "istio", Name: "proxyv2", Tag: "1.1.0"}
+		istioCR := helpers.GetIstioCR(expectedImage.Tag)
+		finishTime := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+		lastReset := &v1alpha2.ProxyResetStatus{
+			TargetImage:   "istio/proxyv2:1.0.0",
+			MatchedPods:   3,
+			RestartedPods: 3,
+			FinishTime:    &finishTime,
+		}
+		istioCR.Status.ProxyReset = lastReset.DeepCopy()
+		actionRestarter := restart.NewActionRestarter(c, &logger)
+		proxyRestarter := sidecars.NewProxyRestarter(c, podsLister, actionRestarter, &logger)
+
+		// when
+		_, err := proxyRestarter.RestartProxies(ctx, expectedImage, helpers.DefaultSidecarResources, &istioCR)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(istioCR.Status.ProxyReset).To(Equal(lastReset))
+	})
+
+	It("should not defer restart of Customer proxies when no Customer pod must be restarted", func() {
+		// given
+		c := fakeClient()
+
+		podsLister := pods.NewPods(c, &logger)
+		expectedImage := images.Image{Registry: "istio", Name: "proxyv2", Tag: "1.1.0"}
+		istioCR := helpers.GetIstioCR(expectedImage.Tag)
+		istioCR.Spec.ProxyRestart = &v1alpha2.ProxyRestart{
+			MaintenanceWindows: []v1alpha2.MaintenanceWindow{
+				{Schedule: "0 0 31 2 *", Duration: metav1.Duration{Duration: time.Hour}},
+			},
+		}
+		actionRestarter := restart.NewActionRestarter(c, &logger)
+		proxyRestarter := sidecars.NewProxyRestarter(c, podsLister, actionRestarter, &logger)
+
+		// when
+		warnings, err := proxyRestarter.RestartProxies(ctx, expectedImage, helpers.DefaultSidecarResources, &istioCR)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+	})
 })
 
 var _ = Describe("RestartWithPredicates", func() {
@@ -443,6 +1024,9 @@ func getPod(name, namespace, ownerName, ownerKind string) *v1.Pod {
 					Kind: ownerKind,
 				},
 			},
+			Labels: map[string]string{
+				"security.istio.io/tlsMode": "istio",
+			},
 			Annotations: map[string]string{
 				"sidecar.istio.io/status": "abc",
 			},
@@ -466,6 +1050,31 @@ func getPod(name, namespace, ownerName, ownerKind string) *v1.Pod {
 	}
 }
 
+func getUnavailableDeployment(name, namespace string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(int32(1)),
+		},
+		Status: appsv1.DeploymentStatus{
+			Replicas:        1,
+			UpdatedReplicas: 1,
+		},
+	}
+}
+
+func getNamespace(name string, namespaceLabels map[string]string) *v1.Namespace {
+	return &v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: namespaceLabels,
+		},
+	}
+}
+
 func getReplicaSet(name, namespace, ownerName, ownerKind string) *appsv1.ReplicaSet {
 	return &appsv1.ReplicaSet{
 		ObjectMeta: metav1.ObjectMeta{
@@ -564,3 +1173,7 @@ func NewActionRestartMock(warnings []restart.Warning, err error) *ActionRestartM
 func (p *ActionRestartMock) Restart(ctx context.Context, podList *v1.PodList, failOnError bool) ([]restart.Warning, error) {
 	return p.warnings, p.err
 }
+
+func (p *ActionRestartMock) WithOptions(_ restart.Options) restart.ActionRestarter {
+	return p
+}
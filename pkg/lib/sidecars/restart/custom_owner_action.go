@@ -0,0 +1,109 @@
+package restart
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kyma-project/istio/operator/pkg/lib/annotations"
+	"github.com/kyma-project/istio/operator/pkg/lib/sidecars/retry"
+)
+
+const noPodTemplateFormat = "pod sidecar could not be updated because %s %s has no pod template in spec.template."
+
+var errNoPodTemplate = errors.New("owner has no pod template")
+
+func newArgoRolloutAction(owner actionObject) restartAction {
+	return restartAction{
+		object: owner,
+		run:    argoRolloutRun,
+	}
+}
+
+// argoRolloutRun sets spec.restartAt of the Argo Rollout, which makes Argo Rollouts restart the pods of the Rollout while respecting
+// its maxUnavailable setting. Adding the restart annotation to the pod template would start a new canary or blue-green rollout instead.
+func argoRolloutRun(ctx context.Context, c client.Client, object actionObject, logger *logr.Logger) ([]Warning, error) {
+	logger.Info("Restart Argo Rollout due to proxy restart", "name", object.Name, "namespace", object.Namespace, "kind", object.Kind)
+
+	err := retry.OnError(retry.DefaultBackoff, func() error {
+		rollout, err := getUnstructuredOwner(ctx, c, object)
+		if err != nil {
+			return err
+		}
+		patch := client.MergeFrom(rollout.DeepCopy())
+		err = unstructured.SetNestedField(rollout.Object, time.Now().UTC().Format(time.RFC3339), "spec", "restartAt")
+		if err != nil {
+			return err
+		}
+		return c.Patch(ctx, rollout, patch)
+	})
+	if isOwnerAccessError(err) {
+		return []Warning{newRestartWarning(object, fmt.Sprintf(ownerNotAccessibleFormat, object.Kind, object.Name))}, nil
+	}
+
+	return nil, err
+}
+
+func newPatchTemplateAction(owner actionObject) restartAction {
+	return restartAction{
+		object: owner,
+		run:    patchTemplateRun,
+	}
+}
+
+// patchTemplateRun adds the restart annotation to the pod template of an owner whose kind is not supported for the restart, assuming
+// that it follows the convention of Kubernetes workloads and rolls out its pods when spec.template changes.
+func patchTemplateRun(ctx context.Context, c client.Client, object actionObject, logger *logr.Logger) ([]Warning, error) {
+	logger.Info("Patch pod template due to proxy restart", "name", object.Name, "namespace", object.Namespace, "kind", object.Kind)
+
+	err := retry.OnError(retry.DefaultBackoff, func() error {
+		owner, err := getUnstructuredOwner(ctx, c, object)
+		if err != nil {
+			return err
+		}
+		if _, found, _ := unstructured.NestedMap(owner.Object, "spec", "template"); !found {
+			return errNoPodTemplate
+		}
+		templateAnnotations, _, err := unstructured.NestedStringMap(owner.Object, "spec", "template", "metadata", "annotations")
+		if err != nil {
+			return err
+		}
+		patch := client.MergeFrom(owner.DeepCopy())
+		err = unstructured.SetNestedStringMap(owner.Object, annotations.AddRestartAnnotation(templateAnnotations),
+			"spec", "template", "metadata", "annotations")
+		if err != nil {
+			return err
+		}
+		return c.Patch(ctx, owner, patch)
+	})
+	if errors.Is(err, errNoPodTemplate) {
+		return []Warning{newRestartWarning(object, fmt.Sprintf(noPodTemplateFormat, object.Kind, object.Name))}, nil
+	}
+	if isOwnerAccessError(err) {
+		return []Warning{newRestartWarning(object, fmt.Sprintf(ownerNotAccessibleFormat, object.Kind, object.Name))}, nil
+	}
+
+	return nil, err
+}
+
+func getUnstructuredOwner(ctx context.Context, c client.Client, object actionObject) (*unstructured.Unstructured, error) {
+	owner := &unstructured.Unstructured{}
+	owner.SetGroupVersionKind(schema.FromAPIVersionAndKind(object.APIVersion, object.Kind))
+	err := c.Get(ctx, client.ObjectKey{Name: object.Name, Namespace: object.Namespace}, owner)
+	return owner, err
+}
+
+func newUnknownOwnerKindAction(pod v1.Pod, kind string) restartAction {
+	return restartAction{
+		object: actionObjectFromPod(pod),
+		run:    warningAction{message: fmt.Sprintf(unknownOwnerKindFormat, kind)}.run,
+	}
+}
@@ -0,0 +1,152 @@
+package restart_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kyma-project/istio/operator/pkg/lib/sidecars/restart"
+)
+
+var _ = Describe("Restart Pods with custom owners", func() {
+	ctx := context.Background()
+	logger := logr.Discard()
+
+	It("should set restartAt of the Argo Rollout owning the ReplicaSet of the pod", func() {
+		// given
+		pod := podFixture("p1", "test-ns", "ReplicaSet", "rs1")
+		rs := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Name:      "rs1",
+			Namespace: "test-ns",
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "argoproj.io/v1alpha1", Kind: "Rollout", Name: "owner"},
+			},
+		}}
+		rollout := unstructuredOwnerFixture("argoproj.io/v1alpha1", "Rollout", "owner", "test-ns",
+			map[string]interface{}{"replicas": int64(1)}, map[string]interface{}{})
+		c := fakeClient(&pod, rs, rollout)
+
+		// when
+		actionRestarter := restart.NewActionRestarter(c, &logger)
+		warnings, err := actionRestarter.Restart(ctx, &v1.PodList{Items: []v1.Pod{pod}}, false)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+
+		updated := unstructuredOwner("argoproj.io/v1alpha1", "Rollout")
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: "test-ns", Name: "owner"}, updated)).To(Succeed())
+		restartAt, found, err := unstructured.NestedString(updated.Object, "spec", "restartAt")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		_, err = time.Parse(time.RFC3339, restartAt)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should return a warning for a pod owned by an unknown kind by default", func() {
+		// given
+		pod := podOwnedByFixture("p1", "test-ns", "example.com/v1", "CustomWorkload", "owner")
+		c := fakeClient(&pod)
+
+		// when
+		actionRestarter := restart.NewActionRestarter(c, &logger)
+		warnings, err := actionRestarter.Restart(ctx, &v1.PodList{Items: []v1.Pod{pod}}, true)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(ConsistOf(restart.Warning{
+			Name:      "p1",
+			Namespace: "test-ns",
+			Kind:      "Pod",
+			Message:   "pod sidecar could not be updated because its owner kind CustomWorkload is not supported for the restart.",
+		}))
+
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: "test-ns", Name: "p1"}, &v1.Pod{})).To(Succeed())
+	})
+
+	It("should delete the pod owned by an unknown kind with the DeletePod strategy", func() {
+		// given
+		pod := podOwnedByFixture("p1", "test-ns", "example.com/v1", "CustomWorkload", "owner")
+		owner := unstructuredOwnerFixture("example.com/v1", "CustomWorkload", "owner", "test-ns",
+			map[string]interface{}{"replicas": int64(1)},
+			map[string]interface{}{"readyReplicas": int64(1)})
+		c := fakeClient(&pod, owner)
+
+		// when
+		actionRestarter := restart.NewActionRestarter(c, &logger).WithOptions(restart.Options{
+			UnknownOwnerStrategy: restart.UnknownOwnerStrategyDeletePod,
+		})
+		warnings, err := actionRestarter.Restart(ctx, &v1.PodList{Items: []v1.Pod{pod}}, true)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+
+		err = c.Get(ctx, types.NamespacedName{Namespace: "test-ns", Name: "p1"}, &v1.Pod{})
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should add the restart annotation to the pod template of an unknown owner with the PatchTemplate strategy", func() {
+		// given
+		pod := podOwnedByFixture("p1", "test-ns", "example.com/v1", "CustomWorkload", "owner")
+		owner := unstructuredOwnerFixture("example.com/v1", "CustomWorkload", "owner", "test-ns",
+			map[string]interface{}{"template": map[string]interface{}{"metadata": map[string]interface{}{}}},
+			map[string]interface{}{})
+		c := fakeClient(&pod, owner)
+
+		// when
+		actionRestarter := restart.NewActionRestarter(c, &logger).WithOptions(restart.Options{
+			UnknownOwnerStrategy: restart.UnknownOwnerStrategyPatchTemplate,
+		})
+		warnings, err := actionRestarter.Restart(ctx, &v1.PodList{Items: []v1.Pod{pod}}, true)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+
+		updated := unstructuredOwner("example.com/v1", "CustomWorkload")
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: "test-ns", Name: "owner"}, updated)).To(Succeed())
+		templateAnnotations, _, err := unstructured.NestedStringMap(updated.Object, "spec", "template", "metadata", "annotations")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(templateAnnotations[restartAnnotationName]).NotTo(BeEmpty())
+	})
+
+	It("should return a warning with the PatchTemplate strategy if the unknown owner has no pod template", func() {
+		// given
+		pod := podOwnedByFixture("p1", "test-ns", "example.com/v1", "CustomWorkload", "owner")
+		owner := unstructuredOwnerFixture("example.com/v1", "CustomWorkload", "owner", "test-ns",
+			map[string]interface{}{}, map[string]interface{}{})
+		c := fakeClient(&pod, owner)
+
+		// when
+		actionRestarter := restart.NewActionRestarter(c, &logger).WithOptions(restart.Options{
+			UnknownOwnerStrategy: restart.UnknownOwnerStrategyPatchTemplate,
+		})
+		warnings, err := actionRestarter.Restart(ctx, &v1.PodList{Items: []v1.Pod{pod}}, true)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(ConsistOf(restart.Warning{
+			Name:      "owner",
+			Namespace: "test-ns",
+			Kind:      "CustomWorkload",
+			Message:   "pod sidecar could not be updated because CustomWorkload owner has no pod template in spec.template.",
+		}))
+	})
+})
+
+func unstructuredOwner(apiVersion, kind string) *unstructured.Unstructured {
+	owner := &unstructured.Unstructured{}
+	owner.SetGroupVersionKind(schema.FromAPIVersionAndKind(apiVersion, kind))
+	return owner
+}
@@ -3,6 +3,7 @@ package restart_test
 import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 func podWithoutOwnerFixture(name, namespace string) v1.Pod {
@@ -36,3 +37,21 @@ func podFixture(name, namespace, ownerKind, ownerName string) v1.Pod {
 		},
 	}
 }
+
+func podOwnedByFixture(name, namespace, ownerAPIVersion, ownerKind, ownerName string) v1.Pod {
+	pod := podFixture(name, namespace, ownerKind, ownerName)
+	pod.OwnerReferences[0].APIVersion = ownerAPIVersion
+	return pod
+}
+
+func unstructuredOwnerFixture(apiVersion, kind, name, namespace string, spec, status map[string]interface{}) *unstructured.Unstructured {
+	owner := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec":   spec,
+		"status": status,
+	}}
+	owner.SetAPIVersion(apiVersion)
+	owner.SetKind(kind)
+	owner.SetName(name)
+	owner.SetNamespace(namespace)
+	return owner
+}
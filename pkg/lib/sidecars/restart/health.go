@@ -0,0 +1,158 @@
+package restart
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kyma-project/istio/operator/pkg/lib/sidecars/retry"
+)
+
+// Workload identifies a Deployment, StatefulSet, or DaemonSet that rolls out the restart of its pods.
+type Workload struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// RolloutHealthChecker checks whether the Deployments, StatefulSets and DaemonSets owning the restarted pods finished their rollout.
+// It doesn't wait for the rollouts, so that the caller can check them again in a later reconciliation.
+type RolloutHealthChecker interface {
+	// GetRolloutOwners returns the workloads that roll out the restart of the given pods.
+	GetRolloutOwners(ctx context.Context, podList *v1.PodList) ([]Workload, error)
+	// IsRolloutReady returns true if the workload finished its rollout. Workloads that no longer exist are considered ready.
+	IsRolloutReady(ctx context.Context, workload Workload) (bool, error)
+}
+
+type rolloutHealthChecker struct {
+	k8sClient client.Client
+}
+
+func NewRolloutHealthChecker(c client.Client) RolloutHealthChecker {
+	return &rolloutHealthChecker{
+		k8sClient: c,
+	}
+}
+
+// GetRolloutOwners returns the Deployments, StatefulSets and DaemonSets owning the given pods sorted by namespace, kind and name.
+// Pods that are not restarted by a rollout, for example, pods owned by a Job, are skipped.
+func (h *rolloutHealthChecker) GetRolloutOwners(ctx context.Context, podList *v1.PodList) ([]Workload, error) {
+	owners := map[Workload]bool{}
+	for _, pod := range podList.Items {
+		ownedBy, exists := getOwnerReferences(pod)
+		if !exists {
+			continue
+		}
+
+		owner := Workload{Name: ownedBy.Name, Namespace: pod.Namespace, Kind: ownedBy.Kind}
+		if ownedBy.Kind == "ReplicaSet" {
+			replicaSet := &appsv1.ReplicaSet{}
+			err := retry.OnError(retry.DefaultRetry, func() error {
+				return h.k8sClient.Get(ctx, client.ObjectKey{Name: ownedBy.Name, Namespace: pod.Namespace}, replicaSet)
+			})
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			rsOwnedBy, rsOwnerExists := getReplicaSetOwner(replicaSet)
+			if !rsOwnerExists {
+				continue
+			}
+			owner = Workload{Name: rsOwnedBy.Name, Namespace: pod.Namespace, Kind: rsOwnedBy.Kind}
+		}
+
+		switch owner.Kind {
+		case "Deployment", "StatefulSet", "DaemonSet":
+			owners[owner] = true
+		}
+	}
+
+	sorted := slices.SortedFunc(maps.Keys(owners), func(a, b Workload) int {
+		return cmp.Or(cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Kind, b.Kind), cmp.Compare(a.Name, b.Name))
+	})
+	return sorted, nil
+}
+
+// IsRolloutReady follows the checks of `kubectl rollout status`. Workloads that no longer exist are considered ready.
+func (h *rolloutHealthChecker) IsRolloutReady(ctx context.Context, workload Workload) (bool, error) {
+	key := client.ObjectKey{Name: workload.Name, Namespace: workload.Namespace}
+
+	var obj client.Object
+	switch workload.Kind {
+	case "Deployment":
+		obj = &appsv1.Deployment{}
+	case "StatefulSet":
+		obj = &appsv1.StatefulSet{}
+	case "DaemonSet":
+		obj = &appsv1.DaemonSet{}
+	default:
+		return false, fmt.Errorf("kind %s is not supported for rollout status", workload.Kind)
+	}
+
+	err := retry.OnError(retry.DefaultRetry, func() error {
+		return h.k8sClient.Get(ctx, key, obj)
+	})
+	if k8serrors.IsNotFound(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	switch o := obj.(type) {
+	case *appsv1.Deployment:
+		return IsDeploymentReady(o), nil
+	case *appsv1.StatefulSet:
+		return isStatefulSetReady(o), nil
+	case *appsv1.DaemonSet:
+		return isDaemonSetReady(o), nil
+	default:
+		return false, errors.New("unexpected object type for rollout status")
+	}
+}
+
+// IsDeploymentReady returns true if the rollout of the Deployment is finished and all updated replicas are available.
+func IsDeploymentReady(d *appsv1.Deployment) bool {
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+	return d.Status.ObservedGeneration >= d.Generation &&
+		d.Status.UpdatedReplicas >= replicas &&
+		d.Status.Replicas == d.Status.UpdatedReplicas &&
+		d.Status.AvailableReplicas >= d.Status.UpdatedReplicas
+}
+
+// StatefulSets and DaemonSets with the OnDelete update strategy are not rolled out by a restart, so they are considered ready.
+func isStatefulSetReady(s *appsv1.StatefulSet) bool {
+	if s.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+		return true
+	}
+	replicas := int32(1)
+	if s.Spec.Replicas != nil {
+		replicas = *s.Spec.Replicas
+	}
+	return s.Status.ObservedGeneration >= s.Generation &&
+		s.Status.UpdatedReplicas >= replicas &&
+		s.Status.ReadyReplicas >= replicas &&
+		s.Status.UpdateRevision == s.Status.CurrentRevision
+}
+
+func isDaemonSetReady(ds *appsv1.DaemonSet) bool {
+	if ds.Spec.UpdateStrategy.Type == appsv1.OnDeleteDaemonSetStrategyType {
+		return true
+	}
+	return ds.Status.ObservedGeneration >= ds.Generation &&
+		ds.Status.UpdatedNumberScheduled >= ds.Status.DesiredNumberScheduled &&
+		ds.Status.NumberAvailable >= ds.Status.DesiredNumberScheduled
+}
@@ -0,0 +1,180 @@
+package restart_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/kyma-project/istio/operator/pkg/lib/sidecars/restart"
+)
+
+var _ = Describe("RolloutHealthChecker", func() {
+	ctx := context.Background()
+
+	Context("GetRolloutOwners", func() {
+		It("should return the Deployment owning the pod through its ReplicaSet", func() {
+			// given
+			pod := podFixture("p1", "test-ns", "ReplicaSet", "rs1")
+			rs := replicaSetOwnedByFixture("rs1", "test-ns", "Deployment", "dep1")
+			c := fakeClient(&pod, rs)
+
+			// when
+			owners, err := restart.NewRolloutHealthChecker(c).GetRolloutOwners(ctx, &v1.PodList{Items: []v1.Pod{pod}})
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(owners).To(Equal([]restart.Workload{{Kind: "Deployment", Namespace: "test-ns", Name: "dep1"}}))
+		})
+
+		It("should return each owner only once sorted by namespace, kind and name", func() {
+			// given
+			p1 := podFixture("p1", "ns-b", "StatefulSet", "sts1")
+			p2 := podFixture("p2", "ns-a", "StatefulSet", "sts1")
+			p3 := podFixture("p3", "ns-a", "DaemonSet", "ds1")
+			p4 := podFixture("p4", "ns-a", "DaemonSet", "ds1")
+			c := fakeClient(&p1, &p2, &p3, &p4)
+
+			// when
+			owners, err := restart.NewRolloutHealthChecker(c).GetRolloutOwners(ctx, &v1.PodList{Items: []v1.Pod{p1, p2, p3, p4}})
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(owners).To(Equal([]restart.Workload{
+				{Kind: "DaemonSet", Namespace: "ns-a", Name: "ds1"},
+				{Kind: "StatefulSet", Namespace: "ns-a", Name: "sts1"},
+				{Kind: "StatefulSet", Namespace: "ns-b", Name: "sts1"},
+			}))
+		})
+
+		It("should skip pods that are not restarted by a rollout", func() {
+			// given
+			jobPod := podFixture("p1", "test-ns", "Job", "job1")
+			podWithoutOwner := podWithoutOwnerFixture("p2", "test-ns")
+			c := fakeClient(&jobPod, &podWithoutOwner)
+
+			// when
+			owners, err := restart.NewRolloutHealthChecker(c).GetRolloutOwners(ctx, &v1.PodList{Items: []v1.Pod{jobPod, podWithoutOwner}})
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(owners).To(BeEmpty())
+		})
+	})
+
+	Context("IsRolloutReady", func() {
+		It("should return true if the Deployment finished its rollout", func() {
+			// given
+			c := fakeClient(deploymentFixture("dep1", "test-ns", 2, 2))
+
+			// when
+			ready, err := restart.NewRolloutHealthChecker(c).IsRolloutReady(ctx, restart.Workload{Kind: "Deployment", Namespace: "test-ns", Name: "dep1"})
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ready).To(BeTrue())
+		})
+
+		It("should return false if the Deployment did not finish its rollout", func() {
+			// given
+			c := fakeClient(deploymentFixture("dep1", "test-ns", 2, 1))
+
+			// when
+			ready, err := restart.NewRolloutHealthChecker(c).IsRolloutReady(ctx, restart.Workload{Kind: "Deployment", Namespace: "test-ns", Name: "dep1"})
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ready).To(BeFalse())
+		})
+
+		It("should return false if the StatefulSet did not finish its rollout", func() {
+			// given
+			sts := &appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "sts1", Namespace: "test-ns"},
+				Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To(int32(1))},
+				Status: appsv1.StatefulSetStatus{
+					Replicas:        1,
+					ReadyReplicas:   1,
+					UpdatedReplicas: 1,
+					CurrentRevision: "old",
+					UpdateRevision:  "new",
+				},
+			}
+			c := fakeClient(sts)
+
+			// when
+			ready, err := restart.NewRolloutHealthChecker(c).IsRolloutReady(ctx, restart.Workload{Kind: "StatefulSet", Namespace: "test-ns", Name: "sts1"})
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ready).To(BeFalse())
+		})
+
+		It("should return true for a DaemonSet with the OnDelete update strategy", func() {
+			// given
+			ds := &appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "ds1", Namespace: "test-ns"},
+				Spec: appsv1.DaemonSetSpec{
+					UpdateStrategy: appsv1.DaemonSetUpdateStrategy{Type: appsv1.OnDeleteDaemonSetStrategyType},
+				},
+				Status: appsv1.DaemonSetStatus{DesiredNumberScheduled: 3},
+			}
+			c := fakeClient(ds)
+
+			// when
+			ready, err := restart.NewRolloutHealthChecker(c).IsRolloutReady(ctx, restart.Workload{Kind: "DaemonSet", Namespace: "test-ns", Name: "ds1"})
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ready).To(BeTrue())
+		})
+
+		It("should return true if the workload no longer exists", func() {
+			// given
+			c := fakeClient()
+
+			// when
+			ready, err := restart.NewRolloutHealthChecker(c).IsRolloutReady(ctx, restart.Workload{Kind: "StatefulSet", Namespace: "test-ns", Name: "deleted"})
+
+			// then
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ready).To(BeTrue())
+		})
+	})
+})
+
+func replicaSetOwnedByFixture(name, namespace, ownerKind, ownerName string) *appsv1.ReplicaSet {
+	return &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					Kind: ownerKind,
+					Name: ownerName,
+				},
+			},
+		},
+	}
+}
+
+func deploymentFixture(name, namespace string, replicas, availableReplicas int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(replicas),
+		},
+		Status: appsv1.DeploymentStatus{
+			Replicas:          replicas,
+			UpdatedReplicas:   replicas,
+			AvailableReplicas: availableReplicas,
+		},
+	}
+}
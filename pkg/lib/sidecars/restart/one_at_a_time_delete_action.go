@@ -0,0 +1,161 @@
+package restart
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kyma-project/istio/operator/pkg/lib/sidecars/retry"
+)
+
+const (
+	ownerNotReadyFormat     = "pod sidecar restart is pending until %s %s is ready. The restart continues in the next reconciliation."
+	ownerReplacingPodFormat = "pod sidecar restart is pending until %s %s replaced its deleted pod. The restart continues in the next reconciliation."
+)
+
+// oneAtATimeDeleteAction restarts a pod by deleting it, for owners that don't restart their pods when the pod template changes,
+// for example, StatefulSets with the OnDelete update strategy. To keep the owner available, the pod is only deleted when the owner
+// is ready, and only one pod of the same owner is deleted in a restart run. The action doesn't wait for the owner, so the other pods
+// of the owner get a pending warning and are restarted in one of the next reconciliations, once the owner replaced the deleted pod.
+type oneAtATimeDeleteAction struct {
+	owner actionObject
+	// deletedOwners contains the keys of the owners that already had a pod deleted in the restart run.
+	deletedOwners *processedActions
+}
+
+func newOneAtATimeDeleteAction(pod v1.Pod, owner actionObject, deletedOwners *processedActions) restartAction {
+	return restartAction{
+		object:          actionObjectFromPod(pod),
+		run:             oneAtATimeDeleteAction{owner: owner, deletedOwners: deletedOwners}.run,
+		sequentialOwner: &owner,
+	}
+}
+
+func (a oneAtATimeDeleteAction) run(ctx context.Context, c client.Client, object actionObject, logger *logr.Logger) ([]Warning, error) {
+	pod := &v1.Pod{}
+	err := retry.OnError(retry.DefaultRetry, func() error {
+		return c.Get(ctx, client.ObjectKey{Name: object.Name, Namespace: object.Namespace}, pod)
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if a.deletedOwners.has(a.owner.getKey()) {
+		return []Warning{newPendingWarning(object, fmt.Sprintf(ownerReplacingPodFormat, a.owner.Kind, a.owner.Name))}, nil
+	}
+
+	ready, err := isOwnerReady(ctx, c, a.owner)
+	if isOwnerAccessError(err) {
+		return []Warning{newRestartWarning(object, fmt.Sprintf(ownerNotAccessibleFormat, a.owner.Kind, a.owner.Name))}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !ready {
+		logger.Info("Pod was not deleted because its owner is not ready", "name", object.Name, "namespace", object.Namespace,
+			"owner", a.owner.Name, "kind", a.owner.Kind)
+		return []Warning{newPendingWarning(object, fmt.Sprintf(ownerNotReadyFormat, a.owner.Kind, a.owner.Name))}, nil
+	}
+
+	a.deletedOwners.add(a.owner.getKey())
+	logger.Info("Delete pod due to proxy restart", "name", object.Name, "namespace", object.Namespace, "owner", a.owner.Name, "kind", a.owner.Kind)
+	err = c.Delete(ctx, pod, client.Preconditions{UID: &pod.UID})
+	if err != nil && !k8serrors.IsNotFound(err) && !k8serrors.IsConflict(err) {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// isOwnerReady returns true if all pods of the owner are ready. Owners that no longer exist are considered ready.
+func isOwnerReady(ctx context.Context, c client.Client, owner actionObject) (bool, error) {
+	var obj client.Object
+	gvk := schema.FromAPIVersionAndKind(owner.APIVersion, owner.Kind)
+	switch {
+	case owner.Kind == "StatefulSet" && (owner.APIVersion == "" || gvk.Group == "apps"):
+		obj = &appsv1.StatefulSet{}
+	case owner.Kind == "DaemonSet" && (owner.APIVersion == "" || gvk.Group == "apps"):
+		obj = &appsv1.DaemonSet{}
+	default:
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(gvk)
+		obj = u
+	}
+
+	err := retry.OnError(retry.DefaultRetry, func() error {
+		return c.Get(ctx, client.ObjectKey{Name: owner.Name, Namespace: owner.Namespace}, obj)
+	})
+	if k8serrors.IsNotFound(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	switch o := obj.(type) {
+	case *appsv1.StatefulSet:
+		replicas := int32(1)
+		if o.Spec.Replicas != nil {
+			replicas = *o.Spec.Replicas
+		}
+		return o.Status.ObservedGeneration >= o.Generation && o.Status.ReadyReplicas >= replicas, nil
+	case *appsv1.DaemonSet:
+		return o.Status.ObservedGeneration >= o.Generation && o.Status.NumberReady >= o.Status.DesiredNumberScheduled, nil
+	case *unstructured.Unstructured:
+		return isUnstructuredOwnerReady(o), nil
+	default:
+		return false, fmt.Errorf("unexpected object type %T for owner readiness", obj)
+	}
+}
+
+// isUnstructuredOwnerReady follows the status conventions of Kubernetes workloads, which are also used by OpenKruise. Owners that
+// don't report their ready pods in one of these fields are considered ready.
+func isUnstructuredOwnerReady(u *unstructured.Unstructured) bool {
+	if desired, found, err := unstructured.NestedInt64(u.Object, "status", "desiredNumberScheduled"); err == nil && found {
+		ready, _, _ := unstructured.NestedInt64(u.Object, "status", "numberReady")
+		return ready >= desired
+	}
+	if replicas, found, err := unstructured.NestedInt64(u.Object, "spec", "replicas"); err == nil && found {
+		ready, _, _ := unstructured.NestedInt64(u.Object, "status", "readyReplicas")
+		return ready >= replicas
+	}
+	return true
+}
+
+// hasOnDeleteUpdateStrategy returns true if the StatefulSet or DaemonSet doesn't roll out its pods when the pod template changes.
+func hasOnDeleteUpdateStrategy(ctx context.Context, c client.Client, owner actionObject) (bool, error) {
+	key := client.ObjectKey{Name: owner.Name, Namespace: owner.Namespace}
+	switch owner.Kind {
+	case "StatefulSet":
+		sts := &appsv1.StatefulSet{}
+		err := retry.OnError(retry.DefaultRetry, func() error {
+			return c.Get(ctx, key, sts)
+		})
+		if err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+		return sts.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType, nil
+	case "DaemonSet":
+		ds := &appsv1.DaemonSet{}
+		err := retry.OnError(retry.DefaultRetry, func() error {
+			return c.Get(ctx, key, ds)
+		})
+		if err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+		return ds.Spec.UpdateStrategy.Type == appsv1.OnDeleteDaemonSetStrategyType, nil
+	default:
+		return false, nil
+	}
+}
@@ -0,0 +1,198 @@
+package restart_test
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kyma-project/istio/operator/pkg/lib/sidecars/restart"
+)
+
+var _ = Describe("Restart Pods one at a time", func() {
+	ctx := context.Background()
+	logger := logr.Discard()
+	options := restart.Options{}
+
+	It("should delete only one pod of a StatefulSet with the OnDelete update strategy instead of patching the pod template", func() {
+		// given
+		p1 := podFixture("p1", "test-ns", "StatefulSet", "owner")
+		p2 := podFixture("p2", "test-ns", "StatefulSet", "owner")
+		c := fakeClient(&p1, &p2, onDeleteStatefulSetFixture("owner", "test-ns", 2, 2))
+
+		// when
+		actionRestarter := restart.NewActionRestarter(c, &logger).WithOptions(options)
+		warnings, err := actionRestarter.Restart(ctx, &v1.PodList{Items: []v1.Pod{p1, p2}}, false)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(HaveLen(1))
+		Expect(warnings[0].Pending).To(BeTrue())
+		Expect(warnings[0].Message).To(Equal("pod sidecar restart is pending until StatefulSet owner replaced its deleted pod. The restart continues in the next reconciliation."))
+
+		pods := v1.PodList{}
+		Expect(c.List(ctx, &pods)).To(Succeed())
+		Expect(pods.Items).To(HaveLen(1))
+		Expect(pods.Items[0].Name).To(Equal(warnings[0].Name))
+
+		sts := appsv1.StatefulSet{}
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: "test-ns", Name: "owner"}, &sts)).To(Succeed())
+		Expect(sts.Spec.Template.Annotations[restartAnnotationName]).To(BeEmpty())
+	})
+
+	It("should not delete the pods of a StatefulSet with the OnDelete update strategy that is not ready and report them as pending", func() {
+		// given
+		p1 := podFixture("p1", "test-ns", "StatefulSet", "owner")
+		p2 := podFixture("p2", "test-ns", "StatefulSet", "owner")
+		c := fakeClient(&p1, &p2, onDeleteStatefulSetFixture("owner", "test-ns", 2, 1))
+
+		// when
+		actionRestarter := restart.NewActionRestarter(c, &logger).WithOptions(options)
+		warnings, err := actionRestarter.Restart(ctx, &v1.PodList{Items: []v1.Pod{p1, p2}}, false)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(ConsistOf(
+			restart.Warning{Name: "p1", Namespace: "test-ns", Kind: "Pod", Pending: true,
+				Message: "pod sidecar restart is pending until StatefulSet owner is ready. The restart continues in the next reconciliation."},
+			restart.Warning{Name: "p2", Namespace: "test-ns", Kind: "Pod", Pending: true,
+				Message: "pod sidecar restart is pending until StatefulSet owner is ready. The restart continues in the next reconciliation."},
+		))
+
+		pods := v1.PodList{}
+		Expect(c.List(ctx, &pods)).To(Succeed())
+		Expect(pods.Items).To(HaveLen(2))
+	})
+
+	It("should delete the pod of a DaemonSet with the OnDelete update strategy", func() {
+		// given
+		pod := podFixture("p1", "test-ns", "DaemonSet", "owner")
+		ds := &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "owner", Namespace: "test-ns"},
+			Spec: appsv1.DaemonSetSpec{
+				UpdateStrategy: appsv1.DaemonSetUpdateStrategy{Type: appsv1.OnDeleteDaemonSetStrategyType},
+			},
+			Status: appsv1.DaemonSetStatus{DesiredNumberScheduled: 1, NumberReady: 1},
+		}
+		c := fakeClient(&pod, ds)
+
+		// when
+		actionRestarter := restart.NewActionRestarter(c, &logger).WithOptions(options)
+		warnings, err := actionRestarter.Restart(ctx, &v1.PodList{Items: []v1.Pod{pod}}, false)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+
+		err = c.Get(ctx, types.NamespacedName{Namespace: "test-ns", Name: "p1"}, &v1.Pod{})
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should delete the pod of an OpenKruise CloneSet", func() {
+		// given
+		pod := podOwnedByFixture("p1", "test-ns", "apps.kruise.io/v1alpha1", "CloneSet", "owner")
+		cloneSet := unstructuredOwnerFixture("apps.kruise.io/v1alpha1", "CloneSet", "owner", "test-ns",
+			map[string]interface{}{"replicas": int64(1)},
+			map[string]interface{}{"readyReplicas": int64(1)})
+		c := fakeClient(&pod, cloneSet)
+
+		// when
+		actionRestarter := restart.NewActionRestarter(c, &logger).WithOptions(options)
+		warnings, err := actionRestarter.Restart(ctx, &v1.PodList{Items: []v1.Pod{pod}}, false)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+
+		err = c.Get(ctx, types.NamespacedName{Namespace: "test-ns", Name: "p1"}, &v1.Pod{})
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should return a warning if the owner can't be accessed", func() {
+		// given
+		pod := podFixture("p1", "test-ns", "StatefulSet", "owner")
+		c := &ownerGetErrorClient{
+			Client: fakeClient(&pod, onDeleteStatefulSetFixture("owner", "test-ns", 1, 1)),
+			err:    k8serrors.NewForbidden(schema.GroupResource{Group: "apps", Resource: "statefulsets"}, "owner", nil),
+		}
+
+		// when
+		actionRestarter := restart.NewActionRestarter(c, &logger).WithOptions(options)
+		warnings, err := actionRestarter.Restart(ctx, &v1.PodList{Items: []v1.Pod{pod}}, true)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(ConsistOf(restart.Warning{
+			Name:      "p1",
+			Namespace: "test-ns",
+			Kind:      "Pod",
+			Message: "pod sidecar could not be updated because StatefulSet owner could not be accessed. " +
+				"Grant the Istio module the permissions to get and patch the owner, or restart the pod manually.",
+		}))
+
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: "test-ns", Name: "p1"}, &v1.Pod{})).To(Succeed())
+	})
+
+	It("should return a warning if the API of the owner is not served", func() {
+		// given
+		pod := podOwnedByFixture("p1", "test-ns", "apps.kruise.io/v1alpha1", "CloneSet", "owner")
+		c := &ownerGetErrorClient{
+			Client: fakeClient(&pod),
+			err:    &meta.NoKindMatchError{GroupKind: schema.GroupKind{Group: "apps.kruise.io", Kind: "CloneSet"}},
+		}
+
+		// when
+		actionRestarter := restart.NewActionRestarter(c, &logger).WithOptions(options)
+		warnings, err := actionRestarter.Restart(ctx, &v1.PodList{Items: []v1.Pod{pod}}, true)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(ConsistOf(restart.Warning{
+			Name:      "p1",
+			Namespace: "test-ns",
+			Kind:      "Pod",
+			Message: "pod sidecar could not be updated because CloneSet owner could not be accessed. " +
+				"Grant the Istio module the permissions to get and patch the owner, or restart the pod manually.",
+		}))
+	})
+})
+
+func onDeleteStatefulSetFixture(name, namespace string, replicas, readyReplicas int32) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:       ptr.To(replicas),
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{Type: appsv1.OnDeleteStatefulSetStrategyType},
+		},
+		Status: appsv1.StatefulSetStatus{
+			Replicas:      replicas,
+			ReadyReplicas: readyReplicas,
+		},
+	}
+}
+
+// ownerGetErrorClient returns the given error when an owner of a pod is read, for example, to simulate missing permissions.
+type ownerGetErrorClient struct {
+	client.Client
+	err error
+}
+
+func (o *ownerGetErrorClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if _, isPod := obj.(*v1.Pod); isPod {
+		return o.Client.Get(ctx, key, obj, opts...)
+	}
+	return o.err
+}
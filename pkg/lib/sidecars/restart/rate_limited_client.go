@@ -0,0 +1,64 @@
+package restart
+
+import (
+	"context"
+
+	"golang.org/x/time/rate"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// rateLimitedClient limits the requests that the restart actions send to the Kubernetes API server with a token bucket, so that
+// restarting many workloads in parallel doesn't overload the API server.
+type rateLimitedClient struct {
+	client.Client
+	limiter *rate.Limiter
+}
+
+func newRateLimitedClient(c client.Client, requestsPerSecond int) *rateLimitedClient {
+	return &rateLimitedClient{
+		Client:  c,
+		limiter: rate.NewLimiter(rate.Limit(requestsPerSecond), requestsPerSecond),
+	}
+}
+
+func (c *rateLimitedClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
+func (c *rateLimitedClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	return c.Client.List(ctx, list, opts...)
+}
+
+func (c *rateLimitedClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c *rateLimitedClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+func (c *rateLimitedClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *rateLimitedClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
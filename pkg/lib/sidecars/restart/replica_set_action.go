@@ -7,63 +7,40 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	"github.com/kyma-project/istio/operator/pkg/lib/sidecars/retry"
-
-	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func getReplicaSetAction(ctx context.Context, c client.Client, pod v1.Pod, replicaSetRef *metav1.OwnerReference) (restartAction, error) {
-	replicaSetKey := client.ObjectKey{
-		Name:      replicaSetRef.Name,
-		Namespace: pod.Namespace,
-	}
-
-	var replicaSet = &appsv1.ReplicaSet{}
-	err := retry.OnError(retry.DefaultRetry, func() error {
-		return c.Get(ctx, replicaSetKey, replicaSet)
-	})
+func getReplicaSetAction(ctx context.Context, c client.Client, replicaSets *replicaSetCache, deletedOwners *processedActions, pod v1.Pod, replicaSetRef *metav1.OwnerReference, options Options) (restartAction, error) {
+	replicaSet, found, err := replicaSets.get(ctx, pod.Namespace, replicaSetRef.Name)
 	if err != nil {
-		if k8serrors.IsNotFound(err) {
-			return newOwnerNotFoundAction(pod), nil
-		}
 		return restartAction{object: actionObject{
 			Name:      replicaSetRef.Name,
 			Namespace: pod.Namespace,
 			Kind:      "ReplicaSet",
 		}}, err
 	}
-	rsOwnedBy, exists := getReplicaSetOwner(replicaSet)
-	if !exists {
+	if !found {
+		return newOwnerNotFoundAction(pod), nil
+	}
+	if replicaSet.owner == nil {
 		// If the ReplicaSet is not managed by a parent resource(e.g. deployment), we need to delete the pods in the ReplicaSet to force a restart.
-		return newDeleteAction(actionObjectFromPod(pod)), nil
+		action := newDeleteAction(actionObjectFromPod(pod))
+		action.replicaSet = &replicaSet
+		return action, nil
 	}
+	rsOwnedBy := replicaSet.owner
+
 	// If another ReplicaSet exists that is not ready for the same parent resource with
 	// the same number of desired replicas,
 	// we should not trigger another rollout to ensure that the rollout is not triggered multiple times.
-	namespaceReplicaSets := &appsv1.ReplicaSetList{}
-	err = c.List(ctx, namespaceReplicaSets, &client.ListOptions{
-		Namespace: pod.Namespace,
-	})
-	if err != nil {
-		return restartAction{}, err
-	}
-
-	relatedReplicaSets := &appsv1.ReplicaSetList{}
-	for _, replicaSetFromNs := range namespaceReplicaSets.Items {
-		if len(replicaSetFromNs.OwnerReferences) > 0 && replicaSetFromNs.OwnerReferences[0].UID == rsOwnedBy.UID {
-			relatedReplicaSets.Items = append(relatedReplicaSets.Items, replicaSetFromNs)
-		}
-	}
-
-	for _, rs := range relatedReplicaSets.Items {
-		if rs.Name != replicaSet.Name &&
-			rs.Status.Replicas != 0 &&
-			rs.Status.ReadyReplicas != rs.Status.Replicas {
+	for _, rs := range replicaSets.ownedBy(pod.Namespace, rsOwnedBy.UID) {
+		if rs.name != replicaSet.name &&
+			rs.replicas != 0 &&
+			rs.readyReplicas != rs.replicas {
 			return restartAction{
 				object: actionObject{
 					Name:      rsOwnedBy.Name,
-					Namespace: replicaSet.Namespace,
+					Namespace: replicaSet.namespace,
 					Kind:      rsOwnedBy.Kind,
 				},
 				run: logAction{message: notReadyReplicaSetExistsMessage}.run,
@@ -71,11 +48,9 @@ func getReplicaSetAction(ctx context.Context, c client.Client, pod v1.Pod, repli
 		}
 	}
 
-	return newRolloutAction(actionObject{
-		Name:      rsOwnedBy.Name,
-		Namespace: replicaSet.Namespace,
-		Kind:      rsOwnedBy.Kind,
-	}), nil
+	action, err := getOwnerAction(ctx, c, deletedOwners, pod, rsOwnedBy, options)
+	action.replicaSet = &replicaSet
+	return action, err
 }
 
 // getOwnerReferences returns the owner reference of the pod and a boolean to verify if the owner reference exists or not.
@@ -0,0 +1,162 @@
+package restart
+
+import (
+	"context"
+	"sync"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kyma-project/istio/operator/pkg/lib/sidecars/retry"
+)
+
+const replicaSetListLimit = 500
+
+// replicaSetInfo holds the fields of a ReplicaSet that are needed to create the restart action of its pods, so that the cache doesn't
+// keep the pod templates of all ReplicaSets in memory.
+type replicaSetInfo struct {
+	name          string
+	namespace     string
+	owner         *metav1.OwnerReference
+	replicas      int32
+	readyReplicas int32
+}
+
+type namespaceReplicaSets struct {
+	byName map[string]replicaSetInfo
+	// refreshed is set when the ReplicaSets were listed again because one of them was not found, so that the namespace is listed
+	// at most twice.
+	refreshed bool
+}
+
+// replicaSetCache lists the ReplicaSets of a namespace once for all pods of the namespace restarted in a restart run, instead of
+// getting and listing them for every pod. The ReplicaSets restarted in the run are invalidated, so that they are listed again if
+// another pod of them is restarted.
+type replicaSetCache struct {
+	mu         sync.Mutex
+	k8sClient  client.Client
+	namespaces map[string]*namespaceReplicaSets
+}
+
+func newReplicaSetCache(c client.Client) *replicaSetCache {
+	return &replicaSetCache{
+		k8sClient:  c,
+		namespaces: make(map[string]*namespaceReplicaSets),
+	}
+}
+
+// get returns the ReplicaSet with the given name. If the ReplicaSet is not cached, the ReplicaSets of the namespace are listed again
+// once, because the ReplicaSet might have been created after the namespace was listed.
+func (r *replicaSetCache) get(ctx context.Context, namespace, name string) (replicaSetInfo, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cached, exists := r.namespaces[namespace]
+	if !exists {
+		var err error
+		cached, err = r.load(ctx, namespace)
+		if err != nil {
+			return replicaSetInfo{}, false, err
+		}
+	}
+
+	if info, found := cached.byName[name]; found {
+		return info, true, nil
+	}
+	if cached.refreshed {
+		return replicaSetInfo{}, false, nil
+	}
+
+	cached, err := r.load(ctx, namespace)
+	if err != nil {
+		return replicaSetInfo{}, false, err
+	}
+	cached.refreshed = true
+
+	info, found := cached.byName[name]
+	return info, found, nil
+}
+
+// ownedBy returns the cached ReplicaSets of the namespace that are owned by the object with the given UID.
+func (r *replicaSetCache) ownedBy(namespace string, ownerUID types.UID) []replicaSetInfo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cached, exists := r.namespaces[namespace]
+	if !exists {
+		return nil
+	}
+
+	var related []replicaSetInfo
+	for _, info := range cached.byName {
+		if info.owner != nil && info.owner.UID == ownerUID {
+			related = append(related, info)
+		}
+	}
+	return related
+}
+
+// invalidate removes the restarted ReplicaSet from the cache. If the ReplicaSet has an owner, all ReplicaSets of the owner are removed,
+// because the rollout of the owner creates a new ReplicaSet and scales down the others. The ReplicaSets of the namespace are listed
+// again when one of the removed ReplicaSets is requested.
+func (r *replicaSetCache) invalidate(restarted replicaSetInfo) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cached, exists := r.namespaces[restarted.namespace]
+	if !exists {
+		return
+	}
+
+	for name, info := range cached.byName {
+		if name == restarted.name || (restarted.owner != nil && info.owner != nil && info.owner.UID == restarted.owner.UID) {
+			delete(cached.byName, name)
+		}
+	}
+	cached.refreshed = false
+}
+
+func (r *replicaSetCache) load(ctx context.Context, namespace string) (*namespaceReplicaSets, error) {
+	cached := &namespaceReplicaSets{byName: make(map[string]replicaSetInfo)}
+	continueToken := ""
+
+	for {
+		replicaSets := &appsv1.ReplicaSetList{}
+		err := retry.OnError(retry.DefaultRetry, func() error {
+			listOps := []client.ListOption{
+				client.InNamespace(namespace),
+				client.Limit(replicaSetListLimit),
+			}
+			if continueToken != "" {
+				listOps = append(listOps, client.Continue(continueToken))
+			}
+			return r.k8sClient.List(ctx, replicaSets, listOps...)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, rs := range replicaSets.Items {
+			info := replicaSetInfo{
+				name:          rs.Name,
+				namespace:     rs.Namespace,
+				replicas:      rs.Status.Replicas,
+				readyReplicas: rs.Status.ReadyReplicas,
+			}
+			if owner, hasOwner := getReplicaSetOwner(&rs); hasOwner {
+				info.owner = owner
+			}
+			cached.byName[rs.Name] = info
+		}
+
+		continueToken = replicaSets.Continue
+		if continueToken == "" {
+			break
+		}
+	}
+
+	r.namespaces[namespace] = cached
+	return cached, nil
+}
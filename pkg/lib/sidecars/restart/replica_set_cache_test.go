@@ -0,0 +1,163 @@
+package restart_test
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kyma-project/istio/operator/pkg/lib/sidecars/restart"
+)
+
+var _ = Describe("Restart Pods owned by ReplicaSets", func() {
+	ctx := context.Background()
+	logger := logr.Discard()
+
+	It("should list the ReplicaSets of a namespace only once for all pods of the namespace", func() {
+		// given
+		p1 := podFixture("p1", "test-ns", "ReplicaSet", "rs1")
+		p2 := podFixture("p2", "test-ns", "ReplicaSet", "rs2")
+		p3 := podFixture("p3", "other-ns", "ReplicaSet", "rs3")
+		c := &countingClient{Client: fakeClient(&p1, &p2, &p3,
+			deploymentReplicaSetFixture("rs1", "test-ns", "d1", "d1-uid"),
+			deploymentReplicaSetFixture("rs2", "test-ns", "d2", "d2-uid"),
+			deploymentReplicaSetFixture("rs3", "other-ns", "d3", "d3-uid"),
+			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "d1", Namespace: "test-ns"}},
+			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "d2", Namespace: "test-ns"}},
+			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "d3", Namespace: "other-ns"}},
+		)}
+
+		// when
+		actionRestarter := restart.NewActionRestarter(c, &logger)
+		warnings, err := actionRestarter.Restart(ctx, &v1.PodList{Items: []v1.Pod{p1, p2, p3}}, true)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+		Expect(c.replicaSetLists).To(Equal(2))
+
+		for _, deployment := range []types.NamespacedName{
+			{Namespace: "test-ns", Name: "d1"},
+			{Namespace: "test-ns", Name: "d2"},
+			{Namespace: "other-ns", Name: "d3"},
+		} {
+			d := appsv1.Deployment{}
+			Expect(c.Get(ctx, deployment, &d)).To(Succeed())
+			Expect(d.Spec.Template.Annotations[restartAnnotationName]).NotTo(BeEmpty())
+		}
+	})
+
+	It("should not roll out the Deployment if another ReplicaSet of the Deployment is not ready", func() {
+		// given
+		pod := podFixture("p1", "test-ns", "ReplicaSet", "rs1")
+		notReady := deploymentReplicaSetFixture("rs2", "test-ns", "d1", "d1-uid")
+		notReady.Status = appsv1.ReplicaSetStatus{Replicas: 2, ReadyReplicas: 1}
+		c := fakeClient(&pod, deploymentReplicaSetFixture("rs1", "test-ns", "d1", "d1-uid"), notReady,
+			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "d1", Namespace: "test-ns"}})
+
+		// when
+		actionRestarter := restart.NewActionRestarter(c, &logger)
+		warnings, err := actionRestarter.Restart(ctx, &v1.PodList{Items: []v1.Pod{pod}}, true)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+
+		d := appsv1.Deployment{}
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: "test-ns", Name: "d1"}, &d)).To(Succeed())
+		Expect(d.Spec.Template.Annotations[restartAnnotationName]).To(BeEmpty())
+	})
+
+	It("should list the ReplicaSets of a namespace again if the ReplicaSet of a pod was created after the namespace was listed", func() {
+		// given
+		p1 := podFixture("p1", "test-ns", "ReplicaSet", "rs1")
+		p2 := podFixture("p2", "test-ns", "ReplicaSet", "rs2")
+		c := &countingClient{Client: fakeClient(&p1, &p2,
+			deploymentReplicaSetFixture("rs1", "test-ns", "d1", "d1-uid"),
+			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "d1", Namespace: "test-ns"}},
+			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "d2", Namespace: "test-ns"}},
+		)}
+		c.afterFirstReplicaSetList = func() {
+			Expect(c.Create(ctx, deploymentReplicaSetFixture("rs2", "test-ns", "d2", "d2-uid"))).To(Succeed())
+		}
+
+		// when
+		actionRestarter := restart.NewActionRestarter(c, &logger)
+		warnings, err := actionRestarter.Restart(ctx, &v1.PodList{Items: []v1.Pod{p1, p2}}, true)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+		Expect(c.replicaSetLists).To(Equal(2))
+
+		d := appsv1.Deployment{}
+		Expect(c.Get(ctx, types.NamespacedName{Namespace: "test-ns", Name: "d2"}, &d)).To(Succeed())
+		Expect(d.Spec.Template.Annotations[restartAnnotationName]).NotTo(BeEmpty())
+	})
+
+	It("should keep the ReplicaSets for all pages of a run and list them again only if a restarted ReplicaSet is requested", func() {
+		// given
+		p1 := podFixture("p1", "test-ns", "ReplicaSet", "rs1")
+		p2 := podFixture("p2", "test-ns", "ReplicaSet", "rs2")
+		p3 := podFixture("p3", "test-ns", "ReplicaSet", "rs1")
+		c := &countingClient{Client: fakeClient(&p1, &p2, &p3,
+			deploymentReplicaSetFixture("rs1", "test-ns", "d1", "d1-uid"),
+			deploymentReplicaSetFixture("rs2", "test-ns", "d2", "d2-uid"),
+			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "d1", Namespace: "test-ns"}},
+			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "d2", Namespace: "test-ns"}},
+		)}
+		actionRestarter := restart.NewActionRestarter(c, &logger).WithOptions(restart.Options{})
+
+		// when
+		_, err := actionRestarter.Restart(ctx, &v1.PodList{Items: []v1.Pod{p1}}, true)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = actionRestarter.Restart(ctx, &v1.PodList{Items: []v1.Pod{p2}}, true)
+		Expect(err).NotTo(HaveOccurred())
+
+		// then
+		Expect(c.replicaSetLists).To(Equal(1))
+
+		// when
+		_, err = actionRestarter.Restart(ctx, &v1.PodList{Items: []v1.Pod{p3}}, true)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.replicaSetLists).To(Equal(2))
+	})
+})
+
+func deploymentReplicaSetFixture(name, namespace, deploymentName string, deploymentUID types.UID) *appsv1.ReplicaSet {
+	return &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "apps/v1", Kind: "Deployment", Name: deploymentName, UID: deploymentUID},
+			},
+		},
+	}
+}
+
+// countingClient counts the list requests for ReplicaSets.
+type countingClient struct {
+	client.Client
+	replicaSetLists          int
+	afterFirstReplicaSetList func()
+}
+
+func (c *countingClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	err := c.Client.List(ctx, list, opts...)
+	if _, ok := list.(*appsv1.ReplicaSetList); ok {
+		c.replicaSetLists++
+		if c.replicaSetLists == 1 && c.afterFirstReplicaSetList != nil {
+			c.afterFirstReplicaSetList()
+		}
+	}
+	return err
+}
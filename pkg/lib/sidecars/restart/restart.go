@@ -3,6 +3,7 @@ package restart
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/go-logr/logr"
 
@@ -15,26 +16,137 @@ const (
 	ownedByJobMessage               = "pod sidecar could not be updated because it is owned by a Job."
 	notReadyReplicaSetExistsMessage = "was not restarted because there exists another " +
 		"not ready ReplicaSet for the same object"
+	unknownOwnerKindFormat = "pod sidecar could not be updated because its owner kind %s is not supported for the restart."
+	// ownerNotAccessibleFormat is used if the operator is not allowed to access the owner, or the API of the owner is not served.
+	ownerNotAccessibleFormat = "pod sidecar could not be updated because %s %s could not be accessed. Grant the Istio module " +
+		"the permissions to get and patch the owner, or restart the pod manually."
+	restartActionFailedFormat = "pod sidecar could not be updated because the restart failed: %s"
+
+	defaultMaxConcurrentRestarts             = 5
+	defaultMaxConcurrentRestartsPerNamespace = 2
+	defaultRequestsPerSecond                 = 20
+)
+
+// UnknownOwnerStrategy defines how pods owned by a workload kind that is not supported for the restart are restarted.
+type UnknownOwnerStrategy string
+
+const (
+	// UnknownOwnerStrategyWarning doesn't restart the pods and returns a warning for each of them.
+	UnknownOwnerStrategyWarning UnknownOwnerStrategy = "Warning"
+	// UnknownOwnerStrategyDeletePod deletes the pods one at a time, so that their owner recreates them.
+	UnknownOwnerStrategyDeletePod UnknownOwnerStrategy = "DeletePod"
+	// UnknownOwnerStrategyPatchTemplate adds the restart annotation to the pod template in spec.template of the owner.
+	UnknownOwnerStrategyPatchTemplate UnknownOwnerStrategy = "PatchTemplate"
 )
 
+// Options configure how the pods are restarted.
+type Options struct {
+	// UnknownOwnerStrategy defines how pods owned by a workload kind that is not supported for the restart are restarted.
+	// The default is UnknownOwnerStrategyWarning.
+	UnknownOwnerStrategy UnknownOwnerStrategy
+	// MaxConcurrentRestarts limits the number of workloads that are restarted at the same time. The default is 5.
+	MaxConcurrentRestarts int
+	// MaxConcurrentRestartsPerNamespace limits the number of workloads in a single namespace that are restarted at the same time.
+	// The default is 2.
+	MaxConcurrentRestartsPerNamespace int
+	// RequestsPerSecond limits the requests that the restart sends to the Kubernetes API server. The default is 20.
+	RequestsPerSecond int
+}
+
+func (o Options) withDefaults() Options {
+	if o.UnknownOwnerStrategy == "" {
+		o.UnknownOwnerStrategy = UnknownOwnerStrategyWarning
+	}
+	if o.MaxConcurrentRestarts <= 0 {
+		o.MaxConcurrentRestarts = defaultMaxConcurrentRestarts
+	}
+	if o.MaxConcurrentRestartsPerNamespace <= 0 {
+		o.MaxConcurrentRestartsPerNamespace = defaultMaxConcurrentRestartsPerNamespace
+	}
+	if o.RequestsPerSecond <= 0 {
+		o.RequestsPerSecond = defaultRequestsPerSecond
+	}
+	return o
+}
+
 type ActionRestarter interface {
 	Restart(ctx context.Context, podList *v1.PodList, failOnError bool) ([]Warning, error)
+	// WithOptions returns an ActionRestarter that restarts the pods with the given options. The returned ActionRestarter restarts
+	// each owner only once, even if the pods of the owner are passed to multiple Restart calls, so it should be used for a single restart run.
+	WithOptions(options Options) ActionRestarter
 }
 
 type actionRestarter struct {
 	k8sClient client.Client
 	logger    *logr.Logger
+	options   Options
+	// processed contains the keys of the objects whose restart action was already run by a previous Restart call. It is nil if
+	// the objects are only de-duplicated within a single Restart call.
+	processed *processedActions
+	// deletedOwners contains the keys of the owners that had a pod deleted one at a time by a previous Restart call. It is nil if
+	// only a single Restart call is considered.
+	deletedOwners *processedActions
+	// replicaSets caches the ReplicaSets for all Restart calls of the run. It is nil if the ReplicaSets are only cached within a single
+	// Restart call.
+	replicaSets *replicaSetCache
 }
 
 func NewActionRestarter(c client.Client, logger *logr.Logger) ActionRestarter {
+	options := Options{}.withDefaults()
 	return &actionRestarter{
-		k8sClient: c,
+		k8sClient: newRateLimitedClient(c, options.RequestsPerSecond),
 		logger:    logger,
+		options:   options,
+	}
+}
+
+func (s *actionRestarter) WithOptions(options Options) ActionRestarter {
+	options = options.withDefaults()
+	c := s.k8sClient
+	if rateLimited, ok := c.(*rateLimitedClient); ok {
+		c = rateLimited.Client
+	}
+	k8sClient := newRateLimitedClient(c, options.RequestsPerSecond)
+	return &actionRestarter{
+		k8sClient:     k8sClient,
+		logger:        s.logger,
+		options:       options,
+		processed:     newProcessedActions(),
+		deletedOwners: newProcessedActions(),
+		replicaSets:   newReplicaSetCache(k8sClient),
 	}
 }
 
+// processedActions de-duplicates the restart actions of an owner whose pods are restarted in multiple pages.
+type processedActions struct {
+	mu   sync.Mutex
+	keys map[string]bool
+}
+
+func newProcessedActions() *processedActions {
+	return &processedActions{keys: make(map[string]bool)}
+}
+
+// add marks the key as processed.
+func (p *processedActions) add(key string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.keys[key] = true
+}
+
+// has returns true if the key was already added.
+func (p *processedActions) has(key string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.keys[key]
+}
+
 type Warning struct {
 	Name, Namespace, Kind, Message string
+	// Pending is set if the restart of the pod waits for its owner and continues in the next reconciliation.
+	Pending bool
 }
 
 func newRestartWarning(o actionObject, message string) Warning {
@@ -46,13 +158,36 @@ func newRestartWarning(o actionObject, message string) Warning {
 	}
 }
 
-// Restarts pods in the given list through their respective owners by adding an annotation. If failOnError is set to true, the function will return an error if any of the restart actions fail.
+func newPendingWarning(o actionObject, message string) Warning {
+	warning := newRestartWarning(o, message)
+	warning.Pending = true
+	return warning
+}
+
+// Restarts pods in the given list through their respective owners. Depending on the owner, the restart annotation is added to the
+// pod template, the owner-specific restart mechanism is used, or the pods are deleted. The owners are restarted concurrently within the
+// limits of the options. If failOnError is set to true, the function will return an error if any of the restart actions fail. Otherwise,
+// a failed restart action is returned as a warning.
 func (s *actionRestarter) Restart(ctx context.Context, podList *v1.PodList, failOnError bool) ([]Warning, error) {
 	warnings := make([]Warning, 0)
-	processedActionObjects := make(map[string]bool)
+	processed := s.processed
+	if processed == nil {
+		processed = newProcessedActions()
+	}
+	deletedOwners := s.deletedOwners
+	if deletedOwners == nil {
+		deletedOwners = newProcessedActions()
+	}
+	replicaSets := s.replicaSets
+	if replicaSets == nil {
+		replicaSets = newReplicaSetCache(s.k8sClient)
+	}
 
+	var groups []actionGroup
+	groupIndexes := make(map[string]int)
+	added := make(map[string]bool)
 	for _, pod := range podList.Items {
-		action, err := restartActionFactory(ctx, s.k8sClient, pod)
+		action, err := restartActionFactory(ctx, s.k8sClient, replicaSets, deletedOwners, pod, s.options)
 		if err != nil {
 			s.logger.Error(err, "pod", action.object.getKey(), "Creating pod restart action failed")
 			if failOnError {
@@ -62,18 +197,39 @@ func (s *actionRestarter) Restart(ctx context.Context, podList *v1.PodList, fail
 		}
 
 		// We want to avoid performing the same action multiple times for a parent if it contains multiple pods that need to be restarted.
-		if _, exists := processedActionObjects[action.object.getKey()]; !exists {
-			currentWarnings, actionErr := action.run(ctx, s.k8sClient, action.object, s.logger)
-			if actionErr != nil {
-				s.logger.Error(actionErr, "pod", action.object.getKey(), "Running pod restart action failed")
-				if failOnError {
-					return warnings, fmt.Errorf("running pod restart action failed: %w", actionErr)
-				}
-			}
-			warnings = append(warnings, currentWarnings...)
-			processedActionObjects[action.object.getKey()] = true
+		// The object is only marked as processed after its action ran, so that a later Restart call runs the actions that were not
+		// started because the restart was stopped.
+		key := action.object.getKey()
+		if added[key] || processed.has(key) {
+			continue
+		}
+		added[key] = true
+
+		groupKey := action.groupKey()
+		if i, exists := groupIndexes[groupKey]; exists {
+			groups[i].actions = append(groups[i].actions, action)
+			continue
 		}
+		groupIndexes[groupKey] = len(groups)
+		groups = append(groups, actionGroup{namespace: action.object.Namespace, actions: []restartAction{action}})
 	}
 
-	return warnings, nil
+	pool := newWorkerPool(s.options.MaxConcurrentRestarts, s.options.MaxConcurrentRestartsPerNamespace)
+	return pool.run(ctx, groups, failOnError, func(ctx context.Context, action restartAction) ([]Warning, error) {
+		currentWarnings, actionErr := action.run(ctx, s.k8sClient, action.object, s.logger)
+		processed.add(action.object.getKey())
+		if actionErr != nil {
+			s.logger.Error(actionErr, "pod", action.object.getKey(), "Running pod restart action failed")
+			if !failOnError {
+				return append(currentWarnings, newRestartWarning(action.object, fmt.Sprintf(restartActionFailedFormat, actionErr))), nil
+			}
+			return currentWarnings, fmt.Errorf("running pod restart action failed: %w", actionErr)
+		}
+		// The restart of a ReplicaSet changes its replicas and those of the other ReplicaSets of its owner, so they are listed again
+		// if they are requested by the restart of a later page.
+		if action.replicaSet != nil {
+			replicaSets.invalidate(*action.replicaSet)
+		}
+		return currentWarnings, nil
+	})
 }
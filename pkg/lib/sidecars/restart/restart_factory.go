@@ -6,33 +6,98 @@ import (
 	"github.com/go-logr/logr"
 
 	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func restartActionFactory(ctx context.Context, c client.Client, pod v1.Pod) (restartAction, error) {
+const (
+	argoRolloutsGroup = "argoproj.io"
+	openKruiseGroup   = "apps.kruise.io"
+)
+
+func restartActionFactory(ctx context.Context, c client.Client, replicaSets *replicaSetCache, deletedOwners *processedActions, pod v1.Pod, options Options) (restartAction, error) {
 	ownedBy, exists := getOwnerReferences(pod)
 
 	if !exists {
 		return newOwnerNotFoundAction(pod), nil
 	}
 
-	switch ownedBy.Kind {
-	case "Job":
+	switch {
+	case ownedBy.Kind == "Job" && isGroup(ownedBy, "batch"):
 		return newOwnedByJobAction(pod), nil
-	case "ReplicaSet":
-		return getReplicaSetAction(ctx, c, pod, ownedBy)
-	case "ReplicationController":
+	case ownedBy.Kind == "ReplicaSet" && isGroup(ownedBy, "apps"):
+		return getReplicaSetAction(ctx, c, replicaSets, deletedOwners, pod, ownedBy, options)
+	case ownedBy.Kind == "ReplicationController" && isGroup(ownedBy, ""):
 		return newDeleteAction(actionObjectFromPod(pod)), nil
 	default:
-		return newRolloutAction(actionObject{
-			Name:      ownedBy.Name,
-			Namespace: pod.Namespace,
-			Kind:      ownedBy.Kind,
-		}), nil
+		return getOwnerAction(ctx, c, deletedOwners, pod, ownedBy, options)
+	}
+}
+
+// getOwnerAction returns the action restarting the pod through the given workload owning it, either directly or through a ReplicaSet.
+func getOwnerAction(ctx context.Context, c client.Client, deletedOwners *processedActions, pod v1.Pod, ownedBy *metav1.OwnerReference, options Options) (restartAction, error) {
+	owner := actionObject{
+		Name:       ownedBy.Name,
+		Namespace:  pod.Namespace,
+		Kind:       ownedBy.Kind,
+		APIVersion: ownedBy.APIVersion,
+	}
+
+	switch {
+	case (ownedBy.Kind == "StatefulSet" || ownedBy.Kind == "DaemonSet") && isGroup(ownedBy, "apps"):
+		onDelete, err := hasOnDeleteUpdateStrategy(ctx, c, owner)
+		if isOwnerAccessError(err) {
+			return newOwnerNotAccessibleAction(pod, owner), nil
+		}
+		if err != nil {
+			return restartAction{object: owner}, err
+		}
+		if onDelete {
+			return newOneAtATimeDeleteAction(pod, owner, deletedOwners), nil
+		}
+		return newRolloutAction(owner), nil
+	case (ownedBy.Kind == "Deployment" || ownedBy.Kind == "ReplicaSet") && isGroup(ownedBy, "apps"):
+		return newRolloutAction(owner), nil
+	case ownedBy.Kind == "Rollout" && isGroup(ownedBy, argoRolloutsGroup):
+		return newArgoRolloutAction(owner), nil
+	// OpenKruise workloads may update pods in place when only the metadata of the pod template changes, which doesn't inject
+	// the new sidecar, so their pods are deleted instead.
+	case (ownedBy.Kind == "CloneSet" || ownedBy.Kind == "StatefulSet" || ownedBy.Kind == "DaemonSet") && isGroup(ownedBy, openKruiseGroup):
+		return newOneAtATimeDeleteAction(pod, owner, deletedOwners), nil
+	}
+
+	switch options.UnknownOwnerStrategy {
+	case UnknownOwnerStrategyDeletePod:
+		return newOneAtATimeDeleteAction(pod, owner, deletedOwners), nil
+	case UnknownOwnerStrategyPatchTemplate:
+		return newPatchTemplateAction(owner), nil
+	default:
+		return newUnknownOwnerKindAction(pod, ownedBy.Kind), nil
 	}
 }
 
+// isOwnerAccessError returns true if the owner can't be accessed, because the operator lacks the RBAC permissions for it or its API is
+// not served in the cluster. Such errors don't go away by retrying, so they are reported as warnings instead.
+func isOwnerAccessError(err error) bool {
+	return k8serrors.IsForbidden(err) || meta.IsNoMatchError(err)
+}
+
+// isGroup returns true if the owner reference belongs to the given API group. Owner references without an API version are
+// considered to belong to any group, so that only the kind is relevant for them.
+func isGroup(ownerRef *metav1.OwnerReference, group string) bool {
+	if ownerRef.APIVersion == "" {
+		return true
+	}
+	gv, err := schema.ParseGroupVersion(ownerRef.APIVersion)
+	if err != nil {
+		return false
+	}
+	return gv.Group == group
+}
+
 // getOwnerReferences returns the owner reference of the pod and a boolean to verify if the owner reference exists or not.
 func getOwnerReferences(pod v1.Pod) (*metav1.OwnerReference, bool) {
 	if len(pod.OwnerReferences) == 0 {
@@ -45,12 +110,26 @@ func getOwnerReferences(pod v1.Pod) (*metav1.OwnerReference, bool) {
 type restartAction struct {
 	run    func(context.Context, client.Client, actionObject, *logr.Logger) ([]Warning, error)
 	object actionObject
+	// sequentialOwner is set if the action must not run concurrently with the actions of other pods of the same owner.
+	sequentialOwner *actionObject
+	// replicaSet is set if the pod is owned by a ReplicaSet, so that the ReplicaSet is invalidated in the cache after the restart.
+	replicaSet *replicaSetInfo
+}
+
+// groupKey returns a key that is shared by the actions that must run one after another.
+func (a restartAction) groupKey() string {
+	if a.sequentialOwner != nil {
+		return a.sequentialOwner.getKey()
+	}
+	return a.object.getKey()
 }
 
 type actionObject struct {
 	Name      string
 	Namespace string
 	Kind      string
+	// APIVersion is used to get owners that are not known to the client scheme, for example, Argo Rollouts.
+	APIVersion string
 }
 
 // getKey returns a key that identifies this object.
@@ -471,7 +471,7 @@ var _ = Describe("Restart Pods", func() {
 		}
 
 		c := fakeClient(&pod)
-		failClient := &shouldFailClient{c, true, false}
+		failClient := &shouldFailClient{Client: c, FailOnList: true}
 
 		// when
 		actionRestarter := restart.NewActionRestarter(failClient, &logger)
@@ -479,7 +479,7 @@ var _ = Describe("Restart Pods", func() {
 
 		// then
 		Expect(err).To(HaveOccurred())
-		Expect(err.Error()).To(ContainSubstring("creating pod restart action failed: intentionally failing client on client.Get"))
+		Expect(err.Error()).To(ContainSubstring("creating pod restart action failed: intentionally failing client on client.List"))
 		Expect(warnings).To(BeEmpty())
 
 		pods := v1.PodList{}
@@ -489,6 +489,24 @@ var _ = Describe("Restart Pods", func() {
 		Expect(pods.Items).NotTo(BeEmpty())
 	})
 
+	It("should return a warning for a Pod whose owner did not succeed to be patched if errors are not returned", func() {
+		// given
+		pod := podFixture("p1", "test-ns", "Deployment", "podOwner")
+		c := fakeClient(&pod, &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "podOwner", Namespace: "test-ns"}})
+		failClient := &shouldFailClient{Client: c, FailOnPatch: true}
+
+		// when
+		actionRestarter := restart.NewActionRestarter(failClient, &logger)
+		warnings, err := actionRestarter.Restart(ctx, &v1.PodList{Items: []v1.Pod{pod}}, false)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(HaveLen(1))
+		Expect(warnings[0].Name).To(Equal("podOwner"))
+		Expect(warnings[0].Kind).To(Equal("Deployment"))
+		Expect(warnings[0].Message).To(ContainSubstring("intentionally failing client on client.Patch"))
+	})
+
 	It("should return an error when specified for a Pod owned by a ReplicaSet that did not succeed to be patched", func() {
 		// given
 		pod := podFixture("p1", "test-ns", "ReplicaSet", "podOwner")
@@ -510,7 +528,7 @@ var _ = Describe("Restart Pods", func() {
 			Namespace: "test-ns",
 		}})
 
-		failClient := &shouldFailClient{c, false, true}
+		failClient := &shouldFailClient{Client: c, FailOnPatch: true}
 
 		// when
 		actionRestarter := restart.NewActionRestarter(failClient, &logger)
@@ -543,6 +561,7 @@ func fakeClient(objects ...client.Object) client.Client {
 type shouldFailClient struct {
 	client.Client
 	FailOnGet   bool
+	FailOnList  bool
 	FailOnPatch bool
 }
 
@@ -553,6 +572,13 @@ func (p *shouldFailClient) Get(ctx context.Context, key client.ObjectKey, obj cl
 	return p.Client.Get(ctx, key, obj, opts...)
 }
 
+func (p *shouldFailClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if p.FailOnList {
+		return errors.New("intentionally failing client on client.List")
+	}
+	return p.Client.List(ctx, list, opts...)
+}
+
 func (p *shouldFailClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
 	if p.FailOnPatch {
 		return errors.New("intentionally failing client on client.Patch")
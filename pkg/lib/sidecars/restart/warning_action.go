@@ -2,6 +2,7 @@ package restart
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/go-logr/logr"
 
@@ -30,3 +31,10 @@ func newOwnedByJobAction(pod v1.Pod) restartAction {
 		run:    warningAction{message: ownedByJobMessage}.run,
 	}
 }
+
+func newOwnerNotAccessibleAction(pod v1.Pod, owner actionObject) restartAction {
+	return restartAction{
+		object: actionObjectFromPod(pod),
+		run:    warningAction{message: fmt.Sprintf(ownerNotAccessibleFormat, owner.Kind, owner.Name)}.run,
+	}
+}
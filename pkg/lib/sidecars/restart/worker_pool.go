@@ -0,0 +1,111 @@
+package restart
+
+import (
+	"context"
+	"sync"
+)
+
+// actionGroup contains the restart actions that run one after another, because they restart the pods of the same owner one at a time.
+type actionGroup struct {
+	namespace string
+	actions   []restartAction
+}
+
+// workerPool limits the number of action groups that run at the same time in total and in each namespace, so that a large number of
+// workloads is restarted in parallel without restarting too many workloads of a single namespace at once.
+type workerPool struct {
+	global       chan struct{}
+	perNamespace int
+
+	mu         sync.Mutex
+	namespaces map[string]chan struct{}
+}
+
+func newWorkerPool(maxConcurrent, maxConcurrentPerNamespace int) *workerPool {
+	return &workerPool{
+		global:       make(chan struct{}, maxConcurrent),
+		perNamespace: maxConcurrentPerNamespace,
+		namespaces:   make(map[string]chan struct{}),
+	}
+}
+
+// acquire blocks until a worker in the namespace is free. It returns false if the context is done before a worker is free.
+// The namespace slot is acquired first, so that a global slot is not held while waiting for a busy namespace.
+func (p *workerPool) acquire(ctx context.Context, namespace string) (func(), bool) {
+	namespaceSlots := p.namespaceSlots(namespace)
+
+	select {
+	case namespaceSlots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, false
+	}
+
+	select {
+	case p.global <- struct{}{}:
+	case <-ctx.Done():
+		<-namespaceSlots
+		return nil, false
+	}
+
+	return func() {
+		<-p.global
+		<-namespaceSlots
+	}, true
+}
+
+func (p *workerPool) namespaceSlots(namespace string) chan struct{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	slots, exists := p.namespaces[namespace]
+	if !exists {
+		slots = make(chan struct{}, p.perNamespace)
+		p.namespaces[namespace] = slots
+	}
+	return slots
+}
+
+// run runs the action groups concurrently and returns the warnings in the order of the groups. If stopOnError is set to true, no further
+// actions are started after the first action failed, and the error of that action is returned.
+func (p *workerPool) run(ctx context.Context, groups []actionGroup, stopOnError bool,
+	runAction func(context.Context, restartAction) ([]Warning, error)) ([]Warning, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	groupWarnings := make([][]Warning, len(groups))
+	var firstErr error
+	var errOnce sync.Once
+	var wg sync.WaitGroup
+
+	for i, group := range groups {
+		wg.Go(func() {
+			release, acquired := p.acquire(ctx, group.namespace)
+			if !acquired {
+				return
+			}
+			defer release()
+
+			for _, action := range group.actions {
+				if ctx.Err() != nil {
+					return
+				}
+				warnings, err := runAction(ctx, action)
+				groupWarnings[i] = append(groupWarnings[i], warnings...)
+				if err != nil && stopOnError {
+					errOnce.Do(func() {
+						firstErr = err
+					})
+					cancel()
+					return
+				}
+			}
+		})
+	}
+	wg.Wait()
+
+	warnings := make([]Warning, 0)
+	for _, w := range groupWarnings {
+		warnings = append(warnings, w...)
+	}
+	return warnings, firstErr
+}
@@ -0,0 +1,177 @@
+package restart_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kyma-project/istio/operator/pkg/lib/sidecars/restart"
+)
+
+var _ = Describe("Restart Pods concurrently", func() {
+	ctx := context.Background()
+	logger := logr.Discard()
+
+	It("should restart the owners concurrently within the global and per-namespace limits", func() {
+		// given
+		var objects []client.Object
+		var podList v1.PodList
+		for _, namespace := range []string{"ns-a", "ns-b", "ns-c"} {
+			for i := range 4 {
+				name := fmt.Sprintf("deployment-%d", i)
+				pod := podFixture(fmt.Sprintf("pod-%d", i), namespace, "Deployment", name)
+				podList.Items = append(podList.Items, pod)
+				objects = append(objects, &pod, &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}})
+			}
+		}
+		c := newConcurrencyTrackingClient(fakeClient(objects...))
+
+		// when
+		actionRestarter := restart.NewActionRestarter(c, &logger).WithOptions(restart.Options{
+			MaxConcurrentRestarts:             3,
+			MaxConcurrentRestartsPerNamespace: 2,
+			RequestsPerSecond:                 1000,
+		})
+		warnings, err := actionRestarter.Restart(ctx, &podList, true)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+		Expect(c.patches).To(Equal(12))
+		Expect(c.maxInFlight).To(BeNumerically(">", 1))
+		Expect(c.maxInFlight).To(BeNumerically("<=", 3))
+		for _, maxInFlight := range c.maxInFlightPerNamespace {
+			Expect(maxInFlight).To(BeNumerically("<=", 2))
+		}
+	})
+
+	It("should restart an owner only once if its pods are restarted in multiple pages", func() {
+		// given
+		p1 := podFixture("p1", "test-ns", "Deployment", "owner")
+		p2 := podFixture("p2", "test-ns", "Deployment", "owner")
+		c := newConcurrencyTrackingClient(fakeClient(&p1, &p2,
+			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "owner", Namespace: "test-ns"}}))
+
+		// when
+		actionRestarter := restart.NewActionRestarter(c, &logger).WithOptions(restart.Options{})
+		_, err := actionRestarter.Restart(ctx, &v1.PodList{Items: []v1.Pod{p1}}, true)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = actionRestarter.Restart(ctx, &v1.PodList{Items: []v1.Pod{p2}}, true)
+		Expect(err).NotTo(HaveOccurred())
+
+		// then
+		Expect(c.patches).To(Equal(1))
+	})
+
+	It("should restart an owner in a later page if the restart stopped before its action started", func() {
+		// given
+		var objects []client.Object
+		var podList v1.PodList
+		for _, name := range []string{"failing", "healthy"} {
+			pod := podFixture("pod-"+name, "test-ns", "Deployment", name)
+			podList.Items = append(podList.Items, pod)
+			objects = append(objects, &pod, &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-ns"}})
+		}
+		c := newConcurrencyTrackingClient(fakeClient(objects...))
+		c.failPatchOf = "failing"
+		actionRestarter := restart.NewActionRestarter(c, &logger).WithOptions(restart.Options{MaxConcurrentRestarts: 1})
+
+		// when
+		_, err := actionRestarter.Restart(ctx, &podList, true)
+		Expect(err).To(HaveOccurred())
+		failedPatches := c.patchesOf["failing"]
+		_, err = actionRestarter.Restart(ctx, &podList, true)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.patchesOf["failing"]).To(Equal(failedPatches))
+		Expect(c.patchesOf["healthy"]).To(Equal(1))
+	})
+
+	It("should limit the requests sent to the API server", func() {
+		// given
+		var objects []client.Object
+		var podList v1.PodList
+		for i := range 4 {
+			name := fmt.Sprintf("deployment-%d", i)
+			pod := podFixture(fmt.Sprintf("pod-%d", i), "test-ns", "Deployment", name)
+			podList.Items = append(podList.Items, pod)
+			objects = append(objects, &pod, &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-ns"}})
+		}
+		c := fakeClient(objects...)
+
+		// when
+		actionRestarter := restart.NewActionRestarter(c, &logger).WithOptions(restart.Options{RequestsPerSecond: 5})
+		start := time.Now()
+		warnings, err := actionRestarter.Restart(ctx, &podList, true)
+
+		// then
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+		// Each rollout gets and patches the Deployment, so the 8 requests exceed the burst of 5 requests by 3 requests.
+		Expect(time.Since(start)).To(BeNumerically(">=", 500*time.Millisecond))
+	})
+})
+
+// concurrencyTrackingClient records how many patches are running at the same time in total and in each namespace.
+type concurrencyTrackingClient struct {
+	client.Client
+
+	// failPatchOf is the name of the object whose patches fail.
+	failPatchOf string
+
+	mu                      sync.Mutex
+	patches                 int
+	patchesOf               map[string]int
+	inFlight                int
+	maxInFlight             int
+	inFlightPerNamespace    map[string]int
+	maxInFlightPerNamespace map[string]int
+}
+
+func newConcurrencyTrackingClient(c client.Client) *concurrencyTrackingClient {
+	return &concurrencyTrackingClient{
+		Client:                  c,
+		patchesOf:               map[string]int{},
+		inFlightPerNamespace:    map[string]int{},
+		maxInFlightPerNamespace: map[string]int{},
+	}
+}
+
+func (c *concurrencyTrackingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	namespace := obj.GetNamespace()
+
+	c.mu.Lock()
+	c.patches++
+	c.patchesOf[obj.GetName()]++
+	c.inFlight++
+	c.maxInFlight = max(c.maxInFlight, c.inFlight)
+	c.inFlightPerNamespace[namespace]++
+	c.maxInFlightPerNamespace[namespace] = max(c.maxInFlightPerNamespace[namespace], c.inFlightPerNamespace[namespace])
+	c.mu.Unlock()
+
+	time.Sleep(50 * time.Millisecond)
+	var err error
+	if obj.GetName() == c.failPatchOf {
+		err = errors.New("intentionally failing patch")
+	} else {
+		err = c.Client.Patch(ctx, obj, patch, opts...)
+	}
+
+	c.mu.Lock()
+	c.inFlight--
+	c.inFlightPerNamespace[namespace]--
+	c.mu.Unlock()
+
+	return err
+}
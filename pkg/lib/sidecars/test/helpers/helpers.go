@@ -60,7 +60,7 @@ func NewSidecarPodBuilder() *SidecarPodFixtureBuilder {
 		sidecarImageTag:        "1.10.0",
 		initContainerName:      "istio-init",
 		podAnnotations:         map[string]string{"sidecar.istio.io/status": "{\"containers\":[\"istio-proxy\"]}"},
-		podLabels:              map[string]string{},
+		podLabels:              map[string]string{"security.istio.io/tlsMode": "istio"},
 		podStatusPhase:         "Running",
 		conditionStatus:        "True",
 		hostNetwork:            false,
@@ -146,6 +146,7 @@ func (r *SidecarPodFixtureBuilder) DisableSidecar() *SidecarPodFixtureBuilder {
 	r.sidecarImageTag = "1.0"
 	r.initContainerName = "customer-init"
 	r.podAnnotations = map[string]string{}
+	delete(r.podLabels, "security.istio.io/tlsMode")
 	return r
 }
 
@@ -267,6 +268,12 @@ func FixPodWithSidecarAsInitContainer(name, namespace string) *v1.Pod {
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
+			Labels: map[string]string{
+				"security.istio.io/tlsMode": "istio",
+			},
+			Annotations: map[string]string{
+				"sidecar.istio.io/status": "{\"initContainers\":[\"istio-proxy\"]}",
+			},
 			OwnerReferences: []metav1.OwnerReference{
 				{Kind: "ReplicaSet"},
 			},
@@ -305,7 +312,47 @@ func FixPodIngressGateway() *v1.Pod {
 				{Kind: "ReplicaSet"},
 			},
 			Labels: map[string]string{
-				"istio": "ingressgateway",
+				"istio":                     "ingressgateway",
+				"security.istio.io/tlsMode": "istio",
+			},
+			Annotations: map[string]string{
+				"sidecar.istio.io/status": "{\"containers\":[\"istio-proxy\"]}",
+			},
+		},
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Pod",
+			APIVersion: "v1",
+		},
+		Status: v1.PodStatus{
+			Phase: "Running",
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{
+					Name:      "istio-proxy",
+					Image:     "istio/istio-proxy:1.0.0",
+					Resources: DefaultSidecarResources,
+				},
+			},
+		},
+	}
+}
+
+func FixPodAdditionalIngressGateway(name string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name + "-1234567890-abcde",
+			Namespace: "istio-system",
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: "ReplicaSet"},
+			},
+			Labels: map[string]string{
+				"istio":                       name,
+				"operator.istio.io/component": "IngressGateways",
+				"security.istio.io/tlsMode":   "istio",
+			},
+			Annotations: map[string]string{
+				"sidecar.istio.io/status": "{\"containers\":[\"istio-proxy\"]}",
 			},
 		},
 		TypeMeta: metav1.TypeMeta{
@@ -336,7 +383,11 @@ func FixPodIEgressGateway() *v1.Pod {
 				{Kind: "ReplicaSet"},
 			},
 			Labels: map[string]string{
-				"istio": "egressgateway",
+				"istio":                     "egressgateway",
+				"security.istio.io/tlsMode": "istio",
+			},
+			Annotations: map[string]string{
+				"sidecar.istio.io/status": "{\"containers\":[\"istio-proxy\"]}",
 			},
 		},
 		TypeMeta: metav1.TypeMeta{
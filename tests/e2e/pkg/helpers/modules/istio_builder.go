@@ -179,7 +179,7 @@ func (b *IstioCRBuilder) WithPilot(pilot *v1alpha2.IstioComponent) *IstioCRBuild
 }
 
 // WithIngressGateway configures the Istio Ingress Gateway component
-func (b *IstioCRBuilder) WithIngressGateway(ingressGateway *v1alpha2.IstioComponent) *IstioCRBuilder {
+func (b *IstioCRBuilder) WithIngressGateway(ingressGateway *v1alpha2.IngressGateway) *IstioCRBuilder {
 	if b.istio.Spec.Components == nil {
 		b.istio.Spec.Components = &v1alpha2.Components{}
 	}
@@ -384,8 +384,8 @@ func NewPilotComponent() *v1alpha2.IstioComponent {
 }
 
 // NewIngressGatewayComponent creates a basic Ingress Gateway component configuration
-func NewIngressGatewayComponent() *v1alpha2.IstioComponent {
-	return &v1alpha2.IstioComponent{
+func NewIngressGatewayComponent() *v1alpha2.IngressGateway {
+	return &v1alpha2.IngressGateway{
 		K8s: &v1alpha2.KubernetesResourcesConfig{},
 	}
 }